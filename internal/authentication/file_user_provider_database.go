@@ -219,6 +219,7 @@ type FileUserDatabaseUserDetails struct {
 	Email       string                 `json:"email" jsonschema:"title=Email" jsonschema_description:"The email for the user"`
 	Groups      []string               `json:"groups" jsonschema:"title=Groups" jsonschema_description:"The groups list for the user"`
 	Disabled    bool                   `json:"disabled" jsonschema:"default=false,title=Disabled" jsonschema_description:"The disabled status for the user"`
+	Extra       map[string]string      `json:"extra" jsonschema:"title=Extra" jsonschema_description:"The custom attributes for the user"`
 }
 
 // ToUserDetails converts FileUserDatabaseUserDetails into a *UserDetails given a username.
@@ -228,6 +229,7 @@ func (m FileUserDatabaseUserDetails) ToUserDetails() (details *UserDetails) {
 		DisplayName: m.DisplayName,
 		Emails:      []string{m.Email},
 		Groups:      m.Groups,
+		Extra:       m.Extra,
 	}
 }
 
@@ -238,6 +240,7 @@ func (m FileUserDatabaseUserDetails) ToUserDetailsModel() (model FileDatabaseUse
 		DisplayName: m.DisplayName,
 		Email:       m.Email,
 		Groups:      m.Groups,
+		Extra:       m.Extra,
 	}
 }
 
@@ -310,11 +313,12 @@ func (m *FileDatabaseModel) Write(fileName string) (err error) {
 
 // FileDatabaseUserDetailsModel is the model of user details in the file database.
 type FileDatabaseUserDetailsModel struct {
-	Password    string   `yaml:"password" valid:"required"`
-	DisplayName string   `yaml:"displayname" valid:"required"`
-	Email       string   `yaml:"email"`
-	Groups      []string `yaml:"groups"`
-	Disabled    bool     `yaml:"disabled"`
+	Password    string            `yaml:"password" valid:"required"`
+	DisplayName string            `yaml:"displayname" valid:"required"`
+	Email       string            `yaml:"email"`
+	Groups      []string          `yaml:"groups"`
+	Disabled    bool              `yaml:"disabled"`
+	Extra       map[string]string `yaml:"extra,omitempty"`
 }
 
 // ToDatabaseUserDetailsModel converts a FileDatabaseUserDetailsModel into a *FileUserDatabaseUserDetails.
@@ -332,5 +336,6 @@ func (m FileDatabaseUserDetailsModel) ToDatabaseUserDetailsModel(username string
 		DisplayName: m.DisplayName,
 		Email:       m.Email,
 		Groups:      m.Groups,
+		Extra:       m.Extra,
 	}, nil
 }
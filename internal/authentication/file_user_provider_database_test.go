@@ -37,3 +37,30 @@ func TestDatabaseModel_Read(t *testing.T) {
 
 	assert.EqualError(t, model.Read(f), "could not parse the YAML database: yaml: line 2: found character that cannot start any token")
 }
+
+func TestDatabaseModel_Extra(t *testing.T) {
+	dir := t.TempDir()
+
+	f := filepath.Join(dir, "users_database.yml")
+
+	assert.NoError(t, os.WriteFile(f, []byte(`users:
+  john:
+    displayname: "John Doe"
+    password: "$argon2id$v=19$m=65536,t=3,p=2$BpLnfgDsc2WD8F2q$o/vzA4myCqZZ36bUGsDY//8mKUYNZZaR0t4MFFSs+iM"
+    email: john@example.com
+    groups: []
+    extra:
+      employee_id: "1234"
+`), 0600))
+
+	model := &FileDatabaseModel{}
+
+	assert.NoError(t, model.Read(f))
+
+	db := &FileUserDatabase{}
+
+	assert.NoError(t, model.ReadToFileUserDatabase(db))
+
+	assert.Equal(t, map[string]string{"employee_id": "1234"}, db.Users["john"].ToUserDetails().Extra)
+	assert.Equal(t, map[string]string{"employee_id": "1234"}, db.Users["john"].ToUserDetailsModel().Extra)
+}
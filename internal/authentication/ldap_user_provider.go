@@ -150,6 +150,7 @@ func (p *LDAPUserProvider) GetDetails(username string) (details *UserDetails, er
 		DisplayName: profile.DisplayName,
 		Emails:      profile.Emails,
 		Groups:      groups,
+		Extra:       profile.Extra,
 	}, nil
 }
 
@@ -383,6 +384,18 @@ func (p *LDAPUserProvider) getUserProfileResultToProfile(username string, result
 
 			userProfile.MemberOf = attr.Values
 		}
+
+		for name, extra := range p.config.Attributes.Extra {
+			if attr.Name != extra.Name || attrs == 0 {
+				continue
+			}
+
+			if userProfile.Extra == nil {
+				userProfile.Extra = map[string]string{}
+			}
+
+			userProfile.Extra[name] = attr.Values[0]
+		}
 	}
 
 	if userProfile.Username == "" {
@@ -2,6 +2,7 @@ package authentication
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/go-ldap/ldap/v3"
@@ -105,6 +106,20 @@ func (p *LDAPUserProvider) parseDynamicUsersConfiguration() {
 		p.usersAttributes = append(p.usersAttributes, p.config.Attributes.DisplayName)
 	}
 
+	extra := make([]string, 0, len(p.config.Attributes.Extra))
+
+	for _, attribute := range p.config.Attributes.Extra {
+		extra = append(extra, attribute.Name)
+	}
+
+	sort.Strings(extra)
+
+	for _, attribute := range extra {
+		if len(attribute) != 0 && !utils.IsStringInSlice(attribute, p.usersAttributes) {
+			p.usersAttributes = append(p.usersAttributes, attribute)
+		}
+	}
+
 	if p.config.AdditionalUsersDN != "" {
 		p.usersBaseDN = p.config.AdditionalUsersDN + "," + p.config.BaseDN
 	} else {
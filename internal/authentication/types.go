@@ -57,6 +57,9 @@ type UserDetails struct {
 	DisplayName string
 	Emails      []string
 	Groups      []string
+
+	// Extra contains the custom attributes of the user keyed by their configured names.
+	Extra map[string]string
 }
 
 // Addresses returns the Emails []string as []mail.Address formatted with DisplayName as the Name attribute.
@@ -83,6 +86,7 @@ type ldapUserProfile struct {
 	DisplayName string
 	Username    string
 	MemberOf    []string
+	Extra       map[string]string
 }
 
 // LDAPSupportedFeatures represents features which a server may support which are implemented in code.
@@ -0,0 +1,161 @@
+package authorization
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/common/types"
+)
+
+// NewAccessControlExpression compiles an expression string into an AccessControlExpression.
+func NewAccessControlExpression(expression string) (exp *AccessControlExpression, err error) {
+	var (
+		env *cel.Env
+		ast *cel.Ast
+	)
+
+	if env, err = newAccessControlExpressionEnv(); err != nil {
+		return nil, fmt.Errorf("failed to create the expression environment: %w", err)
+	}
+
+	ast, issues := env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+
+	if !ast.OutputType().IsExactType(types.BoolType) {
+		return nil, fmt.Errorf("expression must evaluate to a bool but it evaluates to a %s", ast.OutputType())
+	}
+
+	exp = &AccessControlExpression{
+		Expression: expression,
+	}
+
+	if exp.program, err = env.Program(ast, cel.EvalOptions(cel.OptOptimize), cel.CostLimit(expressionCostLimit)); err != nil {
+		return nil, fmt.Errorf("failed to create the expression program: %w", err)
+	}
+
+	checked, err := cel.AstToCheckedExpr(ast)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check the expression: %w", err)
+	}
+
+	for _, reference := range checked.GetReferenceMap() {
+		if reference.GetName() == expressionVarSubject {
+			exp.subjects = true
+
+			break
+		}
+	}
+
+	return exp, nil
+}
+
+func newAccessControlExpressionEnv() (env *cel.Env, err error) {
+	return cel.NewEnv(
+		cel.Variable(expressionVarSubject, cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable(expressionVarObject, cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable(expressionVarHeaders, cel.MapType(cel.StringType, cel.StringType)),
+	)
+}
+
+// AccessControlExpression represents an ACL expression criteria.
+type AccessControlExpression struct {
+	Expression string
+
+	program  cel.Program
+	subjects bool
+	deny     bool
+}
+
+// HasSubjects returns true if the expression references the subject.
+func (e *AccessControlExpression) HasSubjects() bool {
+	return e.subjects
+}
+
+// IsMatch returns true if the expression evaluates to true for the subject and object. If the expression failed to
+// compile or fails to evaluate it only matches when it belongs to a deny rule so the rule fails closed.
+func (e *AccessControlExpression) IsMatch(subject Subject, object Object) (match bool) {
+	if e.program == nil {
+		return e.deny
+	}
+
+	out, _, err := e.program.Eval(map[string]any{
+		expressionVarSubject: expressionSubject(subject),
+		expressionVarObject:  expressionObject(object),
+		expressionVarHeaders: expressionHeaders(object),
+	})
+
+	if err != nil {
+		return e.deny
+	}
+
+	match, ok := out.Value().(bool)
+
+	return ok && match
+}
+
+func expressionSubject(subject Subject) map[string]any {
+	ip := ""
+
+	if subject.IP != nil {
+		ip = subject.IP.String()
+	}
+
+	return map[string]any{
+		"username":     subject.Username,
+		"display_name": subject.DisplayName,
+		"groups":       nonNilStrings(subject.Groups),
+		"emails":       nonNilStrings(subject.Emails),
+		"ip":           ip,
+		"country":      subject.Country,
+		"asn":          int64(subject.ASN),
+		"attributes":   nonNilAttributes(subject.Attributes),
+	}
+}
+
+func expressionObject(object Object) map[string]any {
+	values := map[string]any{
+		"domain": object.Domain,
+		"path":   object.Path,
+		"method": object.Method,
+		"url":    "",
+		"scheme": "",
+		"query":  map[string][]string{},
+	}
+
+	if object.URL != nil {
+		values["url"] = object.URL.String()
+		values["scheme"] = object.URL.Scheme
+		values["query"] = map[string][]string(object.URL.Query())
+	}
+
+	return values
+}
+
+func expressionHeaders(object Object) map[string]string {
+	headers := make(map[string]string, len(object.Headers))
+
+	for key, values := range object.Headers {
+		headers[strings.ToLower(key)] = strings.Join(values, ", ")
+	}
+
+	return headers
+}
+
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+
+	return values
+}
+
+func nonNilAttributes(values map[string]string) map[string]string {
+	if values == nil {
+		return map[string]string{}
+	}
+
+	return values
+}
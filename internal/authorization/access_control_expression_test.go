@@ -0,0 +1,144 @@
+package authorization
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestNewAccessControlExpression(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		subjects bool
+		err      string
+	}{
+		{"ShouldCompileSubjectExpression", "subject.emails.exists(e, e.endsWith('@contractor.com'))", true, ""},
+		{"ShouldCompileObjectExpression", "object.method == 'GET' && headers['x-tenant'] == 'abc'", false, ""},
+		{"ShouldErrorNonBoolExpression", "object.path", false, "expression must evaluate to a bool but it evaluates to a dyn"},
+		{"ShouldErrorUnknownVariable", "user.name == 'john'", false, "ERROR: <input>:1:1: undeclared reference to 'user' (in container '')\n | user.name == 'john'\n | ^"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			exp, err := NewAccessControlExpression(tc.have)
+
+			if tc.err == "" {
+				require.NoError(t, err)
+				require.NotNil(t, exp)
+				assert.Equal(t, tc.have, exp.Expression)
+				assert.Equal(t, tc.subjects, exp.HasSubjects())
+			} else {
+				assert.EqualError(t, err, tc.err)
+				assert.Nil(t, exp)
+			}
+		})
+	}
+}
+
+func TestAccessControlExpression_IsMatch(t *testing.T) {
+	targetURL, err := url.ParseRequestURI("https://app.example.com/api?tenant=abc")
+	require.NoError(t, err)
+
+	object := NewObject(targetURL, fasthttp.MethodGet)
+	object.Headers = http.Header{"X-Tenant": []string{"abc"}}
+
+	subject := Subject{
+		Username:   "john",
+		Groups:     []string{"dev"},
+		Emails:     []string{"john@contractor.com"},
+		IP:         net.ParseIP("192.168.1.1"),
+		Attributes: map[string]string{"department": "finance"},
+	}
+
+	testCases := []struct {
+		name     string
+		have     string
+		subject  Subject
+		expected bool
+	}{
+		{"ShouldMatchEmailSuffix", "subject.emails.exists(e, e.endsWith('@contractor.com'))", subject, true},
+		{"ShouldNotMatchEmailSuffix", "subject.emails.exists(e, e.endsWith('@example.com'))", subject, false},
+		{"ShouldMatchHeader", "headers['x-tenant'] == 'abc'", subject, true},
+		{"ShouldMatchHeaderAgainstQuery", "headers['x-tenant'] == object.query['tenant'][0]", subject, true},
+		{"ShouldMatchObject", "object.domain == 'app.example.com' && object.path.startsWith('/api') && object.method == 'GET'", subject, true},
+		{"ShouldMatchIP", "subject.ip.startsWith('192.168.')", subject, true},
+		{"ShouldNotMatchMissingHeader", "headers['x-missing'] == 'abc'", subject, false},
+		{"ShouldNotMatchAnonymousEmails", "subject.emails.exists(e, e.endsWith('@contractor.com'))", Subject{}, false},
+		{"ShouldMatchAttribute", "subject.attributes['department'] == 'finance'", subject, true},
+		{"ShouldNotMatchAttribute", "subject.attributes['department'] == 'engineering'", subject, false},
+		{"ShouldMatchAttributePresence", "'department' in subject.attributes", subject, true},
+		{"ShouldNotMatchMissingAttribute", "subject.attributes['cost_center'] == '1001'", subject, false},
+		{"ShouldNotMatchAnonymousAttributes", "'department' in subject.attributes", Subject{}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			exp, err := NewAccessControlExpression(tc.have)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, exp.IsMatch(tc.subject, object))
+		})
+	}
+}
+
+func TestAccessControlRule_MatchesExpression(t *testing.T) {
+	exp, err := NewAccessControlExpression("subject.username == 'john'")
+	require.NoError(t, err)
+
+	rule := &AccessControlRule{Expression: exp}
+
+	targetURL, err := url.ParseRequestURI("https://app.example.com/")
+	require.NoError(t, err)
+
+	object := NewObject(targetURL, fasthttp.MethodGet)
+
+	assert.True(t, rule.MatchesExpression(Subject{}, object))
+	assert.False(t, rule.MatchesExpressionExact(Subject{}, object))
+	assert.True(t, rule.MatchesExpressionExact(Subject{Username: "john"}, object))
+	assert.False(t, rule.MatchesExpression(Subject{Username: "fred"}, object))
+
+	rule = &AccessControlRule{Expression: &AccessControlExpression{Expression: "invalid"}}
+
+	assert.False(t, rule.MatchesExpression(Subject{Username: "john"}, object))
+}
+
+func TestShouldFailClosedOnExpressionErrors(t *testing.T) {
+	targetURL, err := url.ParseRequestURI("https://app.example.com/")
+	require.NoError(t, err)
+
+	object := NewObject(targetURL, fasthttp.MethodGet)
+	subject := Subject{Username: "john"}
+
+	testCases := []struct {
+		name       string
+		policy     string
+		expression string
+		expected   bool
+	}{
+		{"ShouldMatchDenyOnCompileError", deny, "user.name == 'john'", true},
+		{"ShouldMatchDenyOnEvaluationError", deny, "headers['x-missing'] == 'abc'", true},
+		{"ShouldNotMatchDenyOnFalse", deny, "subject.username == 'fred'", false},
+		{"ShouldNotMatchOneFactorOnCompileError", oneFactor, "user.name == 'john'", false},
+		{"ShouldNotMatchOneFactorOnEvaluationError", oneFactor, "headers['x-missing'] == 'abc'", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rule := NewAccessControlRule(1, schema.AccessControlRule{
+				Domains:    []string{"app.example.com"},
+				Policy:     tc.policy,
+				Expression: tc.expression,
+			}, nil, nil, nil)
+
+			assert.Equal(t, tc.expected, rule.IsMatch(subject, object))
+		})
+	}
+}
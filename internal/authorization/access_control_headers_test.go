@@ -0,0 +1,95 @@
+package authorization
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestNewAccessControlHeaders(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     [][]schema.AccessControlRuleHeader
+		expected []AccessControlHeaders
+		matches  [][]Object
+	}{
+		{
+			"ShouldSkipInvalidTypeEqual",
+			[][]schema.AccessControlRuleHeader{
+				{
+					{Operator: operatorEqual, Key: "X-Example", Value: 1},
+				},
+			},
+			[]AccessControlHeaders{{Rules: []ObjectMatcher(nil)}},
+			[][]Object{{{}}},
+		},
+		{
+			"ShouldSkipInvalidTypePattern",
+			[][]schema.AccessControlRuleHeader{
+				{
+					{Operator: operatorPattern, Key: "X-Example", Value: 1},
+				},
+			},
+			[]AccessControlHeaders{{Rules: []ObjectMatcher(nil)}},
+			[][]Object{{{}}},
+		},
+		{
+			"ShouldSkipInvalidOperator",
+			[][]schema.AccessControlRuleHeader{
+				{
+					{Operator: "nop", Key: "X-Example", Value: 1},
+				},
+			},
+			[]AccessControlHeaders{{Rules: []ObjectMatcher(nil)}},
+			[][]Object{{{}}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := NewAccessControlHeaders(tc.have)
+			assert.Equal(t, tc.expected, actual)
+
+			for i, rule := range actual {
+				for _, object := range tc.matches[i] {
+					assert.True(t, rule.IsMatch(object))
+				}
+			}
+		})
+	}
+}
+
+func TestAccessControlHeaders_IsMatch(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     schema.AccessControlRuleHeader
+		headers  http.Header
+		expected bool
+	}{
+		{"ShouldMatchEqual", schema.AccessControlRuleHeader{Operator: operatorEqual, Key: "x-tenant", Value: "abc"}, http.Header{"X-Tenant": []string{"abc"}}, true},
+		{"ShouldNotMatchEqual", schema.AccessControlRuleHeader{Operator: operatorEqual, Key: "X-Tenant", Value: "abc"}, http.Header{"X-Tenant": []string{"xyz"}}, false},
+		{"ShouldMatchNotEqual", schema.AccessControlRuleHeader{Operator: operatorNotEqual, Key: "X-Tenant", Value: "abc"}, http.Header{"X-Tenant": []string{"xyz"}}, true},
+		{"ShouldNotMatchNotEqual", schema.AccessControlRuleHeader{Operator: operatorNotEqual, Key: "X-Tenant", Value: "abc"}, http.Header{"X-Tenant": []string{"abc"}}, false},
+		{"ShouldMatchPresent", schema.AccessControlRuleHeader{Operator: operatorPresent, Key: "X-Tenant"}, http.Header{"X-Tenant": []string{""}}, true},
+		{"ShouldNotMatchPresent", schema.AccessControlRuleHeader{Operator: operatorPresent, Key: "X-Tenant"}, http.Header{}, false},
+		{"ShouldNotMatchPresentNilHeaders", schema.AccessControlRuleHeader{Operator: operatorPresent, Key: "X-Tenant"}, nil, false},
+		{"ShouldMatchAbsent", schema.AccessControlRuleHeader{Operator: operatorAbsent, Key: "X-Tenant"}, nil, true},
+		{"ShouldNotMatchAbsent", schema.AccessControlRuleHeader{Operator: operatorAbsent, Key: "X-Tenant"}, http.Header{"X-Tenant": []string{"abc"}}, false},
+		{"ShouldMatchPattern", schema.AccessControlRuleHeader{Operator: operatorPattern, Key: "User-Agent", Value: regexp.MustCompile(`^curl/`)}, http.Header{"User-Agent": []string{"curl/8.0.1"}}, true},
+		{"ShouldNotMatchPattern", schema.AccessControlRuleHeader{Operator: operatorPattern, Key: "User-Agent", Value: regexp.MustCompile(`^curl/`)}, http.Header{"User-Agent": []string{"Mozilla/5.0"}}, false},
+		{"ShouldMatchNotPattern", schema.AccessControlRuleHeader{Operator: operatorNotPattern, Key: "User-Agent", Value: regexp.MustCompile(`^curl/`)}, http.Header{"User-Agent": []string{"Mozilla/5.0"}}, true},
+		{"ShouldNotMatchNotPattern", schema.AccessControlRuleHeader{Operator: operatorNotPattern, Key: "User-Agent", Value: regexp.MustCompile(`^curl/`)}, http.Header{"User-Agent": []string{"curl/8.0.1"}}, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rule := &AccessControlRule{Headers: NewAccessControlHeaders([][]schema.AccessControlRuleHeader{{tc.have}})}
+
+			assert.Equal(t, tc.expected, rule.MatchesHeaders(Object{Headers: tc.headers}))
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package authorization
+
+import (
+	"time"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// NewAccessControlIdentityAssertion creates a new AccessControlIdentityAssertion from a
+// schema.AccessControlRuleIdentityAssertion.
+func NewAccessControlIdentityAssertion(config schema.AccessControlRuleIdentityAssertion) AccessControlIdentityAssertion {
+	return AccessControlIdentityAssertion{
+		Audience: config.Audience,
+		Lifespan: config.Lifespan,
+	}
+}
+
+// AccessControlIdentityAssertion represents the identity assertion options for an ACL. The zero values indicate the
+// options of the authz endpoint should be used.
+type AccessControlIdentityAssertion struct {
+	Audience []string
+	Lifespan time.Duration
+}
@@ -0,0 +1,44 @@
+package authorization
+
+import (
+	"time"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// NewAccessControlMaxAuthenticationAge creates a new AccessControlMaxAuthenticationAge from a
+// schema.AccessControlRuleMaxAuthenticationAge.
+func NewAccessControlMaxAuthenticationAge(config schema.AccessControlRuleMaxAuthenticationAge) AccessControlMaxAuthenticationAge {
+	return AccessControlMaxAuthenticationAge{
+		FirstFactor:  config.FirstFactor,
+		SecondFactor: config.SecondFactor,
+	}
+}
+
+// AccessControlMaxAuthenticationAge represents the maximum age of each authentication factor for an ACL.
+type AccessControlMaxAuthenticationAge struct {
+	FirstFactor  time.Duration
+	SecondFactor time.Duration
+}
+
+// IsZero returns true if neither of the maximum authentication ages are configured.
+func (a AccessControlMaxAuthenticationAge) IsZero() bool {
+	return a.FirstFactor <= 0 && a.SecondFactor <= 0
+}
+
+// EffectiveLevel returns the authentication.Level which remains valid given the current time, the current
+// authentication.Level, and the times the first and second factor were last authenticated. If the first factor is
+// older than the maximum then authentication.NotAuthenticated is returned, if the second factor is older than the
+// maximum then authentication.OneFactor is returned, otherwise the level is returned unchanged.
+func (a AccessControlMaxAuthenticationAge) EffectiveLevel(now time.Time, level authentication.Level, firstFactor, secondFactor time.Time) authentication.Level {
+	if a.FirstFactor > 0 && level >= authentication.OneFactor && now.Sub(firstFactor) > a.FirstFactor {
+		return authentication.NotAuthenticated
+	}
+
+	if a.SecondFactor > 0 && level >= authentication.TwoFactor && now.Sub(secondFactor) > a.SecondFactor {
+		return authentication.OneFactor
+	}
+
+	return level
+}
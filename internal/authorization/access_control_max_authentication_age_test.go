@@ -0,0 +1,88 @@
+package authorization
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestNewAccessControlMaxAuthenticationAge(t *testing.T) {
+	age := NewAccessControlMaxAuthenticationAge(schema.AccessControlRuleMaxAuthenticationAge{FirstFactor: time.Hour, SecondFactor: time.Minute})
+
+	assert.Equal(t, time.Hour, age.FirstFactor)
+	assert.Equal(t, time.Minute, age.SecondFactor)
+	assert.False(t, age.IsZero())
+	assert.True(t, AccessControlMaxAuthenticationAge{}.IsZero())
+}
+
+func TestAccessControlMaxAuthenticationAge_EffectiveLevel(t *testing.T) {
+	now := time.Unix(1000000, 0)
+
+	testCases := []struct {
+		name         string
+		have         AccessControlMaxAuthenticationAge
+		level        authentication.Level
+		firstFactor  time.Time
+		secondFactor time.Time
+		expected     authentication.Level
+	}{
+		{
+			"ShouldNotChangeLevelWithoutAges",
+			AccessControlMaxAuthenticationAge{},
+			authentication.TwoFactor,
+			time.Unix(0, 0),
+			time.Unix(0, 0),
+			authentication.TwoFactor,
+		},
+		{
+			"ShouldNotChangeLevelWithinAges",
+			AccessControlMaxAuthenticationAge{FirstFactor: time.Hour, SecondFactor: time.Minute},
+			authentication.TwoFactor,
+			now.Add(-time.Minute * 30),
+			now.Add(-time.Second * 30),
+			authentication.TwoFactor,
+		},
+		{
+			"ShouldLowerLevelToOneFactorWhenSecondFactorExceeded",
+			AccessControlMaxAuthenticationAge{FirstFactor: time.Hour, SecondFactor: time.Minute},
+			authentication.TwoFactor,
+			now.Add(-time.Minute * 30),
+			now.Add(-time.Minute * 2),
+			authentication.OneFactor,
+		},
+		{
+			"ShouldLowerLevelToNotAuthenticatedWhenFirstFactorExceeded",
+			AccessControlMaxAuthenticationAge{FirstFactor: time.Hour, SecondFactor: time.Minute},
+			authentication.TwoFactor,
+			now.Add(-time.Hour * 2),
+			now.Add(-time.Second * 30),
+			authentication.NotAuthenticated,
+		},
+		{
+			"ShouldIgnoreSecondFactorAgeForOneFactorLevel",
+			AccessControlMaxAuthenticationAge{SecondFactor: time.Minute},
+			authentication.OneFactor,
+			now,
+			time.Unix(0, 0),
+			authentication.OneFactor,
+		},
+		{
+			"ShouldIgnoreNotAuthenticated",
+			AccessControlMaxAuthenticationAge{FirstFactor: time.Minute},
+			authentication.NotAuthenticated,
+			time.Unix(0, 0),
+			time.Unix(0, 0),
+			authentication.NotAuthenticated,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.have.EffectiveLevel(now, tc.level, tc.firstFactor, tc.secondFactor))
+		})
+	}
+}
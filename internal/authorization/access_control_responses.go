@@ -0,0 +1,55 @@
+package authorization
+
+import (
+	"net/url"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// NewAccessControlResponses creates a new AccessControlResponses from a schema.AuthzResponses.
+func NewAccessControlResponses(config schema.AuthzResponses) AccessControlResponses {
+	return AccessControlResponses{
+		Forbidden:    NewAccessControlResponse(config.Forbidden),
+		Unauthorized: NewAccessControlResponse(config.Unauthorized),
+	}
+}
+
+// NewAccessControlResponse creates a new AccessControlResponse from a schema.AuthzResponse.
+func NewAccessControlResponse(config schema.AuthzResponse) AccessControlResponse {
+	return AccessControlResponse{
+		StatusCode:  config.StatusCode,
+		Template:    config.Template,
+		RedirectURL: config.RedirectURL,
+	}
+}
+
+// AccessControlResponses represents the customized responses sent when access is denied.
+type AccessControlResponses struct {
+	Forbidden    AccessControlResponse
+	Unauthorized AccessControlResponse
+}
+
+// AccessControlResponse represents a customized response sent when access is denied. The zero values indicate the
+// default response should be used.
+type AccessControlResponse struct {
+	StatusCode  int
+	Template    string
+	RedirectURL *url.URL
+}
+
+// Merge returns a copy of the AccessControlResponse with the zero values replaced by the values of the fallback.
+func (r AccessControlResponse) Merge(fallback AccessControlResponse) AccessControlResponse {
+	if r.StatusCode == 0 {
+		r.StatusCode = fallback.StatusCode
+	}
+
+	if r.Template == "" {
+		r.Template = fallback.Template
+	}
+
+	if r.RedirectURL == nil {
+		r.RedirectURL = fallback.RedirectURL
+	}
+
+	return r
+}
@@ -0,0 +1,58 @@
+package authorization
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestNewAccessControlResponses(t *testing.T) {
+	redirect := &url.URL{Scheme: "https", Host: "example.com", Path: "/denied"}
+
+	responses := NewAccessControlResponses(schema.AuthzResponses{
+		Forbidden:    schema.AuthzResponse{StatusCode: 404, Template: "/config/forbidden.html", RedirectURL: redirect},
+		Unauthorized: schema.AuthzResponse{StatusCode: 401},
+	})
+
+	assert.Equal(t, AccessControlResponse{StatusCode: 404, Template: "/config/forbidden.html", RedirectURL: redirect}, responses.Forbidden)
+	assert.Equal(t, AccessControlResponse{StatusCode: 401}, responses.Unauthorized)
+}
+
+func TestAccessControlResponse_Merge(t *testing.T) {
+	redirect := &url.URL{Scheme: "https", Host: "example.com", Path: "/denied"}
+
+	testCases := []struct {
+		name     string
+		have     AccessControlResponse
+		fallback AccessControlResponse
+		expected AccessControlResponse
+	}{
+		{
+			"ShouldUseFallback",
+			AccessControlResponse{},
+			AccessControlResponse{StatusCode: 404, Template: "a.html", RedirectURL: redirect},
+			AccessControlResponse{StatusCode: 404, Template: "a.html", RedirectURL: redirect},
+		},
+		{
+			"ShouldPreferValues",
+			AccessControlResponse{StatusCode: 403, Template: "b.json"},
+			AccessControlResponse{StatusCode: 404, Template: "a.html", RedirectURL: redirect},
+			AccessControlResponse{StatusCode: 403, Template: "b.json", RedirectURL: redirect},
+		},
+		{
+			"ShouldHandleEmpty",
+			AccessControlResponse{},
+			AccessControlResponse{},
+			AccessControlResponse{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.have.Merge(tc.fallback))
+		})
+	}
+}
@@ -3,30 +3,38 @@ package authorization
 import (
 	"net"
 
+	"github.com/authelia/authelia/v4/internal/clock"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/utils"
 )
 
 // NewAccessControlRules converts a schema.AccessControl into an AccessControlRule slice.
-func NewAccessControlRules(config schema.AccessControl) (rules []*AccessControlRule) {
+func NewAccessControlRules(config schema.AccessControl, clock clock.Provider) (rules []*AccessControlRule) {
 	networksMap, networksCacheMap := parseSchemaNetworks(config.Networks)
 
 	for i, schemaRule := range config.Rules {
-		rules = append(rules, NewAccessControlRule(i+1, schemaRule, networksMap, networksCacheMap))
+		rules = append(rules, NewAccessControlRule(i+1, schemaRule, networksMap, networksCacheMap, clock))
 	}
 
 	return rules
 }
 
 // NewAccessControlRule parses a schema ACL and generates an internal ACL.
-func NewAccessControlRule(pos int, rule schema.AccessControlRule, networksMap map[string][]*net.IPNet, networksCacheMap map[string]*net.IPNet) *AccessControlRule {
+func NewAccessControlRule(pos int, rule schema.AccessControlRule, networksMap map[string][]*net.IPNet, networksCacheMap map[string]*net.IPNet, clock clock.Provider) *AccessControlRule {
 	r := &AccessControlRule{
-		Position: pos,
-		Query:    NewAccessControlQuery(rule.Query),
-		Methods:  schemaMethodsToACL(rule.Methods),
-		Networks: schemaNetworksToACL(rule.Networks, networksMap, networksCacheMap),
-		Subjects: schemaSubjectsToACL(rule.Subjects),
-		Policy:   NewLevel(rule.Policy),
+		Position:  pos,
+		Query:     NewAccessControlQuery(rule.Query),
+		Headers:   NewAccessControlHeaders(rule.Headers),
+		Methods:   schemaMethodsToACL(rule.Methods),
+		Networks:  schemaNetworksToACL(rule.Networks, networksMap, networksCacheMap),
+		Countries: schemaCountriesToACL(rule.Countries),
+		ASNs:      schemaASNsToACL(rule.ASNs),
+		Subjects:  schemaSubjectsToACL(rule.Subjects),
+		Policy:    NewLevel(rule.Policy),
+
+		MaxAuthenticationAge: NewAccessControlMaxAuthenticationAge(rule.MaxAuthenticationAge),
+		IdentityAssertion:    NewAccessControlIdentityAssertion(rule.IdentityAssertion),
+		Responses:            NewAccessControlResponses(rule.Responses),
 	}
 
 	if len(r.Subjects) != 0 {
@@ -36,6 +44,9 @@ func NewAccessControlRule(pos int, rule schema.AccessControlRule, networksMap ma
 	ruleAddDomain(rule.Domains, r)
 	ruleAddDomainRegex(rule.DomainsRegex, r)
 	ruleAddResources(rule.Resources, r)
+	ruleAddExpression(pos, rule.Expression, r)
+	ruleAddSchedule(pos, rule.Schedule, clock, r)
+	ruleAddIdentityHeaders(pos, rule.IdentityHeaders, r)
 
 	return r
 }
@@ -48,10 +59,23 @@ type AccessControlRule struct {
 	Domains   []AccessControlDomain
 	Resources []AccessControlResource
 	Query     []AccessControlQuery
+	Headers   []AccessControlHeaders
 	Methods   []string
 	Networks  []*net.IPNet
+	Countries []string
+	ASNs      []uint
 	Subjects  []AccessControlSubjects
 	Policy    Level
+
+	Expression *AccessControlExpression
+	Schedule   *AccessControlSchedule
+
+	MaxAuthenticationAge AccessControlMaxAuthenticationAge
+
+	IdentityHeaders   []IdentityHeader
+	IdentityAssertion AccessControlIdentityAssertion
+
+	Responses AccessControlResponses
 }
 
 // IsMatch returns true if all elements of an AccessControlRule match the object and subject.
@@ -68,6 +92,10 @@ func (acr *AccessControlRule) IsMatch(subject Subject, object Object) (match boo
 		return false
 	}
 
+	if !acr.MatchesHeaders(object) {
+		return false
+	}
+
 	if !acr.MatchesMethods(object) {
 		return false
 	}
@@ -76,10 +104,26 @@ func (acr *AccessControlRule) IsMatch(subject Subject, object Object) (match boo
 		return false
 	}
 
+	if !acr.MatchesCountries(subject) {
+		return false
+	}
+
+	if !acr.MatchesASNs(subject) {
+		return false
+	}
+
 	if !acr.MatchesSubjects(subject) {
 		return false
 	}
 
+	if !acr.MatchesExpression(subject, object) {
+		return false
+	}
+
+	if !acr.MatchesSchedule() {
+		return false
+	}
+
 	return true
 }
 
@@ -134,6 +178,23 @@ func (acr *AccessControlRule) MatchesQuery(object Object) (match bool) {
 	return false
 }
 
+// MatchesHeaders returns true if the rule matches the request headers.
+func (acr *AccessControlRule) MatchesHeaders(object Object) (match bool) {
+	// If there are no header rules in this rule then the headers condition is a match.
+	if len(acr.Headers) == 0 {
+		return true
+	}
+
+	// Iterate over the headers until we find a match (return true) or until we exit the loop (return false).
+	for _, headers := range acr.Headers {
+		if headers.IsMatch(object) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // MatchesMethods returns true if the rule matches the method.
 func (acr *AccessControlRule) MatchesMethods(object Object) (match bool) {
 	// If there are no methods in this rule then the method condition is a match.
@@ -161,6 +222,36 @@ func (acr *AccessControlRule) MatchesNetworks(subject Subject) (match bool) {
 	return false
 }
 
+// MatchesCountries returns true if the rule matches the country of the subject.
+func (acr *AccessControlRule) MatchesCountries(subject Subject) (match bool) {
+	// If there are no countries in this rule then the countries condition is a match.
+	if len(acr.Countries) == 0 {
+		return true
+	}
+
+	return utils.IsStringInSliceFold(subject.Country, acr.Countries)
+}
+
+// MatchesASNs returns true if the rule matches the Autonomous System Number of the subject.
+func (acr *AccessControlRule) MatchesASNs(subject Subject) (match bool) {
+	// If there are no ASNs in this rule then the ASNs condition is a match.
+	if len(acr.ASNs) == 0 {
+		return true
+	}
+
+	if subject.ASN == 0 {
+		return false
+	}
+
+	for _, asn := range acr.ASNs {
+		if asn == subject.ASN {
+			return true
+		}
+	}
+
+	return false
+}
+
 // MatchesSubjects returns true if the rule matches the subjects.
 func (acr *AccessControlRule) MatchesSubjects(subject Subject) (match bool) {
 	if subject.IsAnonymous() {
@@ -188,3 +279,33 @@ func (acr *AccessControlRule) MatchesSubjectExact(subject Subject) (match bool)
 
 	return false
 }
+
+// MatchesExpression returns true if the rule matches the expression. If the expression references the subject and the
+// subject is anonymous this is considered a match so the user is required to authenticate, similar to MatchesSubjects.
+func (acr *AccessControlRule) MatchesExpression(subject Subject, object Object) (match bool) {
+	if acr.Expression != nil && acr.Expression.HasSubjects() && subject.IsAnonymous() {
+		return true
+	}
+
+	return acr.MatchesExpressionExact(subject, object)
+}
+
+// MatchesExpressionExact returns true if the rule matches the expression exactly.
+func (acr *AccessControlRule) MatchesExpressionExact(subject Subject, object Object) (match bool) {
+	// If there is no expression in this rule then the expression condition is a match.
+	if acr.Expression == nil {
+		return true
+	}
+
+	return acr.Expression.IsMatch(subject, object)
+}
+
+// MatchesSchedule returns true if the rule matches the schedule.
+func (acr *AccessControlRule) MatchesSchedule() (match bool) {
+	// If there is no schedule in this rule then the schedule condition is a match.
+	if acr.Schedule == nil {
+		return true
+	}
+
+	return acr.Schedule.IsMatch()
+}
@@ -0,0 +1,221 @@
+package authorization
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/authelia/authelia/v4/internal/clock"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// NewAccessControlSchedule parses a schema.AccessControlRuleSchedule into an AccessControlSchedule.
+func NewAccessControlSchedule(config schema.AccessControlRuleSchedule, clock clock.Provider) (schedule *AccessControlSchedule, err error) {
+	schedule = &AccessControlSchedule{
+		Location: time.Local,
+		clock:    clock,
+	}
+
+	if config.Timezone != "" {
+		if schedule.Location, err = time.LoadLocation(config.Timezone); err != nil {
+			return nil, fmt.Errorf("option 'timezone' is invalid: %w", err)
+		}
+	}
+
+	for _, day := range config.Weekdays {
+		weekday, ok := scheduleWeekdays[strings.ToLower(day)]
+		if !ok {
+			return nil, fmt.Errorf("option 'weekdays' has an invalid value '%s': must be the full or abbreviated name of a day of the week", day)
+		}
+
+		schedule.Weekdays = append(schedule.Weekdays, weekday)
+	}
+
+	for _, value := range config.Times {
+		var r AccessControlScheduleTimeRange
+
+		if r, err = parseScheduleTimeRange(value); err != nil {
+			return nil, fmt.Errorf("option 'times' has an invalid value '%s': %w", value, err)
+		}
+
+		schedule.Times = append(schedule.Times, r)
+	}
+
+	if config.NotBefore != "" {
+		if schedule.NotBefore, err = parseScheduleDate(config.NotBefore, schedule.Location, false); err != nil {
+			return nil, fmt.Errorf("option 'not_before' is invalid: %w", err)
+		}
+	}
+
+	if config.NotAfter != "" {
+		if schedule.NotAfter, err = parseScheduleDate(config.NotAfter, schedule.Location, true); err != nil {
+			return nil, fmt.Errorf("option 'not_after' is invalid: %w", err)
+		}
+	}
+
+	if !schedule.NotBefore.IsZero() && !schedule.NotAfter.IsZero() && !schedule.NotAfter.After(schedule.NotBefore) {
+		return nil, fmt.Errorf("option 'not_after' must be after the option 'not_before'")
+	}
+
+	return schedule, nil
+}
+
+// AccessControlSchedule represents an ACL schedule criteria.
+type AccessControlSchedule struct {
+	Location  *time.Location
+	Weekdays  []time.Weekday
+	Times     []AccessControlScheduleTimeRange
+	NotBefore time.Time
+	NotAfter  time.Time
+
+	clock   clock.Provider
+	invalid bool
+}
+
+// AccessControlScheduleTimeRange represents a time of day range in an AccessControlSchedule as the offset from midnight.
+// When the end is before the start the range spans midnight.
+type AccessControlScheduleTimeRange struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// IsMatch returns true if the current time of the clock is within the schedule.
+func (s *AccessControlSchedule) IsMatch() (match bool) {
+	return s.IsMatchTime(s.clock.Now())
+}
+
+// IsMatchTime returns true if the provided time is within the schedule.
+func (s *AccessControlSchedule) IsMatchTime(t time.Time) (match bool) {
+	if s.invalid {
+		return false
+	}
+
+	t = t.In(s.Location)
+
+	if !s.NotBefore.IsZero() && t.Before(s.NotBefore) {
+		return false
+	}
+
+	if !s.NotAfter.IsZero() && !t.Before(s.NotAfter) {
+		return false
+	}
+
+	if len(s.Times) == 0 {
+		return s.isMatchWeekday(t.Weekday())
+	}
+
+	offset := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+
+	for _, r := range s.Times {
+		if r.IsMatch(offset) && s.isMatchWeekday(r.StartWeekday(t.Weekday(), offset)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *AccessControlSchedule) isMatchWeekday(weekday time.Weekday) bool {
+	if len(s.Weekdays) == 0 {
+		return true
+	}
+
+	for _, day := range s.Weekdays {
+		if day == weekday {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsMatch returns true if the offset from midnight is within the range.
+func (r AccessControlScheduleTimeRange) IsMatch(offset time.Duration) bool {
+	if r.End > r.Start {
+		return offset >= r.Start && offset < r.End
+	}
+
+	return offset >= r.Start || offset < r.End
+}
+
+// StartWeekday returns the weekday the range started on given the weekday and offset from midnight of a time within the
+// range. This is the previous weekday when the range spans midnight and the offset is after midnight.
+func (r AccessControlScheduleTimeRange) StartWeekday(weekday time.Weekday, offset time.Duration) time.Weekday {
+	if r.End < r.Start && offset < r.End {
+		return (weekday + 6) % 7
+	}
+
+	return weekday
+}
+
+func parseScheduleTimeRange(value string) (r AccessControlScheduleTimeRange, err error) {
+	parts := strings.Split(value, "-")
+
+	if len(parts) != 2 {
+		return r, fmt.Errorf("must be in the format 'HH:MM-HH:MM'")
+	}
+
+	if r.Start, err = parseScheduleTimeOfDay(parts[0]); err != nil {
+		return r, err
+	}
+
+	if r.End, err = parseScheduleTimeOfDay(parts[1]); err != nil {
+		return r, err
+	}
+
+	if r.Start == r.End {
+		return r, fmt.Errorf("the start and end must not be equal")
+	}
+
+	return r, nil
+}
+
+func parseScheduleTimeOfDay(value string) (offset time.Duration, err error) {
+	value = strings.TrimSpace(value)
+
+	if value == "24:00" {
+		return time.Hour * 24, nil
+	}
+
+	var t time.Time
+
+	if t, err = time.Parse(scheduleLayoutTime, value); err != nil {
+		return 0, fmt.Errorf("the time '%s' must be in the format 'HH:MM'", value)
+	}
+
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func parseScheduleDate(value string, location *time.Location, end bool) (t time.Time, err error) {
+	if t, err = time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	if t, err = time.ParseInLocation(scheduleLayoutDate, value, location); err != nil {
+		return t, fmt.Errorf("the value '%s' must be a date in the format 'YYYY-MM-DD' or a RFC3339 timestamp", value)
+	}
+
+	if end {
+		// A date without a time includes the entire day.
+		t = t.AddDate(0, 0, 1)
+	}
+
+	return t, nil
+}
+
+var scheduleWeekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"sun":       time.Sunday,
+	"monday":    time.Monday,
+	"mon":       time.Monday,
+	"tuesday":   time.Tuesday,
+	"tue":       time.Tuesday,
+	"wednesday": time.Wednesday,
+	"wed":       time.Wednesday,
+	"thursday":  time.Thursday,
+	"thu":       time.Thursday,
+	"friday":    time.Friday,
+	"fri":       time.Friday,
+	"saturday":  time.Saturday,
+	"sat":       time.Saturday,
+}
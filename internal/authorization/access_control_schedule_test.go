@@ -0,0 +1,166 @@
+package authorization
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/clock"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestNewAccessControlSchedule(t *testing.T) {
+	testCases := []struct {
+		name string
+		have schema.AccessControlRuleSchedule
+		err  string
+	}{
+		{
+			"ShouldParseFullSchedule",
+			schema.AccessControlRuleSchedule{
+				Timezone:  "Australia/Melbourne",
+				Weekdays:  []string{"Monday", "tue", "WED"},
+				Times:     []string{"09:00-17:00", "22:00-02:00"},
+				NotBefore: "2023-01-01",
+				NotAfter:  "2023-12-31T00:00:00Z",
+			},
+			"",
+		},
+		{
+			"ShouldErrorInvalidTimezone",
+			schema.AccessControlRuleSchedule{Timezone: "Mars/Olympus"},
+			"option 'timezone' is invalid: unknown time zone Mars/Olympus",
+		},
+		{
+			"ShouldErrorInvalidWeekday",
+			schema.AccessControlRuleSchedule{Weekdays: []string{"funday"}},
+			"option 'weekdays' has an invalid value 'funday': must be the full or abbreviated name of a day of the week",
+		},
+		{
+			"ShouldErrorInvalidTimeFormat",
+			schema.AccessControlRuleSchedule{Times: []string{"09:00"}},
+			"option 'times' has an invalid value '09:00': must be in the format 'HH:MM-HH:MM'",
+		},
+		{
+			"ShouldErrorInvalidTime",
+			schema.AccessControlRuleSchedule{Times: []string{"09:00-25:00"}},
+			"option 'times' has an invalid value '09:00-25:00': the time '25:00' must be in the format 'HH:MM'",
+		},
+		{
+			"ShouldErrorEqualTimes",
+			schema.AccessControlRuleSchedule{Times: []string{"09:00-09:00"}},
+			"option 'times' has an invalid value '09:00-09:00': the start and end must not be equal",
+		},
+		{
+			"ShouldErrorInvalidNotBefore",
+			schema.AccessControlRuleSchedule{NotBefore: "tomorrow"},
+			"option 'not_before' is invalid: the value 'tomorrow' must be a date in the format 'YYYY-MM-DD' or a RFC3339 timestamp",
+		},
+		{
+			"ShouldErrorNotAfterBeforeNotBefore",
+			schema.AccessControlRuleSchedule{NotBefore: "2023-02-01", NotAfter: "2023-01-01", Timezone: "UTC"},
+			"option 'not_after' must be after the option 'not_before'",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			schedule, err := NewAccessControlSchedule(tc.have, clock.New())
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+				assert.NotNil(t, schedule)
+			} else {
+				assert.EqualError(t, err, tc.err)
+				assert.Nil(t, schedule)
+			}
+		})
+	}
+}
+
+func TestAccessControlSchedule_IsMatch(t *testing.T) {
+	config := schema.AccessControlRuleSchedule{
+		Timezone:  "Australia/Melbourne",
+		Weekdays:  []string{"monday", "tuesday", "wednesday", "thursday", "friday"},
+		Times:     []string{"09:00-17:00", "22:00-02:00"},
+		NotBefore: "2023-01-01",
+		NotAfter:  "2023-12-31",
+	}
+
+	location, err := time.LoadLocation("Australia/Melbourne")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		have     time.Time
+		expected bool
+	}{
+		{"ShouldMatchBusinessHours", time.Date(2023, 6, 5, 9, 0, 0, 0, location), true},
+		{"ShouldMatchBusinessHoursUTC", time.Date(2023, 6, 5, 2, 0, 0, 0, time.UTC), true},
+		{"ShouldNotMatchEndOfBusinessHours", time.Date(2023, 6, 5, 17, 0, 0, 0, location), false},
+		{"ShouldMatchOvernightBeforeMidnight", time.Date(2023, 6, 5, 23, 30, 0, 0, location), true},
+		{"ShouldMatchOvernightAfterMidnight", time.Date(2023, 6, 6, 1, 30, 0, 0, location), true},
+		{"ShouldNotMatchOvernightAfterMidnightStartedOnWeekend", time.Date(2023, 6, 5, 1, 30, 0, 0, location), false},
+		{"ShouldMatchOvernightAfterMidnightStartedOnWeekday", time.Date(2023, 6, 10, 1, 30, 0, 0, location), true},
+		{"ShouldNotMatchOvernightBeforeMidnightOnWeekend", time.Date(2023, 6, 10, 23, 30, 0, 0, location), false},
+		{"ShouldNotMatchWeekend", time.Date(2023, 6, 3, 10, 0, 0, 0, location), false},
+		{"ShouldNotMatchBeforeNotBefore", time.Date(2022, 12, 30, 10, 0, 0, 0, location), false},
+		{"ShouldMatchLastDay", time.Date(2023, 12, 29, 16, 59, 59, 0, location), true},
+		{"ShouldNotMatchAfterNotAfter", time.Date(2024, 1, 2, 10, 0, 0, 0, location), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			schedule, err := NewAccessControlSchedule(config, clock.NewFixed(tc.have))
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, schedule.IsMatch())
+
+			rule := &AccessControlRule{Schedule: schedule}
+
+			assert.Equal(t, tc.expected, rule.MatchesSchedule())
+		})
+	}
+}
+
+func TestAccessControlSchedule_ShouldMatchRangeSpanningMidnightAgainstStartWeekday(t *testing.T) {
+	config := schema.AccessControlRuleSchedule{
+		Timezone: "UTC",
+		Weekdays: []string{"friday"},
+		Times:    []string{"22:00-02:00"},
+	}
+
+	testCases := []struct {
+		name     string
+		have     time.Time
+		expected bool
+	}{
+		{"ShouldMatchFridayBeforeMidnight", time.Date(2023, 6, 9, 22, 0, 0, 0, time.UTC), true},
+		{"ShouldMatchSaturdayAfterMidnight", time.Date(2023, 6, 10, 1, 59, 59, 0, time.UTC), true},
+		{"ShouldNotMatchSaturdayAfterRange", time.Date(2023, 6, 10, 2, 0, 0, 0, time.UTC), false},
+		{"ShouldNotMatchFridayAfterMidnight", time.Date(2023, 6, 9, 1, 0, 0, 0, time.UTC), false},
+		{"ShouldNotMatchSaturdayBeforeMidnight", time.Date(2023, 6, 10, 23, 0, 0, 0, time.UTC), false},
+		{"ShouldNotMatchThursdayBeforeMidnight", time.Date(2023, 6, 8, 23, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			schedule, err := NewAccessControlSchedule(config, clock.NewFixed(tc.have))
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, schedule.IsMatch())
+		})
+	}
+}
+
+func TestAccessControlSchedule_ShouldNotMatchInvalid(t *testing.T) {
+	rule := &AccessControlRule{}
+
+	ruleAddSchedule(1, &schema.AccessControlRuleSchedule{Timezone: "Mars/Olympus"}, clock.New(), rule)
+
+	require.NotNil(t, rule.Schedule)
+	assert.False(t, rule.MatchesSchedule())
+	assert.True(t, (&AccessControlRule{}).MatchesSchedule())
+}
@@ -1,72 +1,112 @@
 package authorization
 
 import (
+	"sync/atomic"
+
 	"github.com/sirupsen/logrus"
 
+	"github.com/authelia/authelia/v4/internal/clock"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/logging"
 )
 
 // Authorizer the component in charge of checking whether a user can access a given resource.
 type Authorizer struct {
+	acl    atomic.Pointer[authorizerACL]
+	clock  clock.Provider
+	config *schema.Configuration
+	log    *logrus.Logger
+}
+
+// authorizerACL is the compiled access control configuration used by the Authorizer. It is immutable once created so
+// it can be swapped atomically when the access control configuration is reloaded.
+type authorizerACL struct {
 	defaultPolicy Level
 	rules         []*AccessControlRule
 	mfa           bool
-	config        *schema.Configuration
-	log           *logrus.Logger
 }
 
 // NewAuthorizer create an instance of authorizer with a given access control config.
-func NewAuthorizer(config *schema.Configuration) (authorizer *Authorizer) {
+func NewAuthorizer(config *schema.Configuration, clock clock.Provider) (authorizer *Authorizer) {
 	authorizer = &Authorizer{
-		defaultPolicy: NewLevel(config.AccessControl.DefaultPolicy),
-		rules:         NewAccessControlRules(config.AccessControl),
-		config:        config,
-		log:           logging.Logger(),
+		clock:  clock,
+		config: config,
+		log:    logging.Logger(),
 	}
 
-	if authorizer.defaultPolicy == TwoFactor {
-		authorizer.mfa = true
+	authorizer.acl.Store(authorizer.newACL(config.AccessControl))
+
+	return authorizer
+}
 
-		return authorizer
+// Reload replaces the access control configuration used by the Authorizer. Requests which are being evaluated while
+// the configuration is reloaded are evaluated entirely against either the previous or the new configuration.
+func (p *Authorizer) Reload(config schema.AccessControl) {
+	p.acl.Store(p.newACL(config))
+}
+
+func (p *Authorizer) load() *authorizerACL {
+	return p.acl.Load()
+}
+
+func (p *Authorizer) newACL(config schema.AccessControl) (acl *authorizerACL) {
+	acl = &authorizerACL{
+		defaultPolicy: NewLevel(config.DefaultPolicy),
+		rules:         NewAccessControlRules(config, p.clock),
 	}
 
-	for _, rule := range authorizer.rules {
+	if acl.defaultPolicy == TwoFactor {
+		acl.mfa = true
+
+		return acl
+	}
+
+	for _, rule := range acl.rules {
 		if rule.Policy == TwoFactor {
-			authorizer.mfa = true
+			acl.mfa = true
 
-			return authorizer
+			return acl
 		}
 	}
 
-	if authorizer.config.IdentityProviders.OIDC != nil {
-		for _, client := range authorizer.config.IdentityProviders.OIDC.Clients {
+	if p.config.IdentityProviders.OIDC != nil {
+		for _, client := range p.config.IdentityProviders.OIDC.Clients {
 			if client.AuthorizationPolicy == twoFactor {
-				authorizer.mfa = true
+				acl.mfa = true
 
-				return authorizer
+				return acl
 			}
 		}
 	}
 
-	return authorizer
+	return acl
 }
 
 // IsSecondFactorEnabled return true if at least one policy is set to second factor.
 func (p *Authorizer) IsSecondFactorEnabled() bool {
-	return p.mfa
+	return p.load().mfa
 }
 
 // GetRequiredLevel retrieve the required level of authorization to access the object.
 func (p *Authorizer) GetRequiredLevel(subject Subject, object Object) (hasSubjects bool, level Level) {
+	_, hasSubjects, level = p.GetRequiredPolicy(subject, object)
+
+	return hasSubjects, level
+}
+
+// GetRequiredPolicy retrieve the rule which applies to the object alongside the required level of authorization to
+// access the object. The rule is nil when the default policy is applied.
+func (p *Authorizer) GetRequiredPolicy(subject Subject, object Object) (rule *AccessControlRule, hasSubjects bool, level Level) {
 	p.log.Debugf("Check authorization of subject %s and object %s (method %s).",
 		subject.String(), object.String(), object.Method)
 
-	for _, rule := range p.rules {
+	acl := p.load()
+
+	for _, rule = range acl.rules {
 		if rule.IsMatch(subject, object) {
 			p.log.Tracef(traceFmtACLHitMiss, "HIT", rule.Position, subject, object, object.Method)
 
-			return rule.HasSubjects, rule.Policy
+			return rule, rule.HasSubjects, rule.Policy
 		}
 
 		p.log.Tracef(traceFmtACLHitMiss, "MISS", rule.Position, subject, object, object.Method)
@@ -74,16 +114,18 @@ func (p *Authorizer) GetRequiredLevel(subject Subject, object Object) (hasSubjec
 
 	p.log.Debugf("No matching rule for subject %s and url %s (method %s) applying default policy", subject, object, object.Method)
 
-	return false, p.defaultPolicy
+	return nil, false, acl.defaultPolicy
 }
 
 // GetRuleMatchResults iterates through the rules and produces a list of RuleMatchResult provided a subject and object.
 func (p *Authorizer) GetRuleMatchResults(subject Subject, object Object) (results []RuleMatchResult) {
 	skipped := false
 
-	results = make([]RuleMatchResult, len(p.rules))
+	rules := p.load().rules
+
+	results = make([]RuleMatchResult, len(rules))
 
-	for i, rule := range p.rules {
+	for i, rule := range rules {
 		results[i] = RuleMatchResult{
 			Rule:    rule,
 			Skipped: skipped,
@@ -91,10 +133,18 @@ func (p *Authorizer) GetRuleMatchResults(subject Subject, object Object) (result
 			MatchDomain:        rule.MatchesDomains(subject, object),
 			MatchResources:     rule.MatchesResources(subject, object),
 			MatchQuery:         rule.MatchesQuery(object),
+			MatchHeaders:       rule.MatchesHeaders(object),
 			MatchMethods:       rule.MatchesMethods(object),
 			MatchNetworks:      rule.MatchesNetworks(subject),
+			MatchCountries:     rule.MatchesCountries(subject),
+			MatchASNs:          rule.MatchesASNs(subject),
 			MatchSubjects:      rule.MatchesSubjects(subject),
 			MatchSubjectsExact: rule.MatchesSubjectExact(subject),
+
+			MatchExpression:      rule.MatchesExpression(subject, object),
+			MatchExpressionExact: rule.MatchesExpressionExact(subject, object),
+
+			MatchSchedule: rule.MatchesSchedule(),
 		}
 
 		skipped = skipped || results[i].IsMatch()
@@ -5,12 +5,14 @@ import (
 	"net/url"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/valyala/fasthttp"
 
+	"github.com/authelia/authelia/v4/internal/clock"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 )
 
@@ -28,7 +30,7 @@ func NewAuthorizerTester(config schema.AccessControl) *AuthorizerTester {
 	}
 
 	return &AuthorizerTester{
-		NewAuthorizer(fullConfig),
+		NewAuthorizer(fullConfig, clock.New()),
 	}
 }
 
@@ -209,6 +211,39 @@ func (s *AuthorizerSuite) TestShouldCheckFactorsPolicy() {
 	tester.CheckAuthorizations(s.T(), UserWithGroups, "https://example.com/", fasthttp.MethodGet, Denied)
 }
 
+func (s *AuthorizerSuite) TestShouldReturnRequiredPolicyRule() {
+	tester := NewAuthorizerBuilder().
+		WithDefaultPolicy(deny).
+		WithRule(schema.AccessControlRule{
+			Domains: []string{"finance.example.com"},
+			Policy:  twoFactor,
+			MaxAuthenticationAge: schema.AccessControlRuleMaxAuthenticationAge{
+				FirstFactor:  time.Hour,
+				SecondFactor: time.Minute * 15,
+			},
+		}).
+		Build()
+
+	targetURL, _ := url.ParseRequestURI("https://finance.example.com/")
+
+	rule, hasSubjects, level := tester.GetRequiredPolicy(UserWithGroups, NewObject(targetURL, fasthttp.MethodGet))
+
+	s.Require().NotNil(rule)
+	s.False(hasSubjects)
+	s.Equal(TwoFactor, level)
+	s.Equal(1, rule.Position)
+	s.Equal(time.Hour, rule.MaxAuthenticationAge.FirstFactor)
+	s.Equal(time.Minute*15, rule.MaxAuthenticationAge.SecondFactor)
+
+	targetURL, _ = url.ParseRequestURI("https://example.com/")
+
+	rule, hasSubjects, level = tester.GetRequiredPolicy(UserWithGroups, NewObject(targetURL, fasthttp.MethodGet))
+
+	s.Nil(rule)
+	s.False(hasSubjects)
+	s.Equal(Denied, level)
+}
+
 func (s *AuthorizerSuite) TestShouldCheckQueryPolicy() {
 	tester := NewAuthorizerBuilder().
 		WithDefaultPolicy(deny).
@@ -398,57 +433,57 @@ func (s *AuthorizerSuite) TestShouldCheckDomainMatching() {
 	tester.CheckAuthorizations(s.T(), Bob, "https://x.example.com", fasthttp.MethodGet, TwoFactor)
 	tester.CheckAuthorizations(s.T(), AnonymousUser, "https://x.example.com", fasthttp.MethodGet, OneFactor)
 
-	s.Require().Len(tester.rules, 5)
+	s.Require().Len(tester.load().rules, 5)
 
-	s.Require().Len(tester.rules[0].Domains, 1)
+	s.Require().Len(tester.load().rules[0].Domains, 1)
 
 	s.Assert().Equal("public.example.com", tester.config.AccessControl.Rules[0].Domains[0])
 
-	ruleMatcher0, ok := tester.rules[0].Domains[0].Matcher.(*AccessControlDomainMatcher)
+	ruleMatcher0, ok := tester.load().rules[0].Domains[0].Matcher.(*AccessControlDomainMatcher)
 	s.Require().True(ok)
 	s.Assert().Equal("public.example.com", ruleMatcher0.Name)
 	s.Assert().False(ruleMatcher0.Wildcard)
 	s.Assert().False(ruleMatcher0.UserWildcard)
 	s.Assert().False(ruleMatcher0.GroupWildcard)
 
-	s.Require().Len(tester.rules[1].Domains, 1)
+	s.Require().Len(tester.load().rules[1].Domains, 1)
 
 	s.Assert().Equal("one-factor.example.com", tester.config.AccessControl.Rules[1].Domains[0])
 
-	ruleMatcher1, ok := tester.rules[1].Domains[0].Matcher.(*AccessControlDomainMatcher)
+	ruleMatcher1, ok := tester.load().rules[1].Domains[0].Matcher.(*AccessControlDomainMatcher)
 	s.Require().True(ok)
 	s.Assert().Equal("one-factor.example.com", ruleMatcher1.Name)
 	s.Assert().False(ruleMatcher1.Wildcard)
 	s.Assert().False(ruleMatcher1.UserWildcard)
 	s.Assert().False(ruleMatcher1.GroupWildcard)
 
-	s.Require().Len(tester.rules[2].Domains, 1)
+	s.Require().Len(tester.load().rules[2].Domains, 1)
 
 	s.Assert().Equal("two-factor.example.com", tester.config.AccessControl.Rules[2].Domains[0])
 
-	ruleMatcher2, ok := tester.rules[2].Domains[0].Matcher.(*AccessControlDomainMatcher)
+	ruleMatcher2, ok := tester.load().rules[2].Domains[0].Matcher.(*AccessControlDomainMatcher)
 	s.Require().True(ok)
 	s.Assert().Equal("two-factor.example.com", ruleMatcher2.Name)
 	s.Assert().False(ruleMatcher2.Wildcard)
 	s.Assert().False(ruleMatcher2.UserWildcard)
 	s.Assert().False(ruleMatcher2.GroupWildcard)
 
-	s.Require().Len(tester.rules[3].Domains, 1)
+	s.Require().Len(tester.load().rules[3].Domains, 1)
 
 	s.Assert().Equal("*.example.com", tester.config.AccessControl.Rules[3].Domains[0])
 
-	ruleMatcher3, ok := tester.rules[3].Domains[0].Matcher.(*AccessControlDomainMatcher)
+	ruleMatcher3, ok := tester.load().rules[3].Domains[0].Matcher.(*AccessControlDomainMatcher)
 	s.Require().True(ok)
 	s.Assert().Equal(".example.com", ruleMatcher3.Name)
 	s.Assert().True(ruleMatcher3.Wildcard)
 	s.Assert().False(ruleMatcher3.UserWildcard)
 	s.Assert().False(ruleMatcher3.GroupWildcard)
 
-	s.Require().Len(tester.rules[4].Domains, 1)
+	s.Require().Len(tester.load().rules[4].Domains, 1)
 
 	s.Assert().Equal("*.example.com", tester.config.AccessControl.Rules[4].Domains[0])
 
-	ruleMatcher4, ok := tester.rules[4].Domains[0].Matcher.(*AccessControlDomainMatcher)
+	ruleMatcher4, ok := tester.load().rules[4].Domains[0].Matcher.(*AccessControlDomainMatcher)
 	s.Require().True(ok)
 	s.Assert().Equal(".example.com", ruleMatcher4.Name)
 	s.Assert().True(ruleMatcher4.Wildcard)
@@ -495,45 +530,45 @@ func (s *AuthorizerSuite) TestShouldCheckDomainRegexMatching() {
 	tester.CheckAuthorizations(s.T(), John, "https://group-dev.regex.com", fasthttp.MethodGet, TwoFactor)
 	tester.CheckAuthorizations(s.T(), Bob, "https://group-dev.regex.com", fasthttp.MethodGet, Denied)
 
-	s.Require().Len(tester.rules, 5)
+	s.Require().Len(tester.load().rules, 5)
 
-	s.Require().Len(tester.rules[0].Domains, 1)
+	s.Require().Len(tester.load().rules[0].Domains, 1)
 
 	s.Assert().Equal("^.*\\.example.com$", tester.config.AccessControl.Rules[0].DomainsRegex[0].String())
 
-	ruleMatcher0, ok := tester.rules[0].Domains[0].Matcher.(RegexpStringSubjectMatcher)
+	ruleMatcher0, ok := tester.load().rules[0].Domains[0].Matcher.(RegexpStringSubjectMatcher)
 	s.Require().True(ok)
 	s.Assert().Equal("^.*\\.example.com$", ruleMatcher0.String())
 
-	s.Require().Len(tester.rules[1].Domains, 1)
+	s.Require().Len(tester.load().rules[1].Domains, 1)
 
 	s.Assert().Equal("^.*\\.example2.com$", tester.config.AccessControl.Rules[1].DomainsRegex[0].String())
 
-	ruleMatcher1, ok := tester.rules[1].Domains[0].Matcher.(RegexpStringSubjectMatcher)
+	ruleMatcher1, ok := tester.load().rules[1].Domains[0].Matcher.(RegexpStringSubjectMatcher)
 	s.Require().True(ok)
 	s.Assert().Equal("^.*\\.example2.com$", ruleMatcher1.String())
 
-	s.Require().Len(tester.rules[2].Domains, 1)
+	s.Require().Len(tester.load().rules[2].Domains, 1)
 
 	s.Assert().Equal("^(?P<User>[a-zA-Z0-9]+)\\.regex.com$", tester.config.AccessControl.Rules[2].DomainsRegex[0].String())
 
-	ruleMatcher2, ok := tester.rules[2].Domains[0].Matcher.(RegexpGroupStringSubjectMatcher)
+	ruleMatcher2, ok := tester.load().rules[2].Domains[0].Matcher.(RegexpGroupStringSubjectMatcher)
 	s.Require().True(ok)
 	s.Assert().Equal("^(?P<User>[a-zA-Z0-9]+)\\.regex.com$", ruleMatcher2.String())
 
-	s.Require().Len(tester.rules[3].Domains, 1)
+	s.Require().Len(tester.load().rules[3].Domains, 1)
 
 	s.Assert().Equal("^group-(?P<Group>[a-zA-Z0-9]+)\\.regex.com$", tester.config.AccessControl.Rules[3].DomainsRegex[0].String())
 
-	ruleMatcher3, ok := tester.rules[3].Domains[0].Matcher.(RegexpGroupStringSubjectMatcher)
+	ruleMatcher3, ok := tester.load().rules[3].Domains[0].Matcher.(RegexpGroupStringSubjectMatcher)
 	s.Require().True(ok)
 	s.Assert().Equal("^group-(?P<Group>[a-zA-Z0-9]+)\\.regex.com$", ruleMatcher3.String())
 
-	s.Require().Len(tester.rules[4].Domains, 1)
+	s.Require().Len(tester.load().rules[4].Domains, 1)
 
 	s.Assert().Equal("^.*\\.(one|two).com$", tester.config.AccessControl.Rules[4].DomainsRegex[0].String())
 
-	ruleMatcher4, ok := tester.rules[4].Domains[0].Matcher.(RegexpStringSubjectMatcher)
+	ruleMatcher4, ok := tester.load().rules[4].Domains[0].Matcher.(RegexpStringSubjectMatcher)
 	s.Require().True(ok)
 	s.Assert().Equal("^.*\\.(one|two).com$", ruleMatcher4.String())
 }
@@ -603,25 +638,25 @@ func (s *AuthorizerSuite) TestShouldCheckResourceSubjectMatching() {
 	tester.CheckAuthorizations(s.T(), Bob, "https://id.example.com/invalidgroup/group", fasthttp.MethodGet, Denied)
 	tester.CheckAuthorizations(s.T(), AnonymousUser, "https://id.example.com/invalidgroup/group", fasthttp.MethodGet, OneFactor)
 
-	s.Require().Len(tester.rules, 3)
+	s.Require().Len(tester.load().rules, 3)
 
-	s.Require().Len(tester.rules[0].Resources, 2)
+	s.Require().Len(tester.load().rules[0].Resources, 2)
 
-	ruleMatcher00, ok := tester.rules[0].Resources[0].Matcher.(RegexpGroupStringSubjectMatcher)
+	ruleMatcher00, ok := tester.load().rules[0].Resources[0].Matcher.(RegexpGroupStringSubjectMatcher)
 	s.Require().True(ok)
 	s.Assert().Equal("^/(?P<User>[a-zA-Z0-9]+)/personal(/|/.*)?$", ruleMatcher00.String())
 
-	ruleMatcher01, ok := tester.rules[0].Resources[1].Matcher.(RegexpGroupStringSubjectMatcher)
+	ruleMatcher01, ok := tester.load().rules[0].Resources[1].Matcher.(RegexpGroupStringSubjectMatcher)
 	s.Require().True(ok)
 	s.Assert().Equal("^/(?P<Group>[a-zA-Z0-9]+)/group(/|/.*)?$", ruleMatcher01.String())
 
-	s.Require().Len(tester.rules[1].Resources, 2)
+	s.Require().Len(tester.load().rules[1].Resources, 2)
 
-	ruleMatcher10, ok := tester.rules[1].Resources[0].Matcher.(RegexpStringSubjectMatcher)
+	ruleMatcher10, ok := tester.load().rules[1].Resources[0].Matcher.(RegexpStringSubjectMatcher)
 	s.Require().True(ok)
 	s.Assert().Equal("^/([a-zA-Z0-9]+)/personal(/|/.*)?$", ruleMatcher10.String())
 
-	ruleMatcher11, ok := tester.rules[1].Resources[1].Matcher.(RegexpStringSubjectMatcher)
+	ruleMatcher11, ok := tester.load().rules[1].Resources[1].Matcher.(RegexpStringSubjectMatcher)
 	s.Require().True(ok)
 	s.Assert().Equal("^/([a-zA-Z0-9]+)/group(/|/.*)?$", ruleMatcher11.String())
 }
@@ -729,6 +764,51 @@ func (s *AuthorizerSuite) TestShouldCheckIPMatching() {
 	tester.CheckAuthorizations(s.T(), Sam, "https://ipv6.example.com/", fasthttp.MethodGet, TwoFactor)
 }
 
+func (s *AuthorizerSuite) TestShouldCheckCountryMatching() {
+	tester := NewAuthorizerBuilder().
+		WithDefaultPolicy(deny).
+		WithRule(schema.AccessControlRule{
+			Domains:   []string{"protected.example.com"},
+			Policy:    bypass,
+			Countries: []string{"AU", "nz"},
+		}).
+		WithRule(schema.AccessControlRule{
+			Domains:   []string{"protected.example.com"},
+			Policy:    twoFactor,
+			Countries: []string{"US"},
+		}).
+		Build()
+
+	tester.CheckAuthorizations(s.T(), Subject{Username: "john", Country: "AU"}, "https://protected.example.com/", fasthttp.MethodGet, Bypass)
+	tester.CheckAuthorizations(s.T(), Subject{Username: "john", Country: "NZ"}, "https://protected.example.com/", fasthttp.MethodGet, Bypass)
+	tester.CheckAuthorizations(s.T(), Subject{Username: "john", Country: "US"}, "https://protected.example.com/", fasthttp.MethodGet, TwoFactor)
+	tester.CheckAuthorizations(s.T(), Subject{Username: "john", Country: "GB"}, "https://protected.example.com/", fasthttp.MethodGet, Denied)
+	tester.CheckAuthorizations(s.T(), John, "https://protected.example.com/", fasthttp.MethodGet, Denied)
+}
+
+func (s *AuthorizerSuite) TestShouldCheckASNMatching() {
+	tester := NewAuthorizerBuilder().
+		WithDefaultPolicy(deny).
+		WithRule(schema.AccessControlRule{
+			Domains: []string{"protected.example.com"},
+			Policy:  bypass,
+			ASNs:    []int{64512, 64513},
+		}).
+		WithRule(schema.AccessControlRule{
+			Domains:   []string{"protected.example.com"},
+			Policy:    oneFactor,
+			Countries: []string{"AU"},
+			ASNs:      []int{64514},
+		}).
+		Build()
+
+	tester.CheckAuthorizations(s.T(), Subject{Username: "john", ASN: 64512}, "https://protected.example.com/", fasthttp.MethodGet, Bypass)
+	tester.CheckAuthorizations(s.T(), Subject{Username: "john", ASN: 64513}, "https://protected.example.com/", fasthttp.MethodGet, Bypass)
+	tester.CheckAuthorizations(s.T(), Subject{Username: "john", Country: "AU", ASN: 64514}, "https://protected.example.com/", fasthttp.MethodGet, OneFactor)
+	tester.CheckAuthorizations(s.T(), Subject{Username: "john", Country: "NZ", ASN: 64514}, "https://protected.example.com/", fasthttp.MethodGet, Denied)
+	tester.CheckAuthorizations(s.T(), John, "https://protected.example.com/", fasthttp.MethodGet, Denied)
+}
+
 func (s *AuthorizerSuite) TestShouldCheckMethodMatching() {
 	tester := NewAuthorizerBuilder().
 		WithDefaultPolicy(deny).
@@ -1023,16 +1103,16 @@ func TestNewAuthorizer(t *testing.T) {
 		},
 	}
 
-	authorizer := NewAuthorizer(config)
+	authorizer := NewAuthorizer(config, clock.New())
 
-	assert.Equal(t, Denied, authorizer.defaultPolicy)
-	assert.Equal(t, TwoFactor, authorizer.rules[0].Policy)
+	assert.Equal(t, Denied, authorizer.load().defaultPolicy)
+	assert.Equal(t, TwoFactor, authorizer.load().rules[0].Policy)
 
-	user, ok := authorizer.rules[0].Subjects[0].Subjects[0].(AccessControlUser)
+	user, ok := authorizer.load().rules[0].Subjects[0].Subjects[0].(AccessControlUser)
 	require.True(t, ok)
 	assert.Equal(t, "admin", user.Name)
 
-	group, ok := authorizer.rules[0].Subjects[1].Subjects[0].(AccessControlGroup)
+	group, ok := authorizer.load().rules[0].Subjects[1].Subjects[0].(AccessControlGroup)
 	require.True(t, ok)
 	assert.Equal(t, "admins", group.Name)
 }
@@ -1050,12 +1130,55 @@ func TestAuthorizerIsSecondFactorEnabledRuleWithNoOIDC(t *testing.T) {
 		},
 	}
 
-	authorizer := NewAuthorizer(config)
+	authorizer := NewAuthorizer(config, clock.New())
 	assert.False(t, authorizer.IsSecondFactorEnabled())
 
 	config.AccessControl.Rules[0].Policy = twoFactor
-	authorizer = NewAuthorizer(config)
+	authorizer = NewAuthorizer(config, clock.New())
+	assert.True(t, authorizer.IsSecondFactorEnabled())
+}
+
+func TestAuthorizerReload(t *testing.T) {
+	config := &schema.Configuration{
+		AccessControl: schema.AccessControl{
+			DefaultPolicy: deny,
+			Rules: []schema.AccessControlRule{
+				{
+					Domains: []string{"example.com"},
+					Policy:  oneFactor,
+				},
+			},
+		},
+	}
+
+	authorizer := NewAuthorizer(config, clock.New())
+
+	object := NewObject(&url.URL{Scheme: "https", Host: "example.com", Path: "/"}, fasthttp.MethodGet)
+
+	_, level := authorizer.GetRequiredLevel(Subject{}, object)
+	assert.Equal(t, OneFactor, level)
+	assert.False(t, authorizer.IsSecondFactorEnabled())
+
+	authorizer.Reload(schema.AccessControl{
+		DefaultPolicy: deny,
+		Rules: []schema.AccessControlRule{
+			{
+				Domains: []string{"example.com"},
+				Policy:  twoFactor,
+			},
+			{
+				Domains: []string{"*.example.com"},
+				Policy:  bypass,
+			},
+		},
+	})
+
+	_, level = authorizer.GetRequiredLevel(Subject{}, object)
+	assert.Equal(t, TwoFactor, level)
 	assert.True(t, authorizer.IsSecondFactorEnabled())
+	assert.Len(t, authorizer.GetRuleMatchResults(Subject{}, object), 2)
+
+	assert.Equal(t, oneFactor, config.AccessControl.Rules[0].Policy)
 }
 
 func TestAuthorizerIsSecondFactorEnabledRuleWithOIDC(t *testing.T) {
@@ -1080,27 +1203,27 @@ func TestAuthorizerIsSecondFactorEnabledRuleWithOIDC(t *testing.T) {
 		},
 	}
 
-	authorizer := NewAuthorizer(config)
+	authorizer := NewAuthorizer(config, clock.New())
 	assert.False(t, authorizer.IsSecondFactorEnabled())
 
 	config.AccessControl.Rules[0].Policy = twoFactor
-	authorizer = NewAuthorizer(config)
+	authorizer = NewAuthorizer(config, clock.New())
 	assert.True(t, authorizer.IsSecondFactorEnabled())
 
 	config.AccessControl.Rules[0].Policy = oneFactor
-	authorizer = NewAuthorizer(config)
+	authorizer = NewAuthorizer(config, clock.New())
 	assert.False(t, authorizer.IsSecondFactorEnabled())
 
 	config.IdentityProviders.OIDC.Clients[0].AuthorizationPolicy = twoFactor
-	authorizer = NewAuthorizer(config)
+	authorizer = NewAuthorizer(config, clock.New())
 	assert.True(t, authorizer.IsSecondFactorEnabled())
 
 	config.AccessControl.Rules[0].Policy = oneFactor
 	config.IdentityProviders.OIDC.Clients[0].AuthorizationPolicy = oneFactor
-	authorizer = NewAuthorizer(config)
+	authorizer = NewAuthorizer(config, clock.New())
 	assert.False(t, authorizer.IsSecondFactorEnabled())
 
 	config.AccessControl.DefaultPolicy = twoFactor
-	authorizer = NewAuthorizer(config)
+	authorizer = NewAuthorizer(config, clock.New())
 	assert.True(t, authorizer.IsSecondFactorEnabled())
 }
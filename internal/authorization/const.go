@@ -29,6 +29,21 @@ const (
 	deny      = "deny"
 )
 
+// Criteria names as they're configured in the access control rules.
+const (
+	CriteriaDomain     = "domain"
+	CriteriaResources  = "resources"
+	CriteriaQuery      = "query"
+	CriteriaHeaders    = "headers"
+	CriteriaMethods    = "methods"
+	CriteriaNetworks   = "networks"
+	CriteriaCountries  = "countries"
+	CriteriaASNs       = "asns"
+	CriteriaSubject    = "subject"
+	CriteriaExpression = "expression"
+	CriteriaSchedule   = "schedule"
+)
+
 const (
 	operatorPresent    = "present"
 	operatorAbsent     = "absent"
@@ -48,4 +63,17 @@ var (
 	IdentitySubexpNames = []string{subexpNameUser, subexpNameGroup}
 )
 
+const (
+	expressionVarSubject = "subject"
+	expressionVarObject  = "object"
+	expressionVarHeaders = "headers"
+
+	expressionCostLimit = 100000
+)
+
+const (
+	scheduleLayoutTime = "15:04"
+	scheduleLayoutDate = "2006-01-02"
+)
+
 const traceFmtACLHitMiss = "ACL %s Position %d for subject %s and object %s (method %s)"
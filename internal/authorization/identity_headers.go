@@ -0,0 +1,92 @@
+package authorization
+
+import (
+	"fmt"
+	"net/textproto"
+	"strings"
+	"text/template"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/templates"
+)
+
+// NewIdentityHeaders parses the schema.AuthzIdentityHeader configuration into a list of IdentityHeader.
+func NewIdentityHeaders(config []schema.AuthzIdentityHeader) (headers []IdentityHeader, err error) {
+	if len(config) == 0 {
+		return nil, nil
+	}
+
+	headers = make([]IdentityHeader, len(config))
+
+	for i, header := range config {
+		if headers[i], err = NewIdentityHeader(header); err != nil {
+			return nil, err
+		}
+	}
+
+	return headers, nil
+}
+
+// NewIdentityHeader parses a schema.AuthzIdentityHeader into an IdentityHeader.
+func NewIdentityHeader(config schema.AuthzIdentityHeader) (header IdentityHeader, err error) {
+	name := textproto.CanonicalMIMEHeaderKey(config.Name)
+
+	var value *template.Template
+
+	if value, err = template.New(name).Funcs(templates.FuncMap()).Option("missingkey=zero").Parse(config.Value); err != nil {
+		return header, fmt.Errorf("failed to parse the value of the identity header '%s': %w", name, err)
+	}
+
+	return IdentityHeader{Name: name, Value: value}, nil
+}
+
+// IdentityHeader is a response header which contains a value rendered from the identity of the user.
+type IdentityHeader struct {
+	Name  string
+	Value *template.Template
+}
+
+// Render the value of the IdentityHeader from the provided IdentityHeaderData.
+func (h IdentityHeader) Render(data IdentityHeaderData) (value string, err error) {
+	buf := &strings.Builder{}
+
+	if err = h.Value.Execute(buf, data); err != nil {
+		return "", fmt.Errorf("failed to render the value of the identity header '%s': %w", h.Name, err)
+	}
+
+	value = buf.String()
+
+	if strings.ContainsAny(value, "\r\n") {
+		return "", fmt.Errorf("failed to render the value of the identity header '%s': the value contains a line break", h.Name)
+	}
+
+	return value, nil
+}
+
+// NewIdentityHeaderData creates the IdentityHeaderData from the authentication.UserDetails.
+func NewIdentityHeaderData(details authentication.UserDetails) (data IdentityHeaderData) {
+	data = IdentityHeaderData{
+		Username:    details.Username,
+		DisplayName: details.DisplayName,
+		Emails:      details.Emails,
+		Groups:      details.Groups,
+		Extra:       details.Extra,
+	}
+
+	if len(details.Emails) != 0 {
+		data.Email = details.Emails[0]
+	}
+
+	return data
+}
+
+// IdentityHeaderData is the data available to the IdentityHeader value templates.
+type IdentityHeaderData struct {
+	Username    string
+	DisplayName string
+	Email       string
+	Emails      []string
+	Groups      []string
+	Extra       map[string]string
+}
@@ -0,0 +1,97 @@
+package authorization
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestIdentityHeaderRender(t *testing.T) {
+	details := authentication.UserDetails{
+		Username:    "john",
+		DisplayName: "John Smith",
+		Emails:      []string{"john@example.com", "jsmith@example.com"},
+		Groups:      []string{"admins", "dev"},
+		Extra:       map[string]string{"employee_id": "1234"},
+	}
+
+	testCases := []struct {
+		name     string
+		have     string
+		details  authentication.UserDetails
+		expected string
+		err      string
+	}{
+		{"ShouldRenderUsername", "{{ .Username }}", details, "john", ""},
+		{"ShouldRenderDisplayName", "{{ .DisplayName }}", details, "John Smith", ""},
+		{"ShouldRenderFirstEmail", "{{ .Email }}", details, "john@example.com", ""},
+		{"ShouldRenderEmails", `{{ join "," .Emails }}`, details, "john@example.com,jsmith@example.com", ""},
+		{"ShouldRenderGroupsWithSeparator", `{{ join "|" .Groups }}`, details, "admins|dev", ""},
+		{"ShouldRenderExtra", `{{ index .Extra "employee_id" }}`, details, "1234", ""},
+		{"ShouldRenderMissingExtra", `{{ index .Extra "cost_centre" }}`, details, "", ""},
+		{"ShouldRenderEmptyEmail", "{{ .Email }}", authentication.UserDetails{Username: "john"}, "", ""},
+		{"ShouldRenderStatic", "static", details, "static", ""},
+		{"ShouldErrorOnLineBreak", "{{ .Username }}\nInjected: true", details, "", "failed to render the value of the identity header 'X-Test': the value contains a line break"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			header, err := NewIdentityHeader(schema.AuthzIdentityHeader{Name: "x-test", Value: tc.have})
+			require.NoError(t, err)
+
+			assert.Equal(t, "X-Test", header.Name)
+
+			actual, err := header.Render(NewIdentityHeaderData(tc.details))
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, actual)
+			} else {
+				assert.EqualError(t, err, tc.err)
+				assert.Equal(t, "", actual)
+			}
+		})
+	}
+}
+
+func TestNewIdentityHeaders(t *testing.T) {
+	headers, err := NewIdentityHeaders(nil)
+
+	assert.NoError(t, err)
+	assert.Nil(t, headers)
+
+	headers, err = NewIdentityHeaders([]schema.AuthzIdentityHeader{{Name: "remote-user", Value: "{{ .Username }}"}, {Name: "Remote-Groups", Value: `{{ join "," .Groups }}`}})
+
+	assert.NoError(t, err)
+	require.Len(t, headers, 2)
+	assert.Equal(t, "Remote-User", headers[0].Name)
+	assert.Equal(t, "Remote-Groups", headers[1].Name)
+
+	headers, err = NewIdentityHeaders([]schema.AuthzIdentityHeader{{Name: "Remote-User", Value: "{{ .Username"}})
+
+	assert.EqualError(t, err, "failed to parse the value of the identity header 'Remote-User': template: Remote-User:1: unclosed action")
+	assert.Nil(t, headers)
+}
+
+func TestShouldParseRuleIdentityHeaders(t *testing.T) {
+	rule := NewAccessControlRule(1, schema.AccessControlRule{
+		Domains:         []string{"example.com"},
+		Policy:          "one_factor",
+		IdentityHeaders: []schema.AuthzIdentityHeader{{Name: "X-Employee", Value: `{{ index .Extra "employee_id" }}`}},
+	}, nil, nil, nil)
+
+	require.Len(t, rule.IdentityHeaders, 1)
+	assert.Equal(t, "X-Employee", rule.IdentityHeaders[0].Name)
+
+	rule = NewAccessControlRule(1, schema.AccessControlRule{
+		Domains:         []string{"example.com"},
+		Policy:          "one_factor",
+		IdentityHeaders: []schema.AuthzIdentityHeader{{Name: "X-Employee", Value: "{{ .Extra"}},
+	}, nil, nil, nil)
+
+	assert.Len(t, rule.IdentityHeaders, 0)
+}
@@ -3,6 +3,7 @@ package authorization
 import (
 	"fmt"
 	"net"
+	"net/http"
 	"net/url"
 	"strings"
 
@@ -31,9 +32,14 @@ type ObjectMatcher interface {
 
 // Subject represents the identity of a user for the purposes of ACL matching.
 type Subject struct {
-	Username string
-	Groups   []string
-	IP       net.IP
+	Username    string
+	DisplayName string
+	Groups      []string
+	Emails      []string
+	IP          net.IP
+	Country     string
+	ASN         uint
+	Attributes  map[string]string
 }
 
 // String returns a string representation of the Subject.
@@ -53,6 +59,8 @@ type Object struct {
 	Domain string
 	Path   string
 	Method string
+
+	Headers http.Header
 }
 
 // String is a string representation of the Object.
@@ -60,9 +68,13 @@ func (o Object) String() string {
 	return o.URL.String()
 }
 
-// NewObjectRaw creates a new Object type from a URL and a method header.
-func NewObjectRaw(targetURL *url.URL, method []byte) (object Object) {
-	return NewObject(targetURL, string(method))
+// NewObjectRaw creates a new Object type from a URL, a method header, and the forwarded request headers.
+func NewObjectRaw(targetURL *url.URL, method []byte, headers http.Header) (object Object) {
+	object = NewObject(targetURL, string(method))
+
+	object.Headers = headers
+
+	return object
 }
 
 // NewObject creates a new Object type from a URL and a method header.
@@ -84,18 +96,56 @@ type RuleMatchResult struct {
 	MatchDomain        bool
 	MatchResources     bool
 	MatchQuery         bool
+	MatchHeaders       bool
 	MatchMethods       bool
 	MatchNetworks      bool
+	MatchCountries     bool
+	MatchASNs          bool
 	MatchSubjects      bool
 	MatchSubjectsExact bool
+
+	MatchExpression      bool
+	MatchExpressionExact bool
+
+	MatchSchedule bool
 }
 
 // IsMatch returns true if all the criteria matched.
 func (r RuleMatchResult) IsMatch() (match bool) {
-	return r.MatchDomain && r.MatchResources && r.MatchMethods && r.MatchNetworks && r.MatchSubjectsExact
+	return r.MatchDomain && r.MatchResources && r.MatchQuery && r.MatchHeaders && r.MatchMethods && r.MatchNetworks && r.MatchCountries && r.MatchASNs && r.MatchSubjectsExact && r.MatchExpressionExact && r.MatchSchedule
 }
 
 // IsPotentialMatch returns true if the rule is potentially a match.
 func (r RuleMatchResult) IsPotentialMatch() (match bool) {
-	return r.MatchDomain && r.MatchResources && r.MatchMethods && r.MatchNetworks && r.MatchSubjects && !r.MatchSubjectsExact
+	return r.MatchDomain && r.MatchResources && r.MatchQuery && r.MatchHeaders && r.MatchMethods && r.MatchNetworks && r.MatchCountries && r.MatchASNs && r.MatchSubjects && r.MatchExpression && r.MatchSchedule &&
+		!(r.MatchSubjectsExact && r.MatchExpressionExact)
+}
+
+// FailedCriteria returns the names of the criteria which did not match in the order they're evaluated. Criteria which
+// are potentially a match are not included.
+func (r RuleMatchResult) FailedCriteria() (criteria []string) {
+	checks := []struct {
+		name  string
+		match bool
+	}{
+		{CriteriaDomain, r.MatchDomain},
+		{CriteriaResources, r.MatchResources},
+		{CriteriaQuery, r.MatchQuery},
+		{CriteriaHeaders, r.MatchHeaders},
+		{CriteriaMethods, r.MatchMethods},
+		{CriteriaNetworks, r.MatchNetworks},
+		{CriteriaCountries, r.MatchCountries},
+		{CriteriaASNs, r.MatchASNs},
+		{CriteriaSubject, r.MatchSubjects},
+		{CriteriaExpression, r.MatchExpression},
+		{CriteriaSchedule, r.MatchSchedule},
+	}
+
+	for _, check := range checks {
+		if !check.match {
+			criteria = append(criteria, check.name)
+		}
+	}
+
+	return criteria
 }
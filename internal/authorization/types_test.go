@@ -1,6 +1,7 @@
 package authorization
 
 import (
+	"net/http"
 	"net/url"
 	"testing"
 
@@ -27,13 +28,14 @@ func TestShouldCreateNewObjectFromRaw(t *testing.T) {
 
 	require.NoError(t, err)
 
-	object := NewObjectRaw(targetURL, []byte(fasthttp.MethodGet))
+	object := NewObjectRaw(targetURL, []byte(fasthttp.MethodGet), http.Header{"X-Example": []string{"abc"}})
 
 	assert.Equal(t, "https", object.URL.Scheme)
 	assert.Equal(t, "domain.example.com", object.Domain)
 	assert.Equal(t, "/api", object.URL.Path)
 	assert.Equal(t, "/api", object.Path)
 	assert.Equal(t, fasthttp.MethodGet, object.Method)
+	assert.Equal(t, "abc", object.Headers.Get("X-Example"))
 }
 
 func TestShouldCleanURL(t *testing.T) {
@@ -99,12 +101,27 @@ func TestRuleMatchResult_IsPotentialMatch(t *testing.T) {
 		},
 		{
 			"ShouldMatch",
-			RuleMatchResult{nil, true, true, true, true, true, true, true, false},
+			RuleMatchResult{nil, true, true, true, true, true, true, true, true, true, true, false, true, true, true},
 			true,
 		},
+		{
+			"ShouldMatchExpression",
+			RuleMatchResult{nil, true, true, true, true, true, true, true, true, true, true, true, true, false, true},
+			true,
+		},
+		{
+			"ShouldNotMatchExpression",
+			RuleMatchResult{nil, true, true, true, true, true, true, true, true, true, true, false, false, false, true},
+			false,
+		},
+		{
+			"ShouldNotMatchSchedule",
+			RuleMatchResult{nil, true, true, true, true, true, true, true, true, true, true, false, true, true, false},
+			false,
+		},
 		{
 			"ShouldMatchExact",
-			RuleMatchResult{nil, true, true, true, true, true, true, true, true},
+			RuleMatchResult{nil, true, true, true, true, true, true, true, true, true, true, true, true, true, true},
 			false,
 		},
 	}
@@ -115,3 +132,33 @@ func TestRuleMatchResult_IsPotentialMatch(t *testing.T) {
 		})
 	}
 }
+
+func TestRuleMatchResult_FailedCriteria(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     RuleMatchResult
+		expected []string
+	}{
+		{
+			"ShouldFailAll",
+			RuleMatchResult{},
+			[]string{"domain", "resources", "query", "headers", "methods", "networks", "countries", "asns", "subject", "expression", "schedule"},
+		},
+		{
+			"ShouldNotFailPotentialMatch",
+			RuleMatchResult{nil, true, true, true, true, true, true, true, true, true, true, false, true, false, true},
+			nil,
+		},
+		{
+			"ShouldFailQueryAndSchedule",
+			RuleMatchResult{nil, true, true, true, false, true, true, true, true, true, true, true, true, true, false},
+			[]string{"query", "schedule"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.have.FailedCriteria())
+		})
+	}
+}
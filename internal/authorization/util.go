@@ -4,9 +4,12 @@ import (
 	"net"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/clock"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/logging"
 )
 
 // NewLevel converts a string policy to int authorization level.
@@ -106,6 +109,59 @@ func ruleAddResources(exps []regexp.Regexp, rule *AccessControlRule) {
 	}
 }
 
+func ruleAddExpression(pos int, expression string, rule *AccessControlRule) {
+	if expression == "" {
+		return
+	}
+
+	exp, err := NewAccessControlExpression(expression)
+	if err != nil {
+		if rule.Policy == Denied {
+			logging.Logger().WithError(err).Errorf("Error occurred compiling the expression for access control rule #%d, the rule will always match as it has the deny policy", pos)
+		} else {
+			logging.Logger().WithError(err).Errorf("Error occurred compiling the expression for access control rule #%d, the rule will never match", pos)
+		}
+
+		rule.Expression = &AccessControlExpression{Expression: expression, deny: rule.Policy == Denied}
+
+		return
+	}
+
+	exp.deny = rule.Policy == Denied
+
+	rule.Expression = exp
+
+	if !rule.HasSubjects && exp.HasSubjects() {
+		rule.HasSubjects = true
+	}
+}
+
+func ruleAddSchedule(pos int, config *schema.AccessControlRuleSchedule, clock clock.Provider, rule *AccessControlRule) {
+	if config == nil {
+		return
+	}
+
+	schedule, err := NewAccessControlSchedule(*config, clock)
+	if err != nil {
+		logging.Logger().WithError(err).Errorf("Error occurred parsing the schedule for access control rule #%d, the rule will never match", pos)
+
+		schedule = &AccessControlSchedule{Location: time.UTC, clock: clock, invalid: true}
+	}
+
+	rule.Schedule = schedule
+}
+
+func ruleAddIdentityHeaders(pos int, config []schema.AuthzIdentityHeader, rule *AccessControlRule) {
+	headers, err := NewIdentityHeaders(config)
+	if err != nil {
+		logging.Logger().WithError(err).Errorf("Error occurred parsing the identity headers for access control rule #%d, the identity headers will not be sent", pos)
+
+		return
+	}
+
+	rule.IdentityHeaders = headers
+}
+
 func schemaMethodsToACL(methodRules []string) (methods []string) {
 	for _, method := range methodRules {
 		methods = append(methods, strings.ToUpper(method))
@@ -114,6 +170,26 @@ func schemaMethodsToACL(methodRules []string) (methods []string) {
 	return methods
 }
 
+func schemaCountriesToACL(countryRules []string) (countries []string) {
+	for _, country := range countryRules {
+		countries = append(countries, strings.ToUpper(country))
+	}
+
+	return countries
+}
+
+func schemaASNsToACL(asnRules []int) (asns []uint) {
+	for _, asn := range asnRules {
+		if asn <= 0 {
+			continue
+		}
+
+		asns = append(asns, uint(asn))
+	}
+
+	return asns
+}
+
 func schemaNetworksToACL(networkRules []string, networksMap map[string][]*net.IPNet, networksCacheMap map[string]*net.IPNet) (networks []*net.IPNet) {
 	for _, network := range networkRules {
 		if _, ok := networksMap[network]; !ok {
@@ -4,14 +4,20 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"net/http"
 	"net/url"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/valyala/fasthttp"
 
 	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/clock"
 	"github.com/authelia/authelia/v4/internal/configuration/validator"
+	"github.com/authelia/authelia/v4/internal/geoip"
+	"github.com/authelia/authelia/v4/internal/utils"
 )
 
 func newAccessControlCommand(ctx *CmdCtx) (cmd *cobra.Command) {
@@ -47,10 +53,19 @@ func newAccessControlCheckCommand(ctx *CmdCtx) (cmd *cobra.Command) {
 
 	cmd.Flags().String("url", "", "the url of the object")
 	cmd.Flags().String("method", fasthttp.MethodGet, "the HTTP method of the object")
+	cmd.Flags().StringArray("header", nil, "a header of the object in the format 'Name: Value', can be specified multiple times")
 	cmd.Flags().String("username", "", "the username of the subject")
 	cmd.Flags().StringSlice("groups", nil, "the groups of the subject")
+	cmd.Flags().StringSlice("emails", nil, "the emails of the subject")
+	cmd.Flags().String("display-name", "", "the display name of the subject")
 	cmd.Flags().String("ip", "", "the ip of the subject")
+	cmd.Flags().String("country", "", "the ISO 3166-1 alpha-2 country code of the subject, defaults to the country of the ip if geoip is configured")
+	cmd.Flags().Uint("asn", 0, "the Autonomous System Number of the subject, defaults to the asn of the ip if geoip is configured")
+	cmd.Flags().String("time", "", "the time of the request in RFC3339 format, defaults to the current time")
 	cmd.Flags().Bool("verbose", false, "enables verbose output")
+	cmd.Flags().Bool("explain", false, "explains which criteria did not match for each rule which matched the domain of the request but was not applied")
+	cmd.Flags().String("file", "", "a YAML or JSON file of test cases to check instead of a single request from the flags, exits with a non-zero status if any test case fails")
+	cmd.Flags().String("format", accessControlTestFormatText, fmt.Sprintf("the output format when checking a file of test cases, options are %s", strings.Join(accessControlTestFormats, ", ")))
 
 	return cmd
 }
@@ -62,13 +77,31 @@ func (ctx *CmdCtx) AccessControlCheckRunE(cmd *cobra.Command, _ []string) (err e
 		return errors.New("your configuration has errors")
 	}
 
-	authorizer := authorization.NewAuthorizer(ctx.config)
+	filename, err := cmd.Flags().GetString("file")
+	if err != nil {
+		return err
+	}
+
+	if filename != "" {
+		return ctx.accessControlCheckFile(cmd, filename)
+	}
 
 	subject, object, err := getSubjectAndObjectFromFlags(cmd)
 	if err != nil {
 		return err
 	}
 
+	if err = ctx.accessControlCheckResolveGeoIP(&subject); err != nil {
+		return err
+	}
+
+	provider, err := getClockFromFlags(cmd)
+	if err != nil {
+		return err
+	}
+
+	authorizer := authorization.NewAuthorizer(ctx.config, provider)
+
 	results := authorizer.GetRuleMatchResults(subject, object)
 
 	if len(results) == 0 {
@@ -82,8 +115,130 @@ func (ctx *CmdCtx) AccessControlCheckRunE(cmd *cobra.Command, _ []string) (err e
 		return err
 	}
 
+	explain, err := cmd.Flags().GetBool("explain")
+	if err != nil {
+		return err
+	}
+
 	accessControlCheckWriteOutput(object, subject, results, ctx.config.AccessControl.DefaultPolicy, verbose)
 
+	if explain {
+		accessControlCheckWriteNearMisses(accessControlNearMisses(results))
+	}
+
+	return nil
+}
+
+func (ctx *CmdCtx) accessControlCheckFile(cmd *cobra.Command, filename string) (err error) {
+	var (
+		format  string
+		explain bool
+	)
+
+	if format, err = cmd.Flags().GetString("format"); err != nil {
+		return err
+	}
+
+	if !utils.IsStringInSlice(format, accessControlTestFormats) {
+		return fmt.Errorf("the format '%s' is invalid: must be one of %s", format, strings.Join(accessControlTestFormats, ", "))
+	}
+
+	if explain, err = cmd.Flags().GetBool("explain"); err != nil {
+		return err
+	}
+
+	var (
+		suite    *AccessControlTestSuite
+		fallback clock.Provider
+	)
+
+	if suite, err = loadAccessControlTestSuite(filename); err != nil {
+		return err
+	}
+
+	if fallback, err = getClockFromFlags(cmd); err != nil {
+		return err
+	}
+
+	results := AccessControlTestResults{
+		Tests:   len(suite.Tests),
+		Results: make([]AccessControlTestResult, len(suite.Tests)),
+	}
+
+	for i, tc := range suite.Tests {
+		var (
+			subject  authorization.Subject
+			object   authorization.Object
+			provider clock.Provider
+		)
+
+		if subject, object, err = tc.SubjectObject(); err != nil {
+			return fmt.Errorf("failed to load the access control test case '%s': %w", tc.Name, err)
+		}
+
+		if err = ctx.accessControlCheckResolveGeoIP(&subject); err != nil {
+			return err
+		}
+
+		if provider, err = tc.Clock(fallback); err != nil {
+			return fmt.Errorf("failed to load the access control test case '%s': %w", tc.Name, err)
+		}
+
+		authorizer := authorization.NewAuthorizer(ctx.config, provider)
+
+		results.Results[i] = tc.Evaluate(authorizer.GetRuleMatchResults(subject, object), ctx.config.AccessControl.DefaultPolicy)
+
+		if !results.Results[i].Passed {
+			results.Failures++
+		}
+	}
+
+	switch format {
+	case accessControlTestFormatJSON:
+		err = accessControlTestWriteJSON(cmd.OutOrStdout(), results)
+	case accessControlTestFormatJUnit:
+		err = accessControlTestWriteJUnit(cmd.OutOrStdout(), results)
+	default:
+		accessControlTestWriteText(cmd.OutOrStdout(), results, explain)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if results.Failures != 0 {
+		cmd.SilenceUsage = true
+
+		return fmt.Errorf("%d of %d access control test cases failed", results.Failures, results.Tests)
+	}
+
+	return nil
+}
+
+func (ctx *CmdCtx) accessControlCheckResolveGeoIP(subject *authorization.Subject) (err error) {
+	if subject.IP == nil || !ctx.config.GeoIP.IsEnabled() || (subject.Country != "" && subject.ASN != 0) {
+		return nil
+	}
+
+	provider := geoip.NewMMDBProvider(&ctx.config.GeoIP)
+
+	if err = provider.StartupCheck(); err != nil {
+		return err
+	}
+
+	record, err := provider.Lookup(subject.IP)
+	if err != nil {
+		return err
+	}
+
+	if subject.Country == "" {
+		subject.Country = record.Country
+	}
+
+	if subject.ASN == 0 {
+		subject.ASN = record.ASN
+	}
+
 	return nil
 }
 
@@ -96,6 +251,20 @@ func accessControlCheckWriteObjectSubject(object authorization.Object, subject a
 		output.WriteString(fmt.Sprintf(" method '%s'", object.Method))
 	}
 
+	names := make([]string, 0, len(object.Headers))
+
+	for name := range object.Headers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, value := range object.Headers[name] {
+			output.WriteString(fmt.Sprintf(" header '%s: %s'", name, value))
+		}
+	}
+
 	if subject.Username != "" {
 		output.WriteString(fmt.Sprintf(" username '%s'", subject.Username))
 	}
@@ -104,10 +273,22 @@ func accessControlCheckWriteObjectSubject(object authorization.Object, subject a
 		output.WriteString(fmt.Sprintf(" groups '%s'", strings.Join(subject.Groups, ",")))
 	}
 
+	if len(subject.Emails) != 0 {
+		output.WriteString(fmt.Sprintf(" emails '%s'", strings.Join(subject.Emails, ",")))
+	}
+
 	if subject.IP != nil {
 		output.WriteString(fmt.Sprintf(" from IP '%s'", subject.IP.String()))
 	}
 
+	if subject.Country != "" {
+		output.WriteString(fmt.Sprintf(" country '%s'", subject.Country))
+	}
+
+	if subject.ASN != 0 {
+		output.WriteString(fmt.Sprintf(" asn '%d'", subject.ASN))
+	}
+
 	output.WriteString(".\n")
 
 	fmt.Println(output.String())
@@ -116,7 +297,7 @@ func accessControlCheckWriteObjectSubject(object authorization.Object, subject a
 func accessControlCheckWriteOutput(object authorization.Object, subject authorization.Subject, results []authorization.RuleMatchResult, defaultPolicy string, verbose bool) {
 	accessControlCheckWriteObjectSubject(object, subject)
 
-	fmt.Printf("  #\tDomain\tResource\tMethod\tNetwork\tSubject\n")
+	fmt.Printf("  #\tDomain\tResource\tMethod\tHeaders\tNetwork\tCountry\tASN\tSubject\tExpression\tSchedule\n")
 
 	var (
 		appliedPos int
@@ -135,15 +316,15 @@ func accessControlCheckWriteOutput(object authorization.Object, subject authoriz
 		case result.IsMatch() && !result.Skipped:
 			appliedPos, applied = i+1, result
 
-			fmt.Printf("* %d\t%s\t%s\t\t%s\t%s\t%s\n", i+1, hitMissMay(result.MatchDomain), hitMissMay(result.MatchResources), hitMissMay(result.MatchMethods), hitMissMay(result.MatchNetworks), hitMissMay(result.MatchSubjects, result.MatchSubjectsExact))
+			fmt.Printf("* %d\t%s\t%s\t\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", i+1, hitMissMay(result.MatchDomain), hitMissMay(result.MatchResources), hitMissMay(result.MatchMethods), hitMissMay(result.MatchHeaders), hitMissMay(result.MatchNetworks), hitMissMay(result.MatchCountries), hitMissMay(result.MatchASNs), hitMissMay(result.MatchSubjects, result.MatchSubjectsExact), hitMissMay(result.MatchExpression, result.MatchExpressionExact), hitMissMay(result.MatchSchedule))
 		case result.IsPotentialMatch() && !result.Skipped:
 			if potentialPos == 0 {
 				potentialPos, potential = i+1, result
 			}
 
-			fmt.Printf("~ %d\t%s\t%s\t\t%s\t%s\t%s\n", i+1, hitMissMay(result.MatchDomain), hitMissMay(result.MatchResources), hitMissMay(result.MatchMethods), hitMissMay(result.MatchNetworks), hitMissMay(result.MatchSubjects, result.MatchSubjectsExact))
+			fmt.Printf("~ %d\t%s\t%s\t\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", i+1, hitMissMay(result.MatchDomain), hitMissMay(result.MatchResources), hitMissMay(result.MatchMethods), hitMissMay(result.MatchHeaders), hitMissMay(result.MatchNetworks), hitMissMay(result.MatchCountries), hitMissMay(result.MatchASNs), hitMissMay(result.MatchSubjects, result.MatchSubjectsExact), hitMissMay(result.MatchExpression, result.MatchExpressionExact), hitMissMay(result.MatchSchedule))
 		default:
-			fmt.Printf("  %d\t%s\t%s\t\t%s\t%s\t%s\n", i+1, hitMissMay(result.MatchDomain), hitMissMay(result.MatchResources), hitMissMay(result.MatchMethods), hitMissMay(result.MatchNetworks), hitMissMay(result.MatchSubjects, result.MatchSubjectsExact))
+			fmt.Printf("  %d\t%s\t%s\t\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", i+1, hitMissMay(result.MatchDomain), hitMissMay(result.MatchResources), hitMissMay(result.MatchMethods), hitMissMay(result.MatchHeaders), hitMissMay(result.MatchNetworks), hitMissMay(result.MatchCountries), hitMissMay(result.MatchASNs), hitMissMay(result.MatchSubjects, result.MatchSubjectsExact), hitMissMay(result.MatchExpression, result.MatchExpressionExact), hitMissMay(result.MatchSchedule))
 		}
 	}
 
@@ -157,6 +338,44 @@ func accessControlCheckWriteOutput(object authorization.Object, subject authoriz
 	default:
 		fmt.Printf("\nThe policy '%s' from the default policy will be applied to this request as no rules matched the request.\n\n", defaultPolicy)
 	}
+
+	if potentialPos != 0 && (appliedPos == 0 || potentialPos < appliedPos) {
+		accessControlCheckWriteMaxAuthenticationAge(potentialPos, potential.Rule)
+	}
+
+	if appliedPos != 0 {
+		accessControlCheckWriteMaxAuthenticationAge(appliedPos, applied.Rule)
+	}
+}
+
+func accessControlCheckWriteMaxAuthenticationAge(position int, rule *authorization.AccessControlRule) {
+	if rule == nil || rule.MaxAuthenticationAge.IsZero() {
+		return
+	}
+
+	if rule.MaxAuthenticationAge.FirstFactor > 0 {
+		fmt.Printf("The rule #%d requires the first factor to have been authenticated within the last %s.\n", position, rule.MaxAuthenticationAge.FirstFactor)
+	}
+
+	if rule.MaxAuthenticationAge.SecondFactor > 0 {
+		fmt.Printf("The rule #%d requires the second factor to have been authenticated within the last %s.\n", position, rule.MaxAuthenticationAge.SecondFactor)
+	}
+
+	fmt.Println()
+}
+
+func accessControlCheckWriteNearMisses(misses []AccessControlNearMiss) {
+	if len(misses) == 0 {
+		fmt.Printf("No rules matched the domain of this request without being applied.\n\n")
+
+		return
+	}
+
+	for _, miss := range misses {
+		fmt.Printf("The rule #%d with policy '%s' matched the domain of this request but did not match the criteria: %s.\n", miss.Rule, miss.Policy, strings.Join(miss.FailedCriteria, ", "))
+	}
+
+	fmt.Println()
 }
 
 func hitMissMay(in ...bool) (out string) {
@@ -196,6 +415,11 @@ func getSubjectAndObjectFromFlags(cmd *cobra.Command) (subject authorization.Sub
 		return subject, object, err
 	}
 
+	headers, err := getHeadersFromFlags(cmd)
+	if err != nil {
+		return subject, object, err
+	}
+
 	username, err := cmd.Flags().GetString("username")
 	if err != nil {
 		return subject, object, err
@@ -206,6 +430,16 @@ func getSubjectAndObjectFromFlags(cmd *cobra.Command) (subject authorization.Sub
 		return subject, object, err
 	}
 
+	emails, err := cmd.Flags().GetStringSlice("emails")
+	if err != nil {
+		return subject, object, err
+	}
+
+	displayName, err := cmd.Flags().GetString("display-name")
+	if err != nil {
+		return subject, object, err
+	}
+
 	remoteIP, err := cmd.Flags().GetString("ip")
 	if err != nil {
 		return subject, object, err
@@ -213,13 +447,67 @@ func getSubjectAndObjectFromFlags(cmd *cobra.Command) (subject authorization.Sub
 
 	parsedIP := net.ParseIP(remoteIP)
 
+	country, err := cmd.Flags().GetString("country")
+	if err != nil {
+		return subject, object, err
+	}
+
+	asn, err := cmd.Flags().GetUint("asn")
+	if err != nil {
+		return subject, object, err
+	}
+
 	subject = authorization.Subject{
-		Username: username,
-		Groups:   groups,
-		IP:       parsedIP,
+		Username:    username,
+		DisplayName: displayName,
+		Groups:      groups,
+		Emails:      emails,
+		IP:          parsedIP,
+		Country:     strings.ToUpper(country),
+		ASN:         asn,
 	}
 
 	object = authorization.NewObject(parsedURL, method)
 
+	object.Headers = headers
+
 	return subject, object, nil
 }
+
+func getHeadersFromFlags(cmd *cobra.Command) (headers http.Header, err error) {
+	values, err := cmd.Flags().GetStringArray("header")
+	if err != nil {
+		return nil, err
+	}
+
+	headers = http.Header{}
+
+	for _, value := range values {
+		name, content, found := strings.Cut(value, ":")
+		if !found || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("failed to parse the header flag value '%s': must be in the format 'Name: Value'", value)
+		}
+
+		headers.Add(strings.TrimSpace(name), strings.TrimSpace(content))
+	}
+
+	return headers, nil
+}
+
+func getClockFromFlags(cmd *cobra.Command) (provider clock.Provider, err error) {
+	value, err := cmd.Flags().GetString("time")
+	if err != nil {
+		return nil, err
+	}
+
+	if value == "" {
+		return clock.New(), nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the time flag value '%s' as a RFC3339 timestamp: %w", value, err)
+	}
+
+	return clock.NewFixed(t), nil
+}
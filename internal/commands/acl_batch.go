@@ -0,0 +1,308 @@
+package commands
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/clock"
+)
+
+// AccessControlTestSuite is a list of access control test cases loaded from a YAML or JSON file.
+type AccessControlTestSuite struct {
+	Tests []AccessControlTestCase `yaml:"tests" json:"tests"`
+}
+
+// AccessControlTestCase is a request and the policy which is expected to be applied to it.
+type AccessControlTestCase struct {
+	Name string `yaml:"name" json:"name"`
+
+	URL     string            `yaml:"url" json:"url"`
+	Method  string            `yaml:"method" json:"method"`
+	Headers map[string]string `yaml:"headers" json:"headers"`
+
+	Username    string   `yaml:"username" json:"username"`
+	DisplayName string   `yaml:"display_name" json:"display_name"`
+	Groups      []string `yaml:"groups" json:"groups"`
+	Emails      []string `yaml:"emails" json:"emails"`
+	IP          string   `yaml:"ip" json:"ip"`
+	Country     string   `yaml:"country" json:"country"`
+	ASN         uint     `yaml:"asn" json:"asn"`
+
+	Attributes map[string]string `yaml:"attributes" json:"attributes"`
+
+	Time string `yaml:"time" json:"time"`
+
+	ExpectedPolicy string `yaml:"expected_policy" json:"expected_policy"`
+	ExpectedRule   *int   `yaml:"expected_rule" json:"expected_rule"`
+}
+
+// AccessControlTestResults is the machine-readable output of an access control test suite.
+type AccessControlTestResults struct {
+	Tests    int                       `json:"tests"`
+	Failures int                       `json:"failures"`
+	Results  []AccessControlTestResult `json:"results"`
+}
+
+// AccessControlTestResult is the machine-readable output of an access control test case.
+type AccessControlTestResult struct {
+	Name    string `json:"name"`
+	Passed  bool   `json:"passed"`
+	Message string `json:"message,omitempty"`
+
+	ExpectedPolicy string `json:"expected_policy"`
+	ExpectedRule   *int   `json:"expected_rule,omitempty"`
+	Policy         string `json:"policy"`
+	Rule           int    `json:"rule"`
+
+	NearMisses []AccessControlNearMiss `json:"near_misses,omitempty"`
+}
+
+// AccessControlNearMiss is a rule which came close to matching a request alongside the criteria which did not match.
+type AccessControlNearMiss struct {
+	Rule           int      `json:"rule"`
+	Policy         string   `json:"policy"`
+	FailedCriteria []string `json:"failed_criteria"`
+}
+
+func loadAccessControlTestSuite(filename string) (suite *AccessControlTestSuite, err error) {
+	var data []byte
+
+	if data, err = os.ReadFile(filename); err != nil {
+		return nil, err
+	}
+
+	suite = &AccessControlTestSuite{}
+
+	if err = yaml.Unmarshal(data, suite); err != nil {
+		return nil, fmt.Errorf("failed to parse the access control test cases file '%s': %w", filename, err)
+	}
+
+	if len(suite.Tests) == 0 {
+		return nil, fmt.Errorf("the access control test cases file '%s' does not contain any test cases", filename)
+	}
+
+	for i, tc := range suite.Tests {
+		if tc.Name == "" {
+			suite.Tests[i].Name = fmt.Sprintf("#%d", i+1)
+		}
+
+		if tc.ExpectedPolicy == "" {
+			return nil, fmt.Errorf("the access control test case '%s' does not have an expected_policy", suite.Tests[i].Name)
+		}
+	}
+
+	return suite, nil
+}
+
+// SubjectObject returns the authorization.Subject and authorization.Object of the test case.
+func (tc AccessControlTestCase) SubjectObject() (subject authorization.Subject, object authorization.Object, err error) {
+	var parsedURL *url.URL
+
+	if parsedURL, err = url.ParseRequestURI(tc.URL); err != nil {
+		return subject, object, fmt.Errorf("failed to parse the url '%s': %w", tc.URL, err)
+	}
+
+	method := tc.Method
+
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	subject = authorization.Subject{
+		Username:    tc.Username,
+		DisplayName: tc.DisplayName,
+		Groups:      tc.Groups,
+		Emails:      tc.Emails,
+		IP:          net.ParseIP(tc.IP),
+		Country:     strings.ToUpper(tc.Country),
+		ASN:         tc.ASN,
+		Attributes:  tc.Attributes,
+	}
+
+	object = authorization.NewObject(parsedURL, method)
+
+	object.Headers = http.Header{}
+
+	for name, value := range tc.Headers {
+		object.Headers.Add(name, value)
+	}
+
+	return subject, object, nil
+}
+
+// Clock returns the clock.Provider of the test case or the fallback if the test case does not have a time.
+func (tc AccessControlTestCase) Clock(fallback clock.Provider) (provider clock.Provider, err error) {
+	if tc.Time == "" {
+		return fallback, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, tc.Time)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the time '%s' as a RFC3339 timestamp: %w", tc.Time, err)
+	}
+
+	return clock.NewFixed(t), nil
+}
+
+// Evaluate compares the results of the rules to the expectations of the test case.
+func (tc AccessControlTestCase) Evaluate(results []authorization.RuleMatchResult, defaultPolicy string) (result AccessControlTestResult) {
+	result = AccessControlTestResult{
+		Name:           tc.Name,
+		ExpectedPolicy: tc.ExpectedPolicy,
+		ExpectedRule:   tc.ExpectedRule,
+		Policy:         defaultPolicy,
+		NearMisses:     accessControlNearMisses(results),
+	}
+
+	for i, r := range results {
+		if r.IsMatch() && !r.Skipped {
+			result.Policy, result.Rule = r.Rule.Policy.String(), i+1
+
+			break
+		}
+	}
+
+	switch {
+	case result.Policy != tc.ExpectedPolicy:
+		result.Message = fmt.Sprintf("expected the policy '%s' but the policy '%s' from %s is applied", tc.ExpectedPolicy, result.Policy, accessControlRuleName(result.Rule))
+	case tc.ExpectedRule != nil && *tc.ExpectedRule != result.Rule:
+		result.Message = fmt.Sprintf("expected the policy to be applied from %s but it's applied from %s", accessControlRuleName(*tc.ExpectedRule), accessControlRuleName(result.Rule))
+	default:
+		result.Passed = true
+	}
+
+	return result
+}
+
+// accessControlNearMisses returns the rules evaluated before the applied rule which match the domain of the request but
+// not every other criteria.
+func accessControlNearMisses(results []authorization.RuleMatchResult) (misses []AccessControlNearMiss) {
+	for i, r := range results {
+		if r.Skipped {
+			break
+		}
+
+		if !r.MatchDomain || r.IsMatch() || r.IsPotentialMatch() {
+			continue
+		}
+
+		misses = append(misses, AccessControlNearMiss{
+			Rule:           i + 1,
+			Policy:         r.Rule.Policy.String(),
+			FailedCriteria: r.FailedCriteria(),
+		})
+	}
+
+	return misses
+}
+
+func accessControlRuleName(position int) string {
+	if position == 0 {
+		return "the default policy"
+	}
+
+	return fmt.Sprintf("rule #%d", position)
+}
+
+func accessControlTestWriteText(w io.Writer, results AccessControlTestResults, explain bool) {
+	for _, result := range results.Results {
+		if result.Passed {
+			_, _ = fmt.Fprintf(w, "PASS %s\n", result.Name)
+		} else {
+			_, _ = fmt.Fprintf(w, "FAIL %s: %s\n", result.Name, result.Message)
+		}
+
+		if explain || !result.Passed {
+			for _, miss := range result.NearMisses {
+				_, _ = fmt.Fprintf(w, "     rule #%d with policy '%s' did not match the criteria: %s\n", miss.Rule, miss.Policy, strings.Join(miss.FailedCriteria, ", "))
+			}
+		}
+	}
+
+	_, _ = fmt.Fprintf(w, "\n%d of %d access control test cases passed.\n", results.Tests-results.Failures, results.Tests)
+}
+
+func accessControlTestWriteJSON(w io.Writer, results AccessControlTestResults) (err error) {
+	encoder := json.NewEncoder(w)
+
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(results)
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message  string `xml:"message,attr"`
+	Contents string `xml:",chardata"`
+}
+
+func accessControlTestWriteJUnit(w io.Writer, results AccessControlTestResults) (err error) {
+	suite := junitTestSuite{
+		Name:      "access-control",
+		Tests:     results.Tests,
+		Failures:  results.Failures,
+		TestCases: make([]junitTestCase, len(results.Results)),
+	}
+
+	for i, result := range results.Results {
+		suite.TestCases[i] = junitTestCase{Name: result.Name, ClassName: suite.Name}
+
+		if result.Passed {
+			continue
+		}
+
+		contents := make([]string, len(result.NearMisses))
+
+		for j, miss := range result.NearMisses {
+			contents[j] = fmt.Sprintf("rule #%d with policy '%s' did not match the criteria: %s", miss.Rule, miss.Policy, strings.Join(miss.FailedCriteria, ", "))
+		}
+
+		suite.TestCases[i].Failure = &junitFailure{Message: result.Message, Contents: strings.Join(contents, "\n")}
+	}
+
+	if _, err = io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+
+	encoder.Indent("", "  ")
+
+	if err = encoder.Encode(junitTestSuites{Tests: results.Tests, Failures: results.Failures, Suites: []junitTestSuite{suite}}); err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(w, "\n")
+
+	return err
+}
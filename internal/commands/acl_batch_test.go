@@ -0,0 +1,212 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/clock"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestAccessControlTestCaseEvaluate(t *testing.T) {
+	config := &schema.Configuration{
+		AccessControl: schema.AccessControl{
+			DefaultPolicy: "deny",
+			Rules: []schema.AccessControlRule{
+				{
+					Domains:  []string{"admin.example.com"},
+					Policy:   "two_factor",
+					Subjects: [][]string{{"group:admins"}},
+					Methods:  []string{"GET"},
+				},
+				{
+					Domains: []string{"admin.example.com"},
+					Policy:  "one_factor",
+					Methods: []string{"POST"},
+				},
+				{
+					Domains: []string{"public.example.com"},
+					Policy:  "bypass",
+				},
+				{
+					Domains: []string{"query.example.com"},
+					Policy:  "one_factor",
+					Query:   [][]schema.AccessControlRuleQuery{{{Operator: "equal", Key: "tenant", Value: "abc"}}},
+				},
+			},
+		},
+	}
+
+	rule := func(position int) *int {
+		return &position
+	}
+
+	testCases := []struct {
+		name       string
+		have       AccessControlTestCase
+		passed     bool
+		policy     string
+		rule       int
+		message    string
+		nearMisses []AccessControlNearMiss
+	}{
+		{
+			"ShouldPassBypass",
+			AccessControlTestCase{Name: "bypass", URL: "https://public.example.com", ExpectedPolicy: "bypass", ExpectedRule: rule(3)},
+			true, "bypass", 3, "",
+			nil,
+		},
+		{
+			"ShouldPassAdmin",
+			AccessControlTestCase{Name: "admin", URL: "https://admin.example.com", Username: "john", Groups: []string{"admins"}, ExpectedPolicy: "two_factor"},
+			true, "two_factor", 1, "",
+			nil,
+		},
+		{
+			"ShouldPassDefaultPolicy",
+			AccessControlTestCase{Name: "default", URL: "https://other.example.com", ExpectedPolicy: "deny", ExpectedRule: rule(0)},
+			true, "deny", 0, "",
+			nil,
+		},
+		{
+			"ShouldFailPolicy",
+			AccessControlTestCase{Name: "user", URL: "https://admin.example.com", Username: "john", Groups: []string{"users"}, ExpectedPolicy: "two_factor"},
+			false, "deny", 0, "expected the policy 'two_factor' but the policy 'deny' from the default policy is applied",
+			[]AccessControlNearMiss{
+				{Rule: 1, Policy: "two_factor", FailedCriteria: []string{"subject"}},
+				{Rule: 2, Policy: "one_factor", FailedCriteria: []string{"methods"}},
+			},
+		},
+		{
+			"ShouldFailRule",
+			AccessControlTestCase{Name: "post", URL: "https://admin.example.com", Method: "POST", ExpectedPolicy: "one_factor", ExpectedRule: rule(1)},
+			false, "one_factor", 2, "expected the policy to be applied from rule #1 but it's applied from rule #2",
+			[]AccessControlNearMiss{
+				{Rule: 1, Policy: "two_factor", FailedCriteria: []string{"methods"}},
+			},
+		},
+		{
+			"ShouldPassQuery",
+			AccessControlTestCase{Name: "query", URL: "https://query.example.com/?tenant=abc", ExpectedPolicy: "one_factor", ExpectedRule: rule(4)},
+			true, "one_factor", 4, "",
+			nil,
+		},
+		{
+			"ShouldFailQueryMismatch",
+			AccessControlTestCase{Name: "query-mismatch", URL: "https://query.example.com/?tenant=xyz", ExpectedPolicy: "one_factor", ExpectedRule: rule(4)},
+			false, "deny", 0, "expected the policy 'one_factor' but the policy 'deny' from the default policy is applied",
+			[]AccessControlNearMiss{
+				{Rule: 4, Policy: "one_factor", FailedCriteria: []string{"query"}},
+			},
+		},
+	}
+
+	authorizer := authorization.NewAuthorizer(config, clock.New())
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			subject, object, err := tc.have.SubjectObject()
+			require.NoError(t, err)
+
+			actual := tc.have.Evaluate(authorizer.GetRuleMatchResults(subject, object), config.AccessControl.DefaultPolicy)
+
+			assert.Equal(t, tc.passed, actual.Passed)
+			assert.Equal(t, tc.policy, actual.Policy)
+			assert.Equal(t, tc.rule, actual.Rule)
+			assert.Equal(t, tc.message, actual.Message)
+			assert.Equal(t, tc.nearMisses, actual.NearMisses)
+		})
+	}
+}
+
+func TestLoadAccessControlTestSuite(t *testing.T) {
+	dir := t.TempDir()
+
+	testCases := []struct {
+		name     string
+		content  string
+		expected *AccessControlTestSuite
+		err      string
+	}{
+		{
+			"ShouldLoadYAML",
+			"tests:\n  - name: 'example'\n    url: 'https://example.com'\n    groups: ['admins']\n    headers:\n      X-Tenant: 'example'\n    expected_policy: 'one_factor'\n    expected_rule: 2\n  - url: 'https://example.com'\n    expected_policy: 'deny'\n",
+			&AccessControlTestSuite{Tests: []AccessControlTestCase{
+				{Name: "example", URL: "https://example.com", Groups: []string{"admins"}, Headers: map[string]string{"X-Tenant": "example"}, ExpectedPolicy: "one_factor", ExpectedRule: func() *int { x := 2; return &x }()},
+				{Name: "#2", URL: "https://example.com", ExpectedPolicy: "deny"},
+			}},
+			"",
+		},
+		{
+			"ShouldLoadJSON",
+			`{"tests":[{"name":"example","url":"https://example.com","expected_policy":"bypass"}]}`,
+			&AccessControlTestSuite{Tests: []AccessControlTestCase{
+				{Name: "example", URL: "https://example.com", ExpectedPolicy: "bypass"},
+			}},
+			"",
+		},
+		{
+			"ShouldErrorNoTests",
+			"tests: []\n",
+			nil,
+			"the access control test cases file '%s' does not contain any test cases",
+		},
+		{
+			"ShouldErrorNoExpectedPolicy",
+			"tests:\n  - name: 'example'\n    url: 'https://example.com'\n",
+			nil,
+			"the access control test case 'example' does not have an expected_policy",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			filename := filepath.Join(dir, tc.name+".yml")
+
+			require.NoError(t, os.WriteFile(filename, []byte(tc.content), 0600))
+
+			actual, err := loadAccessControlTestSuite(filename)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expected, actual)
+			} else {
+				assert.EqualError(t, err, strings.ReplaceAll(tc.err, "%s", filename))
+				assert.Nil(t, actual)
+			}
+		})
+	}
+}
+
+func TestAccessControlTestWriteJUnit(t *testing.T) {
+	results := AccessControlTestResults{
+		Tests:    2,
+		Failures: 1,
+		Results: []AccessControlTestResult{
+			{Name: "pass", Passed: true},
+			{Name: "fail", Message: "expected the policy 'bypass' but the policy 'deny' from the default policy is applied", NearMisses: []AccessControlNearMiss{{Rule: 1, Policy: "bypass", FailedCriteria: []string{"methods", "networks"}}}},
+		},
+	}
+
+	buf := &bytes.Buffer{}
+
+	require.NoError(t, accessControlTestWriteJUnit(buf, results))
+
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="2" failures="1">
+  <testsuite name="access-control" tests="2" failures="1">
+    <testcase name="pass" classname="access-control"></testcase>
+    <testcase name="fail" classname="access-control">
+      <failure message="expected the policy &#39;bypass&#39; but the policy &#39;deny&#39; from the default policy is applied">rule #1 with policy &#39;bypass&#39; did not match the criteria: methods, networks</failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`, buf.String())
+}
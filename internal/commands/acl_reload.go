@@ -0,0 +1,114 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/configuration"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/configuration/validator"
+	"github.com/authelia/authelia/v4/internal/metrics"
+)
+
+// NewAccessControlReloader returns a new AccessControlReloader which reloads the access control configuration from
+// the provided configuration files into the provided authorization.Authorizer.
+func NewAccessControlReloader(files []string, filters []configuration.BytesFilter, config *schema.Configuration, authorizer *authorization.Authorizer, metrics metrics.Recorder, log *logrus.Logger) (reloader *AccessControlReloader) {
+	return &AccessControlReloader{
+		files:      files,
+		filters:    filters,
+		config:     config,
+		current:    config.AccessControl,
+		authorizer: authorizer,
+		metrics:    metrics,
+		log:        log.WithField(logFieldProvider, providerNameAccessControl),
+	}
+}
+
+// AccessControlReloader is a ProviderReload which reloads the access control configuration without a restart. The
+// configuration is only swapped into the authorization.Authorizer if it passes validation, otherwise the previous
+// access control configuration is kept.
+type AccessControlReloader struct {
+	mu sync.Mutex
+
+	files   []string
+	filters []configuration.BytesFilter
+
+	config  *schema.Configuration
+	current schema.AccessControl
+
+	authorizer *authorization.Authorizer
+	metrics    metrics.Recorder
+	log        *logrus.Entry
+}
+
+// Reload the access control configuration. The reloaded bool is false if the configuration did not change.
+func (r *AccessControlReloader) Reload() (reloaded bool, err error) {
+	r.mu.Lock()
+
+	defer r.mu.Unlock()
+
+	var config *schema.Configuration
+
+	if config, err = r.load(); err != nil {
+		r.record(false)
+
+		return false, err
+	}
+
+	if reflect.DeepEqual(config.AccessControl, r.current) {
+		return false, nil
+	}
+
+	r.authorizer.Reload(config.AccessControl)
+
+	r.current = config.AccessControl
+
+	r.record(true)
+
+	r.log.WithFields(map[string]any{"default_policy": r.current.DefaultPolicy, "rules": len(r.current.Rules)}).Info("Access control configuration reloaded")
+
+	return true, nil
+}
+
+func (r *AccessControlReloader) load() (config *schema.Configuration, err error) {
+	val := schema.NewStructValidator()
+
+	sources := configuration.NewDefaultSourcesWithDefaults(r.files, r.filters, configuration.DefaultEnvPrefix, configuration.DefaultEnvDelimiter, nil)
+
+	config = &schema.Configuration{}
+
+	if _, err = configuration.LoadAdvanced(val, "", config, sources...); err != nil {
+		return nil, fmt.Errorf("error occurred loading the configuration: %w", err)
+	}
+
+	if errs := val.Errors(); len(errs) != 0 {
+		return nil, fmt.Errorf("error occurred loading the configuration: %w", errors.Join(errs...))
+	}
+
+	// The GeoIP databases and the authz endpoints are not reloaded so the rules must be validated against the running
+	// configuration.
+	config.GeoIP = r.config.GeoIP
+	config.Server = r.config.Server
+
+	validator.ValidateAccessControl(config, val)
+	validator.ValidateRules(config, val)
+
+	if errs := val.Errors(); len(errs) != 0 {
+		return nil, fmt.Errorf("error occurred validating the access control configuration, the previous configuration will continue to be used: %w", errors.Join(errs...))
+	}
+
+	return config, nil
+}
+
+func (r *AccessControlReloader) record(success bool) {
+	if r.metrics == nil {
+		return
+	}
+
+	r.metrics.RecordReload(providerNameAccessControl, success)
+}
@@ -0,0 +1,113 @@
+package commands
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/clock"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestAccessControlReloader(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "configuration.yml")
+
+	config := &schema.Configuration{
+		AccessControl: schema.AccessControl{
+			DefaultPolicy: "deny",
+			Rules: []schema.AccessControlRule{
+				{
+					Domains: []string{"example.com"},
+					Policy:  "one_factor",
+				},
+			},
+		},
+		Server: schema.Server{
+			Endpoints: schema.ServerEndpoints{
+				Authz: map[string]schema.ServerEndpointsAuthz{
+					"forward-auth": {
+						Implementation: "ForwardAuth",
+						Responses: schema.AuthzResponses{
+							Forbidden: schema.AuthzResponse{StatusCode: 403},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	authorizer := authorization.NewAuthorizer(config, clock.New())
+
+	reloader := NewAccessControlReloader([]string{file}, nil, config, authorizer, nil, logrus.New())
+
+	object := authorization.NewObject(&url.URL{Scheme: "https", Host: "example.com", Path: "/"}, "GET")
+
+	level := func() authorization.Level {
+		_, level := authorizer.GetRequiredLevel(authorization.Subject{}, object)
+
+		return level
+	}
+
+	testCases := []struct {
+		name     string
+		content  string
+		reloaded bool
+		err      string
+		expected authorization.Level
+	}{
+		{
+			"ShouldSkipUnchanged",
+			"access_control:\n  default_policy: 'deny'\n  rules:\n    - domain: 'example.com'\n      policy: 'one_factor'\n",
+			false, "",
+			authorization.OneFactor,
+		},
+		{
+			"ShouldReloadChanged",
+			"access_control:\n  default_policy: 'deny'\n  rules:\n    - domain: 'example.com'\n      policy: 'two_factor'\n",
+			true, "",
+			authorization.TwoFactor,
+		},
+		{
+			"ShouldKeepPreviousOnValidationError",
+			"access_control:\n  default_policy: 'deny'\n  rules:\n    - domain: 'example.com'\n      policy: 'three_factor'\n",
+			false, "error occurred validating the access control configuration, the previous configuration will continue to be used: access_control: rule #1 (domain 'example.com'): option 'policy' must be one of 'bypass', 'one_factor', 'two_factor', or 'deny' but it's configured as 'three_factor'",
+			authorization.TwoFactor,
+		},
+		{
+			"ShouldKeepPreviousOnResponsesConflictingWithRunningEndpoints",
+			"access_control:\n  default_policy: 'deny'\n  rules:\n    - domain: 'example.com'\n      policy: 'one_factor'\n      responses:\n        forbidden:\n          redirect_url: 'https://example.com/forbidden'\n",
+			false, "error occurred validating the access control configuration, the previous configuration will continue to be used: access_control: rule #1 (domain 'example.com'): responses: forbidden: option 'redirect_url' can't be combined with the option 'status_code' of the authz endpoint 'forward-auth'",
+			authorization.TwoFactor,
+		},
+		{
+			"ShouldKeepPreviousOnParseError",
+			"access_control:\n  rules: 'abc\n",
+			false, "error occurred loading the configuration: failed to load configuration from file path",
+			authorization.TwoFactor,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.NoError(t, os.WriteFile(file, []byte(tc.content), 0600))
+
+			reloaded, err := reloader.Reload()
+
+			assert.Equal(t, tc.reloaded, reloaded)
+
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.err)
+			}
+
+			assert.Equal(t, tc.expected, level())
+		})
+	}
+}
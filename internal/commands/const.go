@@ -77,12 +77,71 @@ Notes:
 
 	A rule that potentially matches a request will cause a redirection to occur in order to perform one-factor
 	authentication. This is so Authelia can adequately determine if the rule actually matches.
+
+Test Cases:
+
+	The --file flag checks a YAML or JSON file of test cases instead of a single request, reporting the results in
+	the format specified by the --format flag and exiting with a non-zero status if any test case fails. Each test
+	case accepts the same options as the flags in addition to the attributes of the subject, the expected_policy, and
+	the optional expected_rule, where rule 0 is the default policy. For example:
+
+	tests:
+	  - name: 'Admins can access the admin panel'
+	    url: 'https://admin.example.com'
+	    method: 'GET'
+	    username: 'john'
+	    groups: ['admins']
+	    attributes:
+	      department: 'engineering'
+	    ip: '192.168.1.10'
+	    headers:
+	      X-Tenant: 'example'
+	    time: '2023-06-05T09:30:00Z'
+	    expected_policy: 'two_factor'
+	    expected_rule: 2
 `
 	cmdAutheliaAccessControlCheckPolicyExample = `authelia access-control check-policy --config config.yml --url https://example.com
 authelia access-control check-policy --config config.yml --url https://example.com --username john
 authelia access-control check-policy --config config.yml --url https://example.com --groups admin,public
 authelia access-control check-policy --config config.yml --url https://example.com --username john --method GET
-authelia access-control check-policy --config config.yml --url https://example.com --username john --method GET --verbose`
+authelia access-control check-policy --config config.yml --url https://example.com --username john --method GET --verbose
+authelia access-control check-policy --config config.yml --url https://example.com --username john --time 2023-06-05T09:30:00Z
+authelia access-control check-policy --config config.yml --url https://example.com --header "X-Tenant: example"
+authelia access-control check-policy --config config.yml --url https://example.com --username john --explain
+authelia access-control check-policy --config config.yml --file tests.yml
+authelia access-control check-policy --config config.yml --file tests.yml --format junit > report.xml`
+
+	cmdAutheliaSessionsShort = "Manage the Authelia sessions"
+
+	cmdAutheliaSessionsLong = `Manage the Authelia sessions.
+
+This subcommand allows listing and revoking the active sessions of a user. The sessions are managed directly in the
+session store so this subcommand requires the Redis or SQL session provider as the memory session provider only exists in
+the memory of the running process.`
+
+	cmdAutheliaSessionsExample = `authelia sessions --help`
+
+	cmdAutheliaSessionsListShort = "List the active sessions of a user"
+
+	cmdAutheliaSessionsListLong = `List the active sessions of a user.
+
+This subcommand lists the active sessions of a user across all of the session cookie domains.`
+
+	cmdAutheliaSessionsListExample = `authelia sessions list --username john
+authelia sessions list --username john --config config.yml`
+
+	cmdAutheliaSessionsRevokeShort = "Revoke the active sessions of a user"
+
+	cmdAutheliaSessionsRevokeLong = `Revoke the active sessions of a user.
+
+This subcommand revokes an active session of a user using the identifier from the list subcommand. If the identifier is
+not specified every session of the user across all of the session cookie domains is revoked along with the OpenID
+Connect 1.0 access tokens and refresh tokens issued to the user. Sessions of the user which are still presented to a
+running instance are rejected on their next authorization check.`
+
+	cmdAutheliaSessionsRevokeExample = `authelia sessions revoke --username john
+authelia sessions revoke --username john --id 5d41402abc4b2a76b9719d911017c592
+authelia sessions revoke --username john --id 5d41402abc4b2a76b9719d911017c592 --config config.yml`
 
 	cmdAutheliaStorageShort = "Manage the Authelia storage"
 
@@ -327,6 +386,46 @@ authelia storage user totp export png --directory example/dir
 authelia storage user totp export png --config config.yml
 authelia storage user totp export png --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
 
+	cmdAutheliaStorageRegulationShort = "Manage regulation bans"
+
+	cmdAutheliaStorageRegulationLong = `Manage regulation bans.
+
+This subcommand allows listing the current bans and failed authentication attempts, and lifting the bans of users and
+remote networks.`
+
+	cmdAutheliaStorageRegulationExample = `authelia storage regulation --help`
+
+	cmdAutheliaStorageRegulationListShort = "List the current bans or the failed authentication attempts of a user"
+
+	cmdAutheliaStorageRegulationListLong = `List the current bans or the failed authentication attempts of a user.
+
+This subcommand lists the users and remote networks which are currently banned. If a username is provided the latest
+failed authentication attempts of the user are listed instead.`
+
+	cmdAutheliaStorageRegulationListExample = `authelia storage regulation list
+authelia storage regulation list john
+authelia storage regulation list john --limit 50
+authelia storage regulation list --config config.yml
+authelia storage regulation list john --config config.yml
+authelia storage regulation list --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
+
+	cmdAutheliaStorageRegulationUnbanShort = "Lift the bans of a user or remote network"
+
+	cmdAutheliaStorageRegulationUnbanLong = `Lift the bans of a user or remote network.
+
+This subcommand lifts the bans of a user, or of a remote network if the value is an IP or a network range in CIDR
+notation. The failed authentication attempts made before the unban are no longer considered by the regulation.
+
+An IP or a network range within a single remote network unbans the remote network which contains it as determined by
+the configured masks. A network range larger than a remote network unbans each of the currently banned remote networks
+within it, and fails if there are none. Each unbanned user or remote network is printed.`
+
+	cmdAutheliaStorageRegulationUnbanExample = `authelia storage regulation unban john
+authelia storage regulation unban 192.168.1.20
+authelia storage regulation unban 192.168.1.0/24
+authelia storage regulation unban john --config config.yml
+authelia storage regulation unban john --encryption-key b3453fde-ecc2-4a1f-9422-2707ddbed495 --postgres.host postgres --postgres.password autheliapw`
+
 	cmdAutheliaStorageSchemaInfoShort = "Show the storage information"
 
 	cmdAutheliaStorageSchemaInfoLong = `Show the storage information.
@@ -563,6 +662,14 @@ const (
 	storageMigrateDirectionDown = "down"
 )
 
+const (
+	accessControlTestFormatText  = "text"
+	accessControlTestFormatJSON  = "json"
+	accessControlTestFormatJUnit = "junit"
+)
+
+var accessControlTestFormats = []string{accessControlTestFormatText, accessControlTestFormatJSON, accessControlTestFormatJUnit}
+
 const (
 	cmdFlagNameDirectory = "directory"
 
@@ -657,6 +764,9 @@ const (
 	cmdFlagNamePath        = "path"
 	cmdFlagNameTarget      = "target"
 	cmdFlagNameDestroyData = "destroy-data"
+	cmdFlagNameUsername    = "username"
+	cmdFlagNameID          = "id"
+	cmdFlagNameLimit       = "limit"
 
 	cmdFlagNameEncryptionKey      = "encryption-key"
 	cmdFlagNameSQLite3Path        = "sqlite.path"
@@ -707,6 +817,7 @@ const (
 var (
 	errStorageSchemaOutdated     = errors.New("storage schema outdated")
 	errStorageSchemaIncompatible = errors.New("storage schema incompatible")
+	errSessionsProviderMemory    = errors.New("the sessions can't be managed when using the memory session provider as the sessions only exist in the memory of the running process")
 )
 
 const (
@@ -817,14 +928,17 @@ const (
 
 	serviceTypeServer  = "server"
 	serviceTypeWatcher = "watcher"
+	serviceTypeQueue   = "queue"
 
 	logFieldProvider            = "provider"
 	logMessageStartupCheckError = "Error occurred running a startup check"
 
-	providerNameNTP          = "ntp"
-	providerNameStorage      = "storage"
-	providerNameUser         = "user"
-	providerNameNotification = "notification"
+	providerNameNTP           = "ntp"
+	providerNameGeoIP         = "geoip"
+	providerNameStorage       = "storage"
+	providerNameUser          = "user"
+	providerNameNotification  = "notification"
+	providerNameAccessControl = "access-control"
 )
 
 const (
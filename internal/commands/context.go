@@ -20,6 +20,7 @@ import (
 	"github.com/authelia/authelia/v4/internal/configuration"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/configuration/validator"
+	"github.com/authelia/authelia/v4/internal/geoip"
 	"github.com/authelia/authelia/v4/internal/logging"
 	"github.com/authelia/authelia/v4/internal/metrics"
 	"github.com/authelia/authelia/v4/internal/middlewares"
@@ -60,6 +61,8 @@ type CmdCtx struct {
 	trusted   *x509.CertPool
 
 	cconfig *CmdCtxConfig
+
+	acl *AccessControlReloader
 }
 
 // NewCmdCtxConfig returns a new CmdCtxConfig.
@@ -72,6 +75,8 @@ func NewCmdCtxConfig() *CmdCtxConfig {
 // CmdCtxConfig is the configuration for the CmdCtx.
 type CmdCtxConfig struct {
 	defaults  configuration.Source
+	files     []string
+	filters   []configuration.BytesFilter
 	sources   []configuration.Source
 	keys      []string
 	validator *schema.StructValidator
@@ -138,13 +143,23 @@ func (ctx *CmdCtx) LoadProviders() (warns, errs []error) {
 		return warns, errs
 	}
 
+	ctx.providers.Clock = clock.New()
 	ctx.providers.StorageProvider = getStorageProvider(ctx)
 
-	ctx.providers.Authorizer = authorization.NewAuthorizer(ctx.config)
+	if ctx.config.Telemetry.Metrics.Enabled {
+		ctx.providers.Metrics = metrics.NewPrometheus()
+	}
+
+	ctx.providers.Authorizer = authorization.NewAuthorizer(ctx.config, ctx.providers.Clock)
 	ctx.providers.NTP = ntp.NewProvider(&ctx.config.NTP)
+
+	if ctx.config.GeoIP.IsEnabled() {
+		ctx.providers.GeoIP = geoip.NewMMDBProvider(&ctx.config.GeoIP)
+	}
+
 	ctx.providers.PasswordPolicy = middlewares.NewPasswordPolicyProvider(ctx.config.PasswordPolicy)
-	ctx.providers.Regulator = regulation.NewRegulator(ctx.config.Regulation, ctx.providers.StorageProvider, clock.New())
-	ctx.providers.SessionProvider = session.NewProvider(ctx.config.Session, ctx.trusted)
+	ctx.providers.Regulator = regulation.NewRegulator(ctx.config.Regulation, ctx.providers.StorageProvider, ctx.providers.Clock)
+	ctx.providers.SessionProvider = session.NewProvider(ctx.config.Session, ctx.trusted, ctx.providers.StorageProvider, ctx.providers.Metrics, ctx.providers.Clock)
 	ctx.providers.TOTP = totp.NewTimeBasedProvider(ctx.config.TOTP)
 
 	var err error
@@ -165,14 +180,18 @@ func (ctx *CmdCtx) LoadProviders() (warns, errs []error) {
 		ctx.providers.Notifier = notification.NewSMTPNotifier(ctx.config.Notifier.SMTP, ctx.trusted)
 	case ctx.config.Notifier.FileSystem != nil:
 		ctx.providers.Notifier = notification.NewFileNotifier(*ctx.config.Notifier.FileSystem)
+	case ctx.config.Notifier.Webhook != nil:
+		ctx.providers.Notifier = notification.NewWebhookNotifier(ctx.config.Notifier.Webhook, ctx.trusted, ctx.providers.Clock)
+	case ctx.config.Notifier.Sendmail != nil:
+		ctx.providers.Notifier = notification.NewSendmailNotifier(ctx.config.Notifier.Sendmail)
 	}
 
-	ctx.providers.OpenIDConnect = oidc.NewOpenIDConnectProvider(ctx.config.IdentityProviders.OIDC, ctx.providers.StorageProvider, ctx.providers.Templates)
-
-	if ctx.config.Telemetry.Metrics.Enabled {
-		ctx.providers.Metrics = metrics.NewPrometheus()
+	if ctx.config.Notifier.Queue.Enabled && ctx.providers.Notifier != nil {
+		ctx.providers.Notifier = notification.NewQueueNotifier(&ctx.config.Notifier.Queue, ctx.providers.Notifier, ctx.providers.StorageProvider, ctx.providers.Templates, ctx.providers.Metrics, ctx.providers.Clock)
 	}
 
+	ctx.providers.OpenIDConnect = oidc.NewOpenIDConnectProvider(ctx.config.IdentityProviders.OIDC, ctx.providers.StorageProvider, ctx.providers.Templates)
+
 	return warns, errs
 }
 
@@ -398,6 +417,8 @@ func (ctx *CmdCtx) HelperConfigLoadRunE(cmd *cobra.Command, _ []string) (err err
 		ctx.cconfig = NewCmdCtxConfig()
 	}
 
+	ctx.cconfig.files, ctx.cconfig.filters = configs, filters
+
 	ctx.cconfig.sources = configuration.NewDefaultSourcesWithDefaults(
 		configs,
 		filters,
@@ -45,6 +45,7 @@ func NewRootCmd() (cmd *cobra.Command) {
 		newAccessControlCommand(ctx),
 		newBuildInfoCmd(ctx),
 		newCryptoCmd(ctx),
+		newSessionsCmd(ctx),
 		newStorageCmd(ctx),
 		newConfigCmd(ctx),
 		newConfigValidateLegacyCmd(ctx),
@@ -86,6 +87,8 @@ func (ctx *CmdCtx) RootRunE(_ *cobra.Command, _ []string) (err error) {
 
 	doStartupChecks(ctx)
 
+	ctx.acl = NewAccessControlReloader(ctx.cconfig.files, ctx.cconfig.filters, ctx.config, ctx.providers.Authorizer, ctx.providers.Metrics, ctx.log)
+
 	ctx.cconfig = nil
 
 	servicesRun(ctx)
@@ -117,6 +120,12 @@ func doStartupChecks(ctx *CmdCtx) {
 		failures = append(failures, providerNameNotification)
 	}
 
+	if err = doStartupCheck(ctx, providerNameGeoIP, ctx.providers.GeoIP, !ctx.config.GeoIP.IsEnabled()); err != nil {
+		ctx.log.WithError(err).WithField(logFieldProvider, providerNameGeoIP).Error(logMessageStartupCheckError)
+
+		failures = append(failures, providerNameGeoIP)
+	}
+
 	if err = doStartupCheck(ctx, providerNameNTP, ctx.providers.NTP, ctx.config.NTP.DisableStartupCheck); err != nil {
 		if !ctx.config.NTP.DisableFailure {
 			ctx.log.WithError(err).WithField(logFieldProvider, providerNameNTP).Error(logMessageStartupCheckError)
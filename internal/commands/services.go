@@ -18,6 +18,8 @@ import (
 	"golang.org/x/sync/errgroup"
 
 	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/geoip"
+	"github.com/authelia/authelia/v4/internal/notification"
 	"github.com/authelia/authelia/v4/internal/server"
 )
 
@@ -88,6 +90,18 @@ type ProviderReload interface {
 	Reload() (reloaded bool, err error)
 }
 
+// NewNotificationQueueService creates a new NotificationQueueService with the appropriate logger etc.
+func NewNotificationQueueService(queue *notification.QueueNotifier, log *logrus.Logger) (service *NotificationQueueService) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &NotificationQueueService{
+		queue:  queue,
+		ctx:    ctx,
+		cancel: cancel,
+		log:    log.WithFields(map[string]any{logFieldService: serviceTypeQueue, serviceTypeQueue: "notification"}),
+	}
+}
+
 // Service represents the required methods to support handling a service.
 type Service interface {
 	// ServiceType returns the type name for the Service.
@@ -211,16 +225,7 @@ func (service *FileWatcherService) Run() (err error) {
 			case event.Op&fsnotify.Write == fsnotify.Write, event.Op&fsnotify.Create == fsnotify.Create:
 				log.Debug("File modification was detected")
 
-				var reloaded bool
-
-				switch reloaded, err = service.reload.Reload(); {
-				case err != nil:
-					log.WithError(err).Error("Error occurred during reload")
-				case reloaded:
-					log.Info("Reloaded successfully")
-				default:
-					log.Debug("Reload was triggered but it was skipped")
-				}
+				providerReload(service.reload, log)
 			case event.Op&fsnotify.Remove == fsnotify.Remove:
 				log.Debug("File remove was detected")
 			}
@@ -246,6 +251,58 @@ func (service *FileWatcherService) Log() *logrus.Entry {
 	return service.log
 }
 
+// NotificationQueueService is a Service that delivers the notifications in the notification queue.
+type NotificationQueueService struct {
+	queue  *notification.QueueNotifier
+	ctx    context.Context
+	cancel context.CancelFunc
+	log    *logrus.Entry
+}
+
+// ServiceType returns the service type for this service, which is always 'queue'.
+func (service *NotificationQueueService) ServiceType() string {
+	return serviceTypeQueue
+}
+
+// ServiceName returns the individual name for this service.
+func (service *NotificationQueueService) ServiceName() string {
+	return "notification"
+}
+
+// Run the NotificationQueueService.
+func (service *NotificationQueueService) Run() (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			service.log.WithError(recoverErr(r)).Error("Critical error caught (recovered)")
+		}
+	}()
+
+	service.log.Info("Delivering queued notifications")
+
+	return service.queue.Run(service.ctx)
+}
+
+// Shutdown the NotificationQueueService.
+func (service *NotificationQueueService) Shutdown() {
+	service.cancel()
+}
+
+// Log returns the *logrus.Entry of the NotificationQueueService.
+func (service *NotificationQueueService) Log() *logrus.Entry {
+	return service.log
+}
+
+func providerReload(reload ProviderReload, log *logrus.Entry) {
+	switch reloaded, err := reload.Reload(); {
+	case err != nil:
+		log.WithError(err).Error("Error occurred during reload")
+	case reloaded:
+		log.Info("Reloaded successfully")
+	default:
+		log.Debug("Reload was triggered but it was skipped")
+	}
+}
+
 func svcSvrMainFunc(ctx *CmdCtx) (service Service) {
 	switch svr, listener, paths, isTLS, err := server.CreateDefaultServer(ctx.config, ctx.providers); {
 	case err != nil:
@@ -286,6 +343,63 @@ func svcWatcherUsersFunc(ctx *CmdCtx) (service Service) {
 	return service
 }
 
+func svcQueueNotificationFunc(ctx *CmdCtx) (service Service) {
+	if queue, ok := ctx.providers.Notifier.(*notification.QueueNotifier); ok {
+		service = NewNotificationQueueService(queue, ctx.log)
+	}
+
+	return service
+}
+
+func svcWatcherGeoIPCountryFunc(ctx *CmdCtx) (service Service) {
+	return svcWatcherGeoIPDatabase(ctx, "geoip-country", func(provider *geoip.MMDBProvider) *geoip.MMDBDatabase {
+		return provider.CountryDatabase()
+	})
+}
+
+func svcWatcherGeoIPASNFunc(ctx *CmdCtx) (service Service) {
+	return svcWatcherGeoIPDatabase(ctx, "geoip-asn", func(provider *geoip.MMDBProvider) *geoip.MMDBDatabase {
+		return provider.ASNDatabase()
+	})
+}
+
+func svcWatcherGeoIPDatabase(ctx *CmdCtx, name string, get func(provider *geoip.MMDBProvider) *geoip.MMDBDatabase) (service Service) {
+	provider, ok := ctx.providers.GeoIP.(*geoip.MMDBProvider)
+	if !ok {
+		return nil
+	}
+
+	database := get(provider)
+	if database == nil {
+		return nil
+	}
+
+	var err error
+
+	if service, err = NewFileWatcherService(name, database.Path(), database, ctx.log); err != nil {
+		ctx.log.WithError(err).Fatalf("Create Watcher Service (%s) returned error", name)
+	}
+
+	return service
+}
+
+func svcWatchersAccessControlFunc(ctx *CmdCtx) (services []Service) {
+	if ctx.acl == nil || !ctx.config.AccessControl.Watch {
+		return nil
+	}
+
+	for _, file := range ctx.acl.files {
+		service, err := NewFileWatcherService(providerNameAccessControl, file, ctx.acl, ctx.log)
+		if err != nil {
+			ctx.log.WithError(err).Fatal("Create Watcher Service (access-control) returned error")
+		}
+
+		services = append(services, service)
+	}
+
+	return services
+}
+
 func connectionType(isTLS bool) string {
 	if isTLS {
 		return "TLS"
@@ -294,6 +408,31 @@ func connectionType(isTLS bool) string {
 	return "non-TLS"
 }
 
+func servicesWait(cctx context.Context, ctx *CmdCtx, quit, reload chan os.Signal) {
+	for {
+		select {
+		case s := <-reload:
+			if ctx.acl == nil {
+				break
+			}
+
+			log := ctx.log.WithFields(map[string]any{"signal": s.String(), logFieldProvider: providerNameAccessControl})
+
+			log.Info("Reload initiated due to process signal")
+
+			providerReload(ctx.acl, log)
+		case s := <-quit:
+			ctx.log.WithField("signal", s.String()).Debug("Shutdown initiated due to process signal")
+
+			return
+		case <-cctx.Done():
+			ctx.log.Debug("Shutdown initiated due to context completion")
+
+			return
+		}
+	}
+}
+
 func servicesRun(ctx *CmdCtx) {
 	cctx, cancel := context.WithCancel(ctx)
 
@@ -307,13 +446,20 @@ func servicesRun(ctx *CmdCtx) {
 
 	defer signal.Stop(quit)
 
+	reload := make(chan os.Signal, 1)
+
+	signal.Notify(reload, syscall.SIGHUP)
+
+	defer signal.Stop(reload)
+
 	var (
 		services []Service
 	)
 
 	for _, serviceFunc := range []func(ctx *CmdCtx) Service{
 		svcSvrMainFunc, svcSvrMetricsFunc,
-		svcWatcherUsersFunc,
+		svcWatcherUsersFunc, svcWatcherGeoIPCountryFunc, svcWatcherGeoIPASNFunc,
+		svcQueueNotificationFunc,
 	} {
 		if service := serviceFunc(ctx); service != nil {
 			services = append(services, service)
@@ -322,15 +468,16 @@ func servicesRun(ctx *CmdCtx) {
 		}
 	}
 
-	ctx.log.Info("Startup complete")
+	for _, service := range svcWatchersAccessControlFunc(ctx) {
+		services = append(services, service)
 
-	select {
-	case s := <-quit:
-		ctx.log.WithField("signal", s.String()).Debug("Shutdown initiated due to process signal")
-	case <-cctx.Done():
-		ctx.log.Debug("Shutdown initiated due to context completion")
+		group.Go(service.Run)
 	}
 
+	ctx.log.Info("Startup complete")
+
+	servicesWait(cctx, ctx, quit, reload)
+
 	cancel()
 
 	ctx.log.Info("Shutdown initiated")
@@ -0,0 +1,201 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/authelia/authelia/v4/internal/clock"
+	"github.com/authelia/authelia/v4/internal/configuration/validator"
+	"github.com/authelia/authelia/v4/internal/oidc"
+	"github.com/authelia/authelia/v4/internal/session"
+)
+
+func newSessionsCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "sessions",
+		Short:   cmdAutheliaSessionsShort,
+		Long:    cmdAutheliaSessionsLong,
+		Example: cmdAutheliaSessionsExample,
+		PersistentPreRunE: ctx.ChainRunE(
+			ctx.HelperConfigLoadRunE,
+			ctx.ConfigValidateSessionRunE,
+			ctx.LoadProvidersSessionRunE,
+		),
+		Args: cobra.NoArgs,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.AddCommand(
+		newSessionsListCmd(ctx),
+		newSessionsRevokeCmd(ctx),
+	)
+
+	return cmd
+}
+
+func newSessionsListCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "list",
+		Short:   cmdAutheliaSessionsListShort,
+		Long:    cmdAutheliaSessionsListLong,
+		Example: cmdAutheliaSessionsListExample,
+		RunE:    ctx.SessionsListRunE,
+		Args:    cobra.NoArgs,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.Flags().String(cmdFlagNameUsername, "", "the username of the user")
+
+	_ = cmd.MarkFlagRequired(cmdFlagNameUsername)
+
+	return cmd
+}
+
+func newSessionsRevokeCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "revoke",
+		Short:   cmdAutheliaSessionsRevokeShort,
+		Long:    cmdAutheliaSessionsRevokeLong,
+		Example: cmdAutheliaSessionsRevokeExample,
+		RunE:    ctx.SessionsRevokeRunE,
+		Args:    cobra.NoArgs,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.Flags().String(cmdFlagNameUsername, "", "the username of the user")
+	cmd.Flags().String(cmdFlagNameID, "", "the identifier of the session from the list subcommand, all sessions and tokens of the user are revoked when not specified")
+
+	_ = cmd.MarkFlagRequired(cmdFlagNameUsername)
+
+	return cmd
+}
+
+// ConfigValidateSessionRunE validates the session and storage configuration.
+func (ctx *CmdCtx) ConfigValidateSessionRunE(_ *cobra.Command, _ []string) (err error) {
+	validator.ValidateSession(ctx.config, ctx.cconfig.validator)
+	validator.ValidateStorage(ctx.config.Storage, ctx.cconfig.validator)
+
+	if errs := ctx.cconfig.validator.Errors(); len(errs) != 0 {
+		return fmt.Errorf("your configuration has errors: %w", errors.Join(errs...))
+	}
+
+	if ctx.config.Session.Redis == nil && ctx.config.Session.SQL == nil {
+		return errSessionsProviderMemory
+	}
+
+	return nil
+}
+
+// LoadProvidersSessionRunE loads the storage and session providers.
+func (ctx *CmdCtx) LoadProvidersSessionRunE(_ *cobra.Command, _ []string) (err error) {
+	if _, errs := ctx.LoadTrustedCertificates(); len(errs) != 0 {
+		return fmt.Errorf("had the following errors loading the trusted certificates: %w", errors.Join(errs...))
+	}
+
+	ctx.providers.StorageProvider = getStorageProvider(ctx)
+
+	if ctx.config.Session.SQL != nil {
+		if err = ctx.CheckSchema(); err != nil {
+			_ = ctx.providers.StorageProvider.Close()
+
+			return storageWrapCheckSchemaErr(err)
+		}
+	}
+
+	ctx.providers.SessionProvider = session.NewProvider(ctx.config.Session, ctx.trusted, ctx.providers.StorageProvider, nil, clock.New())
+
+	return nil
+}
+
+// SessionsListRunE is the RunE for the authelia sessions list command.
+func (ctx *CmdCtx) SessionsListRunE(cmd *cobra.Command, _ []string) (err error) {
+	var (
+		username string
+		records  []session.UserSessionRecord
+	)
+
+	defer func() {
+		_ = ctx.providers.StorageProvider.Close()
+	}()
+
+	if username, err = cmd.Flags().GetString(cmdFlagNameUsername); err != nil {
+		return err
+	}
+
+	if records, err = ctx.providers.SessionProvider.GetUserSessions(username, time.Now()); err != nil {
+		return fmt.Errorf("failed to list the sessions for user '%s': %w", username, err)
+	}
+
+	if len(records) == 0 {
+		return fmt.Errorf("user '%s' has no active sessions", username)
+	}
+
+	fmt.Printf("Active sessions for user '%s':\n\n", username)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	_, _ = fmt.Fprintln(w, "ID\tCookie Domain\tCreated\tLast Activity\tRemote IP\tUser Agent")
+
+	for _, record := range records {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", record.ID, record.CookieDomain, record.Created.Format(time.RFC3339), record.LastActivity.Format(time.RFC3339), record.RemoteIP, record.UserAgent)
+	}
+
+	return w.Flush()
+}
+
+// SessionsRevokeRunE is the RunE for the authelia sessions revoke command.
+func (ctx *CmdCtx) SessionsRevokeRunE(cmd *cobra.Command, _ []string) (err error) {
+	var username, id string
+
+	defer func() {
+		_ = ctx.providers.StorageProvider.Close()
+	}()
+
+	if username, err = cmd.Flags().GetString(cmdFlagNameUsername); err != nil {
+		return err
+	}
+
+	if id, err = cmd.Flags().GetString(cmdFlagNameID); err != nil {
+		return err
+	}
+
+	if id == "" {
+		return ctx.sessionsRevokeAll(username)
+	}
+
+	if err = ctx.providers.SessionProvider.RevokeUserSession(username, id, time.Now()); err != nil {
+		return fmt.Errorf("failed to revoke the session with id '%s' for user '%s': %w", id, username, err)
+	}
+
+	fmt.Printf("Successfully revoked the session with id '%s' for user '%s'\n", id, username)
+
+	return nil
+}
+
+func (ctx *CmdCtx) sessionsRevokeAll(username string) (err error) {
+	if err = ctx.CheckSchema(); err != nil {
+		return storageWrapCheckSchemaErr(err)
+	}
+
+	var sessions, tokens int
+
+	if sessions, err = ctx.providers.SessionProvider.RevokeAllUserSessions(username, time.Now()); err != nil {
+		return fmt.Errorf("failed to revoke the sessions for user '%s': %w", username, err)
+	}
+
+	if tokens, err = oidc.RevokeUserTokens(ctx, ctx.providers.StorageProvider, username); err != nil {
+		return fmt.Errorf("failed to revoke the tokens for user '%s': %w", username, err)
+	}
+
+	fmt.Printf("Successfully revoked %d sessions and %d tokens for user '%s'\n", sessions, tokens, username)
+
+	return nil
+}
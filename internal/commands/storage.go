@@ -52,6 +52,7 @@ func newStorageCmd(ctx *CmdCtx) (cmd *cobra.Command) {
 		newStorageSchemaInfoCmd(ctx),
 		newStorageEncryptionCmd(ctx),
 		newStorageUserCmd(ctx),
+		newStorageRegulationCmd(ctx),
 	)
 
 	return cmd
@@ -455,6 +456,57 @@ func newStorageUserTOTPExportPNGCmd(ctx *CmdCtx) (cmd *cobra.Command) {
 	return cmd
 }
 
+func newStorageRegulationCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "regulation",
+		Short:   cmdAutheliaStorageRegulationShort,
+		Long:    cmdAutheliaStorageRegulationLong,
+		Example: cmdAutheliaStorageRegulationExample,
+		Args:    cobra.NoArgs,
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.AddCommand(
+		newStorageRegulationListCmd(ctx),
+		newStorageRegulationUnbanCmd(ctx),
+	)
+
+	return cmd
+}
+
+func newStorageRegulationListCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "list [username]",
+		Short:   cmdAutheliaStorageRegulationListShort,
+		Long:    cmdAutheliaStorageRegulationListLong,
+		Example: cmdAutheliaStorageRegulationListExample,
+		RunE:    ctx.StorageRegulationListRunE,
+		Args:    cobra.MaximumNArgs(1),
+
+		DisableAutoGenTag: true,
+	}
+
+	cmd.Flags().Int(cmdFlagNameLimit, 10, "the maximum number of failed authentication attempts of the user to list")
+
+	return cmd
+}
+
+func newStorageRegulationUnbanCmd(ctx *CmdCtx) (cmd *cobra.Command) {
+	cmd = &cobra.Command{
+		Use:     "unban <username|ip>",
+		Short:   cmdAutheliaStorageRegulationUnbanShort,
+		Long:    cmdAutheliaStorageRegulationUnbanLong,
+		Example: cmdAutheliaStorageRegulationUnbanExample,
+		RunE:    ctx.StorageRegulationUnbanRunE,
+		Args:    cobra.ExactArgs(1),
+
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
+
 func newStorageSchemaInfoCmd(ctx *CmdCtx) (cmd *cobra.Command) {
 	cmd = &cobra.Command{
 		Use:     "schema-info",
@@ -11,14 +11,17 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 
+	"github.com/authelia/authelia/v4/internal/clock"
 	"github.com/authelia/authelia/v4/internal/configuration/validator"
 	"github.com/authelia/authelia/v4/internal/model"
 	"github.com/authelia/authelia/v4/internal/random"
+	"github.com/authelia/authelia/v4/internal/regulation"
 	"github.com/authelia/authelia/v4/internal/storage"
 	"github.com/authelia/authelia/v4/internal/totp"
 	"github.com/authelia/authelia/v4/internal/utils"
@@ -45,6 +48,7 @@ func (ctx *CmdCtx) LoadProvidersStorageRunE(cmd *cobra.Command, args []string) (
 		return err
 	default:
 		ctx.providers.StorageProvider = getStorageProvider(ctx)
+		ctx.providers.Regulator = regulation.NewRegulator(ctx.config.Regulation, ctx.providers.StorageProvider, clock.New())
 
 		return nil
 	}
@@ -108,6 +112,8 @@ func (ctx *CmdCtx) ConfigValidateStorageRunE(_ *cobra.Command, _ []string) (err
 
 	validator.ValidateTOTP(ctx.config, ctx.cconfig.validator)
 
+	validator.ValidateRegulation(ctx.config, ctx.cconfig.validator)
+
 	if errs := ctx.cconfig.validator.Errors(); len(errs) != 0 {
 		var (
 			i int
@@ -1276,3 +1282,107 @@ func (ctx *CmdCtx) StorageUserIdentifiersAddRunE(cmd *cobra.Command, args []stri
 
 	return nil
 }
+
+// StorageRegulationListRunE is the RunE for the authelia storage regulation list command.
+func (ctx *CmdCtx) StorageRegulationListRunE(cmd *cobra.Command, args []string) (err error) {
+	defer func() {
+		_ = ctx.providers.StorageProvider.Close()
+	}()
+
+	if err = ctx.CheckSchema(); err != nil {
+		return storageWrapCheckSchemaErr(err)
+	}
+
+	if len(args) != 0 && args[0] != "" {
+		return ctx.StorageRegulationListAttemptsRunE(cmd, args)
+	}
+
+	if ctx.config.Regulation.MaxRetries <= 0 && ctx.config.Regulation.IP.MaxRetries <= 0 {
+		return errors.New("regulation is disabled")
+	}
+
+	var bans []regulation.Ban
+
+	if bans, err = ctx.providers.Regulator.Bans(ctx); err != nil {
+		return fmt.Errorf("failed to list bans: %w", err)
+	}
+
+	if len(bans) == 0 {
+		fmt.Println("No users or remote networks are currently banned")
+
+		return nil
+	}
+
+	fmt.Printf("Bans:\n\nUsername\tRemote Network\tBanned Until\n")
+
+	for _, ban := range bans {
+		fmt.Printf("%s\t%s\t%s\n", ban.Username, ban.RemoteNetwork, ban.BannedUntil.Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+// StorageRegulationListAttemptsRunE is the RunE for the authelia storage regulation list command when a username is
+// specified.
+func (ctx *CmdCtx) StorageRegulationListAttemptsRunE(cmd *cobra.Command, args []string) (err error) {
+	var limit int
+
+	if limit, err = cmd.Flags().GetInt(cmdFlagNameLimit); err != nil {
+		return err
+	}
+
+	if limit <= 0 {
+		return fmt.Errorf("the limit must be greater than 0 but it's configured as %d", limit)
+	}
+
+	var attempts []model.AuthenticationAttempt
+
+	username := args[0]
+
+	attempts, err = ctx.providers.StorageProvider.LoadFailedAuthenticationLogsByUsername(ctx, username, limit, 0)
+
+	switch {
+	case len(attempts) == 0 || (err != nil && errors.Is(err, storage.ErrNoAuthenticationLogs)):
+		return fmt.Errorf("user '%s' has no failed authentication attempts", username)
+	case err != nil:
+		return fmt.Errorf("can't list failed authentication attempts for user '%s': %w", username, err)
+	}
+
+	fmt.Printf("Failed Authentication Attempts for user '%s':\n\n", username)
+	fmt.Printf("Time\tType\tBanned\tRemote IP\tCountry\n")
+
+	for _, attempt := range attempts {
+		var ip string
+
+		if attempt.RemoteIP.IP != nil {
+			ip = attempt.RemoteIP.IP.String()
+		}
+
+		fmt.Printf("%s\t%s\t%t\t%s\t%s\n", attempt.Time.Format(time.RFC3339), attempt.Type, attempt.Banned, ip, attempt.Country)
+	}
+
+	return nil
+}
+
+// StorageRegulationUnbanRunE is the RunE for the authelia storage regulation unban command.
+func (ctx *CmdCtx) StorageRegulationUnbanRunE(_ *cobra.Command, args []string) (err error) {
+	defer func() {
+		_ = ctx.providers.StorageProvider.Close()
+	}()
+
+	if err = ctx.CheckSchema(); err != nil {
+		return storageWrapCheckSchemaErr(err)
+	}
+
+	var unbanned []string
+
+	if unbanned, err = ctx.providers.Regulator.Unban(ctx, args[0]); err != nil {
+		return fmt.Errorf("failed to unban '%s': %w", args[0], err)
+	}
+
+	for _, value := range unbanned {
+		fmt.Printf("Successfully unbanned '%s'\n", value)
+	}
+
+	return nil
+}
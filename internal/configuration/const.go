@@ -55,7 +55,7 @@ const (
 // envSecretSuffixes.
 // Make sure you update these at the same time.
 var (
-	secretSuffix          = []string{"key", "secret", "password", "token", "certificate_chain"}
+	secretSuffix          = []string{"key", "secret", "secrets", "password", "token", "certificate_chain"}
 	secretExclusionPrefix = []string{"identity_providers.oidc.lifespans."}
 	secretExclusionExact  = []string{"server.tls.key", "authentication_backend.disable_reset_password", "tls_key"}
 )
@@ -15,6 +15,7 @@ func TestIsSecretKey(t *testing.T) {
 	assert.True(t, IsSecretKey("my.password"))
 	assert.False(t, IsSecretKey("my.passwords"))
 	assert.False(t, IsSecretKey("my.passwords"))
+	assert.True(t, IsSecretKey("session.secrets"))
 }
 
 func TestGetEnvConfigMaps(t *testing.T) {
@@ -482,6 +482,22 @@ func TestShouldValidateConfigurationWithEnvSecrets(t *testing.T) {
 	assert.Equal(t, "example_secret value", config.Storage.EncryptionKey)
 }
 
+func TestShouldLoadSessionSecretsFromEnvSecret(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session_secrets")
+
+	require.NoError(t, os.WriteFile(path, []byte("first_secret,second_secret\n"), 0600))
+
+	testSetEnv(t, "SESSION_SECRETS_FILE", path)
+
+	val := schema.NewStructValidator()
+	_, config, err := Load(val, NewDefaultSources([]string{"./test_resources/config.yml"}, DefaultEnvPrefix, DefaultEnvDelimiter)...)
+
+	assert.NoError(t, err)
+	assert.Len(t, val.Errors(), 0)
+
+	assert.Equal(t, []string{"first_secret", "second_secret"}, config.Session.Secrets)
+}
+
 func TestShouldLoadURLList(t *testing.T) {
 	val := schema.NewStructValidator()
 	keys, config, err := Load(val, NewDefaultSources([]string{"./test_resources/config_oidc.yml"}, DefaultEnvPrefix, DefaultEnvDelimiter)...)
@@ -1,5 +1,9 @@
 package schema
 
+import (
+	"time"
+)
+
 // AccessControl represents the configuration related to ACLs.
 type AccessControl struct {
 	// The default policy if no other policy matches the request.
@@ -10,6 +14,9 @@ type AccessControl struct {
 
 	// The ACL rules list.
 	Rules []AccessControlRule `koanf:"rules" json:"rules" jsonschema:"title=Rules List" jsonschema_description:"The list of ACL rules to enumerate for requests"`
+
+	// Enables reloading the access control configuration when the configuration files change.
+	Watch bool `koanf:"watch" json:"watch" jsonschema:"default=false,title=Watch" jsonschema_description:"Enables watching the configuration files for external changes and dynamically reloading the access control configuration"`
 }
 
 // AccessControlNetwork represents one ACL network group entry.
@@ -20,14 +27,48 @@ type AccessControlNetwork struct {
 
 // AccessControlRule represents one ACL rule entry.
 type AccessControlRule struct {
-	Domains      AccessControlRuleDomains   `koanf:"domain" json:"domain" jsonschema:"oneof_required=Domain,uniqueItems,title=Domain Literals" jsonschema_description:"The literal domains to match the domain against that this rule applies to"`
-	DomainsRegex AccessControlRuleRegex     `koanf:"domain_regex" json:"domain_regex" jsonschema:"oneof_required=Domain Regex,title=Domain Regex Patterns" jsonschema_description:"The regex patterns to match the domain against that this rule applies to"`
-	Policy       string                     `koanf:"policy" json:"policy" jsonschema:"required,enum=bypass,enum=deny,enum=one_factor,enum=two_factor,title=Rule Policy" jsonschema_description:"The policy this rule applies when all criteria match"`
-	Subjects     AccessControlRuleSubjects  `koanf:"subject" json:"subject" jsonschema:"title=AccessControlRuleSubjects" jsonschema_description:"The users or groups that this rule applies to"`
-	Networks     AccessControlRuleNetworks  `koanf:"networks" json:"networks" jsonschema:"title=Networks" jsonschema_description:"The remote IP's, network ranges in CIDR notation, or network names that this rule applies to"`
-	Resources    AccessControlRuleRegex     `koanf:"resources" json:"resources" jsonschema:"title=Resources or Paths" jsonschema_description:"The regex patterns to match the resource paths that this rule applies to"`
-	Methods      AccessControlRuleMethods   `koanf:"methods" json:"methods" jsonschema:"enum=GET,enum=HEAD,enum=POST,enum=PUT,enum=DELETE,enum=CONNECT,enum=OPTIONS,enum=TRACE,enum=PATCH,enum=PROPFIND,enum=PROPPATCH,enum=MKCOL,enum=COPY,enum=MOVE,enum=LOCK,enum=UNLOCK" jsonschema_description:"The list of request methods this rule applies to"`
-	Query        [][]AccessControlRuleQuery `koanf:"query" json:"query" jsonschema:"title=Query Rules" jsonschema_description:"The list of query parameter rules this rule applies to"`
+	Domains      AccessControlRuleDomains    `koanf:"domain" json:"domain" jsonschema:"oneof_required=Domain,uniqueItems,title=Domain Literals" jsonschema_description:"The literal domains to match the domain against that this rule applies to"`
+	DomainsRegex AccessControlRuleRegex      `koanf:"domain_regex" json:"domain_regex" jsonschema:"oneof_required=Domain Regex,title=Domain Regex Patterns" jsonschema_description:"The regex patterns to match the domain against that this rule applies to"`
+	Policy       string                      `koanf:"policy" json:"policy" jsonschema:"required,enum=bypass,enum=deny,enum=one_factor,enum=two_factor,title=Rule Policy" jsonschema_description:"The policy this rule applies when all criteria match"`
+	Subjects     AccessControlRuleSubjects   `koanf:"subject" json:"subject" jsonschema:"title=AccessControlRuleSubjects" jsonschema_description:"The users or groups that this rule applies to"`
+	Networks     AccessControlRuleNetworks   `koanf:"networks" json:"networks" jsonschema:"title=Networks" jsonschema_description:"The remote IP's, network ranges in CIDR notation, or network names that this rule applies to"`
+	Countries    AccessControlRuleCountries  `koanf:"countries" json:"countries" jsonschema:"title=Countries" jsonschema_description:"The ISO 3166-1 alpha-2 country codes of the remote IP that this rule applies to"`
+	ASNs         AccessControlRuleASNs       `koanf:"asns" json:"asns" jsonschema:"title=Autonomous System Numbers" jsonschema_description:"The Autonomous System Numbers of the remote IP that this rule applies to"`
+	Resources    AccessControlRuleRegex      `koanf:"resources" json:"resources" jsonschema:"title=Resources or Paths" jsonschema_description:"The regex patterns to match the resource paths that this rule applies to"`
+	Methods      AccessControlRuleMethods    `koanf:"methods" json:"methods" jsonschema:"enum=GET,enum=HEAD,enum=POST,enum=PUT,enum=DELETE,enum=CONNECT,enum=OPTIONS,enum=TRACE,enum=PATCH,enum=PROPFIND,enum=PROPPATCH,enum=MKCOL,enum=COPY,enum=MOVE,enum=LOCK,enum=UNLOCK" jsonschema_description:"The list of request methods this rule applies to"`
+	Query        [][]AccessControlRuleQuery  `koanf:"query" json:"query" jsonschema:"title=Query Rules" jsonschema_description:"The list of query parameter rules this rule applies to"`
+	Headers      [][]AccessControlRuleHeader `koanf:"headers" json:"headers" jsonschema:"title=Header Rules" jsonschema_description:"The list of request header rules this rule applies to"`
+	Expression   string                      `koanf:"expression" json:"expression" jsonschema:"title=Expression" jsonschema_description:"The CEL expression which must evaluate to true for this rule to apply"`
+	Schedule     *AccessControlRuleSchedule  `koanf:"schedule" json:"schedule" jsonschema:"title=Schedule" jsonschema_description:"The schedule this rule applies to"`
+
+	MaxAuthenticationAge AccessControlRuleMaxAuthenticationAge `koanf:"max_authentication_age" json:"max_authentication_age" jsonschema:"title=Maximum Authentication Age" jsonschema_description:"The maximum age of each authentication factor before the user is required to authenticate again"`
+
+	IdentityHeaders []AuthzIdentityHeader `koanf:"identity_headers" json:"identity_headers" jsonschema:"title=Identity Headers" jsonschema_description:"The additional identity headers sent in authorized responses for requests which match this rule"`
+
+	IdentityAssertion AccessControlRuleIdentityAssertion `koanf:"identity_assertion" json:"identity_assertion" jsonschema:"title=Identity Assertion" jsonschema_description:"The identity assertion options for requests which match this rule"`
+
+	Responses AuthzResponses `koanf:"responses" json:"responses" jsonschema:"title=Responses" jsonschema_description:"The customized responses sent when access is denied to requests which match this rule"`
+}
+
+// AccessControlRuleSchedule represents the ACL schedule criteria.
+type AccessControlRuleSchedule struct {
+	Timezone  string   `koanf:"timezone" json:"timezone" jsonschema:"title=Timezone" jsonschema_description:"The IANA timezone name the schedule is evaluated in, defaults to the local timezone"`
+	Weekdays  []string `koanf:"weekdays" json:"weekdays" jsonschema:"uniqueItems,enum=monday,enum=tuesday,enum=wednesday,enum=thursday,enum=friday,enum=saturday,enum=sunday,enum=mon,enum=tue,enum=wed,enum=thu,enum=fri,enum=sat,enum=sun,title=Weekdays" jsonschema_description:"The days of the week this rule applies to"`
+	Times     []string `koanf:"times" json:"times" jsonschema:"title=Times" jsonschema_description:"The time ranges in the format HH:MM-HH:MM this rule applies to"`
+	NotBefore string   `koanf:"not_before" json:"not_before" jsonschema:"title=Not Before" jsonschema_description:"The date or RFC3339 timestamp before which this rule does not apply"`
+	NotAfter  string   `koanf:"not_after" json:"not_after" jsonschema:"title=Not After" jsonschema_description:"The date or RFC3339 timestamp after which this rule does not apply"`
+}
+
+// AccessControlRuleMaxAuthenticationAge represents the ACL maximum authentication age criteria.
+type AccessControlRuleMaxAuthenticationAge struct {
+	FirstFactor  time.Duration `koanf:"first_factor" json:"first_factor" jsonschema:"title=First Factor" jsonschema_description:"The maximum age of the first factor authentication"`
+	SecondFactor time.Duration `koanf:"second_factor" json:"second_factor" jsonschema:"title=Second Factor" jsonschema_description:"The maximum age of the second factor authentication"`
+}
+
+// AccessControlRuleIdentityAssertion represents the ACL identity assertion options.
+type AccessControlRuleIdentityAssertion struct {
+	Audience []string      `koanf:"audience" json:"audience" jsonschema:"title=Audience" jsonschema_description:"The audience of the identity assertion"`
+	Lifespan time.Duration `koanf:"lifespan" json:"lifespan" jsonschema:"title=Lifespan" jsonschema_description:"The lifespan of the identity assertion"`
 }
 
 // AccessControlRuleQuery represents the ACL query criteria.
@@ -37,6 +78,13 @@ type AccessControlRuleQuery struct {
 	Value    any    `koanf:"value" json:"value" jsonschema:"title=Value" jsonschema_description:"The Query Parameter value for this rule"`
 }
 
+// AccessControlRuleHeader represents the ACL headers criteria.
+type AccessControlRuleHeader struct {
+	Operator string `koanf:"operator" json:"operator" jsonschema:"enum=equal,enum=not equal,enum=present,enum=absent,enum=pattern,enum=not pattern,title=Operator" jsonschema_description:"The operator this header rule uses to match the header"`
+	Key      string `koanf:"key" json:"key" jsonschema:"required,title=Key" jsonschema_description:"The Header name this rule applies to"`
+	Value    any    `koanf:"value" json:"value" jsonschema:"title=Value" jsonschema_description:"The Header value for this rule"`
+}
+
 // DefaultACLNetwork represents the default configuration related to access control network group configuration.
 var DefaultACLNetwork = []AccessControlNetwork{
 	{
@@ -90,6 +90,47 @@ const (
 	TOTPAlgorithmSHA512 = "SHA512"
 )
 
+const (
+	// SessionBindingStrictnessStrict requires both the client IP prefix and the user agent to match.
+	SessionBindingStrictnessStrict = "strict"
+
+	// SessionBindingStrictnessLax only requires either the client IP prefix or the user agent to match.
+	SessionBindingStrictnessLax = "lax"
+
+	// SessionBindingStrictnessIP only requires the client IP prefix to match.
+	SessionBindingStrictnessIP = "ip"
+
+	// SessionBindingStrictnessUserAgent only requires the user agent to match.
+	SessionBindingStrictnessUserAgent = "user_agent"
+)
+
+const (
+	// SessionBindingActionLog only logs a session binding mismatch.
+	SessionBindingActionLog = "log"
+
+	// SessionBindingActionReauthenticate resets the authentication level of the session on a session binding mismatch.
+	SessionBindingActionReauthenticate = "reauthenticate"
+
+	// SessionBindingActionDestroy destroys the session on a session binding mismatch.
+	SessionBindingActionDestroy = "destroy"
+)
+
+const (
+	// RegulationScopeUser bans the user regardless of the remote network the attempts were made from.
+	RegulationScopeUser = "user"
+
+	// RegulationScopeUserIP only bans the user on the remote network the attempts were made from.
+	RegulationScopeUserIP = "user_ip"
+)
+
+const (
+	// RegulationModeFixed bans for the configured ban time every time.
+	RegulationModeFixed = "fixed"
+
+	// RegulationModeEscalating multiplies the ban time for each successive ban within the lookback.
+	RegulationModeEscalating = "escalating"
+)
+
 const (
 	// RememberMeDisabled represents the duration for a disabled remember me session configuration.
 	RememberMeDisabled = time.Second * -1
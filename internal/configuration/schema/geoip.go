@@ -0,0 +1,12 @@
+package schema
+
+// GeoIP represents the configuration related to GeoIP lookups.
+type GeoIP struct {
+	CountryDatabase string `koanf:"country_database" json:"country_database" jsonschema:"title=Country Database" jsonschema_description:"The path to the MaxMind DB file used to resolve the country of an IP address"`
+	ASNDatabase     string `koanf:"asn_database" json:"asn_database" jsonschema:"title=ASN Database" jsonschema_description:"The path to the MaxMind DB file used to resolve the Autonomous System Number of an IP address"`
+}
+
+// IsEnabled returns true if any database is configured.
+func (c *GeoIP) IsEnabled() bool {
+	return c.CountryDatabase != "" || c.ASNDatabase != ""
+}
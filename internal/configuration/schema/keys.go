@@ -166,6 +166,8 @@ var Keys = []string{
 	"authentication_backend.ldap.attributes.mail",
 	"authentication_backend.ldap.attributes.member_of",
 	"authentication_backend.ldap.attributes.group_name",
+	"authentication_backend.ldap.attributes.extra",
+	"authentication_backend.ldap.attributes.extra.*.name",
 	"authentication_backend.ldap.permit_referrals",
 	"authentication_backend.ldap.permit_unauthenticated_bind",
 	"authentication_backend.ldap.permit_feature_detection_failure",
@@ -178,6 +180,7 @@ var Keys = []string{
 	"session.remember_me",
 	"session",
 	"session.secret",
+	"session.secrets",
 	"session.cookies",
 	"session.cookies[].name",
 	"session.cookies[].same_site",
@@ -210,6 +213,21 @@ var Keys = []string{
 	"session.redis.high_availability.nodes",
 	"session.redis.high_availability.nodes[].host",
 	"session.redis.high_availability.nodes[].port",
+	"session.redis.cluster.read_from_replicas",
+	"session.redis.cluster.route_by_latency",
+	"session.redis.cluster.route_randomly",
+	"session.redis.cluster.maximum_redirects",
+	"session.redis.cluster.nodes",
+	"session.redis.cluster.nodes[].host",
+	"session.redis.cluster.nodes[].port",
+	"session.sql.cleanup_interval",
+	"session.binding.enabled",
+	"session.binding.ipv4_mask",
+	"session.binding.ipv6_mask",
+	"session.binding.strictness",
+	"session.binding.action",
+	"session.logout.global",
+	"session.logout.redirection_url",
 	"session.domain",
 	"totp.disable",
 	"totp.issuer",
@@ -233,12 +251,40 @@ var Keys = []string{
 	"access_control.rules[].policy",
 	"access_control.rules[].subject",
 	"access_control.rules[].networks",
+	"access_control.rules[].countries",
+	"access_control.rules[].asns",
 	"access_control.rules[].resources",
 	"access_control.rules[].methods",
 	"access_control.rules[].query[][].operator",
 	"access_control.rules[].query[][].key",
 	"access_control.rules[].query[][].value",
 	"access_control.rules[].query",
+	"access_control.rules[].headers[][].operator",
+	"access_control.rules[].headers[][].key",
+	"access_control.rules[].headers[][].value",
+	"access_control.rules[].headers",
+	"access_control.rules[].expression",
+	"access_control.rules[].schedule.timezone",
+	"access_control.rules[].schedule.weekdays",
+	"access_control.rules[].schedule.times",
+	"access_control.rules[].schedule.not_before",
+	"access_control.rules[].schedule.not_after",
+	"access_control.rules[].max_authentication_age.first_factor",
+	"access_control.rules[].max_authentication_age.second_factor",
+	"access_control.rules[].identity_headers",
+	"access_control.rules[].identity_headers[].name",
+	"access_control.rules[].identity_headers[].value",
+	"access_control.rules[].identity_assertion.audience",
+	"access_control.rules[].identity_assertion.lifespan",
+	"access_control.rules[].responses.forbidden.status_code",
+	"access_control.rules[].responses.forbidden.template",
+	"access_control.rules[].responses.forbidden.redirect_url",
+	"access_control.rules[].responses.unauthorized.status_code",
+	"access_control.rules[].responses.unauthorized.template",
+	"access_control.rules[].responses.unauthorized.redirect_url",
+	"access_control.watch",
+	"geoip.country_database",
+	"geoip.asn_database",
 	"ntp.address",
 	"ntp.version",
 	"ntp.max_desync",
@@ -247,6 +293,26 @@ var Keys = []string{
 	"regulation.max_retries",
 	"regulation.find_time",
 	"regulation.ban_time",
+	"regulation.scope",
+	"regulation.mode",
+	"regulation.ip.max_retries",
+	"regulation.ip.find_time",
+	"regulation.ip.ban_time",
+	"regulation.ip.ipv4_mask",
+	"regulation.ip.ipv6_mask",
+	"regulation.ip.trusted_networks",
+	"regulation.escalation.multiplier",
+	"regulation.escalation.max_ban_time",
+	"regulation.escalation.lookback",
+	"regulation.second_factor.totp.max_retries",
+	"regulation.second_factor.totp.find_time",
+	"regulation.second_factor.totp.ban_time",
+	"regulation.second_factor.webauthn.max_retries",
+	"regulation.second_factor.webauthn.find_time",
+	"regulation.second_factor.webauthn.ban_time",
+	"regulation.second_factor.duo.max_retries",
+	"regulation.second_factor.duo.find_time",
+	"regulation.second_factor.duo.ban_time",
 	"storage.local.path",
 	"storage.mysql.address",
 	"storage.mysql.database",
@@ -299,9 +365,41 @@ var Keys = []string{
 	"notifier.smtp.tls.server_name",
 	"notifier.smtp.tls.private_key",
 	"notifier.smtp.tls.certificate_chain",
+	"notifier.smtp.dkim.domain",
+	"notifier.smtp.dkim.selector",
+	"notifier.smtp.dkim.private_key",
+	"notifier.smtp.dkim.headers",
 	"notifier.smtp.host",
 	"notifier.smtp.port",
+	"notifier.webhook.url",
+	"notifier.webhook.health_check_url",
+	"notifier.webhook.timeout",
+	"notifier.webhook.secret",
+	"notifier.webhook.headers",
+	"notifier.webhook.headers[].name",
+	"notifier.webhook.headers[].value",
+	"notifier.webhook.tls.minimum_version",
+	"notifier.webhook.tls.maximum_version",
+	"notifier.webhook.tls.skip_verify",
+	"notifier.webhook.tls.server_name",
+	"notifier.webhook.tls.private_key",
+	"notifier.webhook.tls.certificate_chain",
+	"notifier.sendmail.path",
+	"notifier.sendmail.arguments",
+	"notifier.sendmail.timeout",
+	"notifier.sendmail.sender",
+	"notifier.sendmail.subject",
+	"notifier.sendmail.disable_html_emails",
+	"notifier.sendmail.dkim.domain",
+	"notifier.sendmail.dkim.selector",
+	"notifier.sendmail.dkim.private_key",
+	"notifier.sendmail.dkim.headers",
 	"notifier.template_path",
+	"notifier.queue.enabled",
+	"notifier.queue.interval",
+	"notifier.queue.max_attempts",
+	"notifier.queue.backoff",
+	"notifier.queue.max_backoff",
 	"server.address",
 	"server.asset_path",
 	"server.disable_healthcheck",
@@ -315,6 +413,23 @@ var Keys = []string{
 	"server.endpoints.authz.*.implementation",
 	"server.endpoints.authz.*.authn_strategies",
 	"server.endpoints.authz.*.authn_strategies[].name",
+	"server.endpoints.authz.*.identity_headers.client_headers",
+	"server.endpoints.authz.*.identity_headers.headers",
+	"server.endpoints.authz.*.identity_headers.headers[].name",
+	"server.endpoints.authz.*.identity_headers.headers[].value",
+	"server.endpoints.authz.*.identity_assertion.enabled",
+	"server.endpoints.authz.*.identity_assertion.header",
+	"server.endpoints.authz.*.identity_assertion.key_id",
+	"server.endpoints.authz.*.identity_assertion.lifespan",
+	"server.endpoints.authz.*.identity_assertion.audience",
+	"server.endpoints.authz.*.responses.forbidden.status_code",
+	"server.endpoints.authz.*.responses.forbidden.template",
+	"server.endpoints.authz.*.responses.forbidden.redirect_url",
+	"server.endpoints.authz.*.responses.unauthorized.status_code",
+	"server.endpoints.authz.*.responses.unauthorized.template",
+	"server.endpoints.authz.*.responses.unauthorized.redirect_url",
+	"server.endpoints.admin.enabled",
+	"server.endpoints.admin.groups",
 	"server.buffers.read",
 	"server.buffers.write",
 	"server.timeouts.read",
@@ -12,7 +12,19 @@ type Notifier struct {
 	DisableStartupCheck bool                `koanf:"disable_startup_check" json:"disable_startup_check" jsonschema:"default=false,title=Disable Startup Check" jsonschema_description:"Disables the notifier startup checks"`
 	FileSystem          *NotifierFileSystem `koanf:"filesystem" json:"filesystem" jsonschema:"title=File System" jsonschema_description:"The File System notifier"`
 	SMTP                *NotifierSMTP       `koanf:"smtp" json:"smtp" jsonschema:"title=SMTP" jsonschema_description:"The SMTP notifier"`
+	Webhook             *NotifierWebhook    `koanf:"webhook" json:"webhook" jsonschema:"title=Webhook" jsonschema_description:"The Webhook notifier"`
+	Sendmail            *NotifierSendmail   `koanf:"sendmail" json:"sendmail" jsonschema:"title=Sendmail" jsonschema_description:"The Sendmail notifier"`
 	TemplatePath        string              `koanf:"template_path" json:"template_path" jsonschema:"title=Template Path" jsonschema_description:"The path for notifier template overrides"`
+	Queue               NotifierQueue       `koanf:"queue" json:"queue" jsonschema:"title=Queue" jsonschema_description:"The persistent notification queue"`
+}
+
+// NotifierQueue represents the configuration of the persistent queue used to deliver notifications in the background.
+type NotifierQueue struct {
+	Enabled     bool          `koanf:"enabled" json:"enabled" jsonschema:"default=false,title=Enabled" jsonschema_description:"Enables the persistent notification queue"`
+	Interval    time.Duration `koanf:"interval" json:"interval" jsonschema:"default=5 seconds,title=Interval" jsonschema_description:"The interval between checks of the queue for pending notifications"`
+	MaxAttempts int           `koanf:"max_attempts" json:"max_attempts" jsonschema:"default=10,title=Maximum Attempts" jsonschema_description:"The number of delivery attempts before a notification is dead lettered"`
+	Backoff     time.Duration `koanf:"backoff" json:"backoff" jsonschema:"default=30 seconds,title=Backoff" jsonschema_description:"The delay before the first retry which doubles with each subsequent retry"`
+	MaxBackoff  time.Duration `koanf:"max_backoff" json:"max_backoff" jsonschema:"default=1 hour,title=Maximum Backoff" jsonschema_description:"The maximum delay between retries"`
 }
 
 // NotifierFileSystem represents the configuration of the notifier writing emails in a file.
@@ -22,18 +34,19 @@ type NotifierFileSystem struct {
 
 // NotifierSMTP represents the configuration of the SMTP server to send emails with.
 type NotifierSMTP struct {
-	Address             *AddressSMTP  `koanf:"address" json:"address" jsonschema:"default=smtp://localhost:25,title=Address" jsonschema_description:"The SMTP server address"`
-	Timeout             time.Duration `koanf:"timeout" json:"timeout" jsonschema:"default=5 seconds,title=Timeout" jsonschema_description:"The SMTP server connection timeout"`
-	Username            string        `koanf:"username" json:"username" jsonschema:"title=Username" jsonschema_description:"The username for SMTP authentication"`
-	Password            string        `koanf:"password" json:"password" jsonschema:"title=Password" jsonschema_description:"The password for SMTP authentication"`
-	Identifier          string        `koanf:"identifier" json:"identifier" jsonschema:"default=localhost,title=Identifier" jsonschema_description:"The identifier used during the HELO/EHLO command"`
-	Sender              mail.Address  `koanf:"sender" json:"sender" jsonschema:"title=Sender" jsonschema_description:"The sender used for SMTP"`
-	Subject             string        `koanf:"subject" json:"subject" jsonschema:"default=[Authelia] {title},title=Subject" jsonschema_description:"The subject format used"`
-	StartupCheckAddress mail.Address  `koanf:"startup_check_address" json:"startup_check_address" jsonschema:"default=Authelia Test <test@authelia.com>,title=Startup Check Address" jsonschema_description:"The address used for the recipient in the startup check"`
-	DisableRequireTLS   bool          `koanf:"disable_require_tls" json:"disable_require_tls" jsonschema:"default=false,title=Disable Require TLS" jsonschema_description:"Disables the requirement to use TLS"`
-	DisableHTMLEmails   bool          `koanf:"disable_html_emails" json:"disable_html_emails" jsonschema:"default=false,title=Disable HTML Emails" jsonschema_description:"Disables the mixed content type of emails and only sends the plaintext version"`
-	DisableStartTLS     bool          `koanf:"disable_starttls" json:"disable_starttls" jsonschema:"default=false,title=Disable StartTLS" jsonschema_description:"Disables the opportunistic StartTLS functionality which is useful for bad SMTP servers which advertise support for it but don't actually support it'"`
-	TLS                 *TLS          `koanf:"tls" json:"tls" jsonschema:"title=TLS" jsonschema_description:"The SMTP server TLS connection properties"`
+	Address             *AddressSMTP      `koanf:"address" json:"address" jsonschema:"default=smtp://localhost:25,title=Address" jsonschema_description:"The SMTP server address"`
+	Timeout             time.Duration     `koanf:"timeout" json:"timeout" jsonschema:"default=5 seconds,title=Timeout" jsonschema_description:"The SMTP server connection timeout"`
+	Username            string            `koanf:"username" json:"username" jsonschema:"title=Username" jsonschema_description:"The username for SMTP authentication"`
+	Password            string            `koanf:"password" json:"password" jsonschema:"title=Password" jsonschema_description:"The password for SMTP authentication"`
+	Identifier          string            `koanf:"identifier" json:"identifier" jsonschema:"default=localhost,title=Identifier" jsonschema_description:"The identifier used during the HELO/EHLO command"`
+	Sender              mail.Address      `koanf:"sender" json:"sender" jsonschema:"title=Sender" jsonschema_description:"The sender used for SMTP"`
+	Subject             string            `koanf:"subject" json:"subject" jsonschema:"default=[Authelia] {title},title=Subject" jsonschema_description:"The subject format used"`
+	StartupCheckAddress mail.Address      `koanf:"startup_check_address" json:"startup_check_address" jsonschema:"default=Authelia Test <test@authelia.com>,title=Startup Check Address" jsonschema_description:"The address used for the recipient in the startup check"`
+	DisableRequireTLS   bool              `koanf:"disable_require_tls" json:"disable_require_tls" jsonschema:"default=false,title=Disable Require TLS" jsonschema_description:"Disables the requirement to use TLS"`
+	DisableHTMLEmails   bool              `koanf:"disable_html_emails" json:"disable_html_emails" jsonschema:"default=false,title=Disable HTML Emails" jsonschema_description:"Disables the mixed content type of emails and only sends the plaintext version"`
+	DisableStartTLS     bool              `koanf:"disable_starttls" json:"disable_starttls" jsonschema:"default=false,title=Disable StartTLS" jsonschema_description:"Disables the opportunistic StartTLS functionality which is useful for bad SMTP servers which advertise support for it but don't actually support it'"`
+	TLS                 *TLS              `koanf:"tls" json:"tls" jsonschema:"title=TLS" jsonschema_description:"The SMTP server TLS connection properties"`
+	DKIM                *NotifierSMTPDKIM `koanf:"dkim" json:"dkim" jsonschema:"title=DKIM" jsonschema_description:"The DKIM signing properties"`
 
 	// Deprecated: use address instead.
 	Host string `koanf:"host" json:"host" jsonschema:"deprecated"`
@@ -42,6 +55,41 @@ type NotifierSMTP struct {
 	Port int `koanf:"port" json:"port" jsonschema:"deprecated"`
 }
 
+// NotifierSMTPDKIM represents the configuration used to sign emails sent by the SMTP notifier with DKIM.
+type NotifierSMTPDKIM struct {
+	Domain     string                  `koanf:"domain" json:"domain" jsonschema:"format=hostname,title=Domain" jsonschema_description:"The signing domain which defaults to the domain of the sender"`
+	Selector   string                  `koanf:"selector" json:"selector" jsonschema:"required,title=Selector" jsonschema_description:"The selector of the DKIM public key DNS record"`
+	PrivateKey CryptographicPrivateKey `koanf:"private_key" json:"private_key" jsonschema:"required,title=Private Key" jsonschema_description:"The RSA or Ed25519 private key used to sign the emails"`
+	Headers    []string                `koanf:"headers" json:"headers" jsonschema:"title=Headers" jsonschema_description:"The headers included in the signature"`
+}
+
+// NotifierSendmail represents the configuration of the sendmail compatible binary to pipe emails to.
+type NotifierSendmail struct {
+	Path              string            `koanf:"path" json:"path" jsonschema:"default=/usr/sbin/sendmail,title=Path" jsonschema_description:"The path of the sendmail compatible binary"`
+	Arguments         []string          `koanf:"arguments" json:"arguments" jsonschema:"title=Arguments" jsonschema_description:"The arguments passed to the sendmail compatible binary"`
+	Timeout           time.Duration     `koanf:"timeout" json:"timeout" jsonschema:"default=5 seconds,title=Timeout" jsonschema_description:"The timeout of the sendmail compatible binary execution"`
+	Sender            mail.Address      `koanf:"sender" json:"sender" jsonschema:"title=Sender" jsonschema_description:"The sender used for the emails"`
+	Subject           string            `koanf:"subject" json:"subject" jsonschema:"default=[Authelia] {title},title=Subject" jsonschema_description:"The subject format used"`
+	DisableHTMLEmails bool              `koanf:"disable_html_emails" json:"disable_html_emails" jsonschema:"default=false,title=Disable HTML Emails" jsonschema_description:"Disables the mixed content type of emails and only sends the plaintext version"`
+	DKIM              *NotifierSMTPDKIM `koanf:"dkim" json:"dkim" jsonschema:"title=DKIM" jsonschema_description:"The DKIM signing properties"`
+}
+
+// NotifierWebhook represents the configuration of the HTTP endpoint to send notifications to.
+type NotifierWebhook struct {
+	URL            *url.URL                `koanf:"url" json:"url" jsonschema:"format=uri,title=URL" jsonschema_description:"The URL the notifications are sent to"`
+	HealthCheckURL *url.URL                `koanf:"health_check_url" json:"health_check_url" jsonschema:"format=uri,title=Health Check URL" jsonschema_description:"The URL requested during the startup check"`
+	Timeout        time.Duration           `koanf:"timeout" json:"timeout" jsonschema:"default=5 seconds,title=Timeout" jsonschema_description:"The timeout of the requests"`
+	Secret         string                  `koanf:"secret" json:"secret" jsonschema:"title=Secret" jsonschema_description:"The secret used to sign the requests with HMAC-SHA256"`
+	Headers        []NotifierWebhookHeader `koanf:"headers" json:"headers" jsonschema:"title=Headers" jsonschema_description:"The additional headers sent with the requests"`
+	TLS            *TLS                    `koanf:"tls" json:"tls" jsonschema:"title=TLS" jsonschema_description:"The webhook server TLS connection properties"`
+}
+
+// NotifierWebhookHeader represents an additional header sent with the webhook requests.
+type NotifierWebhookHeader struct {
+	Name  string `koanf:"name" json:"name" jsonschema:"required,title=Name" jsonschema_description:"The name of the header"`
+	Value string `koanf:"value" json:"value" jsonschema:"title=Value" jsonschema_description:"The value of the header"`
+}
+
 // DefaultSMTPNotifierConfiguration represents default configuration parameters for the SMTP notifier.
 var DefaultSMTPNotifierConfiguration = NotifierSMTP{
 	Address:             &AddressSMTP{Address{true, false, -1, 25, &url.URL{Scheme: AddressSchemeSMTP, Host: "localhost:25"}}},
@@ -53,3 +101,32 @@ var DefaultSMTPNotifierConfiguration = NotifierSMTP{
 		MinimumVersion: TLSVersion{tls.VersionTLS12},
 	},
 }
+
+// DefaultSMTPNotifierDKIMConfiguration represents default configuration parameters for the SMTP notifier DKIM signing.
+var DefaultSMTPNotifierDKIMConfiguration = NotifierSMTPDKIM{
+	Headers: []string{"From", "To", "Subject", "Date", "Message-ID", "MIME-Version", "Content-Type"},
+}
+
+// DefaultWebhookNotifierConfiguration represents default configuration parameters for the Webhook notifier.
+var DefaultWebhookNotifierConfiguration = NotifierWebhook{
+	Timeout: time.Second * 5,
+	TLS: &TLS{
+		MinimumVersion: TLSVersion{tls.VersionTLS12},
+	},
+}
+
+// DefaultSendmailNotifierConfiguration represents default configuration parameters for the Sendmail notifier.
+var DefaultSendmailNotifierConfiguration = NotifierSendmail{
+	Path:      "/usr/sbin/sendmail",
+	Arguments: []string{"-oi", "-t"},
+	Timeout:   time.Second * 5,
+	Subject:   "[Authelia] {title}",
+}
+
+// DefaultNotifierQueueConfiguration represents default configuration parameters for the notification queue.
+var DefaultNotifierQueueConfiguration = NotifierQueue{
+	Interval:    time.Second * 5,
+	MaxAttempts: 10,
+	Backoff:     time.Second * 30,
+	MaxBackoff:  time.Hour,
+}
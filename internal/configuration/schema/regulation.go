@@ -9,6 +9,44 @@ type Regulation struct {
 	MaxRetries int           `koanf:"max_retries" json:"max_retries" jsonschema:"default=3,title=Maximum Retries" jsonschema_description:"The maximum number of failed attempts permitted before banning a user"`
 	FindTime   time.Duration `koanf:"find_time" json:"find_time" jsonschema:"default=2 minutes,title=Find Time" jsonschema_description:"The amount of time to consider when determining the number of failed attempts"`
 	BanTime    time.Duration `koanf:"ban_time" json:"ban_time" jsonschema:"default=5 minutes,title=Ban Time" jsonschema_description:"The amount of time to ban the user for when it's determined the maximum retries has been exceeded'"`
+	Scope      string        `koanf:"scope" json:"scope" jsonschema:"default=user,enum=user,enum=user_ip,title=Scope" jsonschema_description:"The scope of the user bans, either the user on every remote network or the user on a single remote network"`
+	Mode       string        `koanf:"mode" json:"mode" jsonschema:"default=fixed,enum=fixed,enum=escalating,title=Mode" jsonschema_description:"The mode used to determine the ban time, either the fixed ban time or a ban time which escalates with each successive ban"`
+
+	IP         RegulationIP         `koanf:"ip" json:"ip" jsonschema:"title=IP" jsonschema_description:"Remote IP Regulation configuration"`
+	Escalation RegulationEscalation `koanf:"escalation" json:"escalation" jsonschema:"title=Escalation" jsonschema_description:"Escalating Regulation configuration"`
+
+	SecondFactor RegulationSecondFactor `koanf:"second_factor" json:"second_factor" jsonschema:"title=Second Factor" jsonschema_description:"Second Factor Regulation configuration"`
+}
+
+// RegulationIP represents the configuration related to the regulation of remote IP networks.
+type RegulationIP struct {
+	MaxRetries      int           `koanf:"max_retries" json:"max_retries" jsonschema:"default=0,title=Maximum Retries" jsonschema_description:"The maximum number of failed attempts permitted from a remote network before banning it"`
+	FindTime        time.Duration `koanf:"find_time" json:"find_time" jsonschema:"default=2 minutes,title=Find Time" jsonschema_description:"The amount of time to consider when determining the number of failed attempts from a remote network"`
+	BanTime         time.Duration `koanf:"ban_time" json:"ban_time" jsonschema:"default=5 minutes,title=Ban Time" jsonschema_description:"The amount of time to ban the remote network for when it's determined the maximum retries has been exceeded"`
+	IPv4Mask        int           `koanf:"ipv4_mask" json:"ipv4_mask" jsonschema:"default=32,minimum=1,maximum=32,title=IPv4 Mask" jsonschema_description:"The prefix length applied to IPv4 remote addresses to determine the remote network"`
+	IPv6Mask        int           `koanf:"ipv6_mask" json:"ipv6_mask" jsonschema:"default=64,minimum=1,maximum=128,title=IPv6 Mask" jsonschema_description:"The prefix length applied to IPv6 remote addresses to determine the remote network"`
+	TrustedNetworks []string      `koanf:"trusted_networks" json:"trusted_networks" jsonschema:"title=Trusted Networks" jsonschema_description:"The remote IP's or network ranges in CIDR notation which are never banned by the remote IP regulation"`
+}
+
+// RegulationEscalation represents the configuration related to the escalating regulation mode.
+type RegulationEscalation struct {
+	Multiplier int           `koanf:"multiplier" json:"multiplier" jsonschema:"default=2,minimum=2,title=Multiplier" jsonschema_description:"The multiplier applied to the ban time for each successive ban within the lookback"`
+	MaxBanTime time.Duration `koanf:"max_ban_time" json:"max_ban_time" jsonschema:"default=1 day,title=Maximum Ban Time" jsonschema_description:"The maximum amount of time a ban can escalate to"`
+	Lookback   time.Duration `koanf:"lookback" json:"lookback" jsonschema:"default=1 day,title=Lookback" jsonschema_description:"The amount of time to consider when determining the number of successive bans"`
+}
+
+// RegulationSecondFactor represents the configuration related to the regulation of the second factor methods.
+type RegulationSecondFactor struct {
+	TOTP     RegulationSecondFactorMethod `koanf:"totp" json:"totp" jsonschema:"title=TOTP" jsonschema_description:"Time-based One-Time Password Regulation configuration"`
+	WebAuthn RegulationSecondFactorMethod `koanf:"webauthn" json:"webauthn" jsonschema:"title=WebAuthn" jsonschema_description:"WebAuthn Regulation configuration"`
+	Duo      RegulationSecondFactorMethod `koanf:"duo" json:"duo" jsonschema:"title=Duo" jsonschema_description:"Duo Regulation configuration"`
+}
+
+// RegulationSecondFactorMethod represents the configuration related to the regulation of a single second factor method.
+type RegulationSecondFactorMethod struct {
+	MaxRetries int           `koanf:"max_retries" json:"max_retries" jsonschema:"default=0,title=Maximum Retries" jsonschema_description:"The maximum number of failed attempts with the second factor method permitted before banning a user from it"`
+	FindTime   time.Duration `koanf:"find_time" json:"find_time" jsonschema:"default=2 minutes,title=Find Time" jsonschema_description:"The amount of time to consider when determining the number of failed attempts with the second factor method"`
+	BanTime    time.Duration `koanf:"ban_time" json:"ban_time" jsonschema:"default=5 minutes,title=Ban Time" jsonschema_description:"The amount of time to ban the user from the second factor method for when it's determined the maximum retries has been exceeded"`
 }
 
 // DefaultRegulationConfiguration represents default configuration parameters for the regulator.
@@ -16,4 +54,31 @@ var DefaultRegulationConfiguration = Regulation{
 	MaxRetries: 3,
 	FindTime:   time.Minute * 2,
 	BanTime:    time.Minute * 5,
+	Scope:      RegulationScopeUser,
+	Mode:       RegulationModeFixed,
+	IP: RegulationIP{
+		FindTime: time.Minute * 2,
+		BanTime:  time.Minute * 5,
+		IPv4Mask: 32,
+		IPv6Mask: 64,
+	},
+	Escalation: RegulationEscalation{
+		Multiplier: 2,
+		MaxBanTime: time.Hour * 24,
+		Lookback:   time.Hour * 24,
+	},
+	SecondFactor: RegulationSecondFactor{
+		TOTP: RegulationSecondFactorMethod{
+			FindTime: time.Minute * 2,
+			BanTime:  time.Minute * 5,
+		},
+		WebAuthn: RegulationSecondFactorMethod{
+			FindTime: time.Minute * 2,
+			BanTime:  time.Minute * 5,
+		},
+		Duo: RegulationSecondFactorMethod{
+			FindTime: time.Minute * 2,
+			BanTime:  time.Minute * 5,
+		},
+	},
 }
@@ -34,6 +34,14 @@ type ServerEndpoints struct {
 	EnableExpvars bool `koanf:"enable_expvars" json:"enable_expvars" jsonschema:"default=false,title=Enable ExpVars" jsonschema_description:"Enables the developer specific ExpVars endpoints which should not be used in production and only used for debugging purposes"`
 
 	Authz map[string]ServerEndpointsAuthz `koanf:"authz" json:"authz" jsonschema:"title=Authz" jsonschema_description:"Configures the Authorization endpoints"`
+
+	Admin ServerEndpointsAdmin `koanf:"admin" json:"admin" jsonschema:"title=Admin" jsonschema_description:"Configures the Administration endpoints"`
+}
+
+// ServerEndpointsAdmin is the Administration endpoints configuration for the HTTP server.
+type ServerEndpointsAdmin struct {
+	Enabled bool     `koanf:"enabled" json:"enabled" jsonschema:"default=false,title=Enabled" jsonschema_description:"Enables the Administration endpoints"`
+	Groups  []string `koanf:"groups" json:"groups" jsonschema:"title=Groups" jsonschema_description:"The groups whose members are permitted to use the Administration endpoints"`
 }
 
 // ServerEndpointsAuthz is the Authz endpoints configuration for the HTTP server.
@@ -41,6 +49,46 @@ type ServerEndpointsAuthz struct {
 	Implementation string `koanf:"implementation" json:"implementation" jsonschema:"enum=ForwardAuth,enum=AuthRequest,enum=ExtAuthz,enum=Legacy,title=Implementation" jsonschema_description:"The specific Authorization implementation to use for this endpoint"`
 
 	AuthnStrategies []ServerEndpointsAuthzAuthnStrategy `koanf:"authn_strategies" json:"authn_strategies" jsonschema:"title=Authn Strategies" jsonschema_description:"The specific Authorization strategies to use for this endpoint"`
+
+	IdentityHeaders ServerEndpointsAuthzIdentityHeaders `koanf:"identity_headers" json:"identity_headers" jsonschema:"title=Identity Headers" jsonschema_description:"The headers which contain the identity of the user sent in authorized responses from this endpoint"`
+
+	IdentityAssertion ServerEndpointsAuthzIdentityAssertion `koanf:"identity_assertion" json:"identity_assertion" jsonschema:"title=Identity Assertion" jsonschema_description:"The signed JWT which asserts the identity of the user sent in authorized responses from this endpoint"`
+
+	Responses AuthzResponses `koanf:"responses" json:"responses" jsonschema:"title=Responses" jsonschema_description:"The customized responses sent from this endpoint when access is denied"`
+}
+
+// ServerEndpointsAuthzIdentityAssertion is the Authz endpoints identity assertion configuration for the HTTP server.
+type ServerEndpointsAuthzIdentityAssertion struct {
+	Enabled  bool          `koanf:"enabled" json:"enabled" jsonschema:"default=false,title=Enabled" jsonschema_description:"Enables sending the identity assertion"`
+	Header   string        `koanf:"header" json:"header" jsonschema:"default=Remote-Assertion,title=Header" jsonschema_description:"The name of the header which contains the identity assertion"`
+	KeyID    string        `koanf:"key_id" json:"key_id" jsonschema:"title=Key ID" jsonschema_description:"The key id of the OpenID Connect 1.0 issuer private key used to sign the identity assertion"`
+	Lifespan time.Duration `koanf:"lifespan" json:"lifespan" jsonschema:"default=1 minute,title=Lifespan" jsonschema_description:"The lifespan of the identity assertion"`
+	Audience []string      `koanf:"audience" json:"audience" jsonschema:"title=Audience" jsonschema_description:"The audience of the identity assertion, defaults to the origin of the requested URL"`
+}
+
+// ServerEndpointsAuthzIdentityHeaders is the Authz endpoints identity headers configuration for the HTTP server.
+type ServerEndpointsAuthzIdentityHeaders struct {
+	ClientHeaders string                `koanf:"client_headers" json:"client_headers" jsonschema:"default=allow,enum=allow,enum=strip,enum=forbid,title=Client Headers" jsonschema_description:"How copies of the identity headers supplied by the client are handled"`
+	Headers       []AuthzIdentityHeader `koanf:"headers" json:"headers" jsonschema:"title=Headers" jsonschema_description:"The identity headers to send, defaults to the Remote-User, Remote-Groups, Remote-Name, and Remote-Email headers"`
+}
+
+// AuthzIdentityHeader is an identity header sent in authorized responses from the Authz endpoints.
+type AuthzIdentityHeader struct {
+	Name  string `koanf:"name" json:"name" jsonschema:"required,title=Name" jsonschema_description:"The name of the header"`
+	Value string `koanf:"value" json:"value" jsonschema:"title=Value" jsonschema_description:"The template used to render the value of the header"`
+}
+
+// AuthzResponses are the customized responses sent from the Authz endpoints when access is denied.
+type AuthzResponses struct {
+	Forbidden    AuthzResponse `koanf:"forbidden" json:"forbidden" jsonschema:"title=Forbidden" jsonschema_description:"The response sent when access is forbidden"`
+	Unauthorized AuthzResponse `koanf:"unauthorized" json:"unauthorized" jsonschema:"title=Unauthorized" jsonschema_description:"The response sent when the user must authenticate"`
+}
+
+// AuthzResponse is a customized response sent from the Authz endpoints when access is denied.
+type AuthzResponse struct {
+	StatusCode  int      `koanf:"status_code" json:"status_code" jsonschema:"minimum=300,maximum=599,title=Status Code" jsonschema_description:"The status code of the response"`
+	Template    string   `koanf:"template" json:"template" jsonschema:"title=Template" jsonschema_description:"The path to the template used to render the body of the response"`
+	RedirectURL *url.URL `koanf:"redirect_url" json:"redirect_url" jsonschema:"format=uri,title=Redirect URL" jsonschema_description:"The URL the user is redirected to"`
 }
 
 // ServerEndpointsAuthzAuthnStrategy is the Authz endpoints configuration for the HTTP server.
@@ -60,6 +108,12 @@ type ServerHeaders struct {
 	CSPTemplate CSPTemplate `koanf:"csp_template" json:"csp_template" jsonschema:"title=CSP Template" jsonschema_description:"The Content Security Policy template"`
 }
 
+// DefaultServerEndpointsAuthzIdentityAssertion represents the default values of the ServerEndpointsAuthzIdentityAssertion.
+var DefaultServerEndpointsAuthzIdentityAssertion = ServerEndpointsAuthzIdentityAssertion{
+	Header:   "Remote-Assertion",
+	Lifespan: time.Minute,
+}
+
 // DefaultServerConfiguration represents the default values of the Server.
 var DefaultServerConfiguration = Server{
 	Address: &AddressTCP{Address{true, false, -1, 9091, &url.URL{Scheme: AddressSchemeTCP, Host: ":9091", Path: "/"}}},
@@ -10,11 +10,16 @@ import (
 type Session struct {
 	SessionCookieCommon `koanf:",squash"`
 
-	Secret string `koanf:"secret" json:"secret" jsonschema:"title=Secret" jsonschema_description:"Secret used to encrypt the session data"`
+	Secret  string   `koanf:"secret" json:"secret" jsonschema:"title=Secret" jsonschema_description:"Secret used to encrypt the session data"`
+	Secrets []string `koanf:"secrets" json:"secrets" jsonschema:"title=Secrets" jsonschema_description:"Ordered list of secrets where the first is used to encrypt the session data unless the secret option is configured and all of them are used to decrypt the session data"`
 
 	Cookies []SessionCookie `koanf:"cookies" json:"cookies" jsonschema:"title=Cookies" jsonschema_description:"List of cookie domain configurations"`
 
 	Redis *SessionRedis `koanf:"redis" json:"redis" jsonschema:"title=Redis" jsonschema_description:"Redis Session Provider configuration"`
+	SQL   *SessionSQL   `koanf:"sql" json:"sql" jsonschema:"title=SQL" jsonschema_description:"SQL Session Provider configuration"`
+
+	Binding SessionBinding `koanf:"binding" json:"binding" jsonschema:"title=Binding" jsonschema_description:"Session Binding configuration"`
+	Logout  SessionLogout  `koanf:"logout" json:"logout" jsonschema:"title=Logout" jsonschema_description:"Session Logout configuration"`
 
 	// Deprecated: Use the session cookies option with the same name instead.
 	Domain string `koanf:"domain" json:"domain" jsonschema:"deprecated,title=Domain"`
@@ -53,9 +58,10 @@ type SessionRedis struct {
 	TLS                      *TLS   `koanf:"tls" json:"tls"`
 
 	HighAvailability *SessionRedisHighAvailability `koanf:"high_availability" json:"high_availability"`
+	Cluster          *SessionRedisCluster          `koanf:"cluster" json:"cluster"`
 }
 
-// SessionRedisHighAvailability holds configuration variables for Redis Cluster/Sentinel.
+// SessionRedisHighAvailability holds configuration variables for Redis Sentinel.
 type SessionRedisHighAvailability struct {
 	SentinelName     string `koanf:"sentinel_name" json:"sentinel_name" jsonschema:"title=Sentinel Name" jsonschema_description:"The name of the sentinel instance"`
 	SentinelUsername string `koanf:"sentinel_username" json:"sentinel_username" jsonschema:"title=Sentinel Username" jsonschema_description:"The username for the sentinel instance"`
@@ -72,6 +78,42 @@ type SessionRedisHighAvailabilityNode struct {
 	Port int    `koanf:"port" json:"port" jsonschema:"default=26379,title=Port" jsonschema_description:"The redis sentinel node port"`
 }
 
+// SessionRedisCluster holds configuration variables for Redis Cluster.
+type SessionRedisCluster struct {
+	ReadFromReplicas bool `koanf:"read_from_replicas" json:"read_from_replicas" jsonschema:"default=false,title=Read from Replicas" jsonschema_description:"Allows read only commands to be sent to the replica nodes"`
+	RouteByLatency   bool `koanf:"route_by_latency" json:"route_by_latency" jsonschema:"default=false,title=Route by Latency" jsonschema_description:"Uses the Route by Latency mode which routes read only commands to the closest master or replica node"`
+	RouteRandomly    bool `koanf:"route_randomly" json:"route_randomly" jsonschema:"default=false,title=Route Randomly" jsonschema_description:"Uses the Route Randomly mode which routes read only commands to a random master or replica node"`
+	MaximumRedirects int  `koanf:"maximum_redirects" json:"maximum_redirects" jsonschema:"default=3,title=Maximum Redirects" jsonschema_description:"The maximum number of MOVED or ASK slot redirections which are followed for a command"`
+
+	Nodes []SessionRedisClusterNode `koanf:"nodes" json:"nodes" jsonschema:"title=Nodes" jsonschema_description:"The list of seed nodes used to discover the cluster"`
+}
+
+// SessionRedisClusterNode represents a Redis Cluster seed node.
+type SessionRedisClusterNode struct {
+	Host string `koanf:"host" json:"host" jsonschema:"title=Host" jsonschema_description:"The redis cluster node host"`
+	Port int    `koanf:"port" json:"port" jsonschema:"default=6379,title=Port" jsonschema_description:"The redis cluster node port"`
+}
+
+// SessionSQL represents the configuration related to the SQL session store which uses the storage backend.
+type SessionSQL struct {
+	CleanupInterval time.Duration `koanf:"cleanup_interval" json:"cleanup_interval" jsonschema:"default=5 minutes,title=Cleanup Interval" jsonschema_description:"The interval between removals of expired sessions from the database"`
+}
+
+// SessionBinding represents the configuration which binds a session to the client fingerprint recorded at login.
+type SessionBinding struct {
+	Enabled    bool   `koanf:"enabled" json:"enabled" jsonschema:"default=false,title=Enabled" jsonschema_description:"Enables binding the session to the client fingerprint"`
+	IPv4Mask   int    `koanf:"ipv4_mask" json:"ipv4_mask" jsonschema:"default=24,minimum=1,maximum=32,title=IPv4 Mask" jsonschema_description:"The prefix length applied to IPv4 client addresses before they're compared"`
+	IPv6Mask   int    `koanf:"ipv6_mask" json:"ipv6_mask" jsonschema:"default=64,minimum=1,maximum=128,title=IPv6 Mask" jsonschema_description:"The prefix length applied to IPv6 client addresses before they're compared"`
+	Strictness string `koanf:"strictness" json:"strictness" jsonschema:"default=strict,enum=strict,enum=lax,enum=ip,enum=user_agent,title=Strictness" jsonschema_description:"Which parts of the client fingerprint must match"`
+	Action     string `koanf:"action" json:"action" jsonschema:"default=reauthenticate,enum=log,enum=reauthenticate,enum=destroy,title=Action" jsonschema_description:"The action taken when the client fingerprint does not match"`
+}
+
+// SessionLogout represents the configuration related to logging out of the session.
+type SessionLogout struct {
+	Global         bool     `koanf:"global" json:"global" jsonschema:"default=false,title=Global" jsonschema_description:"Destroys the sessions of the user on all cookie domains when they log out"`
+	RedirectionURL *url.URL `koanf:"redirection_url" json:"redirection_url" jsonschema:"format=uri,title=Redirection URL" jsonschema_description:"The URL users are redirected to after they log out when no safe target URL was provided"`
+}
+
 // DefaultSessionConfiguration is the default session configuration.
 var DefaultSessionConfiguration = Session{
 	SessionCookieCommon: SessionCookieCommon{
@@ -81,6 +123,12 @@ var DefaultSessionConfiguration = Session{
 		RememberMe: time.Hour * 24 * 30,
 		SameSite:   "lax",
 	},
+	Binding: SessionBinding{
+		IPv4Mask:   24,
+		IPv6Mask:   64,
+		Strictness: SessionBindingStrictnessStrict,
+		Action:     SessionBindingActionReauthenticate,
+	},
 }
 
 // DefaultRedisConfiguration is the default redis configuration.
@@ -92,6 +140,23 @@ var DefaultRedisConfiguration = SessionRedis{
 	},
 }
 
+// DefaultRedisClusterConfiguration is the default redis cluster configuration.
+var DefaultRedisClusterConfiguration = SessionRedis{
+	Port:                     6379,
+	MaximumActiveConnections: 8,
+	TLS: &TLS{
+		MinimumVersion: TLSVersion{Value: tls.VersionTLS12},
+	},
+	Cluster: &SessionRedisCluster{
+		MaximumRedirects: 3,
+	},
+}
+
+// DefaultSessionSQLConfiguration is the default SQL session configuration.
+var DefaultSessionSQLConfiguration = SessionSQL{
+	CleanupInterval: time.Minute * 5,
+}
+
 // DefaultRedisHighAvailabilityConfiguration is the default redis configuration.
 var DefaultRedisHighAvailabilityConfiguration = SessionRedis{
 	Port:                     26379,
@@ -362,6 +362,8 @@ func TestJSONSchema(t *testing.T) {
 		&AccessControlNetworkNetworks{},
 		&AccessControlRuleDomains{},
 		&AccessControlRuleMethods{},
+		&AccessControlRuleCountries{},
+		&AccessControlRuleASNs{},
 		&AccessControlRuleRegex{},
 		&AccessControlRuleSubjects{},
 		&IdentityProvidersOpenIDConnectClientRedirectURIs{},
@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"net"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/clock"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/utils"
 )
@@ -105,14 +107,66 @@ func ValidateRules(config *schema.Configuration, validator *schema.StructValidat
 
 		validateMethods(rulePosition, rule, validator)
 
+		validateGeoIPCriteria(i, rule, config, validator)
+
 		validateQuery(i, rule, config, validator)
 
+		validateHeaders(i, rule, config, validator)
+
+		validateExpression(rulePosition, rule, validator)
+
+		validateSchedule(rulePosition, rule, validator)
+
+		validateMaxAuthenticationAge(rulePosition, rule, validator)
+
+		validateAuthzIdentityHeaders(fmt.Sprintf("access_control: rule %s: identity_headers", ruleDescriptor(rulePosition, rule)), rule.IdentityHeaders, validator)
+
+		if rule.IdentityAssertion.Lifespan < 0 {
+			validator.Push(fmt.Errorf(errFmtAccessControlRuleIdentityAssertionLifespanNegative, ruleDescriptor(rulePosition, rule), rule.IdentityAssertion.Lifespan))
+		}
+
+		validateAuthzResponses(fmt.Sprintf("access_control: rule %s: responses", ruleDescriptor(rulePosition, rule)), rule.Responses, validator)
+
+		validateResponsesEndpoints(rulePosition, rule, config, validator)
+
 		if rule.Policy == policyBypass {
 			validateBypass(rulePosition, rule, validator)
 		}
 	}
 }
 
+// validateResponsesEndpoints ensures the forbidden response of the rule is still a valid redirection when the options
+// which are not configured fall back to the options of each authz endpoint.
+func validateResponsesEndpoints(rulePosition int, rule schema.AccessControlRule, config *schema.Configuration, validator *schema.StructValidator) {
+	forbidden := rule.Responses.Forbidden
+
+	// The combination is validated with the rule itself when both options are configured.
+	if (forbidden.RedirectURL == nil) == (forbidden.StatusCode == 0) {
+		return
+	}
+
+	names := make([]string, 0, len(config.Server.Endpoints.Authz))
+
+	for name := range config.Server.Endpoints.Authz {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	prefix := fmt.Sprintf("access_control: rule %s: responses", ruleDescriptor(rulePosition, rule))
+
+	for _, name := range names {
+		fallback := config.Server.Endpoints.Authz[name].Responses.Forbidden
+
+		switch {
+		case forbidden.RedirectURL != nil && fallback.StatusCode != 0 && !isRedirectStatusCode(fallback.StatusCode):
+			validator.Push(fmt.Errorf(errFmtAuthzResponseRedirectEndpoint, prefix, authzResponseForbidden, "redirect_url", "status_code", name, fallback.StatusCode))
+		case forbidden.StatusCode != 0 && !isRedirectStatusCode(forbidden.StatusCode) && fallback.RedirectURL != nil:
+			validator.Push(fmt.Errorf(errFmtAuthzResponseRedirectEndpoint, prefix, authzResponseForbidden, "status_code", "redirect_url", name, forbidden.StatusCode))
+		}
+	}
+}
+
 func validateBypass(rulePosition int, rule schema.AccessControlRule, validator *schema.StructValidator) {
 	if len(rule.Subjects) != 0 {
 		validator.Push(fmt.Errorf(errAccessControlRuleBypassPolicyInvalidWithSubjects, ruleDescriptor(rulePosition, rule)))
@@ -170,59 +224,145 @@ func validateMethods(rulePosition int, rule schema.AccessControlRule, validator
 	}
 }
 
-//nolint:gocyclo
+func validateExpression(rulePosition int, rule schema.AccessControlRule, validator *schema.StructValidator) {
+	if rule.Expression == "" {
+		return
+	}
+
+	if _, err := authorization.NewAccessControlExpression(rule.Expression); err != nil {
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleExpressionInvalid, ruleDescriptor(rulePosition, rule), err))
+	}
+}
+
+func validateSchedule(rulePosition int, rule schema.AccessControlRule, validator *schema.StructValidator) {
+	if rule.Schedule == nil {
+		return
+	}
+
+	if _, err := authorization.NewAccessControlSchedule(*rule.Schedule, clock.New()); err != nil {
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleScheduleInvalid, ruleDescriptor(rulePosition, rule), err))
+	}
+}
+
+func validateMaxAuthenticationAge(rulePosition int, rule schema.AccessControlRule, validator *schema.StructValidator) {
+	if rule.MaxAuthenticationAge.FirstFactor < 0 {
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleMaxAuthenticationAgeNegative, ruleDescriptor(rulePosition, rule), "first_factor", rule.MaxAuthenticationAge.FirstFactor))
+	} else if rule.MaxAuthenticationAge.FirstFactor > 0 && rule.Policy != policyOneFactor && rule.Policy != policyTwoFactor {
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleMaxAuthenticationAgePolicy, ruleDescriptor(rulePosition, rule), "first_factor", strJoinOr([]string{policyOneFactor, policyTwoFactor}), rule.Policy))
+	}
+
+	if rule.MaxAuthenticationAge.SecondFactor < 0 {
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleMaxAuthenticationAgeNegative, ruleDescriptor(rulePosition, rule), "second_factor", rule.MaxAuthenticationAge.SecondFactor))
+	} else if rule.MaxAuthenticationAge.SecondFactor > 0 && rule.Policy != policyTwoFactor {
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleMaxAuthenticationAgePolicy, ruleDescriptor(rulePosition, rule), "second_factor", strJoinOr([]string{policyTwoFactor}), rule.Policy))
+	}
+}
+
+func validateGeoIPCriteria(i int, rule schema.AccessControlRule, config *schema.Configuration, validator *schema.StructValidator) {
+	for j, country := range rule.Countries {
+		if len(country) != 2 || !isAlpha(country) {
+			validator.Push(fmt.Errorf(errFmtAccessControlRuleCountryInvalid, ruleDescriptor(i+1, rule), country))
+
+			continue
+		}
+
+		config.AccessControl.Rules[i].Countries[j] = strings.ToUpper(country)
+	}
+
+	for _, asn := range rule.ASNs {
+		if asn <= 0 {
+			validator.Push(fmt.Errorf(errFmtAccessControlRuleASNInvalid, ruleDescriptor(i+1, rule), asn))
+		}
+	}
+
+	if len(rule.Countries) != 0 && config.GeoIP.CountryDatabase == "" {
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleGeoIPDatabase, ruleDescriptor(i+1, rule), "countries", "country_database"))
+	}
+
+	if len(rule.ASNs) != 0 && config.GeoIP.ASNDatabase == "" {
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleGeoIPDatabase, ruleDescriptor(i+1, rule), "asns", "asn_database"))
+	}
+}
+
+func isAlpha(value string) bool {
+	for _, r := range value {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+
+	return true
+}
+
 func validateQuery(i int, rule schema.AccessControlRule, config *schema.Configuration, validator *schema.StructValidator) {
 	for j := 0; j < len(config.AccessControl.Rules[i].Query); j++ {
 		for k := 0; k < len(config.AccessControl.Rules[i].Query[j]); k++ {
-			if config.AccessControl.Rules[i].Query[j][k].Operator == "" {
-				if config.AccessControl.Rules[i].Query[j][k].Key != "" {
-					switch config.AccessControl.Rules[i].Query[j][k].Value {
-					case "", nil:
-						config.AccessControl.Rules[i].Query[j][k].Operator = operatorPresent
-					default:
-						config.AccessControl.Rules[i].Query[j][k].Operator = operatorEqual
-					}
-				}
-			} else if !utils.IsStringInSliceFold(config.AccessControl.Rules[i].Query[j][k].Operator, validACLRuleOperators) {
-				validator.Push(fmt.Errorf(errFmtAccessControlRuleQueryInvalid, ruleDescriptor(i+1, rule), strJoinOr(validACLRuleOperators), config.AccessControl.Rules[i].Query[j][k].Operator))
-			}
+			query := &config.AccessControl.Rules[i].Query[j][k]
 
-			if config.AccessControl.Rules[i].Query[j][k].Key == "" {
-				validator.Push(fmt.Errorf(errFmtAccessControlRuleQueryInvalidNoValue, ruleDescriptor(i+1, rule), "key"))
-			}
+			validateMatcher(i+1, rule, "query", &query.Operator, query.Key, &query.Value, validator)
+		}
+	}
+}
 
-			op := config.AccessControl.Rules[i].Query[j][k].Operator
+func validateHeaders(i int, rule schema.AccessControlRule, config *schema.Configuration, validator *schema.StructValidator) {
+	for j := 0; j < len(config.AccessControl.Rules[i].Headers); j++ {
+		for k := 0; k < len(config.AccessControl.Rules[i].Headers[j]); k++ {
+			header := &config.AccessControl.Rules[i].Headers[j][k]
 
-			if op == "" {
-				continue
-			}
+			validateMatcher(i+1, rule, "headers", &header.Operator, header.Key, &header.Value, validator)
+		}
+	}
+}
 
-			switch v := config.AccessControl.Rules[i].Query[j][k].Value.(type) {
-			case nil:
-				if op != operatorAbsent && op != operatorPresent {
-					validator.Push(fmt.Errorf(errFmtAccessControlRuleQueryInvalidNoValueOperator, ruleDescriptor(i+1, rule), "value", op))
-				}
-			case string:
-				switch op {
-				case operatorPresent, operatorAbsent:
-					if v != "" {
-						validator.Push(fmt.Errorf(errFmtAccessControlRuleQueryInvalidValue, ruleDescriptor(i+1, rule), "value", op))
-					}
-				case operatorPattern, operatorNotPattern:
-					var (
-						pattern *regexp.Regexp
-						err     error
-					)
-
-					if pattern, err = regexp.Compile(v); err != nil {
-						validator.Push(fmt.Errorf(errFmtAccessControlRuleQueryInvalidValueParse, ruleDescriptor(i+1, rule), "value", err))
-					} else {
-						config.AccessControl.Rules[i].Query[j][k].Value = pattern
-					}
-				}
+// validateMatcher validates and sets the defaults for the operator based criteria such as the query and headers criteria.
+func validateMatcher(rulePosition int, rule schema.AccessControlRule, criteria string, operator *string, key string, value *any, validator *schema.StructValidator) {
+	if *operator == "" {
+		if key != "" {
+			switch *value {
+			case "", nil:
+				*operator = operatorPresent
 			default:
-				validator.Push(fmt.Errorf(errFmtAccessControlRuleQueryInvalidValueType, ruleDescriptor(i+1, rule), v))
+				*operator = operatorEqual
+			}
+		}
+	} else if !utils.IsStringInSliceFold(*operator, validACLRuleOperators) {
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleMatcherInvalid, ruleDescriptor(rulePosition, rule), criteria, strJoinOr(validACLRuleOperators), *operator))
+	}
+
+	if key == "" {
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleMatcherInvalidNoValue, ruleDescriptor(rulePosition, rule), criteria, "key"))
+	}
+
+	op := *operator
+
+	if op == "" {
+		return
+	}
+
+	switch v := (*value).(type) {
+	case nil:
+		if op != operatorAbsent && op != operatorPresent {
+			validator.Push(fmt.Errorf(errFmtAccessControlRuleMatcherInvalidNoValueOperator, ruleDescriptor(rulePosition, rule), criteria, "value", op))
+		}
+	case string:
+		switch op {
+		case operatorPresent, operatorAbsent:
+			if v != "" {
+				validator.Push(fmt.Errorf(errFmtAccessControlRuleMatcherInvalidValue, ruleDescriptor(rulePosition, rule), criteria, "value", op))
+			}
+		case operatorPattern, operatorNotPattern:
+			var (
+				pattern *regexp.Regexp
+				err     error
+			)
+
+			if pattern, err = regexp.Compile(v); err != nil {
+				validator.Push(fmt.Errorf(errFmtAccessControlRuleMatcherInvalidValueParse, ruleDescriptor(rulePosition, rule), criteria, "value", err))
+			} else {
+				*value = pattern
 			}
 		}
+	default:
+		validator.Push(fmt.Errorf(errFmtAccessControlRuleMatcherInvalidValueType, ruleDescriptor(rulePosition, rule), criteria, v))
 	}
 }
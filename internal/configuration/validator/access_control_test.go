@@ -2,8 +2,12 @@ package validator
 
 import (
 	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
@@ -215,6 +219,284 @@ func (suite *AccessControl) TestShouldRaiseErrorDuplicateMethod() {
 	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: rule #1 (domain 'public.example.com'): option 'methods' must have unique values but the values 'GET' are duplicated")
 }
 
+func (suite *AccessControl) TestShouldRaiseErrorInvalidExpression() {
+	suite.config.AccessControl.Rules = []schema.AccessControlRule{
+		{
+			Domains:    []string{"public.example.com"},
+			Policy:     "one_factor",
+			Expression: "subject.emails.exists(e, e.endsWith('@contractor.com'))",
+		},
+		{
+			Domains:    []string{"app.example.com"},
+			Policy:     "one_factor",
+			Expression: "object.path",
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 1)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: rule #2 (domain 'app.example.com'): option 'expression' is invalid: expression must evaluate to a bool but it evaluates to a dyn")
+}
+
+func (suite *AccessControl) TestShouldRaiseErrorInvalidSchedule() {
+	suite.config.AccessControl.Rules = []schema.AccessControlRule{
+		{
+			Domains: []string{"public.example.com"},
+			Policy:  "one_factor",
+			Schedule: &schema.AccessControlRuleSchedule{
+				Timezone: "Europe/London",
+				Weekdays: []string{"mon", "tue"},
+				Times:    []string{"09:00-17:00"},
+			},
+		},
+		{
+			Domains: []string{"app.example.com"},
+			Policy:  "one_factor",
+			Schedule: &schema.AccessControlRuleSchedule{
+				Times: []string{"9am-5pm"},
+			},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 1)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: rule #2 (domain 'app.example.com'): schedule: option 'times' has an invalid value '9am-5pm': the time '9am' must be in the format 'HH:MM'")
+}
+
+func (suite *AccessControl) TestShouldRaiseErrorInvalidMaxAuthenticationAge() {
+	suite.config.AccessControl.Rules = []schema.AccessControlRule{
+		{
+			Domains: []string{"public.example.com"},
+			Policy:  "bypass",
+			MaxAuthenticationAge: schema.AccessControlRuleMaxAuthenticationAge{
+				FirstFactor: time.Minute,
+			},
+		},
+		{
+			Domains: []string{"one.example.com"},
+			Policy:  "one_factor",
+			MaxAuthenticationAge: schema.AccessControlRuleMaxAuthenticationAge{
+				FirstFactor:  time.Minute,
+				SecondFactor: time.Minute,
+			},
+		},
+		{
+			Domains: []string{"two.example.com"},
+			Policy:  "two_factor",
+			MaxAuthenticationAge: schema.AccessControlRuleMaxAuthenticationAge{
+				FirstFactor:  -time.Minute,
+				SecondFactor: time.Minute,
+			},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 3)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: rule #1 (domain 'public.example.com'): max_authentication_age: option 'first_factor' is only valid when the 'policy' option is 'one_factor' or 'two_factor' but it's configured as 'bypass'")
+	suite.Assert().EqualError(suite.validator.Errors()[1], "access_control: rule #2 (domain 'one.example.com'): max_authentication_age: option 'second_factor' is only valid when the 'policy' option is 'two_factor' but it's configured as 'one_factor'")
+	suite.Assert().EqualError(suite.validator.Errors()[2], "access_control: rule #3 (domain 'two.example.com'): max_authentication_age: option 'first_factor' must be a positive duration but it's configured as '-1m0s'")
+}
+
+func (suite *AccessControl) TestShouldRaiseErrorInvalidIdentityHeaders() {
+	suite.config.AccessControl.Rules = []schema.AccessControlRule{
+		{
+			Domains: []string{"one.example.com"},
+			Policy:  "one_factor",
+			IdentityHeaders: []schema.AuthzIdentityHeader{
+				{Name: "X-Forwarded-User", Value: "{{ .Username }}"},
+			},
+		},
+		{
+			Domains: []string{"two.example.com"},
+			Policy:  "two_factor",
+			IdentityHeaders: []schema.AuthzIdentityHeader{
+				{Name: "", Value: "{{ .Username }}"},
+				{Name: "Content-Length", Value: "{{ .Username }}"},
+				{Name: "X-Groups", Value: "{{ .Groups"},
+			},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 3)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: rule #2 (domain 'two.example.com'): identity_headers: header #1: option 'name' is required")
+	suite.Assert().EqualError(suite.validator.Errors()[1], "access_control: rule #2 (domain 'two.example.com'): identity_headers: header #2: option 'name' with value 'Content-Length' is invalid: the header is reserved")
+	suite.Assert().EqualError(suite.validator.Errors()[2], "access_control: rule #2 (domain 'two.example.com'): identity_headers: header #3 (X-Groups): option 'value' is invalid: failed to parse the value of the identity header 'X-Groups': template: X-Groups:1: unclosed action")
+}
+
+func (suite *AccessControl) TestShouldRaiseErrorInvalidIdentityAssertion() {
+	suite.config.AccessControl.Rules = []schema.AccessControlRule{
+		{
+			Domains:           []string{"one.example.com"},
+			Policy:            "one_factor",
+			IdentityAssertion: schema.AccessControlRuleIdentityAssertion{Audience: []string{"app"}, Lifespan: time.Minute},
+		},
+		{
+			Domains:           []string{"two.example.com"},
+			Policy:            "two_factor",
+			IdentityAssertion: schema.AccessControlRuleIdentityAssertion{Lifespan: -time.Minute},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 1)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: rule #2 (domain 'two.example.com'): identity_assertion: option 'lifespan' must be a positive duration but it's configured as '-1m0s'")
+}
+
+func (suite *AccessControl) TestShouldRaiseErrorInvalidResponses() {
+	dir := suite.T().TempDir()
+
+	template := filepath.Join(dir, "forbidden.html")
+
+	suite.Require().NoError(os.WriteFile(template, []byte("<p>{{ .RequestID }}</p>"), 0600))
+
+	suite.config.AccessControl.Rules = []schema.AccessControlRule{
+		{
+			Domains: []string{"one.example.com"},
+			Policy:  "deny",
+			Responses: schema.AuthzResponses{
+				Forbidden: schema.AuthzResponse{StatusCode: 303, Template: template, RedirectURL: &url.URL{Scheme: "https", Host: "example.com", Path: "/denied"}},
+			},
+		},
+		{
+			Domains: []string{"two.example.com"},
+			Policy:  "two_factor",
+			Responses: schema.AuthzResponses{
+				Forbidden:    schema.AuthzResponse{StatusCode: 200, Template: dir},
+				Unauthorized: schema.AuthzResponse{Template: filepath.Join(dir, "missing.html"), RedirectURL: &url.URL{Path: "/login"}},
+			},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 4)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: rule #2 (domain 'two.example.com'): responses: forbidden: option 'status_code' must be between 300 and 599 but it's configured as '200'")
+	suite.Assert().EqualError(suite.validator.Errors()[1], fmt.Sprintf("access_control: rule #2 (domain 'two.example.com'): responses: forbidden: option 'template' refers to location '%s' which is a directory but it must be a file", dir))
+	suite.Assert().EqualError(suite.validator.Errors()[2], fmt.Sprintf("access_control: rule #2 (domain 'two.example.com'): responses: unauthorized: option 'template' refers to location '%s' which does not exist", filepath.Join(dir, "missing.html")))
+	suite.Assert().EqualError(suite.validator.Errors()[3], "access_control: rule #2 (domain 'two.example.com'): responses: unauthorized: option 'redirect_url' must be an absolute URL with the 'http' or 'https' scheme but it's configured as '/login'")
+}
+
+func (suite *AccessControl) TestShouldRaiseErrorRedirectURLWithNonRedirectStatusCode() {
+	suite.config.Server.Endpoints.Authz = map[string]schema.ServerEndpointsAuthz{
+		"forward-auth": {
+			Responses: schema.AuthzResponses{
+				Forbidden: schema.AuthzResponse{StatusCode: 404},
+			},
+		},
+		"legacy": {
+			Responses: schema.AuthzResponses{
+				Forbidden: schema.AuthzResponse{RedirectURL: &url.URL{Scheme: "https", Host: "example.com", Path: "/denied"}},
+			},
+		},
+	}
+
+	suite.config.AccessControl.Rules = []schema.AccessControlRule{
+		{
+			Domains: []string{"one.example.com"},
+			Policy:  "deny",
+			Responses: schema.AuthzResponses{
+				Forbidden:    schema.AuthzResponse{StatusCode: 404, RedirectURL: &url.URL{Scheme: "https", Host: "example.com", Path: "/denied"}},
+				Unauthorized: schema.AuthzResponse{StatusCode: 401, RedirectURL: &url.URL{Scheme: "https", Host: "example.com", Path: "/login"}},
+			},
+		},
+		{
+			Domains: []string{"two.example.com"},
+			Policy:  "deny",
+			Responses: schema.AuthzResponses{
+				Forbidden: schema.AuthzResponse{RedirectURL: &url.URL{Scheme: "https", Host: "example.com", Path: "/help"}},
+			},
+		},
+		{
+			Domains: []string{"three.example.com"},
+			Policy:  "deny",
+			Responses: schema.AuthzResponses{
+				Forbidden: schema.AuthzResponse{StatusCode: 451},
+			},
+		},
+		{
+			Domains: []string{"four.example.com"},
+			Policy:  "deny",
+			Responses: schema.AuthzResponses{
+				Forbidden: schema.AuthzResponse{StatusCode: 307},
+			},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 3)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: rule #1 (domain 'one.example.com'): responses: forbidden: option 'status_code' must be between 300 and 399 when the option 'redirect_url' is configured but it's configured as '404'")
+	suite.Assert().EqualError(suite.validator.Errors()[1], "access_control: rule #2 (domain 'two.example.com'): responses: forbidden: option 'redirect_url' can't be combined with the option 'status_code' of the authz endpoint 'forward-auth' as the 'status_code' must be between 300 and 399 when the 'redirect_url' is configured but it's '404'")
+	suite.Assert().EqualError(suite.validator.Errors()[2], "access_control: rule #3 (domain 'three.example.com'): responses: forbidden: option 'status_code' can't be combined with the option 'redirect_url' of the authz endpoint 'legacy' as the 'status_code' must be between 300 and 399 when the 'redirect_url' is configured but it's '451'")
+}
+
+func (suite *AccessControl) TestShouldRaiseErrorInvalidGeoIPCriteria() {
+	suite.config.AccessControl.Rules = []schema.AccessControlRule{
+		{
+			Domains:   []string{"public.example.com"},
+			Policy:    "bypass",
+			Countries: []string{"au", "AUS", "1A"},
+			ASNs:      []int{64512, 0},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 5)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: rule #1 (domain 'public.example.com'): option 'countries' must only have ISO 3166-1 alpha-2 country codes but the value 'AUS' is present")
+	suite.Assert().EqualError(suite.validator.Errors()[1], "access_control: rule #1 (domain 'public.example.com'): option 'countries' must only have ISO 3166-1 alpha-2 country codes but the value '1A' is present")
+	suite.Assert().EqualError(suite.validator.Errors()[2], "access_control: rule #1 (domain 'public.example.com'): option 'asns' must only have positive integers but the value '0' is present")
+	suite.Assert().EqualError(suite.validator.Errors()[3], "access_control: rule #1 (domain 'public.example.com'): option 'countries' requires the 'geoip' option 'country_database' to be configured")
+	suite.Assert().EqualError(suite.validator.Errors()[4], "access_control: rule #1 (domain 'public.example.com'): option 'asns' requires the 'geoip' option 'asn_database' to be configured")
+
+	suite.Assert().Equal(schema.AccessControlRuleCountries{"AU", "AUS", "1A"}, suite.config.AccessControl.Rules[0].Countries)
+}
+
+func (suite *AccessControl) TestShouldValidateGeoIPCriteria() {
+	suite.config.GeoIP = schema.GeoIP{
+		CountryDatabase: "/var/lib/geoip/country.mmdb",
+		ASNDatabase:     "/var/lib/geoip/asn.mmdb",
+	}
+
+	suite.config.AccessControl.Rules = []schema.AccessControlRule{
+		{
+			Domains:   []string{"public.example.com"},
+			Policy:    "bypass",
+			Countries: []string{"au", "NZ"},
+			ASNs:      []int{64512},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Assert().Len(suite.validator.Errors(), 0)
+
+	suite.Assert().Equal(schema.AccessControlRuleCountries{"AU", "NZ"}, suite.config.AccessControl.Rules[0].Countries)
+}
+
 func (suite *AccessControl) TestShouldRaiseErrorInvalidSubject() {
 	domains := []string{"public.example.com"}
 	subjects := [][]string{{testInvalid}}
@@ -294,6 +576,68 @@ func (suite *AccessControl) TestShouldSetQueryDefaults() {
 	suite.Assert().IsType(t, suite.config.AccessControl.Rules[1].Query[0][0].Value)
 }
 
+func (suite *AccessControl) TestShouldSetHeadersDefaults() {
+	domains := []string{"public.example.com"}
+	suite.config.AccessControl.Rules = []schema.AccessControlRule{
+		{
+			Domains: domains,
+			Policy:  "bypass",
+			Headers: [][]schema.AccessControlRuleHeader{
+				{
+					{Operator: "", Key: "X-Tenant"},
+				},
+				{
+					{Operator: "", Key: "X-Tenant", Value: "abc"},
+				},
+				{
+					{Operator: "pattern", Key: "User-Agent", Value: "^curl/"},
+				},
+			},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Assert().Len(suite.validator.Errors(), 0)
+
+	suite.Assert().Equal("present", suite.config.AccessControl.Rules[0].Headers[0][0].Operator)
+	suite.Assert().Equal("equal", suite.config.AccessControl.Rules[0].Headers[1][0].Operator)
+	suite.Assert().IsType(&regexp.Regexp{}, suite.config.AccessControl.Rules[0].Headers[2][0].Value)
+}
+
+func (suite *AccessControl) TestShouldErrorOnInvalidRulesHeaders() {
+	domains := []string{"public.example.com"}
+	suite.config.AccessControl.Rules = []schema.AccessControlRule{
+		{
+			Domains: domains,
+			Policy:  "bypass",
+			Headers: [][]schema.AccessControlRuleHeader{
+				{
+					{Operator: "equal", Key: "X-Tenant"},
+					{Operator: "present"},
+					{Operator: "absent", Key: "X-Tenant", Value: "abc"},
+					{Operator: "not", Key: "X-Tenant", Value: "abc"},
+					{Operator: "pattern", Key: "X-Tenant", Value: "(bad"},
+					{Operator: "equal", Key: "X-Tenant", Value: 1},
+				},
+			},
+		},
+	}
+
+	ValidateRules(suite.config, suite.validator)
+
+	suite.Assert().Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 6)
+
+	suite.Assert().EqualError(suite.validator.Errors()[0], "access_control: rule #1 (domain 'public.example.com'): headers: option 'value' must be present when the option 'operator' is 'equal' but it's absent")
+	suite.Assert().EqualError(suite.validator.Errors()[1], "access_control: rule #1 (domain 'public.example.com'): headers: option 'key' is required but it's absent")
+	suite.Assert().EqualError(suite.validator.Errors()[2], "access_control: rule #1 (domain 'public.example.com'): headers: option 'value' must not be present when the option 'operator' is 'absent' but it's present")
+	suite.Assert().EqualError(suite.validator.Errors()[3], "access_control: rule #1 (domain 'public.example.com'): headers: option 'operator' must be one of 'present', 'absent', 'equal', 'not equal', 'pattern', or 'not pattern' but it's configured as 'not'")
+	suite.Assert().EqualError(suite.validator.Errors()[4], "access_control: rule #1 (domain 'public.example.com'): headers: option 'value' is invalid: error parsing regexp: missing closing ): `(bad`")
+	suite.Assert().EqualError(suite.validator.Errors()[5], "access_control: rule #1 (domain 'public.example.com'): headers: option 'value' is invalid: expected type was string but got int")
+}
+
 func (suite *AccessControl) TestShouldErrorOnInvalidRulesQuery() {
 	domains := []string{"public.example.com"}
 	suite.config.AccessControl.Rules = []schema.AccessControlRule{
@@ -2,6 +2,7 @@ package validator
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/go-crypt/crypt/algorithm/argon2"
@@ -344,6 +345,24 @@ func validateLDAPAuthenticationBackend(config *schema.AuthenticationBackend, val
 	}
 
 	validateLDAPRequiredParameters(config, validator)
+
+	validateLDAPExtraAttributes(config, validator)
+}
+
+func validateLDAPExtraAttributes(config *schema.AuthenticationBackend, validator *schema.StructValidator) {
+	names := make([]string, 0, len(config.LDAP.Attributes.Extra))
+
+	for name := range config.LDAP.Attributes.Extra {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		if config.LDAP.Attributes.Extra[name].Name == "" {
+			validator.Push(fmt.Errorf(errFmtLDAPAuthBackendExtraAttributeMissingName, name))
+		}
+	}
 }
 
 func validateLDAPAuthenticationBackendImplementation(config *schema.AuthenticationBackend, validator *schema.StructValidator) *schema.TLS {
@@ -755,6 +755,22 @@ func (suite *LDAPAuthenticationBackendSuite) TestShouldRaiseOnEmptyUsersFilter()
 	suite.EqualError(suite.validator.Errors()[0], "authentication_backend: ldap: option 'users_filter' is required")
 }
 
+func (suite *LDAPAuthenticationBackendSuite) TestShouldRaiseOnExtraAttributeWithoutName() {
+	suite.config.LDAP.Attributes.Extra = map[string]schema.AuthenticationBackendLDAPAttributesAttribute{
+		"employee_id": {Name: "employeeNumber"},
+		"department":  {},
+		"cost_centre": {},
+	}
+
+	ValidateAuthenticationBackend(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 2)
+
+	suite.EqualError(suite.validator.Errors()[0], "authentication_backend: ldap: attributes: extra: cost_centre: option 'name' is required")
+	suite.EqualError(suite.validator.Errors()[1], "authentication_backend: ldap: attributes: extra: department: option 'name' is required")
+}
+
 func (suite *LDAPAuthenticationBackendSuite) TestShouldNotRaiseOnEmptyUsernameAttribute() {
 	suite.config.LDAP.Attributes.Username = ""
 
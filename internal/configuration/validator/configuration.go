@@ -58,6 +58,10 @@ func ValidateConfiguration(config *schema.Configuration, validator *schema.Struc
 
 	ValidateIdentityProviders(&config.IdentityProviders, validator)
 
+	validateServerEndpointsAuthzIdentityAssertionKeys(config, validator)
+
+	ValidateGeoIP(config, validator)
+
 	ValidateNTP(config, validator)
 
 	ValidatePasswordPolicy(&config.PasswordPolicy, validator)
@@ -63,7 +63,7 @@ func TestShouldEnsureNotifierConfigIsProvided(t *testing.T) {
 
 	ValidateConfiguration(&config, validator)
 	require.Len(t, validator.Errors(), 1)
-	assert.EqualError(t, validator.Errors()[0], "notifier: you must ensure either the 'smtp' or 'filesystem' notifier is configured")
+	assert.EqualError(t, validator.Errors()[0], "notifier: you must ensure either the 'smtp', 'filesystem', 'webhook', or 'sendmail' notifier is configured")
 }
 
 func TestShouldAddDefaultAccessControl(t *testing.T) {
@@ -55,23 +55,44 @@ const (
 	schemeHTTPS = "https"
 )
 
+// Authz response constants.
+const (
+	authzResponseForbidden    = "forbidden"
+	authzResponseUnauthorized = "unauthorized"
+)
+
 // Notifier Error constants.
 const (
-	errFmtNotifierMultipleConfigured = "notifier: please ensure only one of the 'smtp' or 'filesystem' notifier is configured"
-	errFmtNotifierNotConfigured      = "notifier: you must ensure either the 'smtp' or 'filesystem' notifier " +
-		"is configured"
-	errFmtNotifierTemplatePathNotExist            = "notifier: option 'template_path' refers to location '%s' which does not exist"
-	errFmtNotifierTemplatePathUnknownError        = "notifier: option 'template_path' refers to location '%s' which couldn't be opened: %w"
-	errFmtNotifierFileSystemFileNameNotConfigured = "notifier: filesystem: option 'filename' is required"
-	errFmtNotifierSMTPNotConfigured               = "notifier: smtp: option '%s' is required"
-	errFmtNotifierSMTPTLSConfigInvalid            = "notifier: smtp: tls: %w"
-	errFmtNotifierSMTPAddress                     = "notifier: smtp: option 'address' with value '%s' is invalid: %w"
-	errFmtNotifierSMTPAddressLegacyAndModern      = "notifier: smtp: option 'host' and 'port' can't be configured at the same time as 'address'"
+	errFmtNotifierMultipleConfigured = "notifier: please ensure only one of the 'smtp', 'filesystem', 'webhook', or 'sendmail' " +
+		"notifier is configured"
+	errFmtNotifierNotConfigured = "notifier: you must ensure either the 'smtp', 'filesystem', 'webhook', or 'sendmail' " +
+		"notifier is configured"
+	errFmtNotifierTemplatePathNotExist                 = "notifier: option 'template_path' refers to location '%s' which does not exist"
+	errFmtNotifierTemplatePathUnknownError             = "notifier: option 'template_path' refers to location '%s' which couldn't be opened: %w"
+	errFmtNotifierFileSystemFileNameNotConfigured      = "notifier: filesystem: option 'filename' is required"
+	errFmtNotifierSMTPNotConfigured                    = "notifier: smtp: option '%s' is required"
+	errFmtNotifierSMTPTLSConfigInvalid                 = "notifier: smtp: tls: %w"
+	errFmtNotifierSMTPAddress                          = "notifier: smtp: option 'address' with value '%s' is invalid: %w"
+	errFmtNotifierSMTPAddressLegacyAndModern           = "notifier: smtp: option 'host' and 'port' can't be configured at the same time as 'address'"
+	errFmtNotifierDKIMNotConfigured                    = "notifier: %s: dkim: option '%s' is required"
+	errFmtNotifierDKIMPrivateKeyRSAKeyLessThan1024Bits = "notifier: %s: dkim: option 'private_key' is an RSA %d bit private key but it must at minimum be a RSA 1024 bit private key"
+	errFmtNotifierDKIMPrivateKeyNotRSAOrEd25519        = "notifier: %s: dkim: option 'private_key' must be a RSA private key or Ed25519 private key but it's type is %T"
+	errFmtNotifierDKIMHeadersFrom                      = "notifier: %s: dkim: option 'headers' must include the 'From' header"
+	errFmtNotifierSendmailNotConfigured                = "notifier: sendmail: option '%s' is required"
+	errFmtNotifierWebhookNotConfigured                 = "notifier: webhook: option '%s' is required"
+	errFmtNotifierWebhookURLScheme                     = "notifier: webhook: option '%s' must have the 'http' or 'https' scheme but it's configured as '%s'"
+	errFmtNotifierWebhookHeaderName                    = "notifier: webhook: headers: header #%d: option 'name' is required"
+	errFmtNotifierWebhookTLSConfigInvalid              = "notifier: webhook: tls: %w"
+	errFmtNotifierQueueMaxAttempts                     = "notifier: queue: option 'max_attempts' must be more than 0 but it's configured as '%d'"
+	errFmtNotifierQueueBackoffGreaterThanMaxBackoff    = "notifier: queue: option 'backoff' must be less than or equal to option 'max_backoff'"
 
 	errFmtNotifierStartTlsDisabled = "notifier: smtp: option 'disable_starttls' is enabled: " +
 		"opportunistic STARTTLS is explicitly disabled which means all emails will be sent insecurely over plaintext " +
 		"and this setting is only necessary for non-compliant SMTP servers which advertise they support STARTTLS " +
 		"when they actually don't support STARTTLS"
+
+	errFmtNotifierWebhookInsecure = "notifier: webhook: option 'url' has the 'http' scheme: " +
+		"the notifications which include the identity verification links will be sent insecurely over plaintext"
 )
 
 const (
@@ -121,6 +142,7 @@ const (
 		"must contain one of the %s placeholders when using a group_search_mode of '%s' but they're absent"
 	errFmtLDAPAuthBackendFilterMissingAttribute = "authentication_backend: ldap: attributes: option '%s' " +
 		"must be provided when using the %s placeholder but it's absent"
+	errFmtLDAPAuthBackendExtraAttributeMissingName = "authentication_backend: ldap: attributes: extra: %s: option 'name' is required"
 )
 
 // TOTP Error constants.
@@ -292,16 +314,27 @@ const (
 		"valid Group Name, IP, or CIDR notation"
 	errFmtAccessControlRuleSubjectInvalid = "access_control: rule %s: 'subject' option '%s' is " +
 		"invalid: must start with 'user:' or 'group:'"
-	errFmtAccessControlRuleInvalidEntries              = "access_control: rule %s: option '%s' must only have the values %s but the values %s are present"
-	errFmtAccessControlRuleInvalidDuplicates           = "access_control: rule %s: option '%s' must have unique values but the values %s are duplicated"
-	errFmtAccessControlRuleQueryInvalid                = "access_control: rule %s: query: option 'operator' must be one of %s but it's configured as '%s'"
-	errFmtAccessControlRuleQueryInvalidNoValue         = "access_control: rule %s: query: option '%s' is required but it's absent"
-	errFmtAccessControlRuleQueryInvalidNoValueOperator = "access_control: rule %s: query: option '%s' must be present when the option 'operator' is '%s' but it's absent"
-	errFmtAccessControlRuleQueryInvalidValue           = "access_control: rule %s: query: option '%s' must not be present when the option 'operator' is '%s' but it's present"
-	errFmtAccessControlRuleQueryInvalidValueParse      = "access_control: rule %s: query: option '%s' is " +
+	errFmtAccessControlRuleInvalidEntries                = "access_control: rule %s: option '%s' must only have the values %s but the values %s are present"
+	errFmtAccessControlRuleInvalidDuplicates             = "access_control: rule %s: option '%s' must have unique values but the values %s are duplicated"
+	errFmtAccessControlRuleMatcherInvalid                = "access_control: rule %s: %s: option 'operator' must be one of %s but it's configured as '%s'"
+	errFmtAccessControlRuleMatcherInvalidNoValue         = "access_control: rule %s: %s: option '%s' is required but it's absent"
+	errFmtAccessControlRuleMatcherInvalidNoValueOperator = "access_control: rule %s: %s: option '%s' must be present when the option 'operator' is '%s' but it's absent"
+	errFmtAccessControlRuleMatcherInvalidValue           = "access_control: rule %s: %s: option '%s' must not be present when the option 'operator' is '%s' but it's present"
+	errFmtAccessControlRuleMatcherInvalidValueParse      = "access_control: rule %s: %s: option '%s' is " +
 		"invalid: %w"
-	errFmtAccessControlRuleQueryInvalidValueType = "access_control: rule %s: query: option 'value' is " +
+	errFmtAccessControlRuleMatcherInvalidValueType = "access_control: rule %s: %s: option 'value' is " +
 		"invalid: expected type was string but got %T"
+	errFmtAccessControlRuleCountryInvalid               = "access_control: rule %s: option 'countries' must only have ISO 3166-1 alpha-2 country codes but the value '%s' is present"
+	errFmtAccessControlRuleASNInvalid                   = "access_control: rule %s: option 'asns' must only have positive integers but the value '%d' is present"
+	errFmtAccessControlRuleGeoIPDatabase                = "access_control: rule %s: option '%s' requires the 'geoip' option '%s' to be configured"
+	errFmtAccessControlRuleExpressionInvalid            = "access_control: rule %s: option 'expression' is invalid: %w"
+	errFmtAccessControlRuleScheduleInvalid              = "access_control: rule %s: schedule: %w"
+	errFmtAccessControlRuleMaxAuthenticationAgeNegative = "access_control: rule %s: max_authentication_age: option '%s' " +
+		"must be a positive duration but it's configured as '%s'"
+	errFmtAccessControlRuleMaxAuthenticationAgePolicy = "access_control: rule %s: max_authentication_age: option '%s' " +
+		"is only valid when the 'policy' option is %s but it's configured as '%s'"
+	errFmtAccessControlRuleIdentityAssertionLifespanNegative = "access_control: rule %s: identity_assertion: option 'lifespan' " +
+		"must be a positive duration but it's configured as '%s'"
 )
 
 // Theme Error constants.
@@ -309,6 +342,13 @@ const (
 	errFmtThemeName = "option 'theme' must be one of %s but it's configured as '%s'"
 )
 
+// GeoIP Error constants.
+const (
+	errFmtGeoIPDatabaseNotExist     = "geoip: option '%s' refers to location '%s' which does not exist"
+	errFmtGeoIPDatabaseUnknownError = "geoip: option '%s' refers to location '%s' which couldn't be opened: %w"
+	errFmtGeoIPDatabaseDirectory    = "geoip: option '%s' refers to location '%s' which is a directory but it must be a file"
+)
+
 // NTP Error constants.
 const (
 	errFmtNTPVersion       = "ntp: option 'version' must be either 3 or 4 but it's configured as '%d'"
@@ -323,6 +363,8 @@ const (
 	errFmtSessionLegacyAndWarning         = "session: option 'domain' and option 'cookies' can't be specified at the same time"
 	errFmtSessionSameSite                 = "session: option 'same_site' must be one of %s but it's configured as '%s'"
 	errFmtSessionSecretRequired           = "session: option 'secret' is required when using the '%s' provider"
+	errFmtSessionSecretsEmpty             = "session: option 'secrets' must not contain empty values but secret #%d is empty"
+	errSessionRedisAndSQL                 = "session: option 'redis' and option 'sql' can't be configured at the same time"
 	errFmtSessionRedisPortRange           = "session: redis: option 'port' must be between 1 and 65535 but it's configured as '%d'"
 	errFmtSessionRedisHostRequired        = "session: redis: option 'host' is required"
 	errFmtSessionRedisHostOrNodesRequired = "session: redis: option 'host' or the 'high_availability' option 'nodes' is required"
@@ -331,6 +373,20 @@ const (
 	errFmtSessionRedisSentinelMissingName     = "session: redis: high_availability: option 'sentinel_name' is required"
 	errFmtSessionRedisSentinelNodeHostMissing = "session: redis: high_availability: option 'nodes': option 'host' is required for each node but one or more nodes are missing this"
 
+	errSessionRedisSentinelAndCluster            = "session: redis: option 'high_availability' and option 'cluster' can't be configured at the same time"
+	errFmtSessionRedisHostOrClusterNodesRequired = "session: redis: option 'host' or the 'cluster' option 'nodes' is required"
+	errFmtSessionRedisClusterDatabaseIndex       = "session: redis: option 'database_index' must be 0 when the 'cluster' option is configured but it's configured as '%d'"
+	errFmtSessionRedisClusterNodeHostMissing     = "session: redis: cluster: option 'nodes': option 'host' is required for each node but one or more nodes are missing this"
+	errFmtSessionRedisClusterNodePortRange       = "session: redis: cluster: option 'nodes': option 'port' must be between 1 and 65535 but it's configured as '%d'"
+
+	errFmtSessionBindingIPv4Mask   = "session: binding: option 'ipv4_mask' must be between 1 and 32 but it's configured as '%d'"
+	errFmtSessionBindingIPv6Mask   = "session: binding: option 'ipv6_mask' must be between 1 and 128 but it's configured as '%d'"
+	errFmtSessionBindingStrictness = "session: binding: option 'strictness' must be one of %s but it's configured as '%s'"
+	errFmtSessionBindingAction     = "session: binding: option 'action' must be one of %s but it's configured as '%s'"
+
+	errFmtSessionLogoutRedirectionURLNotAbsolute = "session: logout: option 'redirection_url' is not absolute with a value of '%s'"
+	errFmtSessionLogoutRedirectionURLInsecure    = "session: logout: option 'redirection_url' does not have a secure scheme with a value of '%s'"
+
 	errFmtSessionDomainMustBeRoot                        = "session: domain config %s: option 'domain' must be the domain you wish to protect not a wildcard domain but it's configured as '%s'"
 	errFmtSessionDomainSameSite                          = "session: domain config %s: option 'same_site' must be one of %s but it's configured as '%s'"
 	errFmtSessionDomainOptionRequired                    = "session: domain config %s: option '%s' is required"
@@ -348,7 +404,18 @@ const (
 
 // Regulation Error Consts.
 const (
-	errFmtRegulationFindTimeGreaterThanBanTime = "regulation: option 'find_time' must be less than or equal to option 'ban_time'"
+	errFmtRegulationFindTimeGreaterThanBanTime   = "regulation: option 'find_time' must be less than or equal to option 'ban_time'"
+	errFmtRegulationScope                        = "regulation: option 'scope' must be one of %s but it's configured as '%s'"
+	errFmtRegulationMode                         = "regulation: option 'mode' must be one of %s but it's configured as '%s'"
+	errFmtRegulationEscalationMultiplier         = "regulation: escalation: option 'multiplier' must be 2 or more but it's configured as '%d'"
+	errFmtRegulationEscalationMaxBanTime         = "regulation: escalation: option 'max_ban_time' must be greater than or equal to option 'ban_time'"
+	errFmtRegulationEscalationLookback           = "regulation: escalation: option 'lookback' must be greater than or equal to option 'ban_time'"
+	errFmtRegulationIPFindTimeGreaterThanBanTime = "regulation: ip: option 'find_time' must be less than or equal to option 'ban_time'"
+	errFmtRegulationIPIPv4Mask                   = "regulation: ip: option 'ipv4_mask' must be between 1 and 32 but it's configured as '%d'"
+	errFmtRegulationIPIPv6Mask                   = "regulation: ip: option 'ipv6_mask' must be between 1 and 128 but it's configured as '%d'"
+	errFmtRegulationIPTrustedNetwork             = "regulation: ip: option 'trusted_networks' must only contain IP's or network ranges in CIDR notation but it contains '%s'"
+
+	errFmtRegulationSecondFactorFindTimeGreaterThanBanTime = "regulation: second_factor: %s: option 'find_time' must be less than or equal to option 'ban_time'"
 )
 
 // Server Error constants.
@@ -364,11 +431,35 @@ const (
 	errFmtServerPathNotEndForwardSlash = "server: option 'address' must not and with a forward slash but it's configured as '%s'"
 	errFmtServerPathAlphaNum           = "server: option 'path' must only contain alpha numeric characters"
 
+	errFmtServerEndpointsAdminGroups = "server: endpoints: admin: option 'groups' must be configured when the option 'enabled' is true"
+
 	errFmtServerEndpointsAuthzImplementation    = "server: endpoints: authz: %s: option 'implementation' must be one of %s but it's configured as '%s'"
 	errFmtServerEndpointsAuthzStrategy          = "server: endpoints: authz: %s: authn_strategies: option 'name' must be one of %s but it's configured as '%s'"
 	errFmtServerEndpointsAuthzStrategyDuplicate = "server: endpoints: authz: %s: authn_strategies: duplicate strategy name detected with name '%s'"
 	errFmtServerEndpointsAuthzPrefixDuplicate   = "server: endpoints: authz: %s: endpoint starts with the same prefix as the '%s' endpoint with the '%s' implementation which accepts prefixes as part of its implementation"
 	errFmtServerEndpointsAuthzInvalidName       = "server: endpoints: authz: %s: contains invalid characters"
+	errFmtServerEndpointsAuthzClientHeaders     = "server: endpoints: authz: %s: identity_headers: option 'client_headers' must be one of %s but it's configured as '%s'"
+
+	errFmtServerEndpointsAuthzIdentityAssertionHeaderInvalid   = "server: endpoints: authz: %s: identity_assertion: option 'header' with value '%s' is invalid: must only contain valid header name characters"
+	errFmtServerEndpointsAuthzIdentityAssertionHeaderReserved  = "server: endpoints: authz: %s: identity_assertion: option 'header' with value '%s' is invalid: the header is reserved"
+	errFmtServerEndpointsAuthzIdentityAssertionHeaderDuplicate = "server: endpoints: authz: %s: identity_assertion: option 'header' with value '%s' is invalid: the header is also configured as an identity header"
+	errFmtServerEndpointsAuthzIdentityAssertionLifespan        = "server: endpoints: authz: %s: identity_assertion: option 'lifespan' must be a positive duration but it's configured as '%s'"
+	errFmtServerEndpointsAuthzIdentityAssertionOpenIDConnect   = "server: endpoints: authz: %s: identity_assertion: option 'enabled' requires the 'identity_providers' option 'oidc' to be configured as the assertion is signed with its issuer private keys"
+	errFmtServerEndpointsAuthzIdentityAssertionKeyID           = "server: endpoints: authz: %s: identity_assertion: option 'key_id' must be one of %s but it's configured as '%s'"
+
+	errFmtAuthzIdentityHeaderNameRequired  = "%s: header #%d: option 'name' is required"
+	errFmtAuthzIdentityHeaderNameInvalid   = "%s: header #%d: option 'name' with value '%s' is invalid: must only contain valid header name characters"
+	errFmtAuthzIdentityHeaderNameReserved  = "%s: header #%d: option 'name' with value '%s' is invalid: the header is reserved"
+	errFmtAuthzIdentityHeaderNameDuplicate = "%s: header #%d: option 'name' with value '%s' is invalid: the header is duplicated"
+	errFmtAuthzIdentityHeaderValue         = "%s: header #%d (%s): option 'value' is invalid: %w"
+
+	errFmtAuthzResponseStatusCode           = "%s: %s: option 'status_code' must be between 300 and 599 but it's configured as '%d'"
+	errFmtAuthzResponseTemplateNotExist     = "%s: %s: option 'template' refers to location '%s' which does not exist"
+	errFmtAuthzResponseTemplateDirectory    = "%s: %s: option 'template' refers to location '%s' which is a directory but it must be a file"
+	errFmtAuthzResponseTemplateUnknownError = "%s: %s: option 'template' refers to location '%s' which could not be accessed: %w"
+	errFmtAuthzResponseRedirectURL          = "%s: %s: option 'redirect_url' must be an absolute URL with the 'http' or 'https' scheme but it's configured as '%s'"
+	errFmtAuthzResponseRedirectStatusCode   = "%s: %s: option 'status_code' must be between 300 and 399 when the option 'redirect_url' is configured but it's configured as '%d'"
+	errFmtAuthzResponseRedirectEndpoint     = "%s: %s: option '%s' can't be combined with the option '%s' of the authz endpoint '%s' as the 'status_code' must be between 300 and 399 when the 'redirect_url' is configured but it's '%d'"
 
 	errFmtServerEndpointsAuthzLegacyInvalidImplementation = "server: endpoints: authz: %s: option 'implementation' is invalid: the endpoint with the name 'legacy' must use the 'Legacy' implementation"
 )
@@ -424,6 +515,13 @@ const (
 var (
 	validAuthzImplementations = []string{"AuthRequest", "ForwardAuth", authzImplementationExtAuthz, authzImplementationLegacy}
 	validAuthzAuthnStrategies = []string{"CookieSession", "HeaderAuthorization", "HeaderProxyAuthorization", "HeaderAuthRequestProxyAuthorization", "HeaderLegacy"}
+
+	validAuthzClientIdentityHeaders = []string{"allow", "strip", "forbid"}
+
+	reservedAuthzIdentityHeaders = []string{
+		fasthttp.HeaderConnection, fasthttp.HeaderContentLength, fasthttp.HeaderContentType, fasthttp.HeaderTransferEncoding,
+		fasthttp.HeaderLocation, fasthttp.HeaderSetCookie, fasthttp.HeaderWWWAuthenticate, fasthttp.HeaderProxyAuthenticate,
+	}
 )
 
 var (
@@ -454,6 +552,10 @@ var (
 	validStoragePostgreSQLSSLModes           = []string{"disable", "require", "verify-ca", "verify-full"}
 	validThemeNames                          = []string{"light", "dark", "grey", auto}
 	validSessionSameSiteValues               = []string{"none", "lax", "strict"}
+	validSessionBindingStrictnessValues      = []string{schema.SessionBindingStrictnessStrict, schema.SessionBindingStrictnessLax, schema.SessionBindingStrictnessIP, schema.SessionBindingStrictnessUserAgent}
+	validSessionBindingActionValues          = []string{schema.SessionBindingActionLog, schema.SessionBindingActionReauthenticate, schema.SessionBindingActionDestroy}
+	validRegulationScopeValues               = []string{schema.RegulationScopeUser, schema.RegulationScopeUserIP}
+	validRegulationModeValues                = []string{schema.RegulationModeFixed, schema.RegulationModeEscalating}
 	validLogLevels                           = []string{logging.LevelTrace, logging.LevelDebug, logging.LevelInfo, logging.LevelWarn, logging.LevelError}
 	validLogFormats                          = []string{logging.FormatText, logging.FormatJSON}
 	validWebAuthnConveyancePreferences       = []string{string(protocol.PreferNoAttestation), string(protocol.PreferIndirectAttestation), string(protocol.PreferDirectAttestation)}
@@ -520,6 +622,7 @@ var (
 	reKeyReplacer       = regexp.MustCompile(`\[\d+]`)
 	reDomainCharacters  = regexp.MustCompile(`^[a-z0-9-]+(\.[a-z0-9-]+)+[a-z0-9]$`)
 	reAuthzEndpointName = regexp.MustCompile(`^[a-zA-Z](([a-zA-Z0-9/._-]*)([a-zA-Z]))?$`)
+	reHeaderName        = regexp.MustCompile("^[a-zA-Z0-9!#$%&'*+.^_`|~-]+$")
 	reOpenIDConnectKID  = regexp.MustCompile(`^([a-zA-Z0-9](([a-zA-Z0-9._~-]*)([a-zA-Z0-9]))?)?$`)
 )
 
@@ -0,0 +1,29 @@
+package validator
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// ValidateGeoIP validates and updates the GeoIP configuration.
+func ValidateGeoIP(config *schema.Configuration, validator *schema.StructValidator) {
+	validateGeoIPDatabase("country_database", config.GeoIP.CountryDatabase, validator)
+	validateGeoIPDatabase("asn_database", config.GeoIP.ASNDatabase, validator)
+}
+
+func validateGeoIPDatabase(name, path string, validator *schema.StructValidator) {
+	if path == "" {
+		return
+	}
+
+	switch info, err := os.Stat(path); {
+	case os.IsNotExist(err):
+		validator.Push(fmt.Errorf(errFmtGeoIPDatabaseNotExist, name, path))
+	case err != nil:
+		validator.Push(fmt.Errorf(errFmtGeoIPDatabaseUnknownError, name, path, err))
+	case info.IsDir():
+		validator.Push(fmt.Errorf(errFmtGeoIPDatabaseDirectory, name, path))
+	}
+}
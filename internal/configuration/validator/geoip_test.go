@@ -0,0 +1,68 @@
+package validator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestValidateGeoIP(t *testing.T) {
+	dir := t.TempDir()
+
+	database := filepath.Join(dir, "GeoLite2-Country.mmdb")
+
+	require.NoError(t, os.WriteFile(database, []byte("example"), 0600))
+
+	testCases := []struct {
+		name     string
+		have     schema.GeoIP
+		expected []string
+	}{
+		{
+			"ShouldNotErrorOnDisabled",
+			schema.GeoIP{},
+			nil,
+		},
+		{
+			"ShouldNotErrorOnValidDatabases",
+			schema.GeoIP{CountryDatabase: database, ASNDatabase: database},
+			nil,
+		},
+		{
+			"ShouldErrorOnMissingDatabases",
+			schema.GeoIP{CountryDatabase: filepath.Join(dir, "country.mmdb"), ASNDatabase: filepath.Join(dir, "asn.mmdb")},
+			[]string{
+				"geoip: option 'country_database' refers to location '" + filepath.Join(dir, "country.mmdb") + "' which does not exist",
+				"geoip: option 'asn_database' refers to location '" + filepath.Join(dir, "asn.mmdb") + "' which does not exist",
+			},
+		},
+		{
+			"ShouldErrorOnDirectory",
+			schema.GeoIP{ASNDatabase: dir},
+			[]string{
+				"geoip: option 'asn_database' refers to location '" + dir + "' which is a directory but it must be a file",
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			validator := schema.NewStructValidator()
+			config := &schema.Configuration{GeoIP: tc.have}
+
+			ValidateGeoIP(config, validator)
+
+			assert.Len(t, validator.Warnings(), 0)
+			require.Len(t, validator.Errors(), len(tc.expected))
+
+			for i, err := range tc.expected {
+				assert.EqualError(t, validator.Errors()[i], err)
+			}
+		})
+	}
+}
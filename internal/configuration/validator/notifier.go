@@ -1,24 +1,32 @@
 package validator
 
 import (
+	"crypto/ed25519"
+	"crypto/rsa"
 	"fmt"
+	"net/mail"
 	"os"
+	"strings"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/utils"
 )
 
 // ValidateNotifier validates and update notifier configuration.
 func ValidateNotifier(config *schema.Notifier, validator *schema.StructValidator) {
-	if config.SMTP == nil && config.FileSystem == nil {
+	switch n := countNotifiers(config); {
+	case n == 0:
 		validator.Push(fmt.Errorf(errFmtNotifierNotConfigured))
 
 		return
-	} else if config.SMTP != nil && config.FileSystem != nil {
+	case n > 1:
 		validator.Push(fmt.Errorf(errFmtNotifierMultipleConfigured))
 
 		return
 	}
 
+	validateNotifierQueue(&config.Queue, validator)
+
 	if config.FileSystem != nil {
 		if config.FileSystem.Filename == "" {
 			validator.Push(fmt.Errorf(errFmtNotifierFileSystemFileNameNotConfigured))
@@ -27,11 +35,67 @@ func ValidateNotifier(config *schema.Notifier, validator *schema.StructValidator
 		return
 	}
 
-	validateSMTPNotifier(config.SMTP, validator)
+	switch {
+	case config.Webhook != nil:
+		validateWebhookNotifier(config.Webhook, validator)
+	case config.Sendmail != nil:
+		validateSendmailNotifier(config.Sendmail, validator)
+	default:
+		validateSMTPNotifier(config.SMTP, validator)
+	}
 
 	validateNotifierTemplates(config, validator)
 }
 
+func countNotifiers(config *schema.Notifier) (n int) {
+	if config.SMTP != nil {
+		n++
+	}
+
+	if config.FileSystem != nil {
+		n++
+	}
+
+	if config.Webhook != nil {
+		n++
+	}
+
+	if config.Sendmail != nil {
+		n++
+	}
+
+	return n
+}
+
+func validateNotifierQueue(config *schema.NotifierQueue, validator *schema.StructValidator) {
+	if !config.Enabled {
+		return
+	}
+
+	if config.Interval <= 0 {
+		config.Interval = schema.DefaultNotifierQueueConfiguration.Interval
+	}
+
+	switch {
+	case config.MaxAttempts == 0:
+		config.MaxAttempts = schema.DefaultNotifierQueueConfiguration.MaxAttempts
+	case config.MaxAttempts < 0:
+		validator.Push(fmt.Errorf(errFmtNotifierQueueMaxAttempts, config.MaxAttempts))
+	}
+
+	if config.Backoff <= 0 {
+		config.Backoff = schema.DefaultNotifierQueueConfiguration.Backoff
+	}
+
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = schema.DefaultNotifierQueueConfiguration.MaxBackoff
+	}
+
+	if config.Backoff > config.MaxBackoff {
+		validator.Push(fmt.Errorf(errFmtNotifierQueueBackoffGreaterThanMaxBackoff))
+	}
+}
+
 func validateNotifierTemplates(config *schema.Notifier, validator *schema.StructValidator) {
 	if config.TemplatePath == "" {
 		return
@@ -90,6 +154,69 @@ func validateSMTPNotifier(config *schema.NotifierSMTP, validator *schema.StructV
 	if config.DisableStartTLS {
 		validator.PushWarning(fmt.Errorf(errFmtNotifierStartTlsDisabled))
 	}
+
+	validateNotifierDKIM("smtp", config.DKIM, config.Sender, validator)
+}
+
+func validateNotifierDKIM(name string, config *schema.NotifierSMTPDKIM, sender mail.Address, validator *schema.StructValidator) {
+	if config == nil {
+		return
+	}
+
+	if config.Domain == "" {
+		if at := strings.LastIndex(sender.Address, "@"); at >= 0 {
+			config.Domain = sender.Address[at+1:]
+		} else {
+			validator.Push(fmt.Errorf(errFmtNotifierDKIMNotConfigured, name, "domain"))
+		}
+	}
+
+	if config.Selector == "" {
+		validator.Push(fmt.Errorf(errFmtNotifierDKIMNotConfigured, name, "selector"))
+	}
+
+	switch key := config.PrivateKey.(type) {
+	case nil:
+		validator.Push(fmt.Errorf(errFmtNotifierDKIMNotConfigured, name, "private_key"))
+	case *rsa.PrivateKey:
+		if key.Size() < 128 {
+			validator.Push(fmt.Errorf(errFmtNotifierDKIMPrivateKeyRSAKeyLessThan1024Bits, name, key.Size()*8))
+		}
+	case ed25519.PrivateKey:
+		break
+	default:
+		validator.Push(fmt.Errorf(errFmtNotifierDKIMPrivateKeyNotRSAOrEd25519, name, key))
+	}
+
+	if len(config.Headers) == 0 {
+		config.Headers = schema.DefaultSMTPNotifierDKIMConfiguration.Headers
+	} else if !utils.IsStringInSliceFold("From", config.Headers) {
+		validator.Push(fmt.Errorf(errFmtNotifierDKIMHeadersFrom, name))
+	}
+}
+
+func validateSendmailNotifier(config *schema.NotifierSendmail, validator *schema.StructValidator) {
+	if config.Path == "" {
+		config.Path = schema.DefaultSendmailNotifierConfiguration.Path
+	}
+
+	if len(config.Arguments) == 0 {
+		config.Arguments = schema.DefaultSendmailNotifierConfiguration.Arguments
+	}
+
+	if config.Timeout <= 0 {
+		config.Timeout = schema.DefaultSendmailNotifierConfiguration.Timeout
+	}
+
+	if config.Sender.Address == "" {
+		validator.Push(fmt.Errorf(errFmtNotifierSendmailNotConfigured, "sender"))
+	}
+
+	if config.Subject == "" {
+		config.Subject = schema.DefaultSendmailNotifierConfiguration.Subject
+	}
+
+	validateNotifierDKIM("sendmail", config.DKIM, config.Sender, validator)
 }
 
 func validateSMTPNotifierAddress(config *schema.NotifierSMTP, validator *schema.StructValidator) {
@@ -114,3 +241,45 @@ func validateSMTPNotifierAddress(config *schema.NotifierSMTP, validator *schema.
 		}
 	}
 }
+
+func validateWebhookNotifier(config *schema.NotifierWebhook, validator *schema.StructValidator) {
+	switch {
+	case config.URL == nil:
+		validator.Push(fmt.Errorf(errFmtNotifierWebhookNotConfigured, "url"))
+	case config.URL.Scheme != schemeHTTP && config.URL.Scheme != schemeHTTPS:
+		validator.Push(fmt.Errorf(errFmtNotifierWebhookURLScheme, "url", config.URL.Scheme))
+	case config.URL.Scheme == schemeHTTP:
+		validator.PushWarning(fmt.Errorf(errFmtNotifierWebhookInsecure))
+	}
+
+	if config.HealthCheckURL != nil && config.HealthCheckURL.Scheme != schemeHTTP && config.HealthCheckURL.Scheme != schemeHTTPS {
+		validator.Push(fmt.Errorf(errFmtNotifierWebhookURLScheme, "health_check_url", config.HealthCheckURL.Scheme))
+	}
+
+	if config.Timeout <= 0 {
+		config.Timeout = schema.DefaultWebhookNotifierConfiguration.Timeout
+	}
+
+	for i, header := range config.Headers {
+		if header.Name == "" {
+			validator.Push(fmt.Errorf(errFmtNotifierWebhookHeaderName, i+1))
+		}
+	}
+
+	if config.TLS == nil {
+		config.TLS = &schema.TLS{}
+	}
+
+	configDefaultTLS := &schema.TLS{
+		MinimumVersion: schema.DefaultWebhookNotifierConfiguration.TLS.MinimumVersion,
+		MaximumVersion: schema.DefaultWebhookNotifierConfiguration.TLS.MaximumVersion,
+	}
+
+	if config.URL != nil {
+		configDefaultTLS.ServerName = config.URL.Hostname()
+	}
+
+	if err := ValidateTLSConfig(config.TLS, configDefaultTLS); err != nil {
+		validator.Push(fmt.Errorf(errFmtNotifierWebhookTLSConfigInvalid, err))
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"crypto/tls"
 	"fmt"
 	"net/mail"
+	"net/url"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -29,6 +31,10 @@ func (suite *NotifierSuite) SetupTest() {
 		Sender:   mail.Address{Name: "Authelia", Address: "authelia@example.com"},
 	}
 	suite.config.FileSystem = nil
+	suite.config.Webhook = nil
+	suite.config.Sendmail = nil
+	suite.config.TemplatePath = ""
+	suite.config.Queue = schema.NotifierQueue{}
 }
 
 /*
@@ -231,6 +237,70 @@ func (suite *NotifierSuite) TestSMTPShouldEnsureSenderIsProvided() {
 	suite.EqualError(suite.validator.Errors()[0], fmt.Sprintf(errFmtNotifierSMTPNotConfigured, "sender"))
 }
 
+func (suite *NotifierSuite) TestSMTPShouldSetDKIMDefaults() {
+	suite.config.SMTP.DKIM = &schema.NotifierSMTPDKIM{
+		Selector:   "authelia",
+		PrivateKey: keyRSA2048,
+	}
+
+	ValidateNotifier(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Len(suite.validator.Errors(), 0)
+
+	suite.Equal("example.com", suite.config.SMTP.DKIM.Domain)
+	suite.Equal(schema.DefaultSMTPNotifierDKIMConfiguration.Headers, suite.config.SMTP.DKIM.Headers)
+}
+
+func (suite *NotifierSuite) TestSMTPShouldAllowDKIMEd25519() {
+	suite.config.SMTP.DKIM = &schema.NotifierSMTPDKIM{
+		Domain:     "mail.example.com",
+		Selector:   "authelia",
+		PrivateKey: keyEd2519,
+		Headers:    []string{"from", "subject"},
+	}
+
+	ValidateNotifier(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Len(suite.validator.Errors(), 0)
+
+	suite.Equal("mail.example.com", suite.config.SMTP.DKIM.Domain)
+	suite.Equal([]string{"from", "subject"}, suite.config.SMTP.DKIM.Headers)
+}
+
+func (suite *NotifierSuite) TestSMTPShouldErrorOnInvalidDKIM() {
+	suite.config.SMTP.Sender = mail.Address{Address: "authelia"}
+	suite.config.SMTP.DKIM = &schema.NotifierSMTPDKIM{
+		Headers: []string{"Subject"},
+	}
+
+	ValidateNotifier(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 4)
+
+	suite.EqualError(suite.validator.Errors()[0], "notifier: smtp: dkim: option 'domain' is required")
+	suite.EqualError(suite.validator.Errors()[1], "notifier: smtp: dkim: option 'selector' is required")
+	suite.EqualError(suite.validator.Errors()[2], "notifier: smtp: dkim: option 'private_key' is required")
+	suite.EqualError(suite.validator.Errors()[3], "notifier: smtp: dkim: option 'headers' must include the 'From' header")
+
+	suite.validator.Clear()
+
+	suite.config.SMTP.DKIM = &schema.NotifierSMTPDKIM{
+		Domain:     "example.com",
+		Selector:   "authelia",
+		PrivateKey: keyECDSAP256,
+	}
+
+	ValidateNotifier(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 1)
+
+	suite.EqualError(suite.validator.Errors()[0], "notifier: smtp: dkim: option 'private_key' must be a RSA private key or Ed25519 private key but it's type is *ecdsa.PrivateKey")
+}
+
 func (suite *NotifierSuite) TestTemplatesEmptyDir() {
 	dir := suite.T().TempDir()
 
@@ -282,6 +352,203 @@ func (suite *NotifierSuite) TestFileShouldEnsureFilenameIsProvided() {
 	suite.EqualError(suite.validator.Errors()[0], errFmtNotifierFileSystemFileNameNotConfigured)
 }
 
+/*
+Webhook Tests.
+*/
+func (suite *NotifierSuite) TestWebhookShouldSetDefaults() {
+	suite.config.SMTP = nil
+	suite.config.Webhook = &schema.NotifierWebhook{
+		URL: &url.URL{Scheme: schemeHTTPS, Host: "gateway.example.com", Path: "/notify"},
+	}
+
+	ValidateNotifier(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Len(suite.validator.Errors(), 0)
+
+	suite.Equal(schema.DefaultWebhookNotifierConfiguration.Timeout, suite.config.Webhook.Timeout)
+	suite.Equal("gateway.example.com", suite.config.Webhook.TLS.ServerName)
+	suite.Equal(uint16(tls.VersionTLS12), suite.config.Webhook.TLS.MinimumVersion.MinVersion())
+}
+
+func (suite *NotifierSuite) TestWebhookShouldEnsureEitherSMTPOrWebhookIsProvided() {
+	suite.config.Webhook = &schema.NotifierWebhook{
+		URL: &url.URL{Scheme: schemeHTTPS, Host: "gateway.example.com", Path: "/notify"},
+	}
+
+	ValidateNotifier(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 1)
+
+	suite.EqualError(suite.validator.Errors()[0], "notifier: please ensure only one of the 'smtp', 'filesystem', 'webhook', or 'sendmail' notifier is configured")
+}
+
+func (suite *NotifierSuite) TestWebhookShouldErrorOnInvalidOptions() {
+	suite.config.SMTP = nil
+	suite.config.Webhook = &schema.NotifierWebhook{
+		URL:            &url.URL{Scheme: "ftp", Host: "gateway.example.com"},
+		HealthCheckURL: &url.URL{Scheme: "tcp", Host: "gateway.example.com"},
+		Headers:        []schema.NotifierWebhookHeader{{Name: "Authorization", Value: "Bearer abc"}, {Value: "abc"}},
+	}
+
+	ValidateNotifier(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 3)
+
+	suite.EqualError(suite.validator.Errors()[0], "notifier: webhook: option 'url' must have the 'http' or 'https' scheme but it's configured as 'ftp'")
+	suite.EqualError(suite.validator.Errors()[1], "notifier: webhook: option 'health_check_url' must have the 'http' or 'https' scheme but it's configured as 'tcp'")
+	suite.EqualError(suite.validator.Errors()[2], "notifier: webhook: headers: header #2: option 'name' is required")
+
+	suite.validator.Clear()
+
+	suite.config.Webhook = &schema.NotifierWebhook{}
+
+	ValidateNotifier(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 1)
+
+	suite.EqualError(suite.validator.Errors()[0], "notifier: webhook: option 'url' is required")
+}
+
+func (suite *NotifierSuite) TestWebhookShouldWarnOnInsecureURL() {
+	suite.config.SMTP = nil
+	suite.config.Webhook = &schema.NotifierWebhook{
+		URL: &url.URL{Scheme: schemeHTTP, Host: "gateway.example.com", Path: "/notify"},
+	}
+
+	ValidateNotifier(&suite.config, suite.validator)
+
+	suite.Require().Len(suite.validator.Warnings(), 1)
+	suite.Len(suite.validator.Errors(), 0)
+
+	suite.EqualError(suite.validator.Warnings()[0], "notifier: webhook: option 'url' has the 'http' scheme: the notifications which include the identity verification links will be sent insecurely over plaintext")
+}
+
+/*
+Sendmail Tests.
+*/
+func (suite *NotifierSuite) TestSendmailShouldSetDefaults() {
+	suite.config.SMTP = nil
+	suite.config.Sendmail = &schema.NotifierSendmail{
+		Sender: mail.Address{Name: "Authelia", Address: "authelia@example.com"},
+		DKIM: &schema.NotifierSMTPDKIM{
+			Selector:   "authelia",
+			PrivateKey: keyRSA2048,
+		},
+	}
+
+	ValidateNotifier(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Len(suite.validator.Errors(), 0)
+
+	suite.Equal(schema.DefaultSendmailNotifierConfiguration.Path, suite.config.Sendmail.Path)
+	suite.Equal(schema.DefaultSendmailNotifierConfiguration.Arguments, suite.config.Sendmail.Arguments)
+	suite.Equal(schema.DefaultSendmailNotifierConfiguration.Timeout, suite.config.Sendmail.Timeout)
+	suite.Equal(schema.DefaultSendmailNotifierConfiguration.Subject, suite.config.Sendmail.Subject)
+	suite.Equal("example.com", suite.config.Sendmail.DKIM.Domain)
+	suite.Equal(schema.DefaultSMTPNotifierDKIMConfiguration.Headers, suite.config.Sendmail.DKIM.Headers)
+}
+
+func (suite *NotifierSuite) TestSendmailShouldNotOverrideOptions() {
+	suite.config.SMTP = nil
+	suite.config.Sendmail = &schema.NotifierSendmail{
+		Path:      "/usr/bin/msmtp",
+		Arguments: []string{"--read-envelope-from", "-t"},
+		Timeout:   time.Minute,
+		Sender:    mail.Address{Address: "authelia@example.com"},
+		Subject:   "{title}",
+	}
+
+	ValidateNotifier(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Len(suite.validator.Errors(), 0)
+
+	suite.Equal("/usr/bin/msmtp", suite.config.Sendmail.Path)
+	suite.Equal([]string{"--read-envelope-from", "-t"}, suite.config.Sendmail.Arguments)
+	suite.Equal(time.Minute, suite.config.Sendmail.Timeout)
+	suite.Equal("{title}", suite.config.Sendmail.Subject)
+}
+
+func (suite *NotifierSuite) TestSendmailShouldErrorOnInvalidOptions() {
+	suite.config.SMTP = nil
+	suite.config.Sendmail = &schema.NotifierSendmail{
+		DKIM: &schema.NotifierSMTPDKIM{
+			PrivateKey: keyECDSAP256,
+		},
+	}
+
+	ValidateNotifier(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 4)
+
+	suite.EqualError(suite.validator.Errors()[0], "notifier: sendmail: option 'sender' is required")
+	suite.EqualError(suite.validator.Errors()[1], "notifier: sendmail: dkim: option 'domain' is required")
+	suite.EqualError(suite.validator.Errors()[2], "notifier: sendmail: dkim: option 'selector' is required")
+	suite.EqualError(suite.validator.Errors()[3], "notifier: sendmail: dkim: option 'private_key' must be a RSA private key or Ed25519 private key but it's type is *ecdsa.PrivateKey")
+}
+
+func (suite *NotifierSuite) TestSendmailShouldEnsureEitherSMTPOrSendmailIsProvided() {
+	suite.config.Sendmail = &schema.NotifierSendmail{
+		Sender: mail.Address{Address: "authelia@example.com"},
+	}
+
+	ValidateNotifier(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 1)
+
+	suite.EqualError(suite.validator.Errors()[0], "notifier: please ensure only one of the 'smtp', 'filesystem', 'webhook', or 'sendmail' notifier is configured")
+}
+
+/*
+Queue Tests.
+*/
+func (suite *NotifierSuite) TestQueueShouldNotSetDefaultsWhenDisabled() {
+	ValidateNotifier(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Len(suite.validator.Errors(), 0)
+
+	suite.Equal(schema.NotifierQueue{}, suite.config.Queue)
+}
+
+func (suite *NotifierSuite) TestQueueShouldSetDefaults() {
+	suite.config.Queue = schema.NotifierQueue{Enabled: true}
+
+	ValidateNotifier(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Len(suite.validator.Errors(), 0)
+
+	suite.Equal(schema.DefaultNotifierQueueConfiguration.Interval, suite.config.Queue.Interval)
+	suite.Equal(schema.DefaultNotifierQueueConfiguration.MaxAttempts, suite.config.Queue.MaxAttempts)
+	suite.Equal(schema.DefaultNotifierQueueConfiguration.Backoff, suite.config.Queue.Backoff)
+	suite.Equal(schema.DefaultNotifierQueueConfiguration.MaxBackoff, suite.config.Queue.MaxBackoff)
+}
+
+func (suite *NotifierSuite) TestQueueShouldErrorOnInvalidOptions() {
+	suite.config.Queue = schema.NotifierQueue{
+		Enabled:     true,
+		MaxAttempts: -1,
+		Backoff:     time.Hour * 2,
+		MaxBackoff:  time.Hour,
+	}
+
+	ValidateNotifier(&suite.config, suite.validator)
+
+	suite.Len(suite.validator.Warnings(), 0)
+	suite.Require().Len(suite.validator.Errors(), 2)
+
+	suite.EqualError(suite.validator.Errors()[0], "notifier: queue: option 'max_attempts' must be more than 0 but it's configured as '-1'")
+	suite.EqualError(suite.validator.Errors()[1], "notifier: queue: option 'backoff' must be less than or equal to option 'max_backoff'")
+}
+
 func TestNotifierSuite(t *testing.T) {
 	suite.Run(t, new(NotifierSuite))
 }
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/utils"
 )
 
 // ValidateRegulation validates and update regulator configuration.
@@ -19,4 +20,111 @@ func ValidateRegulation(config *schema.Configuration, validator *schema.StructVa
 	if config.Regulation.FindTime > config.Regulation.BanTime {
 		validator.Push(fmt.Errorf(errFmtRegulationFindTimeGreaterThanBanTime))
 	}
+
+	if config.Regulation.Scope == "" {
+		config.Regulation.Scope = schema.DefaultRegulationConfiguration.Scope
+	} else if !utils.IsStringInSlice(config.Regulation.Scope, validRegulationScopeValues) {
+		validator.Push(fmt.Errorf(errFmtRegulationScope, strJoinOr(validRegulationScopeValues), config.Regulation.Scope))
+	}
+
+	validateRegulationIP(&config.Regulation.IP, validator)
+
+	validateRegulationSecondFactorMethod("totp", &config.Regulation.SecondFactor.TOTP, schema.DefaultRegulationConfiguration.SecondFactor.TOTP, validator)
+	validateRegulationSecondFactorMethod("webauthn", &config.Regulation.SecondFactor.WebAuthn, schema.DefaultRegulationConfiguration.SecondFactor.WebAuthn, validator)
+	validateRegulationSecondFactorMethod("duo", &config.Regulation.SecondFactor.Duo, schema.DefaultRegulationConfiguration.SecondFactor.Duo, validator)
+
+	if config.Regulation.Mode == "" {
+		config.Regulation.Mode = schema.DefaultRegulationConfiguration.Mode
+	} else if !utils.IsStringInSlice(config.Regulation.Mode, validRegulationModeValues) {
+		validator.Push(fmt.Errorf(errFmtRegulationMode, strJoinOr(validRegulationModeValues), config.Regulation.Mode))
+	}
+
+	if config.Regulation.Mode == schema.RegulationModeEscalating {
+		validateRegulationEscalation(&config.Regulation, validator)
+	}
+}
+
+func validateRegulationEscalation(config *schema.Regulation, validator *schema.StructValidator) {
+	switch {
+	case config.Escalation.Multiplier == 0:
+		config.Escalation.Multiplier = schema.DefaultRegulationConfiguration.Escalation.Multiplier
+	case config.Escalation.Multiplier < 2:
+		validator.Push(fmt.Errorf(errFmtRegulationEscalationMultiplier, config.Escalation.Multiplier))
+	}
+
+	if config.Escalation.MaxBanTime <= 0 {
+		config.Escalation.MaxBanTime = schema.DefaultRegulationConfiguration.Escalation.MaxBanTime
+	}
+
+	if config.Escalation.Lookback <= 0 {
+		config.Escalation.Lookback = schema.DefaultRegulationConfiguration.Escalation.Lookback
+	}
+
+	banTime := config.BanTime
+
+	if config.IP.MaxRetries > 0 && config.IP.BanTime > banTime {
+		banTime = config.IP.BanTime
+	}
+
+	for _, method := range []schema.RegulationSecondFactorMethod{config.SecondFactor.TOTP, config.SecondFactor.WebAuthn, config.SecondFactor.Duo} {
+		if method.MaxRetries > 0 && method.BanTime > banTime {
+			banTime = method.BanTime
+		}
+	}
+
+	if config.Escalation.MaxBanTime < banTime {
+		validator.Push(fmt.Errorf(errFmtRegulationEscalationMaxBanTime))
+	}
+
+	if config.Escalation.Lookback < banTime {
+		validator.Push(fmt.Errorf(errFmtRegulationEscalationLookback))
+	}
+}
+
+func validateRegulationSecondFactorMethod(name string, config *schema.RegulationSecondFactorMethod, defaults schema.RegulationSecondFactorMethod, validator *schema.StructValidator) {
+	if config.FindTime <= 0 {
+		config.FindTime = defaults.FindTime
+	}
+
+	if config.BanTime <= 0 {
+		config.BanTime = defaults.BanTime
+	}
+
+	if config.FindTime > config.BanTime {
+		validator.Push(fmt.Errorf(errFmtRegulationSecondFactorFindTimeGreaterThanBanTime, name))
+	}
+}
+
+func validateRegulationIP(config *schema.RegulationIP, validator *schema.StructValidator) {
+	if config.FindTime <= 0 {
+		config.FindTime = schema.DefaultRegulationConfiguration.IP.FindTime
+	}
+
+	if config.BanTime <= 0 {
+		config.BanTime = schema.DefaultRegulationConfiguration.IP.BanTime
+	}
+
+	if config.FindTime > config.BanTime {
+		validator.Push(fmt.Errorf(errFmtRegulationIPFindTimeGreaterThanBanTime))
+	}
+
+	switch {
+	case config.IPv4Mask == 0:
+		config.IPv4Mask = schema.DefaultRegulationConfiguration.IP.IPv4Mask
+	case config.IPv4Mask < 1 || config.IPv4Mask > 32:
+		validator.Push(fmt.Errorf(errFmtRegulationIPIPv4Mask, config.IPv4Mask))
+	}
+
+	switch {
+	case config.IPv6Mask == 0:
+		config.IPv6Mask = schema.DefaultRegulationConfiguration.IP.IPv6Mask
+	case config.IPv6Mask < 1 || config.IPv6Mask > 128:
+		validator.Push(fmt.Errorf(errFmtRegulationIPIPv6Mask, config.IPv6Mask))
+	}
+
+	for _, network := range config.TrustedNetworks {
+		if !IsNetworkValid(network) {
+			validator.Push(fmt.Errorf(errFmtRegulationIPTrustedNetwork, network))
+		}
+	}
 }
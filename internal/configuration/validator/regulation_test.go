@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 )
@@ -52,3 +53,121 @@ func TestShouldRaiseErrorWhenFindTimeLessThanBanTime(t *testing.T) {
 	assert.Len(t, validator.Errors(), 1)
 	assert.EqualError(t, validator.Errors()[0], "regulation: option 'find_time' must be less than or equal to option 'ban_time'")
 }
+
+func TestShouldSetDefaultRegulationScopeAndIPWhenUnset(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := newDefaultRegulationConfig()
+
+	ValidateRegulation(&config, validator)
+
+	assert.Len(t, validator.Errors(), 0)
+	assert.Equal(t, schema.RegulationScopeUser, config.Regulation.Scope)
+	assert.Equal(t, 0, config.Regulation.IP.MaxRetries)
+	assert.Equal(t, schema.DefaultRegulationConfiguration.IP.FindTime, config.Regulation.IP.FindTime)
+	assert.Equal(t, schema.DefaultRegulationConfiguration.IP.BanTime, config.Regulation.IP.BanTime)
+	assert.Equal(t, 32, config.Regulation.IP.IPv4Mask)
+	assert.Equal(t, 64, config.Regulation.IP.IPv6Mask)
+}
+
+func TestShouldRaiseErrorsWhenRegulationIPIncorrectlyConfigured(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := newDefaultRegulationConfig()
+
+	config.Regulation.Scope = "ip"
+	config.Regulation.IP = schema.RegulationIP{
+		MaxRetries:      10,
+		FindTime:        time.Minute,
+		BanTime:         time.Second * 10,
+		IPv4Mask:        33,
+		IPv6Mask:        -1,
+		TrustedNetworks: []string{"10.0.0.0/8", "192.168.1.1", "example.com"},
+	}
+
+	ValidateRegulation(&config, validator)
+
+	assert.Len(t, validator.Warnings(), 0)
+	require.Len(t, validator.Errors(), 5)
+
+	assert.EqualError(t, validator.Errors()[0], "regulation: option 'scope' must be one of 'user' or 'user_ip' but it's configured as 'ip'")
+	assert.EqualError(t, validator.Errors()[1], "regulation: ip: option 'find_time' must be less than or equal to option 'ban_time'")
+	assert.EqualError(t, validator.Errors()[2], "regulation: ip: option 'ipv4_mask' must be between 1 and 32 but it's configured as '33'")
+	assert.EqualError(t, validator.Errors()[3], "regulation: ip: option 'ipv6_mask' must be between 1 and 128 but it's configured as '-1'")
+	assert.EqualError(t, validator.Errors()[4], "regulation: ip: option 'trusted_networks' must only contain IP's or network ranges in CIDR notation but it contains 'example.com'")
+}
+
+func TestShouldSetDefaultRegulationEscalationWhenUnset(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := newDefaultRegulationConfig()
+
+	config.Regulation.Mode = schema.RegulationModeEscalating
+
+	ValidateRegulation(&config, validator)
+
+	assert.Len(t, validator.Errors(), 0)
+	assert.Equal(t, schema.DefaultRegulationConfiguration.Escalation, config.Regulation.Escalation)
+}
+
+func TestShouldRaiseErrorsWhenRegulationEscalationIncorrectlyConfigured(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := newDefaultRegulationConfig()
+
+	config.Regulation.Mode = "exponential"
+
+	ValidateRegulation(&config, validator)
+
+	require.Len(t, validator.Errors(), 1)
+	assert.EqualError(t, validator.Errors()[0], "regulation: option 'mode' must be one of 'fixed' or 'escalating' but it's configured as 'exponential'")
+
+	validator = schema.NewStructValidator()
+	config = newDefaultRegulationConfig()
+
+	config.Regulation.Mode = schema.RegulationModeEscalating
+	config.Regulation.Escalation = schema.RegulationEscalation{
+		Multiplier: 1,
+		MaxBanTime: time.Minute,
+		Lookback:   time.Minute,
+	}
+
+	ValidateRegulation(&config, validator)
+
+	require.Len(t, validator.Errors(), 3)
+	assert.EqualError(t, validator.Errors()[0], "regulation: escalation: option 'multiplier' must be 2 or more but it's configured as '1'")
+	assert.EqualError(t, validator.Errors()[1], "regulation: escalation: option 'max_ban_time' must be greater than or equal to option 'ban_time'")
+	assert.EqualError(t, validator.Errors()[2], "regulation: escalation: option 'lookback' must be greater than or equal to option 'ban_time'")
+}
+
+func TestShouldSetDefaultRegulationSecondFactorWhenUnset(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := newDefaultRegulationConfig()
+
+	ValidateRegulation(&config, validator)
+
+	assert.Len(t, validator.Errors(), 0)
+	assert.Equal(t, schema.DefaultRegulationConfiguration.SecondFactor, config.Regulation.SecondFactor)
+}
+
+func TestShouldRaiseErrorsWhenRegulationSecondFactorIncorrectlyConfigured(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := newDefaultRegulationConfig()
+
+	config.Regulation.SecondFactor.TOTP = schema.RegulationSecondFactorMethod{MaxRetries: 3, FindTime: time.Hour, BanTime: time.Minute}
+	config.Regulation.SecondFactor.Duo = schema.RegulationSecondFactorMethod{FindTime: time.Hour}
+
+	ValidateRegulation(&config, validator)
+
+	require.Len(t, validator.Errors(), 2)
+	assert.EqualError(t, validator.Errors()[0], "regulation: second_factor: totp: option 'find_time' must be less than or equal to option 'ban_time'")
+	assert.EqualError(t, validator.Errors()[1], "regulation: second_factor: duo: option 'find_time' must be less than or equal to option 'ban_time'")
+
+	validator = schema.NewStructValidator()
+	config = newDefaultRegulationConfig()
+
+	config.Regulation.Mode = schema.RegulationModeEscalating
+	config.Regulation.SecondFactor.TOTP = schema.RegulationSecondFactorMethod{MaxRetries: 3, BanTime: time.Hour * 48}
+
+	ValidateRegulation(&config, validator)
+
+	require.Len(t, validator.Errors(), 2)
+	assert.EqualError(t, validator.Errors()[0], "regulation: escalation: option 'max_ban_time' must be greater than or equal to option 'ban_time'")
+	assert.EqualError(t, validator.Errors()[1], "regulation: escalation: option 'lookback' must be greater than or equal to option 'ban_time'")
+}
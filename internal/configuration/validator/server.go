@@ -7,6 +7,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/authelia/authelia/v4/internal/authorization"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/utils"
 )
@@ -147,6 +148,10 @@ func ValidateServerEndpoints(config *schema.Configuration, validator *schema.Str
 		validator.PushWarning(fmt.Errorf("server: endpoints: option 'enable_pprof' should not be enabled in production"))
 	}
 
+	if config.Server.Endpoints.Admin.Enabled && len(config.Server.Endpoints.Admin.Groups) == 0 {
+		validator.Push(fmt.Errorf(errFmtServerEndpointsAdminGroups))
+	}
+
 	if len(config.Server.Endpoints.Authz) == 0 {
 		config.Server.Endpoints.Authz = schema.DefaultServerConfiguration.Endpoints.Authz
 
@@ -184,6 +189,12 @@ func ValidateServerEndpoints(config *schema.Configuration, validator *schema.Str
 		}
 
 		validateServerEndpointsAuthzStrategies(name, endpoint.AuthnStrategies, validator)
+
+		validateServerEndpointsAuthzIdentityHeaders(name, endpoint.IdentityHeaders, validator)
+
+		validateServerEndpointsAuthzIdentityAssertion(config, name, validator)
+
+		validateAuthzResponses(fmt.Sprintf("server: endpoints: authz: %s: responses", name), endpoint.Responses, validator)
 	}
 }
 
@@ -212,6 +223,143 @@ func validateServerEndpointsAuthzEndpoint(config *schema.Configuration, name str
 	}
 }
 
+func validateServerEndpointsAuthzIdentityHeaders(name string, config schema.ServerEndpointsAuthzIdentityHeaders, validator *schema.StructValidator) {
+	if config.ClientHeaders != "" && !utils.IsStringInSlice(config.ClientHeaders, validAuthzClientIdentityHeaders) {
+		validator.Push(fmt.Errorf(errFmtServerEndpointsAuthzClientHeaders, name, strJoinOr(validAuthzClientIdentityHeaders), config.ClientHeaders))
+	}
+
+	validateAuthzIdentityHeaders(fmt.Sprintf("server: endpoints: authz: %s: identity_headers: headers", name), config.Headers, validator)
+}
+
+func validateServerEndpointsAuthzIdentityAssertion(config *schema.Configuration, name string, validator *schema.StructValidator) {
+	endpoint := config.Server.Endpoints.Authz[name]
+
+	if !endpoint.IdentityAssertion.Enabled {
+		return
+	}
+
+	switch {
+	case endpoint.IdentityAssertion.Header == "":
+		endpoint.IdentityAssertion.Header = schema.DefaultServerEndpointsAuthzIdentityAssertion.Header
+	case !reHeaderName.MatchString(endpoint.IdentityAssertion.Header):
+		validator.Push(fmt.Errorf(errFmtServerEndpointsAuthzIdentityAssertionHeaderInvalid, name, endpoint.IdentityAssertion.Header))
+	case utils.IsStringInSliceFold(endpoint.IdentityAssertion.Header, reservedAuthzIdentityHeaders):
+		validator.Push(fmt.Errorf(errFmtServerEndpointsAuthzIdentityAssertionHeaderReserved, name, endpoint.IdentityAssertion.Header))
+	}
+
+	for _, header := range endpoint.IdentityHeaders.Headers {
+		if strings.EqualFold(header.Name, endpoint.IdentityAssertion.Header) {
+			validator.Push(fmt.Errorf(errFmtServerEndpointsAuthzIdentityAssertionHeaderDuplicate, name, endpoint.IdentityAssertion.Header))
+
+			break
+		}
+	}
+
+	switch {
+	case endpoint.IdentityAssertion.Lifespan == 0:
+		endpoint.IdentityAssertion.Lifespan = schema.DefaultServerEndpointsAuthzIdentityAssertion.Lifespan
+	case endpoint.IdentityAssertion.Lifespan < 0:
+		validator.Push(fmt.Errorf(errFmtServerEndpointsAuthzIdentityAssertionLifespan, name, endpoint.IdentityAssertion.Lifespan))
+	}
+
+	if config.IdentityProviders.OIDC == nil {
+		validator.Push(fmt.Errorf(errFmtServerEndpointsAuthzIdentityAssertionOpenIDConnect, name))
+	}
+
+	config.Server.Endpoints.Authz[name] = endpoint
+}
+
+// validateServerEndpointsAuthzIdentityAssertionKeys checks the key id of each identity assertion. It must be called
+// after ValidateIdentityProviders as it relies on the key ids discovered from the issuer private keys.
+func validateServerEndpointsAuthzIdentityAssertionKeys(config *schema.Configuration, validator *schema.StructValidator) {
+	if config.IdentityProviders.OIDC == nil {
+		return
+	}
+
+	names := make([]string, 0, len(config.Server.Endpoints.Authz))
+
+	for name := range config.Server.Endpoints.Authz {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		assertion := config.Server.Endpoints.Authz[name].IdentityAssertion
+
+		if !assertion.Enabled || assertion.KeyID == "" {
+			continue
+		}
+
+		if !utils.IsStringInSlice(assertion.KeyID, config.IdentityProviders.OIDC.Discovery.ResponseObjectSigningKeyIDs) {
+			validator.Push(fmt.Errorf(errFmtServerEndpointsAuthzIdentityAssertionKeyID, name, strJoinOr(config.IdentityProviders.OIDC.Discovery.ResponseObjectSigningKeyIDs), assertion.KeyID))
+		}
+	}
+}
+
+func validateAuthzIdentityHeaders(prefix string, headers []schema.AuthzIdentityHeader, validator *schema.StructValidator) {
+	names := make([]string, 0, len(headers))
+
+	for i, header := range headers {
+		switch {
+		case header.Name == "":
+			validator.Push(fmt.Errorf(errFmtAuthzIdentityHeaderNameRequired, prefix, i+1))
+
+			continue
+		case !reHeaderName.MatchString(header.Name):
+			validator.Push(fmt.Errorf(errFmtAuthzIdentityHeaderNameInvalid, prefix, i+1, header.Name))
+
+			continue
+		case utils.IsStringInSliceFold(header.Name, reservedAuthzIdentityHeaders):
+			validator.Push(fmt.Errorf(errFmtAuthzIdentityHeaderNameReserved, prefix, i+1, header.Name))
+		case utils.IsStringInSliceFold(header.Name, names):
+			validator.Push(fmt.Errorf(errFmtAuthzIdentityHeaderNameDuplicate, prefix, i+1, header.Name))
+		}
+
+		names = append(names, header.Name)
+
+		if _, err := authorization.NewIdentityHeader(header); err != nil {
+			validator.Push(fmt.Errorf(errFmtAuthzIdentityHeaderValue, prefix, i+1, header.Name, err))
+		}
+	}
+}
+
+func validateAuthzResponses(prefix string, config schema.AuthzResponses, validator *schema.StructValidator) {
+	validateAuthzResponse(prefix, authzResponseForbidden, config.Forbidden, validator)
+	validateAuthzResponse(prefix, authzResponseUnauthorized, config.Unauthorized, validator)
+}
+
+func validateAuthzResponse(prefix, name string, config schema.AuthzResponse, validator *schema.StructValidator) {
+	if config.StatusCode != 0 && (config.StatusCode < 300 || config.StatusCode > 599) {
+		validator.Push(fmt.Errorf(errFmtAuthzResponseStatusCode, prefix, name, config.StatusCode))
+	}
+
+	if config.Template != "" {
+		switch info, err := os.Stat(config.Template); {
+		case os.IsNotExist(err):
+			validator.Push(fmt.Errorf(errFmtAuthzResponseTemplateNotExist, prefix, name, config.Template))
+		case err != nil:
+			validator.Push(fmt.Errorf(errFmtAuthzResponseTemplateUnknownError, prefix, name, config.Template, err))
+		case info.IsDir():
+			validator.Push(fmt.Errorf(errFmtAuthzResponseTemplateDirectory, prefix, name, config.Template))
+		}
+	}
+
+	if config.RedirectURL != nil && (!config.RedirectURL.IsAbs() || (config.RedirectURL.Scheme != schemeHTTP && config.RedirectURL.Scheme != schemeHTTPS)) {
+		validator.Push(fmt.Errorf(errFmtAuthzResponseRedirectURL, prefix, name, config.RedirectURL))
+	}
+
+	// The forbidden response is sent as a redirection when the redirect_url is configured so the status code must be a
+	// redirection status code, whereas the unauthorized response only replaces the URL used by the implementation.
+	if name == authzResponseForbidden && config.RedirectURL != nil && config.StatusCode != 0 && !isRedirectStatusCode(config.StatusCode) {
+		validator.Push(fmt.Errorf(errFmtAuthzResponseRedirectStatusCode, prefix, name, config.StatusCode))
+	}
+}
+
+func isRedirectStatusCode(code int) bool {
+	return code >= 300 && code <= 399
+}
+
 func validateServerEndpointsAuthzStrategies(name string, strategies []schema.ServerEndpointsAuthzAuthnStrategy, validator *schema.StructValidator) {
 	names := make([]string, len(strategies))
 
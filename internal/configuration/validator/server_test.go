@@ -2,6 +2,7 @@ package validator
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"testing"
 	"time"
@@ -439,6 +440,36 @@ func TestServerEndpointsDevelShouldWarn(t *testing.T) {
 	assert.EqualError(t, validator.Warnings()[1], "server: endpoints: option 'enable_pprof' should not be enabled in production")
 }
 
+func TestServerEndpointsAdmin(t *testing.T) {
+	config := &schema.Configuration{
+		Server: schema.Server{
+			Endpoints: schema.ServerEndpoints{
+				Admin: schema.ServerEndpointsAdmin{
+					Enabled: true,
+				},
+			},
+		},
+	}
+
+	validator := schema.NewStructValidator()
+
+	ValidateServer(config, validator)
+
+	assert.Len(t, validator.Warnings(), 0)
+	require.Len(t, validator.Errors(), 1)
+
+	assert.EqualError(t, validator.Errors()[0], "server: endpoints: admin: option 'groups' must be configured when the option 'enabled' is true")
+
+	config.Server.Endpoints.Admin.Groups = []string{"admins"}
+
+	validator = schema.NewStructValidator()
+
+	ValidateServer(config, validator)
+
+	assert.Len(t, validator.Warnings(), 0)
+	assert.Len(t, validator.Errors(), 0)
+}
+
 func TestServerAuthzEndpointErrors(t *testing.T) {
 	testCases := []struct {
 		name string
@@ -539,6 +570,40 @@ func TestServerAuthzEndpointErrors(t *testing.T) {
 				"server: endpoints: authz: pear/abc: endpoint starts with the same prefix as the 'pear' endpoint with the 'ExtAuthz' implementation which accepts prefixes as part of its implementation",
 			},
 		},
+		{
+			"ShouldAllowValidIdentityHeaders",
+			map[string]schema.ServerEndpointsAuthz{
+				"example": {Implementation: "ForwardAuth", IdentityHeaders: schema.ServerEndpointsAuthzIdentityHeaders{ClientHeaders: "strip", Headers: []schema.AuthzIdentityHeader{{Name: "Remote-User", Value: "{{ .Username }}"}, {Name: "X-Employee-ID", Value: `{{ index .Extra "employee_id" }}`}}}},
+			},
+			nil,
+		},
+		{
+			"ShouldErrorOnInvalidClientHeaders",
+			map[string]schema.ServerEndpointsAuthz{
+				"example": {Implementation: "ForwardAuth", IdentityHeaders: schema.ServerEndpointsAuthzIdentityHeaders{ClientHeaders: "remove"}},
+			},
+			[]string{"server: endpoints: authz: example: identity_headers: option 'client_headers' must be one of 'allow', 'strip', or 'forbid' but it's configured as 'remove'"},
+		},
+		{
+			"ShouldErrorOnInvalidIdentityHeaders",
+			map[string]schema.ServerEndpointsAuthz{
+				"example": {Implementation: "ForwardAuth", IdentityHeaders: schema.ServerEndpointsAuthzIdentityHeaders{Headers: []schema.AuthzIdentityHeader{
+					{Value: "{{ .Username }}"},
+					{Name: "Remote User", Value: "{{ .Username }}"},
+					{Name: "set-cookie", Value: "{{ .Username }}"},
+					{Name: "Remote-User", Value: "{{ .Username }}"},
+					{Name: "remote-user", Value: "{{ .Username }}"},
+					{Name: "Remote-Groups", Value: "{{ .Groups"},
+				}}},
+			},
+			[]string{
+				"server: endpoints: authz: example: identity_headers: headers: header #1: option 'name' is required",
+				"server: endpoints: authz: example: identity_headers: headers: header #2: option 'name' with value 'Remote User' is invalid: must only contain valid header name characters",
+				"server: endpoints: authz: example: identity_headers: headers: header #3: option 'name' with value 'set-cookie' is invalid: the header is reserved",
+				"server: endpoints: authz: example: identity_headers: headers: header #5: option 'name' with value 'remote-user' is invalid: the header is duplicated",
+				"server: endpoints: authz: example: identity_headers: headers: header #6 (Remote-Groups): option 'value' is invalid: failed to parse the value of the identity header 'Remote-Groups': template: Remote-Groups:1: unclosed action",
+			},
+		},
 	}
 
 	validator := schema.NewStructValidator()
@@ -607,3 +672,140 @@ func TestValidateTLSPathIsDir(t *testing.T) {
 
 	assert.EqualError(t, validator.Errors()[0], fmt.Sprintf("server: tls: option 'key' with path '%s' refers to a directory but it should refer to a file", dir))
 }
+
+func TestServerAuthzEndpointIdentityAssertion(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     schema.ServerEndpointsAuthz
+		oidc     bool
+		expected schema.ServerEndpointsAuthzIdentityAssertion
+		errs     []string
+	}{
+		{
+			"ShouldSetDefaults",
+			schema.ServerEndpointsAuthz{Implementation: "ForwardAuth", IdentityAssertion: schema.ServerEndpointsAuthzIdentityAssertion{Enabled: true}},
+			true,
+			schema.ServerEndpointsAuthzIdentityAssertion{Enabled: true, Header: "Remote-Assertion", Lifespan: time.Minute},
+			nil,
+		},
+		{
+			"ShouldNotSetDefaultsWhenDisabled",
+			schema.ServerEndpointsAuthz{Implementation: "ForwardAuth"},
+			false,
+			schema.ServerEndpointsAuthzIdentityAssertion{},
+			nil,
+		},
+		{
+			"ShouldErrorOnInvalidOptions",
+			schema.ServerEndpointsAuthz{Implementation: "ForwardAuth", IdentityAssertion: schema.ServerEndpointsAuthzIdentityAssertion{Enabled: true, Header: "Remote Assertion", Lifespan: -time.Minute}},
+			false,
+			schema.ServerEndpointsAuthzIdentityAssertion{Enabled: true, Header: "Remote Assertion", Lifespan: -time.Minute},
+			[]string{
+				"server: endpoints: authz: example: identity_assertion: option 'header' with value 'Remote Assertion' is invalid: must only contain valid header name characters",
+				"server: endpoints: authz: example: identity_assertion: option 'lifespan' must be a positive duration but it's configured as '-1m0s'",
+				"server: endpoints: authz: example: identity_assertion: option 'enabled' requires the 'identity_providers' option 'oidc' to be configured as the assertion is signed with its issuer private keys",
+			},
+		},
+		{
+			"ShouldErrorOnReservedHeader",
+			schema.ServerEndpointsAuthz{Implementation: "ForwardAuth", IdentityAssertion: schema.ServerEndpointsAuthzIdentityAssertion{Enabled: true, Header: "Set-Cookie"}},
+			true,
+			schema.ServerEndpointsAuthzIdentityAssertion{Enabled: true, Header: "Set-Cookie", Lifespan: time.Minute},
+			[]string{
+				"server: endpoints: authz: example: identity_assertion: option 'header' with value 'Set-Cookie' is invalid: the header is reserved",
+			},
+		},
+		{
+			"ShouldErrorOnDuplicateHeader",
+			schema.ServerEndpointsAuthz{
+				Implementation:    "ForwardAuth",
+				IdentityHeaders:   schema.ServerEndpointsAuthzIdentityHeaders{Headers: []schema.AuthzIdentityHeader{{Name: "remote-assertion", Value: "{{ .Username }}"}}},
+				IdentityAssertion: schema.ServerEndpointsAuthzIdentityAssertion{Enabled: true},
+			},
+			true,
+			schema.ServerEndpointsAuthzIdentityAssertion{Enabled: true, Header: "Remote-Assertion", Lifespan: time.Minute},
+			[]string{
+				"server: endpoints: authz: example: identity_assertion: option 'header' with value 'Remote-Assertion' is invalid: the header is also configured as an identity header",
+			},
+		},
+	}
+
+	validator := schema.NewStructValidator()
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			validator.Clear()
+
+			config := newDefaultConfig()
+
+			config.Server.Endpoints.Authz = map[string]schema.ServerEndpointsAuthz{"example": tc.have}
+
+			if tc.oidc {
+				config.IdentityProviders.OIDC = &schema.IdentityProvidersOpenIDConnect{}
+			}
+
+			ValidateServerEndpoints(&config, validator)
+
+			assert.Equal(t, tc.expected, config.Server.Endpoints.Authz["example"].IdentityAssertion)
+
+			require.Len(t, validator.Errors(), len(tc.errs))
+
+			for i, expected := range tc.errs {
+				assert.EqualError(t, validator.Errors()[i], expected)
+			}
+		})
+	}
+}
+
+func TestServerAuthzEndpointIdentityAssertionKeyID(t *testing.T) {
+	validator := schema.NewStructValidator()
+
+	config := newDefaultConfig()
+
+	config.Server.Endpoints.Authz = map[string]schema.ServerEndpointsAuthz{
+		"a": {Implementation: "ForwardAuth", IdentityAssertion: schema.ServerEndpointsAuthzIdentityAssertion{Enabled: true, KeyID: "abc"}},
+		"b": {Implementation: "ForwardAuth", IdentityAssertion: schema.ServerEndpointsAuthzIdentityAssertion{Enabled: true, KeyID: "xyz"}},
+		"c": {Implementation: "ForwardAuth", IdentityAssertion: schema.ServerEndpointsAuthzIdentityAssertion{KeyID: "xyz"}},
+	}
+
+	config.IdentityProviders.OIDC = &schema.IdentityProvidersOpenIDConnect{
+		Discovery: schema.IdentityProvidersOpenIDConnectDiscovery{ResponseObjectSigningKeyIDs: []string{"abc", "123"}},
+	}
+
+	validateServerEndpointsAuthzIdentityAssertionKeys(&config, validator)
+
+	assert.Len(t, validator.Warnings(), 0)
+	require.Len(t, validator.Errors(), 1)
+
+	assert.EqualError(t, validator.Errors()[0], "server: endpoints: authz: b: identity_assertion: option 'key_id' must be one of 'abc' or '123' but it's configured as 'xyz'")
+}
+
+func TestServerAuthzEndpointResponses(t *testing.T) {
+	validator := schema.NewStructValidator()
+
+	config := newDefaultConfig()
+
+	config.Server.Endpoints.Authz = map[string]schema.ServerEndpointsAuthz{
+		"example": {
+			Implementation: "ForwardAuth",
+			Responses: schema.AuthzResponses{
+				Forbidden:    schema.AuthzResponse{StatusCode: 600},
+				Unauthorized: schema.AuthzResponse{StatusCode: 302, RedirectURL: &url.URL{Scheme: "ftp", Host: "example.com"}},
+			},
+		},
+		"other": {
+			Implementation: "ForwardAuth",
+			Responses: schema.AuthzResponses{
+				Forbidden: schema.AuthzResponse{StatusCode: 403, RedirectURL: &url.URL{Scheme: "https", Host: "example.com", Path: "/denied"}},
+			},
+		},
+	}
+
+	ValidateServerEndpoints(&config, validator)
+
+	require.Len(t, validator.Errors(), 3)
+
+	assert.EqualError(t, validator.Errors()[0], "server: endpoints: authz: example: responses: forbidden: option 'status_code' must be between 300 and 599 but it's configured as '600'")
+	assert.EqualError(t, validator.Errors()[1], "server: endpoints: authz: example: responses: unauthorized: option 'redirect_url' must be an absolute URL with the 'http' or 'https' scheme but it's configured as 'ftp://example.com'")
+	assert.EqualError(t, validator.Errors()[2], "server: endpoints: authz: other: responses: forbidden: option 'status_code' must be between 300 and 399 when the option 'redirect_url' is configured but it's configured as '403'")
+}
@@ -16,15 +16,109 @@ func ValidateSession(config *schema.Configuration, validator *schema.StructValid
 		config.Session.Name = schema.DefaultSessionConfiguration.Name
 	}
 
+	validateSessionSecrets(&config.Session, validator)
+
 	if config.Session.Redis != nil {
-		if config.Session.Redis.HighAvailability != nil {
+		switch {
+		case config.Session.Redis.HighAvailability != nil && config.Session.Redis.Cluster != nil:
+			validator.Push(fmt.Errorf(errSessionRedisSentinelAndCluster))
+		case config.Session.Redis.HighAvailability != nil:
 			validateRedisSentinel(&config.Session, validator)
-		} else {
+		case config.Session.Redis.Cluster != nil:
+			validateRedisCluster(&config.Session, validator)
+		default:
 			validateRedis(&config.Session, validator)
 		}
 	}
 
+	validateSessionSQL(config, validator)
+
 	validateSession(config, validator)
+
+	validateSessionBinding(&config.Session.Binding, validator)
+
+	validateSessionLogout(&config.Session.Logout, validator)
+}
+
+func validateSessionSecrets(config *schema.Session, validator *schema.StructValidator) {
+	for i, secret := range config.Secrets {
+		if secret == "" {
+			validator.Push(fmt.Errorf(errFmtSessionSecretsEmpty, i+1))
+		}
+	}
+
+	// The first of the ordered secrets is the one used to encrypt the session data when the secret option is not
+	// configured, the remaining ones are only used to decrypt the session data.
+	if config.Secret == "" && len(config.Secrets) != 0 {
+		config.Secret, config.Secrets = config.Secrets[0], config.Secrets[1:]
+	}
+}
+
+func validateSessionSQL(config *schema.Configuration, validator *schema.StructValidator) {
+	switch {
+	case config.Session.SQL == nil:
+		// Automatically select the SQL provider when the storage backend is shared between multiple nodes, as the
+		// memory provider is not suitable for this. The secret is required to encrypt the session data.
+		if config.Session.Redis != nil || config.Session.Secret == "" || (config.Storage.PostgreSQL == nil && config.Storage.MySQL == nil) {
+			return
+		}
+
+		config.Session.SQL = &schema.SessionSQL{}
+	case config.Session.Redis != nil:
+		validator.Push(fmt.Errorf(errSessionRedisAndSQL))
+
+		return
+	case config.Session.Secret == "":
+		validator.Push(fmt.Errorf(errFmtSessionSecretRequired, "sql"))
+	}
+
+	if config.Session.SQL.CleanupInterval <= 0 {
+		config.Session.SQL.CleanupInterval = schema.DefaultSessionSQLConfiguration.CleanupInterval
+	}
+}
+
+func validateSessionBinding(config *schema.SessionBinding, validator *schema.StructValidator) {
+	if !config.Enabled {
+		return
+	}
+
+	switch {
+	case config.IPv4Mask == 0:
+		config.IPv4Mask = schema.DefaultSessionConfiguration.Binding.IPv4Mask
+	case config.IPv4Mask < 1 || config.IPv4Mask > 32:
+		validator.Push(fmt.Errorf(errFmtSessionBindingIPv4Mask, config.IPv4Mask))
+	}
+
+	switch {
+	case config.IPv6Mask == 0:
+		config.IPv6Mask = schema.DefaultSessionConfiguration.Binding.IPv6Mask
+	case config.IPv6Mask < 1 || config.IPv6Mask > 128:
+		validator.Push(fmt.Errorf(errFmtSessionBindingIPv6Mask, config.IPv6Mask))
+	}
+
+	if config.Strictness == "" {
+		config.Strictness = schema.DefaultSessionConfiguration.Binding.Strictness
+	} else if !utils.IsStringInSlice(config.Strictness, validSessionBindingStrictnessValues) {
+		validator.Push(fmt.Errorf(errFmtSessionBindingStrictness, strJoinOr(validSessionBindingStrictnessValues), config.Strictness))
+	}
+
+	if config.Action == "" {
+		config.Action = schema.DefaultSessionConfiguration.Binding.Action
+	} else if !utils.IsStringInSlice(config.Action, validSessionBindingActionValues) {
+		validator.Push(fmt.Errorf(errFmtSessionBindingAction, strJoinOr(validSessionBindingActionValues), config.Action))
+	}
+}
+
+func validateSessionLogout(config *schema.SessionLogout, validator *schema.StructValidator) {
+	if config.RedirectionURL == nil {
+		return
+	}
+
+	if !config.RedirectionURL.IsAbs() {
+		validator.Push(fmt.Errorf(errFmtSessionLogoutRedirectionURLNotAbsolute, config.RedirectionURL))
+	} else if !utils.IsURISecure(config.RedirectionURL) {
+		validator.Push(fmt.Errorf(errFmtSessionLogoutRedirectionURLInsecure, config.RedirectionURL))
+	}
 }
 
 func validateSession(config *schema.Configuration, validator *schema.StructValidator) {
@@ -294,3 +388,48 @@ func validateRedisSentinel(config *schema.Session, validator *schema.StructValid
 		validator.Push(fmt.Errorf(errFmtSessionRedisSentinelNodeHostMissing))
 	}
 }
+
+func validateRedisCluster(config *schema.Session, validator *schema.StructValidator) {
+	if config.Redis.Port == 0 {
+		config.Redis.Port = schema.DefaultRedisClusterConfiguration.Port
+	} else if config.Redis.Port < 1 || config.Redis.Port > 65535 {
+		validator.Push(fmt.Errorf(errFmtSessionRedisPortRange, config.Redis.Port))
+	}
+
+	if config.Redis.Host == "" && len(config.Redis.Cluster.Nodes) == 0 {
+		validator.Push(fmt.Errorf(errFmtSessionRedisHostOrClusterNodesRequired))
+	}
+
+	if config.Redis.DatabaseIndex != 0 {
+		validator.Push(fmt.Errorf(errFmtSessionRedisClusterDatabaseIndex, config.Redis.DatabaseIndex))
+	}
+
+	validateRedisCommon(config, validator)
+
+	if config.Redis.MaximumActiveConnections <= 0 {
+		config.Redis.MaximumActiveConnections = schema.DefaultRedisClusterConfiguration.MaximumActiveConnections
+	}
+
+	if config.Redis.Cluster.MaximumRedirects <= 0 {
+		config.Redis.Cluster.MaximumRedirects = schema.DefaultRedisClusterConfiguration.Cluster.MaximumRedirects
+	}
+
+	hostMissing := false
+
+	for i, node := range config.Redis.Cluster.Nodes {
+		if node.Host == "" {
+			hostMissing = true
+		}
+
+		switch {
+		case node.Port == 0:
+			config.Redis.Cluster.Nodes[i].Port = schema.DefaultRedisClusterConfiguration.Port
+		case node.Port < 1 || node.Port > 65535:
+			validator.Push(fmt.Errorf(errFmtSessionRedisClusterNodePortRange, node.Port))
+		}
+	}
+
+	if hostMissing {
+		validator.Push(fmt.Errorf(errFmtSessionRedisClusterNodeHostMissing))
+	}
+}
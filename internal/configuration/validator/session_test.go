@@ -358,6 +358,102 @@ func TestShouldRaiseErrorWhenRedisIsUsedAndSecretNotSet(t *testing.T) {
 	assert.EqualError(t, validator.Errors()[0], fmt.Sprintf(errFmtSessionSecretRequired, "redis"))
 }
 
+func TestShouldSetDefaultSessionSQLValues(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := newDefaultSessionConfig()
+	config.Session.SQL = &schema.SessionSQL{}
+
+	ValidateSession(&config, validator)
+
+	assert.Len(t, validator.Warnings(), 0)
+	assert.Len(t, validator.Errors(), 0)
+	assert.Equal(t, schema.DefaultSessionSQLConfiguration.CleanupInterval, config.Session.SQL.CleanupInterval)
+
+	validator.Clear()
+
+	config = newDefaultSessionConfig()
+	config.Session.SQL = &schema.SessionSQL{CleanupInterval: time.Hour}
+
+	ValidateSession(&config, validator)
+
+	assert.Len(t, validator.Warnings(), 0)
+	assert.Len(t, validator.Errors(), 0)
+	assert.Equal(t, time.Hour, config.Session.SQL.CleanupInterval)
+}
+
+func TestShouldRaiseErrorWhenSessionSQLIsUsedAndSecretNotSet(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := newDefaultSessionConfig()
+	config.Session.Secret = ""
+	config.Session.SQL = &schema.SessionSQL{}
+
+	ValidateSession(&config, validator)
+
+	assert.Len(t, validator.Warnings(), 0)
+	require.Len(t, validator.Errors(), 1)
+	assert.EqualError(t, validator.Errors()[0], fmt.Sprintf(errFmtSessionSecretRequired, "sql"))
+}
+
+func TestShouldRaiseErrorWhenSessionRedisAndSQLAreUsed(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := newDefaultSessionConfig()
+	config.Session.SQL = &schema.SessionSQL{}
+	config.Session.Redis = &schema.SessionRedis{
+		Host: "redis.localhost",
+		Port: 6379,
+	}
+
+	ValidateSession(&config, validator)
+
+	assert.Len(t, validator.Warnings(), 0)
+	require.Len(t, validator.Errors(), 1)
+	assert.EqualError(t, validator.Errors()[0], "session: option 'redis' and option 'sql' can't be configured at the same time")
+}
+
+func TestShouldAutomaticallySelectSessionSQL(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     schema.Storage
+		redis    bool
+		secret   string
+		expected bool
+	}{
+		{"ShouldSelectWithPostgreSQL", schema.Storage{PostgreSQL: &schema.StoragePostgreSQL{}}, false, testJWTSecret, true},
+		{"ShouldSelectWithMySQL", schema.Storage{MySQL: &schema.StorageMySQL{}}, false, testJWTSecret, true},
+		{"ShouldNotSelectWithLocal", schema.Storage{Local: &schema.StorageLocal{}}, false, testJWTSecret, false},
+		{"ShouldNotSelectWithRedis", schema.Storage{PostgreSQL: &schema.StoragePostgreSQL{}}, true, testJWTSecret, false},
+		{"ShouldNotSelectWithoutSecret", schema.Storage{PostgreSQL: &schema.StoragePostgreSQL{}}, false, "", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			validator := schema.NewStructValidator()
+			config := newDefaultSessionConfig()
+			config.Session.Secret = tc.secret
+			config.Storage = tc.have
+
+			if tc.redis {
+				config.Session.Redis = &schema.SessionRedis{
+					Host: "redis.localhost",
+					Port: 6379,
+				}
+			}
+
+			ValidateSession(&config, validator)
+
+			assert.Len(t, validator.Warnings(), 0)
+			assert.Len(t, validator.Errors(), 0)
+
+			if tc.expected {
+				require.NotNil(t, config.Session.SQL)
+				assert.Equal(t, schema.DefaultSessionSQLConfiguration.CleanupInterval, config.Session.SQL.CleanupInterval)
+			} else {
+				assert.Nil(t, config.Session.SQL)
+			}
+		})
+	}
+}
+
 func TestShouldNotRaiseErrorsAndSetDefaultPortWhenRedisPortBlank(t *testing.T) {
 	validator := schema.NewStructValidator()
 	config := newDefaultSessionConfig()
@@ -627,6 +723,89 @@ func TestShouldRaiseErrorsWhenRedisHostNotSet(t *testing.T) {
 	assert.EqualError(t, errors[0], errFmtSessionRedisHostRequired)
 }
 
+func TestShouldSetDefaultRedisClusterValues(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := newDefaultSessionConfig()
+
+	config.Session.Redis = &schema.SessionRedis{
+		Cluster: &schema.SessionRedisCluster{
+			Nodes: []schema.SessionRedisClusterNode{
+				{Host: "redis-node-0"},
+				{Host: "redis-node-1", Port: 7001},
+			},
+		},
+	}
+
+	ValidateSession(&config, validator)
+
+	assert.Len(t, validator.Warnings(), 0)
+	assert.Len(t, validator.Errors(), 0)
+
+	assert.Equal(t, 6379, config.Session.Redis.Port)
+	assert.Equal(t, 8, config.Session.Redis.MaximumActiveConnections)
+	assert.Equal(t, 3, config.Session.Redis.Cluster.MaximumRedirects)
+	assert.Equal(t, 6379, config.Session.Redis.Cluster.Nodes[0].Port)
+	assert.Equal(t, 7001, config.Session.Redis.Cluster.Nodes[1].Port)
+}
+
+func TestShouldRaiseErrorsWhenRedisClusterOptionsIncorrectlyConfigured(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     *schema.SessionRedis
+		expected []string
+	}{
+		{
+			"ShouldErrorWithoutHostOrNodes",
+			&schema.SessionRedis{Cluster: &schema.SessionRedisCluster{}},
+			[]string{"session: redis: option 'host' or the 'cluster' option 'nodes' is required"},
+		},
+		{
+			"ShouldErrorWithDatabaseIndex",
+			&schema.SessionRedis{Host: "redis", DatabaseIndex: 1, Cluster: &schema.SessionRedisCluster{}},
+			[]string{"session: redis: option 'database_index' must be 0 when the 'cluster' option is configured but it's configured as '1'"},
+		},
+		{
+			"ShouldErrorWithInvalidPorts",
+			&schema.SessionRedis{Host: "redis", Port: 100000, Cluster: &schema.SessionRedisCluster{
+				Nodes: []schema.SessionRedisClusterNode{{Host: "redis-node-0", Port: -1}},
+			}},
+			[]string{
+				"session: redis: option 'port' must be between 1 and 65535 but it's configured as '100000'",
+				"session: redis: cluster: option 'nodes': option 'port' must be between 1 and 65535 but it's configured as '-1'",
+			},
+		},
+		{
+			"ShouldErrorWithNodeHostMissing",
+			&schema.SessionRedis{Cluster: &schema.SessionRedisCluster{
+				Nodes: []schema.SessionRedisClusterNode{{Host: "redis-node-0"}, {Port: 6379}, {Port: 6380}},
+			}},
+			[]string{"session: redis: cluster: option 'nodes': option 'host' is required for each node but one or more nodes are missing this"},
+		},
+		{
+			"ShouldErrorWithHighAvailability",
+			&schema.SessionRedis{Host: "redis", Cluster: &schema.SessionRedisCluster{}, HighAvailability: &schema.SessionRedisHighAvailability{SentinelName: "authelia"}},
+			[]string{"session: redis: option 'high_availability' and option 'cluster' can't be configured at the same time"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			validator := schema.NewStructValidator()
+			config := newDefaultSessionConfig()
+			config.Session.Redis = tc.have
+
+			ValidateSession(&config, validator)
+
+			assert.Len(t, validator.Warnings(), 0)
+			require.Len(t, validator.Errors(), len(tc.expected))
+
+			for i, expected := range tc.expected {
+				assert.EqualError(t, validator.Errors()[i], expected)
+			}
+		})
+	}
+}
+
 func TestShouldSetDefaultRedisTLSOptions(t *testing.T) {
 	validator := schema.NewStructValidator()
 	config := newDefaultSessionConfig()
@@ -987,3 +1166,122 @@ func MustParseURL(uri string) *url.URL {
 
 	return u
 }
+
+func TestShouldSetDefaultSessionBindingValues(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := newDefaultSessionConfig()
+
+	config.Session.Binding = schema.SessionBinding{Enabled: true}
+
+	ValidateSession(&config, validator)
+
+	assert.Len(t, validator.Warnings(), 0)
+	assert.Len(t, validator.Errors(), 0)
+
+	assert.True(t, config.Session.Binding.Enabled)
+	assert.Equal(t, 24, config.Session.Binding.IPv4Mask)
+	assert.Equal(t, 64, config.Session.Binding.IPv6Mask)
+	assert.Equal(t, schema.SessionBindingStrictnessStrict, config.Session.Binding.Strictness)
+	assert.Equal(t, schema.SessionBindingActionReauthenticate, config.Session.Binding.Action)
+}
+
+func TestShouldRaiseErrorsWhenSessionBindingOptionsIncorrectlyConfigured(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := newDefaultSessionConfig()
+
+	config.Session.Binding = schema.SessionBinding{
+		Enabled:    true,
+		IPv4Mask:   33,
+		IPv6Mask:   -1,
+		Strictness: "bad",
+		Action:     "ignore",
+	}
+
+	ValidateSession(&config, validator)
+
+	assert.Len(t, validator.Warnings(), 0)
+	require.Len(t, validator.Errors(), 4)
+
+	assert.EqualError(t, validator.Errors()[0], "session: binding: option 'ipv4_mask' must be between 1 and 32 but it's configured as '33'")
+	assert.EqualError(t, validator.Errors()[1], "session: binding: option 'ipv6_mask' must be between 1 and 128 but it's configured as '-1'")
+	assert.EqualError(t, validator.Errors()[2], "session: binding: option 'strictness' must be one of 'strict', 'lax', 'ip', or 'user_agent' but it's configured as 'bad'")
+	assert.EqualError(t, validator.Errors()[3], "session: binding: option 'action' must be one of 'log', 'reauthenticate', or 'destroy' but it's configured as 'ignore'")
+}
+
+func TestShouldRaiseErrorsWhenSessionLogoutRedirectionURLIncorrectlyConfigured(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		expected string
+	}{
+		{"ShouldRaiseErrorNotAbsolute", "/logged-out", "session: logout: option 'redirection_url' is not absolute with a value of '/logged-out'"},
+		{"ShouldRaiseErrorInsecure", "http://example.com/logged-out", "session: logout: option 'redirection_url' does not have a secure scheme with a value of 'http://example.com/logged-out'"},
+		{"ShouldNotRaiseErrorValid", "https://example.com/logged-out", ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			validator := schema.NewStructValidator()
+			config := newDefaultSessionConfig()
+
+			config.Session.Logout = schema.SessionLogout{Global: true, RedirectionURL: MustParseURL(tc.have)}
+
+			ValidateSession(&config, validator)
+
+			assert.Len(t, validator.Warnings(), 0)
+
+			if tc.expected == "" {
+				assert.Len(t, validator.Errors(), 0)
+			} else {
+				require.Len(t, validator.Errors(), 1)
+				assert.EqualError(t, validator.Errors()[0], tc.expected)
+			}
+		})
+	}
+}
+
+func TestShouldUseFirstOfSessionSecretsAsSecret(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := newDefaultSessionConfig()
+
+	config.Session.Secret = ""
+	config.Session.Secrets = []string{"current", "retired"}
+
+	ValidateSession(&config, validator)
+
+	assert.Len(t, validator.Warnings(), 0)
+	assert.Len(t, validator.Errors(), 0)
+
+	assert.Equal(t, "current", config.Session.Secret)
+	assert.Equal(t, []string{"retired"}, config.Session.Secrets)
+}
+
+func TestShouldKeepSessionSecretsWhenSecretConfigured(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := newDefaultSessionConfig()
+
+	config.Session.Secret = "current"
+	config.Session.Secrets = []string{"retired", "older"}
+
+	ValidateSession(&config, validator)
+
+	assert.Len(t, validator.Warnings(), 0)
+	assert.Len(t, validator.Errors(), 0)
+
+	assert.Equal(t, "current", config.Session.Secret)
+	assert.Equal(t, []string{"retired", "older"}, config.Session.Secrets)
+}
+
+func TestShouldRaiseErrorWhenSessionSecretsContainsEmptyValue(t *testing.T) {
+	validator := schema.NewStructValidator()
+	config := newDefaultSessionConfig()
+
+	config.Session.Secrets = []string{"retired", ""}
+
+	ValidateSession(&config, validator)
+
+	assert.Len(t, validator.Warnings(), 0)
+	require.Len(t, validator.Errors(), 1)
+
+	assert.EqualError(t, validator.Errors()[0], "session: option 'secrets' must not contain empty values but secret #2 is empty")
+}
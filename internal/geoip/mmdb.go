@@ -0,0 +1,144 @@
+package geoip
+
+import (
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/oschwald/maxminddb-golang"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// NewMMDBProvider creates a new MMDBProvider using the configured MaxMind DB files.
+func NewMMDBProvider(config *schema.GeoIP) (provider *MMDBProvider) {
+	provider = &MMDBProvider{}
+
+	if config.CountryDatabase != "" {
+		provider.country = NewMMDBDatabase(config.CountryDatabase)
+	}
+
+	if config.ASNDatabase != "" {
+		provider.asn = NewMMDBDatabase(config.ASNDatabase)
+	}
+
+	return provider
+}
+
+// MMDBProvider is a Provider which uses local MaxMind DB files.
+type MMDBProvider struct {
+	country *MMDBDatabase
+	asn     *MMDBDatabase
+}
+
+// StartupCheck implements the model.StartupCheck interface and loads the configured databases.
+func (p *MMDBProvider) StartupCheck() (err error) {
+	for _, database := range []*MMDBDatabase{p.country, p.asn} {
+		if database == nil {
+			continue
+		}
+
+		if _, err = database.Reload(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// CountryDatabase returns the country MMDBDatabase or nil if it's not configured.
+func (p *MMDBProvider) CountryDatabase() *MMDBDatabase {
+	return p.country
+}
+
+// ASNDatabase returns the ASN MMDBDatabase or nil if it's not configured.
+func (p *MMDBProvider) ASNDatabase() *MMDBDatabase {
+	return p.asn
+}
+
+// Lookup returns the Record for an IP address.
+func (p *MMDBProvider) Lookup(ip net.IP) (record Record, err error) {
+	if ip == nil {
+		return record, nil
+	}
+
+	if p.country != nil {
+		var result mmdbCountryRecord
+
+		if err = p.country.Lookup(ip, &result); err != nil {
+			return record, fmt.Errorf("failed to lookup the country of ip '%s': %w", ip, err)
+		}
+
+		if record.Country = result.Country.ISOCode; record.Country == "" {
+			record.Country = result.RegisteredCountry.ISOCode
+		}
+	}
+
+	if p.asn != nil {
+		var result mmdbASNRecord
+
+		if err = p.asn.Lookup(ip, &result); err != nil {
+			return record, fmt.Errorf("failed to lookup the asn of ip '%s': %w", ip, err)
+		}
+
+		record.ASN, record.Organization = result.AutonomousSystemNumber, result.AutonomousSystemOrganization
+	}
+
+	return record, nil
+}
+
+// NewMMDBDatabase creates a new MMDBDatabase for the provided path. The database is not opened until it's reloaded.
+func NewMMDBDatabase(path string) (database *MMDBDatabase) {
+	return &MMDBDatabase{
+		path: path,
+	}
+}
+
+// MMDBDatabase is a single MaxMind DB file which can be reloaded while it's in use.
+type MMDBDatabase struct {
+	path string
+
+	mutex  sync.RWMutex
+	reader *maxminddb.Reader
+}
+
+// Path returns the path of the database file.
+func (d *MMDBDatabase) Path() string {
+	return d.path
+}
+
+// Reload opens the database file and replaces the currently open database. If the file can't be opened the currently
+// open database continues to be used.
+func (d *MMDBDatabase) Reload() (reloaded bool, err error) {
+	var reader *maxminddb.Reader
+
+	if reader, err = maxminddb.Open(d.path); err != nil {
+		return false, fmt.Errorf("failed to open the database '%s': %w", d.path, err)
+	}
+
+	d.mutex.Lock()
+
+	previous := d.reader
+	d.reader = reader
+
+	d.mutex.Unlock()
+
+	if previous != nil {
+		_ = previous.Close()
+	}
+
+	return true, nil
+}
+
+// Lookup decodes the record for an IP address into result.
+func (d *MMDBDatabase) Lookup(ip net.IP, result any) (err error) {
+	d.mutex.RLock()
+
+	defer d.mutex.RUnlock()
+
+	if d.reader == nil {
+		return fmt.Errorf("the database '%s' is not loaded", d.path)
+	}
+
+	return d.reader.Lookup(ip, result)
+}
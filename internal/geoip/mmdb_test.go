@@ -0,0 +1,143 @@
+package geoip
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/maxmind/mmdbwriter"
+	"github.com/maxmind/mmdbwriter/mmdbtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestMMDBProvider(t *testing.T) {
+	dir := t.TempDir()
+
+	country := filepath.Join(dir, "country.mmdb")
+	asn := filepath.Join(dir, "asn.mmdb")
+
+	writeTestMMDB(t, country, "GeoLite2-Country", map[string]mmdbtype.Map{
+		"192.0.2.0/24": {
+			"country": mmdbtype.Map{"iso_code": mmdbtype.String("AU")},
+		},
+		"198.51.100.0/24": {
+			"registered_country": mmdbtype.Map{"iso_code": mmdbtype.String("NZ")},
+		},
+	})
+
+	writeTestMMDB(t, asn, "GeoLite2-ASN", map[string]mmdbtype.Map{
+		"192.0.2.0/24": {
+			"autonomous_system_number":       mmdbtype.Uint32(64512),
+			"autonomous_system_organization": mmdbtype.String("Example Org"),
+		},
+	})
+
+	provider := NewMMDBProvider(&schema.GeoIP{CountryDatabase: country, ASNDatabase: asn})
+
+	require.NotNil(t, provider.CountryDatabase())
+	require.NotNil(t, provider.ASNDatabase())
+	assert.Equal(t, country, provider.CountryDatabase().Path())
+	assert.Equal(t, asn, provider.ASNDatabase().Path())
+
+	_, err := provider.Lookup(net.ParseIP("192.0.2.1"))
+	assert.EqualError(t, err, "failed to lookup the country of ip '192.0.2.1': the database '"+country+"' is not loaded")
+
+	require.NoError(t, provider.StartupCheck())
+
+	testCases := []struct {
+		name     string
+		ip       net.IP
+		expected Record
+	}{
+		{"ShouldLookupCountryAndASN", net.ParseIP("192.0.2.1"), Record{Country: "AU", ASN: 64512, Organization: "Example Org"}},
+		{"ShouldFallbackToRegisteredCountry", net.ParseIP("198.51.100.20"), Record{Country: "NZ"}},
+		{"ShouldReturnEmptyRecordForUnknownIP", net.ParseIP("203.0.113.1"), Record{}},
+		{"ShouldReturnEmptyRecordForNilIP", nil, Record{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			record, err := provider.Lookup(tc.ip)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, record)
+		})
+	}
+
+	writeTestMMDB(t, country, "GeoLite2-Country", map[string]mmdbtype.Map{
+		"192.0.2.0/24": {
+			"country": mmdbtype.Map{"iso_code": mmdbtype.String("US")},
+		},
+	})
+
+	reloaded, err := provider.CountryDatabase().Reload()
+	require.NoError(t, err)
+	assert.True(t, reloaded)
+
+	record, err := provider.Lookup(net.ParseIP("192.0.2.1"))
+	assert.NoError(t, err)
+	assert.Equal(t, Record{Country: "US", ASN: 64512, Organization: "Example Org"}, record)
+}
+
+func TestMMDBProviderOnlyCountry(t *testing.T) {
+	country := filepath.Join(t.TempDir(), "country.mmdb")
+
+	writeTestMMDB(t, country, "GeoLite2-Country", map[string]mmdbtype.Map{
+		"192.0.2.0/24": {
+			"country": mmdbtype.Map{"iso_code": mmdbtype.String("AU")},
+		},
+	})
+
+	provider := NewMMDBProvider(&schema.GeoIP{CountryDatabase: country})
+
+	assert.Nil(t, provider.ASNDatabase())
+	require.NoError(t, provider.StartupCheck())
+
+	record, err := provider.Lookup(net.ParseIP("192.0.2.1"))
+	assert.NoError(t, err)
+	assert.Equal(t, Record{Country: "AU"}, record)
+}
+
+func TestMMDBProviderShouldErrorOnInvalidDatabase(t *testing.T) {
+	dir := t.TempDir()
+
+	invalid := filepath.Join(dir, "invalid.mmdb")
+
+	require.NoError(t, os.WriteFile(invalid, []byte("not a database"), 0600))
+
+	provider := NewMMDBProvider(&schema.GeoIP{CountryDatabase: invalid})
+
+	err := provider.StartupCheck()
+	assert.ErrorContains(t, err, "failed to open the database '"+invalid+"': ")
+
+	provider = NewMMDBProvider(&schema.GeoIP{ASNDatabase: filepath.Join(dir, "missing.mmdb")})
+
+	err = provider.StartupCheck()
+	assert.ErrorContains(t, err, "failed to open the database '"+filepath.Join(dir, "missing.mmdb")+"': ")
+}
+
+func writeTestMMDB(t *testing.T, path, databaseType string, records map[string]mmdbtype.Map) {
+	t.Helper()
+
+	tree, err := mmdbwriter.New(mmdbwriter.Options{DatabaseType: databaseType, RecordSize: 24, IncludeReservedNetworks: true})
+	require.NoError(t, err)
+
+	for cidr, record := range records {
+		_, network, err := net.ParseCIDR(cidr)
+		require.NoError(t, err)
+
+		require.NoError(t, tree.Insert(network, record))
+	}
+
+	file, err := os.Create(path)
+	require.NoError(t, err)
+
+	defer file.Close()
+
+	_, err = tree.WriteTo(file)
+	require.NoError(t, err)
+}
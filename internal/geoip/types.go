@@ -0,0 +1,40 @@
+package geoip
+
+import (
+	"net"
+
+	"github.com/authelia/authelia/v4/internal/model"
+)
+
+// Provider is the interface used to resolve the country and network owner of an IP address.
+type Provider interface {
+	model.StartupCheck
+
+	Lookup(ip net.IP) (record Record, err error)
+}
+
+// Record represents the result of a GeoIP lookup.
+type Record struct {
+	// Country is the ISO 3166-1 alpha-2 country code, or empty if it's unknown.
+	Country string
+
+	// ASN is the Autonomous System Number, or 0 if it's unknown.
+	ASN uint
+
+	// Organization is the name of the organization which owns the Autonomous System.
+	Organization string
+}
+
+type mmdbCountryRecord struct {
+	Country struct {
+		ISOCode string `maxminddb:"iso_code"`
+	} `maxminddb:"country"`
+	RegisteredCountry struct {
+		ISOCode string `maxminddb:"iso_code"`
+	} `maxminddb:"registered_country"`
+}
+
+type mmdbASNRecord struct {
+	AutonomousSystemNumber       uint   `maxminddb:"autonomous_system_number"`
+	AutonomousSystemOrganization string `maxminddb:"autonomous_system_organization"`
+}
@@ -1,7 +1,12 @@
 package handlers
 
 import (
+	"time"
+
 	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/regulation"
 )
 
 const (
@@ -26,17 +31,34 @@ var (
 	headerProxyAuthorization = []byte(fasthttp.HeaderProxyAuthorization)
 	headerProxyAuthenticate  = []byte(fasthttp.HeaderProxyAuthenticate)
 
+	headerCookie = []byte(fasthttp.HeaderCookie)
+
 	headerSessionUsername = []byte("Session-Username")
 	headerRemoteUser      = []byte("Remote-User")
 	headerRemoteGroups    = []byte("Remote-Groups")
 	headerRemoteName      = []byte("Remote-Name")
 	headerRemoteEmail     = []byte("Remote-Email")
+
+	headerXRequestID = []byte("X-Request-Id")
+
+	authzObjectHeadersExcluded = [][]byte{headerAuthorization, headerProxyAuthorization, headerCookie}
+
+	authzIdentityHeadersDefault = []schema.AuthzIdentityHeader{
+		{Name: string(headerRemoteUser), Value: "{{ .Username }}"},
+		{Name: string(headerRemoteGroups), Value: `{{ join "," .Groups }}`},
+		{Name: string(headerRemoteName), Value: "{{ .DisplayName }}"},
+		{Name: string(headerRemoteEmail), Value: "{{ .Email }}"},
+	}
 )
 
 const (
 	headerAuthorizationSchemeBasic = "basic"
 )
 
+const (
+	identityAssertionTokenType = "JWT"
+)
+
 var (
 	headerValueAuthenticateBasic = []byte(`Basic realm="Authorization Required"`)
 )
@@ -49,13 +71,40 @@ const (
 	queryArgConsentID  = "consent_id"
 	queryArgWorkflow   = "workflow"
 	queryArgWorkflowID = "workflow_id"
+	queryArgToken      = "token"
+)
+
+const (
+	pathLogout = "/api/logout"
+
+	// logoutTokenAudience is the audience of the tokens which carry a global logout between the cookie domains.
+	logoutTokenAudience = "logout"
+
+	// logoutTokenLifespan is how long the user agent has to complete the global logout of all cookie domains.
+	logoutTokenLifespan = time.Minute * 5
+)
+
+const (
+	sessionBindingMismatchIP             = "ip"
+	sessionBindingMismatchUserAgent      = "user_agent"
+	sessionBindingMismatchIPAndUserAgent = "ip_and_user_agent"
+)
+
+const (
+	// UserValueKeySessionID is the router user value key of the public identifier of a session.
+	UserValueKeySessionID = "session_id"
+
+	// UserValueKeyUsername is the router user value key of the username of a user.
+	UserValueKeyUsername = "username"
 )
 
 var (
 	qryArgID        = []byte(queryArgID)
 	qryArgRD        = []byte(queryArgRD)
+	qryArgRM        = []byte(queryArgRM)
 	qryArgAuth      = []byte(queryArgAuth)
 	qryArgConsentID = []byte(queryArgConsentID)
+	qryArgToken     = []byte(queryArgToken)
 )
 
 var (
@@ -77,6 +126,13 @@ const (
 	workflowOpenIDConnect = "openid_connect"
 )
 
+// secondFactorCategories are the event categories of the second factor methods keyed by the authentication type.
+var secondFactorCategories = map[string]string{
+	regulation.AuthTypeTOTP:     "Time-based One-Time Password",
+	regulation.AuthTypeWebAuthn: "WebAuthn Credential",
+	regulation.AuthTypeDuo:      "Duo",
+}
+
 const (
 	logFmtErrParseRequestBody     = "Failed to parse %s request body: %+v"
 	logFmtErrWriteResponseBody    = "Failed to write %s response body for user '%s': %+v"
@@ -32,6 +32,7 @@ const (
 	testInactivity           = time.Second * 10
 	testRedirectionURLString = "https://www.example.com"
 	testUsername             = "john"
+	testJWTSecret            = "abc"
 	exampleDotCom            = "example.com"
 )
 
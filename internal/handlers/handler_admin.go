@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/oidc"
+	"github.com/authelia/authelia/v4/internal/session"
+)
+
+// AdminUserSessionsDELETE revokes every session of a user across all cookie domains along with the OpenID Connect 1.0
+// access tokens and refresh tokens issued to the user.
+func AdminUserSessionsDELETE(ctx *middlewares.AutheliaCtx) {
+	var (
+		userSession session.UserSession
+		response    AdminUserSessionsRevokeResponse
+		err         error
+	)
+
+	if userSession, err = ctx.GetSession(); err != nil {
+		ctx.Logger.WithError(err).Error("Error occurred retrieving user session")
+
+		ctx.ReplyForbidden()
+
+		return
+	}
+
+	username, _ := ctx.UserValue(UserValueKeyUsername).(string)
+
+	if username == "" {
+		ctx.SetStatusCode(fasthttp.StatusBadRequest)
+		ctx.SetJSONError(messageOperationFailed)
+
+		return
+	}
+
+	if response.Sessions, err = ctx.Providers.SessionProvider.RevokeAllUserSessions(username, ctx.Clock.Now()); err != nil {
+		ctx.Error(err, messageOperationFailed)
+
+		return
+	}
+
+	if response.Tokens, err = oidc.RevokeUserTokens(ctx, ctx.Providers.StorageProvider, username); err != nil {
+		ctx.Error(err, messageOperationFailed)
+
+		return
+	}
+
+	ctx.Logger.WithFields(map[string]any{"username": username, "admin": userSession.Username, "sessions": response.Sessions, "tokens": response.Tokens}).
+		Info("All sessions and tokens of the user were revoked by an administrator")
+
+	if err = ctx.SetJSONBody(response); err != nil {
+		ctx.Logger.WithError(err).Error("Error occurred trying to set admin user sessions revoke response in body")
+	}
+}
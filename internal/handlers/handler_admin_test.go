@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+func TestAdminUserSessionsDELETE(t *testing.T) {
+	mock := mocks.NewMockAutheliaCtx(t)
+	mock.Ctx.Clock = &mock.Clock
+
+	defer mock.Close()
+
+	provider, err := mock.Ctx.GetSessionProvider()
+	require.NoError(t, err)
+
+	for _, userAgent := range []string{"laptop", "phone"} {
+		ctx := &fasthttp.RequestCtx{}
+		ctx.Request.Header.SetUserAgent(userAgent)
+
+		userSession, err := provider.GetSession(ctx)
+		require.NoError(t, err)
+
+		userSession.Username = "harry"
+		userSession.AuthenticationLevel = authentication.OneFactor
+		userSession.FirstFactorAuthnTimestamp = mock.Clock.Now().Unix()
+
+		require.NoError(t, provider.SaveIndexedSession(ctx, userSession, mock.Clock.Now(), mock.Ctx.RemoteIP()))
+	}
+
+	userSession, err := mock.Ctx.GetSession()
+	require.NoError(t, err)
+
+	userSession.Username = testUsername
+	userSession.AuthenticationLevel = authentication.TwoFactor
+
+	require.NoError(t, mock.Ctx.SaveSession(userSession))
+
+	gomock.InOrder(
+		mock.StorageMock.EXPECT().
+			LoadOAuth2SessionSignaturesByUsername(mock.Ctx, storage.OAuth2SessionTypeAccessToken, "harry").
+			Return([]string{"at_example"}, nil),
+		mock.StorageMock.EXPECT().
+			RevokeOAuth2Session(mock.Ctx, storage.OAuth2SessionTypeAccessToken, "at_example").
+			Return(nil),
+		mock.StorageMock.EXPECT().
+			LoadOAuth2SessionSignaturesByUsername(mock.Ctx, storage.OAuth2SessionTypeRefreshToken, "harry").
+			Return(nil, nil),
+	)
+
+	mock.Ctx.SetUserValue(UserValueKeyUsername, "harry")
+
+	AdminUserSessionsDELETE(mock.Ctx)
+
+	assert.Equal(t, fasthttp.StatusOK, mock.Ctx.Response.StatusCode())
+	assert.Equal(t, `{"status":"OK","data":{"sessions":2,"tokens":1}}`, string(mock.Ctx.Response.Body()))
+
+	records, err := mock.Ctx.Providers.SessionProvider.GetUserSessions("harry", mock.Clock.Now())
+	require.NoError(t, err)
+	assert.Len(t, records, 0)
+
+	revoked, err := provider.IsRevoked(userSession)
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	userSession.Username = "harry"
+
+	revoked, err = provider.IsRevoked(userSession)
+	require.NoError(t, err)
+	assert.True(t, revoked)
+}
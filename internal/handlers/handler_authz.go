@@ -1,13 +1,22 @@
 package handlers
 
 import (
+	"bytes"
 	"fmt"
 	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ory/fosite/token/jwt"
+	"github.com/valyala/fasthttp"
 
 	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/authorization"
 	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/oidc"
 	"github.com/authelia/authelia/v4/internal/session"
+	"github.com/authelia/authelia/v4/internal/templates"
 	"github.com/authelia/authelia/v4/internal/utils"
 )
 
@@ -75,19 +84,37 @@ func (authz *Authz) Handler(ctx *middlewares.AutheliaCtx) {
 	authn.Object = object
 	authn.Method = friendlyMethod(authn.Object.Method)
 
-	ruleHasSubject, required := ctx.Providers.Authorizer.GetRequiredLevel(
+	record := ctx.RemoteGeoIP()
+
+	rule, ruleHasSubject, required := ctx.Providers.Authorizer.GetRequiredPolicy(
 		authorization.Subject{
-			Username: authn.Details.Username,
-			Groups:   authn.Details.Groups,
-			IP:       ctx.RemoteIP(),
+			Username:    authn.Details.Username,
+			DisplayName: authn.Details.DisplayName,
+			Groups:      authn.Details.Groups,
+			Emails:      authn.Details.Emails,
+			IP:          ctx.RemoteIP(),
+			Country:     record.Country,
+			ASN:         record.ASN,
+			Attributes:  authn.Details.Extra,
 		},
 		object,
 	)
 
+	if rule != nil {
+		authz.handleMaxAuthenticationAge(ctx, &authn, rule)
+	}
+
+	if header, found := authz.getClientIdentityHeader(ctx, rule); found {
+		ctx.Logger.Warnf("Access to '%s' is forbidden to user '%s' as the request contains the identity header '%s' which may be a sign of an attempt to impersonate a user", object.URL.String(), authn.Username, header)
+		authz.handleForbidden(ctx, &authn, rule, required)
+
+		return
+	}
+
 	switch isAuthzResult(authn.Level, required, ruleHasSubject) {
 	case AuthzResultForbidden:
 		ctx.Logger.Infof("Access to '%s' is forbidden to user '%s'", object.URL.String(), authn.Username)
-		ctx.ReplyForbidden()
+		authz.handleForbidden(ctx, &authn, rule, required)
 	case AuthzResultUnauthorized:
 		var handler HandlerAuthzUnauthorized
 
@@ -97,10 +124,324 @@ func (authz *Authz) Handler(ctx *middlewares.AutheliaCtx) {
 			handler = authz.handleUnauthorized
 		}
 
-		handler(ctx, &authn, authz.getRedirectionURL(&object, autheliaURL))
+		authz.handleUnauthorizedResponse(ctx, &authn, rule, required, handler, authz.getRedirectionURL(&object, autheliaURL))
 	case AuthzResultAuthorized:
 		authz.handleAuthorized(ctx, &authn)
+		authz.handleIdentityHeaders(ctx, &authn, rule)
+
+		if err = authz.handleIdentityAssertion(ctx, &authn, rule, autheliaURL); err != nil {
+			ctx.Logger.WithError(err).WithField("username", authn.Username).Errorf("Access to '%s' is denied as an error occurred generating the identity assertion", object.URL.String())
+			ctx.ReplyStatusCode(fasthttp.StatusInternalServerError)
+		}
+	}
+}
+
+// handleIdentityAssertion sets the signed JWT which asserts the identity of the user in an authorized response when
+// enabled. The header is only set for anonymous users when the client identity headers are stripped, in which case
+// it's empty.
+func (authz *Authz) handleIdentityAssertion(ctx *middlewares.AutheliaCtx, authn *Authn, rule *authorization.AccessControlRule, autheliaURL *url.URL) (err error) {
+	if !authz.config.IdentityAssertion.Enabled {
+		return nil
+	}
+
+	if authn.Details.Username == "" {
+		if authz.config.ClientIdentityHeaders == ClientIdentityHeadersStrip {
+			ctx.Response.Header.Set(authz.config.IdentityAssertion.Header, "")
+		}
+
+		return nil
+	}
+
+	if ctx.Providers.OpenIDConnect == nil || ctx.Providers.OpenIDConnect.KeyManager == nil {
+		return fmt.Errorf("the openid connect 1.0 provider is not configured")
+	}
+
+	var jwk *oidc.JWK
+
+	if jwk = ctx.Providers.OpenIDConnect.KeyManager.GetByKID(ctx, authz.config.IdentityAssertion.KeyID); jwk == nil {
+		return fmt.Errorf("the key with id '%s' could not be found", authz.config.IdentityAssertion.KeyID)
+	}
+
+	var jti uuid.UUID
+
+	if jti, err = uuid.NewRandom(); err != nil {
+		return fmt.Errorf("failed to generate jti: %w", err)
+	}
+
+	audience, lifespan := authz.getIdentityAssertionOptions(authn, rule)
+
+	now := ctx.Clock.Now().UTC()
+
+	authTime := authn.FirstFactorAuthnTime
+
+	if authn.SecondFactorAuthnTime.After(authTime) {
+		authTime = authn.SecondFactorAuthnTime
+	}
+
+	claims := jwt.MapClaims{
+		oidc.ClaimJWTID:                          jti.String(),
+		oidc.ClaimSubject:                        authn.Details.Username,
+		oidc.ClaimAudience:                       audience,
+		oidc.ClaimIssuedAt:                       now.Unix(),
+		oidc.ClaimNotBefore:                      now.Unix(),
+		oidc.ClaimExpirationTime:                 now.Add(lifespan).Unix(),
+		oidc.ClaimAuthenticationTime:             authTime.Unix(),
+		oidc.ClaimAuthenticationMethodsReference: authn.AuthenticationMethodRefs.MarshalRFC8176(),
+		oidc.ClaimPreferredUsername:              authn.Details.Username,
+		oidc.ClaimGroups:                         authn.Details.Groups,
+		oidc.ClaimFullName:                       authn.Details.DisplayName,
+	}
+
+	if autheliaURL != nil {
+		claims[oidc.ClaimIssuer] = strings.TrimSuffix(autheliaURL.String(), "/")
+	}
+
+	if len(authn.Details.Emails) != 0 {
+		claims[oidc.ClaimPreferredEmail] = authn.Details.Emails[0]
+	}
+
+	headers := &jwt.Headers{
+		Extra: map[string]any{
+			oidc.JWTHeaderKeyIdentifier: jwk.KeyID(),
+			oidc.JWTHeaderKeyType:       identityAssertionTokenType,
+		},
+	}
+
+	var token string
+
+	if token, _, err = jwk.Strategy().Generate(ctx, claims, headers); err != nil {
+		return fmt.Errorf("failed to sign the identity assertion with the key with id '%s': %w", jwk.KeyID(), err)
+	}
+
+	ctx.Response.Header.Set(authz.config.IdentityAssertion.Header, token)
+
+	return nil
+}
+
+// getIdentityAssertionOptions returns the audience and lifespan of the identity assertion. The options of the matched
+// rule take precedence over the options of the endpoint, and the audience defaults to the origin of the requested URL.
+func (authz *Authz) getIdentityAssertionOptions(authn *Authn, rule *authorization.AccessControlRule) (audience []string, lifespan time.Duration) {
+	audience, lifespan = authz.config.IdentityAssertion.Audience, authz.config.IdentityAssertion.Lifespan
+
+	if rule != nil {
+		if len(rule.IdentityAssertion.Audience) != 0 {
+			audience = rule.IdentityAssertion.Audience
+		}
+
+		if rule.IdentityAssertion.Lifespan > 0 {
+			lifespan = rule.IdentityAssertion.Lifespan
+		}
+	}
+
+	if len(audience) == 0 && authn.Object.URL != nil {
+		audience = []string{(&url.URL{Scheme: authn.Object.URL.Scheme, Host: authn.Object.URL.Host}).String()}
+	}
+
+	return audience, lifespan
+}
+
+// handleIdentityHeaders sets the identity headers of the endpoint and the matched rule in an authorized response. The
+// headers are only set for anonymous users when the client identity headers are stripped, in which case they're empty.
+func (authz *Authz) handleIdentityHeaders(ctx *middlewares.AutheliaCtx, authn *Authn, rule *authorization.AccessControlRule) {
+	isAnonymous := authn.Details.Username == ""
+
+	if isAnonymous && authz.config.ClientIdentityHeaders != ClientIdentityHeadersStrip {
+		return
+	}
+
+	var (
+		data  = authorization.NewIdentityHeaderData(authn.Details)
+		value string
+		err   error
+	)
+
+	for _, header := range authz.getIdentityHeaders(rule) {
+		if isAnonymous {
+			ctx.Response.Header.Set(header.Name, "")
+
+			continue
+		}
+
+		if value, err = header.Render(data); err != nil {
+			ctx.Logger.WithError(err).WithField("username", authn.Username).Error("Error occurred rendering an identity header, the header will be sent with an empty value")
+		}
+
+		ctx.Response.Header.Set(header.Name, value)
+	}
+}
+
+// getIdentityHeaders returns the identity headers of the endpoint combined with the identity headers of the matched
+// rule. The headers of the rule take precedence over the headers of the endpoint with the same name.
+func (authz *Authz) getIdentityHeaders(rule *authorization.AccessControlRule) (headers []authorization.IdentityHeader) {
+	if rule == nil || len(rule.IdentityHeaders) == 0 {
+		return authz.config.IdentityHeaders
+	}
+
+	headers = make([]authorization.IdentityHeader, 0, len(authz.config.IdentityHeaders)+len(rule.IdentityHeaders))
+
+ENDPOINT:
+	for _, header := range authz.config.IdentityHeaders {
+		for _, ruleHeader := range rule.IdentityHeaders {
+			if header.Name == ruleHeader.Name {
+				continue ENDPOINT
+			}
+		}
+
+		headers = append(headers, header)
+	}
+
+	return append(headers, rule.IdentityHeaders...)
+}
+
+// getClientIdentityHeader returns the name of the first identity header present in the request when the client
+// identity headers are forbidden.
+func (authz *Authz) getClientIdentityHeader(ctx *middlewares.AutheliaCtx, rule *authorization.AccessControlRule) (name string, found bool) {
+	if authz.config.ClientIdentityHeaders != ClientIdentityHeadersForbid {
+		return "", false
+	}
+
+	for _, header := range authz.getIdentityHeaders(rule) {
+		if ctx.Request.Header.Peek(header.Name) != nil {
+			return header.Name, true
+		}
+	}
+
+	if authz.config.IdentityAssertion.Enabled && ctx.Request.Header.Peek(authz.config.IdentityAssertion.Header) != nil {
+		return authz.config.IdentityAssertion.Header, true
+	}
+
+	return "", false
+}
+
+// handleForbidden replies to a request which is forbidden using the customized forbidden response of the matched rule
+// or the endpoint. A bare 403 Forbidden is sent when neither is configured.
+func (authz *Authz) handleForbidden(ctx *middlewares.AutheliaCtx, authn *Authn, rule *authorization.AccessControlRule, required authorization.Level) {
+	response := authz.getResponse(rule, true)
+
+	switch {
+	case response.RedirectURL != nil:
+		ctx.SpecialRedirect(response.RedirectURL.String(), response.StatusCode)
+	case response.StatusCode != 0:
+		ctx.ReplyStatusCode(response.StatusCode)
+	default:
+		ctx.ReplyForbidden()
+	}
+
+	authz.handleResponseTemplate(ctx, authn, rule, required, response)
+}
+
+// handleUnauthorizedResponse replies to a request which requires the user to authenticate using the handler of the
+// implementation or strategy, then applies the customized unauthorized response of the matched rule or the endpoint.
+func (authz *Authz) handleUnauthorizedResponse(ctx *middlewares.AutheliaCtx, authn *Authn, rule *authorization.AccessControlRule, required authorization.Level, handler HandlerAuthzUnauthorized, redirectionURL *url.URL) {
+	response := authz.getResponse(rule, false)
+
+	if response.RedirectURL != nil {
+		redirectionURL = response.RedirectURL
+	}
+
+	handler(ctx, authn, redirectionURL)
+
+	if response.StatusCode != 0 {
+		ctx.SetStatusCode(response.StatusCode)
+	}
+
+	authz.handleResponseTemplate(ctx, authn, rule, required, response)
+}
+
+// getResponse returns the customized forbidden or unauthorized response with the options of the rule taking
+// precedence over the options of the endpoint.
+func (authz *Authz) getResponse(rule *authorization.AccessControlRule, forbidden bool) (response authorization.AccessControlResponse) {
+	if forbidden {
+		response = authz.config.Responses.Forbidden
+
+		if rule != nil {
+			response = rule.Responses.Forbidden.Merge(response)
+		}
+	} else {
+		response = authz.config.Responses.Unauthorized
+
+		if rule != nil {
+			response = rule.Responses.Unauthorized.Merge(response)
+		}
+	}
+
+	return response
+}
+
+// handleResponseTemplate replaces the body of a denied response with the rendered template of the customized response
+// when configured. The request ID included in the template values is also sent in the response and logged so the
+// user can quote it to support.
+func (authz *Authz) handleResponseTemplate(ctx *middlewares.AutheliaCtx, authn *Authn, rule *authorization.AccessControlRule, required authorization.Level, response authorization.AccessControlResponse) {
+	if response.Template == "" {
+		return
 	}
+
+	var (
+		t   *templates.AuthzResponseTemplate
+		err error
+	)
+
+	if t, err = ctx.Providers.Templates.GetAuthzResponseTemplate(response.Template); err != nil {
+		ctx.Logger.WithError(err).Error("Error occurred loading the authz response template")
+
+		return
+	}
+
+	statusCode := ctx.Response.StatusCode()
+
+	values := templates.AuthzResponseValues{
+		StatusCode: statusCode,
+		StatusText: fasthttp.StatusMessage(statusCode),
+		RequestID:  getAuthzRequestID(ctx),
+		TargetURL:  authn.Object.URL.String(),
+		Method:     authn.Method,
+		Username:   authn.Username,
+		RemoteIP:   ctx.RemoteIP().String(),
+		Rule:       templates.AuthzResponseRuleValues{Policy: required.String()},
+	}
+
+	if rule != nil {
+		values.Rule.Position = rule.Position
+	}
+
+	if location := ctx.Response.Header.Peek(fasthttp.HeaderLocation); len(location) != 0 {
+		values.RedirectURL = string(location)
+	}
+
+	buf := &bytes.Buffer{}
+
+	if err = t.Template.Execute(buf, values); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred rendering the authz response template '%s'", response.Template)
+
+		return
+	}
+
+	ctx.Logger.WithField("request_id", values.RequestID).Infof("Access to '%s' is denied to user '%s' with the templated response '%s' and status code %d", values.TargetURL, authn.Username, response.Template, statusCode)
+
+	ctx.Response.Header.SetBytesK(headerXRequestID, values.RequestID)
+	ctx.SetContentType(t.ContentType)
+	ctx.SetBody(buf.Bytes())
+}
+
+// handleMaxAuthenticationAge lowers the authentication level of the Authn for this request when the matched rule has
+// a maximum authentication age which has been exceeded. The level of the session is not modified as it may be shared
+// with other domains, instead the portal applies the same rule to the redirection URL to determine the factors which
+// must be performed again.
+func (authz *Authz) handleMaxAuthenticationAge(ctx *middlewares.AutheliaCtx, authn *Authn, rule *authorization.AccessControlRule) {
+	if rule.MaxAuthenticationAge.IsZero() || authn.Level == authentication.NotAuthenticated {
+		return
+	}
+
+	level := rule.MaxAuthenticationAge.EffectiveLevel(ctx.Clock.Now(), authn.Level, authn.FirstFactorAuthnTime, authn.SecondFactorAuthnTime)
+
+	if level == authn.Level {
+		return
+	}
+
+	ctx.Logger.WithFields(map[string]any{"username": authn.Username, "rule": rule.Position, "level": authn.Level.String()}).
+		Infof("Access to '%s' requires the user to authenticate again as the maximum authentication age of the rule has been exceeded", authn.Object.URL.String())
+
+	authn.Level = level
 }
 
 func (authz *Authz) getAutheliaURL(ctx *middlewares.AutheliaCtx, provider *session.Session) (autheliaURL *url.URL, err error) {
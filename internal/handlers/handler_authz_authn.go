@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"maps"
 	"net/url"
 	"strings"
 	"time"
@@ -101,9 +102,17 @@ func (s *CookieSessionAuthnStrategy) Get(ctx *middlewares.AutheliaCtx, provider
 		}
 	}
 
-	if invalid := handleVerifyGETAuthnCookieValidate(ctx, provider, &userSession, s.refresh); invalid {
-		if err = ctx.DestroySession(); err != nil {
-			ctx.Logger.WithError(err).Errorf("Unable to destroy user session")
+	invalid, destroy := handleVerifyGETAuthnCookieValidate(ctx, provider, &userSession, s.refresh), true
+
+	if !invalid {
+		invalid, destroy = handleVerifyGETAuthnCookieValidateBinding(ctx, &userSession)
+	}
+
+	if invalid {
+		if destroy {
+			if err = ctx.DestroySession(); err != nil {
+				ctx.Logger.WithError(err).Errorf("Unable to destroy user session")
+			}
 		}
 
 		userSession = provider.NewDefaultUserSession()
@@ -116,7 +125,7 @@ func (s *CookieSessionAuthnStrategy) Get(ctx *middlewares.AutheliaCtx, provider
 		return authn, nil
 	}
 
-	if err = provider.SaveSession(ctx.RequestCtx, userSession); err != nil {
+	if err = provider.SaveIndexedSession(ctx.RequestCtx, userSession, ctx.Clock.Now(), ctx.RemoteIP()); err != nil {
 		ctx.Logger.WithError(err).Error("Unable to save updated user session")
 	}
 
@@ -127,9 +136,15 @@ func (s *CookieSessionAuthnStrategy) Get(ctx *middlewares.AutheliaCtx, provider
 			DisplayName: userSession.DisplayName,
 			Emails:      userSession.Emails,
 			Groups:      userSession.Groups,
+			Extra:       userSession.Extra,
 		},
 		Level: userSession.AuthenticationLevel,
 		Type:  AuthnTypeCookie,
+
+		FirstFactorAuthnTime:  time.Unix(userSession.FirstFactorAuthnTimestamp, 0).UTC(),
+		SecondFactorAuthnTime: time.Unix(userSession.SecondFactorAuthnTimestamp, 0).UTC(),
+
+		AuthenticationMethodRefs: userSession.AuthenticationMethodRefs,
 	}, nil
 }
 
@@ -202,6 +217,8 @@ func (s *HeaderAuthnStrategy) Get(ctx *middlewares.AutheliaCtx, _ *session.Sessi
 	authn.Username = friendlyUsername(details.Username)
 	authn.Details = *details
 	authn.Level = authentication.OneFactor
+	authn.FirstFactorAuthnTime = ctx.Clock.Now()
+	authn.AuthenticationMethodRefs.UsernameAndPassword = true
 
 	return authn, nil
 }
@@ -288,6 +305,8 @@ func (s *HeaderLegacyAuthnStrategy) Get(ctx *middlewares.AutheliaCtx, _ *session
 	authn.Username = friendlyUsername(details.Username)
 	authn.Details = *details
 	authn.Level = authentication.OneFactor
+	authn.FirstFactorAuthnTime = ctx.Clock.Now()
+	authn.AuthenticationMethodRefs.UsernameAndPassword = true
 
 	return authn, nil
 }
@@ -317,6 +336,12 @@ func handleVerifyGETAuthnCookieValidate(ctx *middlewares.AutheliaCtx, provider *
 		return true
 	}
 
+	if invalid = handleVerifyGETAuthnCookieValidateRevoked(ctx, provider, userSession); invalid {
+		ctx.Logger.WithField("username", userSession.Username).Info("Session for user has been revoked")
+
+		return true
+	}
+
 	if invalid = handleVerifyGETAuthnCookieValidateRefresh(ctx, userSession, isAnonymous, refresh); invalid {
 		return true
 	}
@@ -344,6 +369,81 @@ func handleVerifyGETAuthnCookieValidateInactivity(ctx *middlewares.AutheliaCtx,
 	return time.Unix(userSession.LastActivity, 0).Add(provider.Config.Inactivity).Before(ctx.Clock.Now())
 }
 
+func handleVerifyGETAuthnCookieValidateRevoked(ctx *middlewares.AutheliaCtx, provider *session.Session, userSession *session.UserSession) (invalid bool) {
+	revoked, err := provider.IsRevoked(*userSession)
+	if err != nil {
+		ctx.Logger.WithError(err).WithField("username", userSession.Username).Error("Error occurred while checking if the sessions of the user have been revoked")
+
+		return false
+	}
+
+	return revoked
+}
+
+func handleVerifyGETAuthnCookieValidateBinding(ctx *middlewares.AutheliaCtx, userSession *session.UserSession) (invalid, destroy bool) {
+	config := &ctx.Configuration.Session.Binding
+
+	if !config.Enabled || userSession.IsAnonymous() {
+		return false, false
+	}
+
+	current := session.NewUserSessionBinding(config, ctx.RemoteIP(), string(ctx.UserAgent()))
+
+	if userSession.Binding == nil {
+		// The session can't be verified against the client which established it, so it's bound to the first client which
+		// presents it. This is logged as a warning as the first client may not be the one which established the session.
+		ctx.Logger.WithFields(map[string]any{
+			"event":              "session_binding_established",
+			"username":           userSession.Username,
+			"request_ip_prefix":  current.IPPrefix,
+			"request_user_agent": current.UserAgent,
+		}).Warn("Session for user was established before session binding was enabled and has been bound to the client which presented it")
+
+		userSession.Binding = current
+
+		return false, false
+	}
+
+	mismatch, ip, userAgent := userSession.Binding.Mismatch(config, current)
+
+	if !mismatch {
+		return false, false
+	}
+
+	var reason string
+
+	switch {
+	case ip && userAgent:
+		reason = sessionBindingMismatchIPAndUserAgent
+	case ip:
+		reason = sessionBindingMismatchIP
+	default:
+		reason = sessionBindingMismatchUserAgent
+	}
+
+	ctx.Logger.WithFields(map[string]any{
+		"event":              "session_binding_mismatch",
+		"username":           userSession.Username,
+		"action":             config.Action,
+		"mismatch":           reason,
+		"bound_ip_prefix":    userSession.Binding.IPPrefix,
+		"bound_user_agent":   userSession.Binding.UserAgent,
+		"request_ip_prefix":  current.IPPrefix,
+		"request_user_agent": current.UserAgent,
+	}).Warn("Session for user does not match the client it was bound to which could be a sign of a cookie hijack")
+
+	ctx.RecordSessionBindingMismatch(config.Action, reason)
+
+	switch config.Action {
+	case schema.SessionBindingActionReauthenticate:
+		return true, false
+	case schema.SessionBindingActionDestroy:
+		return true, true
+	default:
+		return false, false
+	}
+}
+
 func handleVerifyGETAuthnCookieValidateRefresh(ctx *middlewares.AutheliaCtx, userSession *session.UserSession, isAnonymous bool, refresh schema.RefreshIntervalDuration) (invalid bool) {
 	if refresh.Never() || isAnonymous {
 		return false
@@ -375,17 +475,18 @@ func handleVerifyGETAuthnCookieValidateRefresh(ctx *middlewares.AutheliaCtx, use
 	}
 
 	var (
-		diffEmails, diffGroups, diffDisplayName bool
+		diffEmails, diffGroups, diffDisplayName, diffExtra bool
 	)
 
 	diffEmails, diffGroups = utils.IsStringSlicesDifferent(userSession.Emails, details.Emails), utils.IsStringSlicesDifferent(userSession.Groups, details.Groups)
 	diffDisplayName = userSession.DisplayName != details.DisplayName
+	diffExtra = !maps.Equal(userSession.Extra, details.Extra)
 
 	if !refresh.Always() {
 		userSession.RefreshTTL = ctx.Clock.Now().Add(refresh.Value())
 	}
 
-	if !diffEmails && !diffGroups && !diffDisplayName {
+	if !diffEmails && !diffGroups && !diffDisplayName && !diffExtra {
 		ctx.Logger.WithField("username", userSession.Username).Trace("Updated profile not detected for user")
 
 		return false
@@ -397,7 +498,7 @@ func handleVerifyGETAuthnCookieValidateRefresh(ctx *middlewares.AutheliaCtx, use
 		generateVerifySessionHasUpToDateProfileTraceLogs(ctx, userSession, details)
 	}
 
-	userSession.Emails, userSession.Groups, userSession.DisplayName = details.Emails, details.Groups, details.DisplayName
+	userSession.Emails, userSession.Groups, userSession.DisplayName, userSession.Extra = details.Emails, details.Groups, details.DisplayName, details.Extra
 
 	return false
 }
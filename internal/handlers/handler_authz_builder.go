@@ -1,8 +1,11 @@
 package handlers
 
 import (
+	"fmt"
+
 	"github.com/valyala/fasthttp"
 
+	"github.com/authelia/authelia/v4/internal/authorization"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 )
 
@@ -82,6 +85,10 @@ func (b *AuthzBuilder) WithEndpointConfig(config schema.ServerEndpointsAuthz) *A
 
 	b.WithStrategies()
 
+	b.WithIdentityHeadersConfig(config.IdentityHeaders)
+	b.WithIdentityAssertionConfig(config.IdentityAssertion)
+	b.WithResponsesConfig(config.Responses)
+
 	for _, strategy := range config.AuthnStrategies {
 		switch strategy.Name {
 		case AuthnStrategyCookieSession:
@@ -100,8 +107,44 @@ func (b *AuthzBuilder) WithEndpointConfig(config schema.ServerEndpointsAuthz) *A
 	return b
 }
 
-// Build returns a new Authz from the currently configured options in this builder.
-func (b *AuthzBuilder) Build() (authz *Authz) {
+// WithIdentityHeadersConfig configures the identity headers sent in authorized responses and how copies of them
+// supplied by the client are handled. Should be called AFTER WithConfig. If any of the headers can't be parsed the
+// error is returned by Build.
+func (b *AuthzBuilder) WithIdentityHeadersConfig(config schema.ServerEndpointsAuthzIdentityHeaders) *AuthzBuilder {
+	var err error
+
+	if b.config.IdentityHeaders, err = authorization.NewIdentityHeaders(config.Headers); err != nil {
+		b.err = fmt.Errorf("error occurred configuring the identity headers: %w", err)
+	}
+
+	b.config.ClientIdentityHeaders = config.ClientHeaders
+
+	return b
+}
+
+// WithIdentityAssertionConfig configures the signed JWT which asserts the identity of the user sent in authorized
+// responses. Should be called AFTER WithConfig.
+func (b *AuthzBuilder) WithIdentityAssertionConfig(config schema.ServerEndpointsAuthzIdentityAssertion) *AuthzBuilder {
+	b.config.IdentityAssertion = config
+
+	return b
+}
+
+// WithResponsesConfig configures the customized responses sent when access is denied. Should be called AFTER
+// WithConfig.
+func (b *AuthzBuilder) WithResponsesConfig(config schema.AuthzResponses) *AuthzBuilder {
+	b.config.Responses = authorization.NewAccessControlResponses(config)
+
+	return b
+}
+
+// Build returns a new Authz from the currently configured options in this builder, or the first error which occurred
+// configuring this builder.
+func (b *AuthzBuilder) Build() (authz *Authz, err error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+
 	authz = &Authz{
 		config:           b.config,
 		strategies:       b.strategies,
@@ -111,6 +154,24 @@ func (b *AuthzBuilder) Build() (authz *Authz) {
 
 	authz.config.StatusCodeBadRequest = fasthttp.StatusBadRequest
 
+	if len(authz.config.IdentityHeaders) == 0 {
+		if authz.config.IdentityHeaders, err = authorization.NewIdentityHeaders(authzIdentityHeadersDefault); err != nil {
+			return nil, fmt.Errorf("error occurred configuring the default identity headers: %w", err)
+		}
+	}
+
+	if authz.config.ClientIdentityHeaders == "" {
+		authz.config.ClientIdentityHeaders = ClientIdentityHeadersAllow
+	}
+
+	if authz.config.IdentityAssertion.Header == "" {
+		authz.config.IdentityAssertion.Header = schema.DefaultServerEndpointsAuthzIdentityAssertion.Header
+	}
+
+	if authz.config.IdentityAssertion.Lifespan <= 0 {
+		authz.config.IdentityAssertion.Lifespan = schema.DefaultServerEndpointsAuthzIdentityAssertion.Lifespan
+	}
+
 	if len(authz.strategies) == 0 {
 		switch b.implementation {
 		case AuthzImplLegacy:
@@ -142,5 +203,5 @@ func (b *AuthzBuilder) Build() (authz *Authz) {
 		authz.handleGetAutheliaURL = handleAuthzPortalURLFromHeader
 	}
 
-	return authz
+	return authz, nil
 }
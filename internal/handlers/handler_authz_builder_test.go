@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 )
@@ -91,3 +92,20 @@ func TestAuthzBuilder_WithEndpointConfig(t *testing.T) {
 
 	assert.Len(t, builder.strategies, 5)
 }
+
+func TestAuthzBuilder_WithIdentityHeadersConfig(t *testing.T) {
+	authz, err := NewAuthzBuilder().WithIdentityHeadersConfig(schema.ServerEndpointsAuthzIdentityHeaders{
+		Headers: []schema.AuthzIdentityHeader{{Name: "X-Employee", Value: `{{ index .Extra "employee_id" }}`}},
+	}).Build()
+
+	require.NoError(t, err)
+	require.Len(t, authz.config.IdentityHeaders, 1)
+	assert.Equal(t, "X-Employee", authz.config.IdentityHeaders[0].Name)
+
+	authz, err = NewAuthzBuilder().WithIdentityHeadersConfig(schema.ServerEndpointsAuthzIdentityHeaders{
+		Headers: []schema.AuthzIdentityHeader{{Name: "X-Employee", Value: "{{ .Extra"}},
+	}).Build()
+
+	assert.Nil(t, authz)
+	assert.EqualError(t, err, "error occurred configuring the identity headers: failed to parse the value of the identity header 'X-Employee': template: X-Employee:1: unclosed action")
+}
@@ -3,7 +3,6 @@ package handlers
 import (
 	"fmt"
 	"net/url"
-	"strings"
 
 	"github.com/valyala/fasthttp"
 
@@ -58,21 +57,8 @@ func handleAuthzPortalURLFromQueryLegacy(ctx *middlewares.AutheliaCtx) (portalUR
 	return portalURL, nil
 }
 
-func handleAuthzAuthorizedStandard(ctx *middlewares.AutheliaCtx, authn *Authn) {
+func handleAuthzAuthorizedStandard(ctx *middlewares.AutheliaCtx, _ *Authn) {
 	ctx.ReplyStatusCode(fasthttp.StatusOK)
-
-	if authn.Details.Username != "" {
-		ctx.Response.Header.SetBytesK(headerRemoteUser, authn.Details.Username)
-		ctx.Response.Header.SetBytesK(headerRemoteGroups, strings.Join(authn.Details.Groups, ","))
-		ctx.Response.Header.SetBytesK(headerRemoteName, authn.Details.DisplayName)
-
-		switch len(authn.Details.Emails) {
-		case 0:
-			ctx.Response.Header.SetBytesK(headerRemoteEmail, "")
-		default:
-			ctx.Response.Header.SetBytesK(headerRemoteEmail, authn.Details.Emails[0])
-		}
-	}
 }
 
 func handleAuthzUnauthorizedAuthorizationBasic(ctx *middlewares.AutheliaCtx, authn *Authn) {
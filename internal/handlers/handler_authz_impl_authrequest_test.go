@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/valyala/fasthttp"
 
@@ -45,7 +46,8 @@ func (s *AuthRequestAuthzSuite) TestShouldHandleAllMethodsDeny() {
 				t.Run(pairURI.TargetURI.String(), func(t *testing.T) {
 					expected := s.RequireParseRequestURI(pairURI.AutheliaURI.String())
 
-					authz := s.Builder().Build()
+					authz, err := s.Builder().Build()
+					require.NoError(t, err)
 
 					mock := mocks.NewMockAutheliaCtx(t)
 
@@ -83,7 +85,8 @@ func (s *AuthRequestAuthzSuite) TestShouldHandleInvalidMethodCharsDeny() {
 				s.RequireParseRequestURI("https://bypass.example2.com/subpath"),
 			} {
 				t.Run(targetURI.String(), func(t *testing.T) {
-					authz := s.Builder().Build()
+					authz, err := s.Builder().Build()
+					require.NoError(t, err)
 
 					mock := mocks.NewMockAutheliaCtx(t)
 
@@ -109,7 +112,8 @@ func (s *AuthRequestAuthzSuite) TestShouldHandleMissingXOriginalMethodDeny() {
 		s.RequireParseRequestURI("https://bypass.example2.com/subpath"),
 	} {
 		s.T().Run(targetURI.String(), func(t *testing.T) {
-			authz := s.Builder().Build()
+			authz, err := s.Builder().Build()
+			require.NoError(t, err)
 
 			mock := mocks.NewMockAutheliaCtx(t)
 
@@ -128,7 +132,8 @@ func (s *AuthRequestAuthzSuite) TestShouldHandleMissingXOriginalMethodDeny() {
 func (s *AuthRequestAuthzSuite) TestShouldHandleMissingXOriginalURLDeny() {
 	for _, method := range testRequestMethods {
 		s.T().Run(fmt.Sprintf("OriginalMethod%s", method), func(t *testing.T) {
-			authz := s.Builder().Build()
+			authz, err := s.Builder().Build()
+			require.NoError(t, err)
 
 			mock := mocks.NewMockAutheliaCtx(t)
 
@@ -154,7 +159,8 @@ func (s *AuthRequestAuthzSuite) TestShouldHandleAllMethodsAllow() {
 				s.RequireParseRequestURI("https://bypass.example2.com/subpath"),
 			} {
 				t.Run(targetURI.String(), func(t *testing.T) {
-					authz := s.Builder().Build()
+					authz, err := s.Builder().Build()
+					require.NoError(t, err)
 
 					mock := mocks.NewMockAutheliaCtx(t)
 
@@ -180,7 +186,8 @@ func (s *AuthRequestAuthzSuite) TestShouldHandleAllMethodsWithMethodsACL() {
 			for _, methodACL := range testRequestMethods {
 				targetURI := s.RequireParseRequestURI(fmt.Sprintf("https://bypass-%s.example.com", strings.ToLower(methodACL)))
 				t.Run(targetURI.String(), func(t *testing.T) {
-					authz := s.Builder().Build()
+					authz, err := s.Builder().Build()
+					require.NoError(t, err)
 
 					mock := mocks.NewMockAutheliaCtx(t)
 
@@ -238,14 +245,15 @@ func (s *AuthRequestAuthzSuite) TestShouldHandleInvalidURLForCVE202132637() {
 		s.T().Run(tc.name, func(t *testing.T) {
 			for _, method := range testRequestMethods {
 				t.Run(fmt.Sprintf("OriginalMethod%s", method), func(t *testing.T) {
-					authz := s.Builder().Build()
+					authz, err := s.Builder().Build()
+					require.NoError(t, err)
 
 					mock := mocks.NewMockAutheliaCtx(t)
 
 					defer mock.Close()
 
 					mock.Ctx.Configuration.AccessControl.DefaultPolicy = testBypass
-					mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&mock.Ctx.Configuration)
+					mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&mock.Ctx.Configuration, &mock.Clock)
 
 					s.ConfigureMockSessionProviderWithAutomaticAutheliaURLs(mock)
 
@@ -272,7 +280,8 @@ func (s *AuthRequestAuthzSuite) TestShouldNotHandleExtAuthzAllMethodsAllow() {
 				s.RequireParseRequestURI("https://bypass.example2.com/subpath"),
 			} {
 				t.Run(targetURI.String(), func(t *testing.T) {
-					authz := s.Builder().Build()
+					authz, err := s.Builder().Build()
+					require.NoError(t, err)
 
 					mock := mocks.NewMockAutheliaCtx(t)
 
@@ -304,7 +313,8 @@ func (s *AuthRequestAuthzSuite) TestShouldNotHandleExtAuthzAllMethodsAllowXHR()
 						s.RequireParseRequestURI("https://bypass.example2.com/subpath"),
 					} {
 						t.Run(targetURI.String(), func(t *testing.T) {
-							authz := s.Builder().Build()
+							authz, err := s.Builder().Build()
+							require.NoError(t, err)
 
 							mock := mocks.NewMockAutheliaCtx(t)
 
@@ -332,7 +342,8 @@ func (s *AuthRequestAuthzSuite) TestShouldNotHandleExtAuthzAllMethodsWithMethods
 			for _, methodACL := range testRequestMethods {
 				targetURI := s.RequireParseRequestURI(fmt.Sprintf("https://bypass-%s.example.com", strings.ToLower(methodACL)))
 				t.Run(targetURI.String(), func(t *testing.T) {
-					authz := s.Builder().Build()
+					authz, err := s.Builder().Build()
+					require.NoError(t, err)
 
 					mock := mocks.NewMockAutheliaCtx(t)
 
@@ -362,7 +373,8 @@ func (s *AuthRequestAuthzSuite) TestShouldNotHandleForwardAuthAllMethodsAllow()
 				s.RequireParseRequestURI("https://bypass.example2.com/subpath"),
 			} {
 				t.Run(targetURI.String(), func(t *testing.T) {
-					authz := s.Builder().Build()
+					authz, err := s.Builder().Build()
+					require.NoError(t, err)
 
 					mock := mocks.NewMockAutheliaCtx(t)
 
@@ -394,7 +406,8 @@ func (s *AuthRequestAuthzSuite) TestShouldNotHandleForwardAuthAllMethodsAllowXHR
 						s.RequireParseRequestURI("https://bypass.example2.com/subpath"),
 					} {
 						t.Run(targetURI.String(), func(t *testing.T) {
-							authz := s.Builder().Build()
+							authz, err := s.Builder().Build()
+							require.NoError(t, err)
 
 							mock := mocks.NewMockAutheliaCtx(t)
 
@@ -422,7 +435,8 @@ func (s *AuthRequestAuthzSuite) TestShouldNotHandleForwardAuthAllMethodsWithMeth
 			for _, methodACL := range testRequestMethods {
 				targetURI := s.RequireParseRequestURI(fmt.Sprintf("https://bypass-%s.example.com", strings.ToLower(methodACL)))
 				t.Run(targetURI.String(), func(t *testing.T) {
-					authz := s.Builder().Build()
+					authz, err := s.Builder().Build()
+					require.NoError(t, err)
 
 					mock := mocks.NewMockAutheliaCtx(t)
 
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/valyala/fasthttp"
 
@@ -45,7 +46,8 @@ func (s *ForwardAuthAuthzSuite) TestShouldHandleAllMethodsDeny() {
 				t.Run(pairURI.TargetURI.String(), func(t *testing.T) {
 					expected := s.RequireParseRequestURI(pairURI.AutheliaURI.String())
 
-					authz := s.Builder().Build()
+					authz, err := s.Builder().Build()
+					require.NoError(t, err)
 
 					mock := mocks.NewMockAutheliaCtx(t)
 
@@ -90,7 +92,8 @@ func (s *ForwardAuthAuthzSuite) TestShouldHandleAllMethodsOverrideAutheliaURLDen
 				t.Run(pairURI.TargetURI.String(), func(t *testing.T) {
 					expected := s.RequireParseRequestURI(pairURI.AutheliaURI.String())
 
-					authz := s.Builder().Build()
+					authz, err := s.Builder().Build()
+					require.NoError(t, err)
 
 					mock := mocks.NewMockAutheliaCtx(t)
 
@@ -132,7 +135,8 @@ func (s *ForwardAuthAuthzSuite) TestShouldHandleAllMethodsMissingAutheliaURLDeny
 				s.RequireParseRequestURI("https://bypass.example2.com/subpath"),
 			} {
 				t.Run(targetURI.String(), func(t *testing.T) {
-					authz := s.Builder().Build()
+					authz, err := s.Builder().Build()
+					require.NoError(t, err)
 
 					mock := mocks.NewMockAutheliaCtx(t)
 
@@ -166,7 +170,8 @@ func (s *ForwardAuthAuthzSuite) TestShouldHandleAllMethodsXHRDeny() {
 						t.Run(pairURI.TargetURI.String(), func(t *testing.T) {
 							expected := s.RequireParseRequestURI(pairURI.AutheliaURI.String())
 
-							authz := s.Builder().Build()
+							authz, err := s.Builder().Build()
+							require.NoError(t, err)
 
 							mock := mocks.NewMockAutheliaCtx(t)
 
@@ -206,7 +211,8 @@ func (s *ForwardAuthAuthzSuite) TestShouldHandleInvalidMethodCharsDeny() {
 				s.RequireParseRequestURI("https://bypass.example2.com/subpath"),
 			} {
 				t.Run(targetURI.String(), func(t *testing.T) {
-					authz := s.Builder().Build()
+					authz, err := s.Builder().Build()
+					require.NoError(t, err)
 
 					mock := mocks.NewMockAutheliaCtx(t)
 
@@ -229,7 +235,8 @@ func (s *ForwardAuthAuthzSuite) TestShouldHandleInvalidMethodCharsDeny() {
 func (s *ForwardAuthAuthzSuite) TestShouldHandleMissingHostDeny() {
 	for _, method := range testRequestMethods {
 		s.T().Run(fmt.Sprintf("Method%s", method), func(t *testing.T) {
-			authz := s.Builder().Build()
+			authz, err := s.Builder().Build()
+			require.NoError(t, err)
 
 			mock := mocks.NewMockAutheliaCtx(t)
 
@@ -261,7 +268,8 @@ func (s *ForwardAuthAuthzSuite) TestShouldHandleAllMethodsAllow() {
 				s.RequireParseRequestURI("https://bypass.example2.com/subpath"),
 			} {
 				t.Run(targetURI.String(), func(t *testing.T) {
-					authz := s.Builder().Build()
+					authz, err := s.Builder().Build()
+					require.NoError(t, err)
 
 					mock := mocks.NewMockAutheliaCtx(t)
 
@@ -287,7 +295,8 @@ func (s *ForwardAuthAuthzSuite) TestShouldHandleAllMethodsWithMethodsACL() {
 			for _, methodACL := range testRequestMethods {
 				targetURI := s.RequireParseRequestURI(fmt.Sprintf("https://bypass-%s.example.com", strings.ToLower(methodACL)))
 				t.Run(targetURI.String(), func(t *testing.T) {
-					authz := s.Builder().Build()
+					authz, err := s.Builder().Build()
+					require.NoError(t, err)
 
 					mock := mocks.NewMockAutheliaCtx(t)
 
@@ -340,7 +349,8 @@ func (s *ForwardAuthAuthzSuite) TestShouldHandleAllMethodsAllowXHR() {
 				s.RequireParseRequestURI("https://bypass.example2.com/subpath"),
 			} {
 				t.Run(targetURI.String(), func(t *testing.T) {
-					authz := s.Builder().Build()
+					authz, err := s.Builder().Build()
+					require.NoError(t, err)
 
 					mock := mocks.NewMockAutheliaCtx(t)
 
@@ -381,14 +391,15 @@ func (s *ForwardAuthAuthzSuite) TestShouldHandleInvalidURLForCVE202132637() {
 		s.T().Run(tc.name, func(t *testing.T) {
 			for _, method := range testRequestMethods {
 				t.Run(fmt.Sprintf("Method%s", method), func(t *testing.T) {
-					authz := s.Builder().Build()
+					authz, err := s.Builder().Build()
+					require.NoError(t, err)
 
 					mock := mocks.NewMockAutheliaCtx(t)
 
 					defer mock.Close()
 
 					mock.Ctx.Configuration.AccessControl.DefaultPolicy = testBypass
-					mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&mock.Ctx.Configuration)
+					mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&mock.Ctx.Configuration, &mock.Clock)
 
 					s.ConfigureMockSessionProviderWithAutomaticAutheliaURLs(mock)
 
@@ -418,7 +429,8 @@ func (s *ForwardAuthAuthzSuite) TestShouldNotHandleAuthRequestAllMethodsAllow()
 				s.RequireParseRequestURI("https://bypass.example2.com/subpath"),
 			} {
 				t.Run(targetURI.String(), func(t *testing.T) {
-					authz := s.Builder().Build()
+					authz, err := s.Builder().Build()
+					require.NoError(t, err)
 
 					mock := mocks.NewMockAutheliaCtx(t)
 
@@ -442,7 +454,8 @@ func (s *ForwardAuthAuthzSuite) TestShouldNotHandleAuthRequestAllMethodsWithMeth
 			for _, methodACL := range testRequestMethods {
 				targetURI := s.RequireParseRequestURI(fmt.Sprintf("https://bypass-%s.example.com", strings.ToLower(methodACL)))
 				t.Run(targetURI.String(), func(t *testing.T) {
-					authz := s.Builder().Build()
+					authz, err := s.Builder().Build()
+					require.NoError(t, err)
 
 					mock := mocks.NewMockAutheliaCtx(t)
 
@@ -472,7 +485,8 @@ func (s *ForwardAuthAuthzSuite) TestShouldNotHandleExtAuthzAllMethodsAllow() {
 				s.RequireParseRequestURI("https://bypass.example2.com/subpath"),
 			} {
 				t.Run(targetURI.String(), func(t *testing.T) {
-					authz := s.Builder().Build()
+					authz, err := s.Builder().Build()
+					require.NoError(t, err)
 
 					mock := mocks.NewMockAutheliaCtx(t)
 
@@ -504,7 +518,8 @@ func (s *ForwardAuthAuthzSuite) TestShouldNotHandleExtAuthzAllMethodsAllowXHR()
 						s.RequireParseRequestURI("https://bypass.example2.com/subpath"),
 					} {
 						t.Run(targetURI.String(), func(t *testing.T) {
-							authz := s.Builder().Build()
+							authz, err := s.Builder().Build()
+							require.NoError(t, err)
 
 							mock := mocks.NewMockAutheliaCtx(t)
 
@@ -532,7 +547,8 @@ func (s *ForwardAuthAuthzSuite) TestShouldNotHandleExtAuthzAllMethodsWithMethods
 			for _, methodACL := range testRequestMethods {
 				targetURI := s.RequireParseRequestURI(fmt.Sprintf("https://bypass-%s.example.com", strings.ToLower(methodACL)))
 				t.Run(targetURI.String(), func(t *testing.T) {
-					authz := s.Builder().Build()
+					authz, err := s.Builder().Build()
+					require.NoError(t, err)
 
 					mock := mocks.NewMockAutheliaCtx(t)
 
@@ -9,6 +9,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/valyala/fasthttp"
 
@@ -48,7 +49,8 @@ func (s *LegacyAuthzSuite) TestShouldHandleAllMethodsDeny() {
 				t.Run(pairURI.TargetURI.String(), func(t *testing.T) {
 					expected := s.RequireParseRequestURI(pairURI.AutheliaURI.String())
 
-					authz := s.Builder().Build()
+					authz, err := s.Builder().Build()
+					require.NoError(t, err)
 
 					mock := mocks.NewMockAutheliaCtx(t)
 
@@ -96,7 +98,8 @@ func (s *LegacyAuthzSuite) TestShouldHandleAllMethodsOverrideAutheliaURLDeny() {
 				t.Run(pairURI.TargetURI.String(), func(t *testing.T) {
 					expected := s.RequireParseRequestURI(pairURI.AutheliaURI.String())
 
-					authz := s.Builder().Build()
+					authz, err := s.Builder().Build()
+					require.NoError(t, err)
 
 					mock := mocks.NewMockAutheliaCtx(t)
 
@@ -142,7 +145,8 @@ func (s *LegacyAuthzSuite) TestShouldHandleAllMethodsMissingAutheliaURLBypassSta
 				s.RequireParseRequestURI("https://bypass.example2.com/subpath"),
 			} {
 				t.Run(targetURI.String(), func(t *testing.T) {
-					authz := s.Builder().Build()
+					authz, err := s.Builder().Build()
+					require.NoError(t, err)
 
 					mock := mocks.NewMockAutheliaCtx(t)
 
@@ -174,7 +178,8 @@ func (s *LegacyAuthzSuite) TestShouldHandleAllMethodsMissingAutheliaURLOneFactor
 				s.RequireParseRequestURI("https://one-factor.example2.com/subpath"),
 			} {
 				t.Run(targetURI.String(), func(t *testing.T) {
-					authz := s.Builder().Build()
+					authz, err := s.Builder().Build()
+					require.NoError(t, err)
 
 					mock := mocks.NewMockAutheliaCtx(t)
 
@@ -204,7 +209,8 @@ func (s *LegacyAuthzSuite) TestShouldHandleAllMethodsRDAutheliaURLOneFactorStatu
 				s.RequireParseRequestURI("https://one-factor.example.com/subpath"),
 			} {
 				t.Run(targetURI.String(), func(t *testing.T) {
-					authz := s.Builder().Build()
+					authz, err := s.Builder().Build()
+					require.NoError(t, err)
 
 					mock := mocks.NewMockAutheliaCtx(t)
 
@@ -251,7 +257,8 @@ func (s *LegacyAuthzSuite) TestShouldHandleAllMethodsXHRDeny() {
 						t.Run(pairURI.TargetURI.String(), func(t *testing.T) {
 							expected := s.RequireParseRequestURI(pairURI.AutheliaURI.String())
 
-							authz := s.Builder().Build()
+							authz, err := s.Builder().Build()
+							require.NoError(t, err)
 
 							mock := mocks.NewMockAutheliaCtx(t)
 
@@ -300,7 +307,8 @@ func (s *LegacyAuthzSuite) TestShouldHandleInvalidMethodCharsDeny() {
 				s.RequireParseRequestURI("https://bypass.example2.com/subpath"),
 			} {
 				t.Run(targetURI.String(), func(t *testing.T) {
-					authz := s.Builder().Build()
+					authz, err := s.Builder().Build()
+					require.NoError(t, err)
 
 					mock := mocks.NewMockAutheliaCtx(t)
 
@@ -327,7 +335,8 @@ func (s *LegacyAuthzSuite) TestShouldHandleInvalidMethodCharsDeny() {
 func (s *LegacyAuthzSuite) TestShouldHandleMissingHostDeny() {
 	for _, method := range testRequestMethods {
 		s.T().Run(fmt.Sprintf("Method%s", method), func(t *testing.T) {
-			authz := s.Builder().Build()
+			authz, err := s.Builder().Build()
+			require.NoError(t, err)
 
 			mock := mocks.NewMockAutheliaCtx(t)
 
@@ -359,7 +368,8 @@ func (s *LegacyAuthzSuite) TestShouldHandleAllMethodsAllow() {
 				s.RequireParseRequestURI("https://bypass.example2.com/subpath"),
 			} {
 				t.Run(targetURI.String(), func(t *testing.T) {
-					authz := s.Builder().Build()
+					authz, err := s.Builder().Build()
+					require.NoError(t, err)
 
 					mock := mocks.NewMockAutheliaCtx(t)
 
@@ -389,7 +399,8 @@ func (s *LegacyAuthzSuite) TestShouldHandleAllMethodsWithMethodsACL() {
 			for _, methodACL := range testRequestMethods {
 				targetURI := s.RequireParseRequestURI(fmt.Sprintf("https://bypass-%s.example.com", strings.ToLower(methodACL)))
 				t.Run(targetURI.String(), func(t *testing.T) {
-					authz := s.Builder().Build()
+					authz, err := s.Builder().Build()
+					require.NoError(t, err)
 
 					mock := mocks.NewMockAutheliaCtx(t)
 
@@ -443,7 +454,8 @@ func (s *LegacyAuthzSuite) TestShouldHandleAllMethodsAllowXHR() {
 				s.RequireParseRequestURI("https://bypass.example2.com/subpath"),
 			} {
 				t.Run(targetURI.String(), func(t *testing.T) {
-					authz := s.Builder().Build()
+					authz, err := s.Builder().Build()
+					require.NoError(t, err)
 
 					mock := mocks.NewMockAutheliaCtx(t)
 
@@ -468,7 +480,8 @@ func (s *LegacyAuthzSuite) TestShouldHandleAllMethodsAllowXHR() {
 }
 
 func (s *LegacyAuthzSuite) TestShouldHandleLegacyBasicAuth() { // TestShouldVerifyAuthBasicArgOk.
-	authz := s.Builder().Build()
+	authz, err := s.Builder().Build()
+	s.Require().NoError(err)
 
 	mock := mocks.NewMockAutheliaCtx(s.T())
 
@@ -551,7 +564,8 @@ func (s *LegacyAuthzSuite) TestShouldHandleLegacyBasicAuthFailures() {
 		},
 	}
 
-	authz := s.Builder().Build()
+	authz, err := s.Builder().Build()
+	s.Require().NoError(err)
 
 	for _, tc := range testCases {
 		s.T().Run(tc.name, func(t *testing.T) {
@@ -599,14 +613,15 @@ func (s *LegacyAuthzSuite) TestShouldHandleInvalidURLForCVE202132637() {
 		s.T().Run(tc.name, func(t *testing.T) {
 			for _, method := range testRequestMethods {
 				t.Run(fmt.Sprintf("Method%s", method), func(t *testing.T) {
-					authz := s.Builder().Build()
+					authz, err := s.Builder().Build()
+					require.NoError(t, err)
 
 					mock := mocks.NewMockAutheliaCtx(t)
 
 					defer mock.Close()
 
 					mock.Ctx.Configuration.AccessControl.DefaultPolicy = testBypass
-					mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&mock.Ctx.Configuration)
+					mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&mock.Ctx.Configuration, &mock.Clock)
 
 					s.ConfigureMockSessionProviderWithAutomaticAutheliaURLs(mock)
 
@@ -1,20 +1,31 @@
 package handlers
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
 	"fmt"
+	"net"
 	"net/url"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
+	gojwt "github.com/golang-jwt/jwt/v5"
 	"github.com/golang/mock/gomock"
+	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/valyala/fasthttp"
 
 	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/clock"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/oidc"
 	"github.com/authelia/authelia/v4/internal/session"
 	"github.com/authelia/authelia/v4/internal/utils"
 )
@@ -55,7 +66,7 @@ func (s *AuthzSuite) ConfigureMockSessionProviderWithAutomaticAutheliaURLs(mock
 		mock.Ctx.Configuration.Session.Cookies[i].AutheliaURL = s.RequireParseRequestURI(fmt.Sprintf("https://auth.%s", cookie.Domain))
 	}
 
-	mock.Ctx.Providers.SessionProvider = session.NewProvider(mock.Ctx.Configuration.Session, nil)
+	mock.Ctx.Providers.SessionProvider = session.NewProvider(mock.Ctx.Configuration.Session, nil, nil, nil, clock.New())
 }
 
 func (s *AuthzSuite) Builder() (builder *AuthzBuilder) {
@@ -84,7 +95,8 @@ func (s *AuthzSuite) TestShouldNotBeAbleToParseBasicAuth() {
 		s.T().Skip()
 	}
 
-	authz := s.Builder().Build()
+	authz, err := s.Builder().Build()
+	s.Require().NoError(err)
 
 	mock := mocks.NewMockAutheliaCtx(s.T())
 
@@ -117,7 +129,8 @@ func (s *AuthzSuite) TestShouldApplyDefaultPolicy() {
 		s.T().Skip()
 	}
 
-	authz := s.Builder().Build()
+	authz, err := s.Builder().Build()
+	s.Require().NoError(err)
 
 	mock := mocks.NewMockAutheliaCtx(s.T())
 
@@ -168,7 +181,8 @@ func (s *AuthzSuite) TestShouldDenyObject() {
 		},
 	}
 
-	authz := s.Builder().Build()
+	authz, err := s.Builder().Build()
+	s.Require().NoError(err)
 
 	for _, tc := range testCases {
 		s.T().Run(tc.name, func(t *testing.T) {
@@ -199,7 +213,8 @@ func (s *AuthzSuite) TestShouldApplyPolicyOfBypassDomain() {
 		s.T().Skip()
 	}
 
-	authz := s.Builder().Build()
+	authz, err := s.Builder().Build()
+	s.Require().NoError(err)
 
 	mock := mocks.NewMockAutheliaCtx(s.T())
 
@@ -231,12 +246,70 @@ func (s *AuthzSuite) TestShouldApplyPolicyOfBypassDomain() {
 	s.Equal([]byte(nil), mock.Ctx.Response.Header.Peek(fasthttp.HeaderProxyAuthenticate))
 }
 
+func (s *AuthzSuite) TestShouldApplyPolicyOfHeadersCriteria() {
+	if s.setRequest == nil {
+		s.T().Skip()
+	}
+
+	testCases := []struct {
+		name     string
+		header   string
+		expected int
+	}{
+		{"ShouldBypassWithHeader", "abc", fasthttp.StatusOK},
+		{"ShouldDenyWithIncorrectHeader", "xyz", fasthttp.StatusForbidden},
+		{"ShouldDenyWithoutHeader", "", fasthttp.StatusForbidden},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			authz, err := s.Builder().WithStrategies(
+				NewCookieSessionAuthnStrategy(schema.NewRefreshIntervalDuration(testInactivity)),
+			).Build()
+			require.NoError(t, err)
+
+			mock := mocks.NewMockAutheliaCtx(t)
+
+			defer mock.Close()
+
+			mock.Ctx.Configuration.AccessControl.Rules = []schema.AccessControlRule{
+				{
+					Domains: []string{"headers.example.com"},
+					Policy:  "bypass",
+					Headers: [][]schema.AccessControlRuleHeader{
+						{
+							{Operator: "equal", Key: "X-Tenant", Value: "abc"},
+						},
+					},
+				},
+			}
+
+			mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&mock.Ctx.Configuration, &mock.Clock)
+
+			s.ConfigureMockSessionProviderWithAutomaticAutheliaURLs(mock)
+
+			targetURI := s.RequireParseRequestURI("https://headers.example.com")
+
+			s.setRequest(mock.Ctx, fasthttp.MethodGet, targetURI, true, false)
+
+			if tc.header != "" {
+				mock.Ctx.Request.Header.Set("X-Tenant", tc.header)
+			}
+
+			authz.Handler(mock.Ctx)
+
+			assert.Equal(t, tc.expected, mock.Ctx.Response.StatusCode())
+		})
+	}
+}
+
 func (s *AuthzSuite) TestShouldVerifyFailureToGetDetailsUsingBasicScheme() {
 	if s.setRequest == nil {
 		s.T().Skip()
 	}
 
-	authz := s.Builder().Build()
+	authz, err := s.Builder().Build()
+	s.Require().NoError(err)
 
 	mock := mocks.NewMockAutheliaCtx(s.T())
 
@@ -287,7 +360,8 @@ func (s *AuthzSuite) TestShouldNotFailOnMissingEmail() {
 
 	mock.Clock.Set(time.Now())
 
-	authz := s.Builder().WithConfig(&mock.Ctx.Configuration).Build()
+	authz, err := s.Builder().WithConfig(&mock.Ctx.Configuration).Build()
+	s.Require().NoError(err)
 
 	s.ConfigureMockSessionProviderWithAutomaticAutheliaURLs(mock)
 
@@ -315,12 +389,430 @@ func (s *AuthzSuite) TestShouldNotFailOnMissingEmail() {
 	s.Equal("abc,123", string(mock.Ctx.Response.Header.PeekBytes(headerRemoteGroups)))
 }
 
+func (s *AuthzSuite) TestShouldSetIdentityHeaders() {
+	if s.setRequest == nil {
+		s.T().Skip()
+	}
+
+	headers := []schema.AuthzIdentityHeader{
+		{Name: "X-Forwarded-User", Value: "{{ .Username }}"},
+		{Name: "X-Forwarded-Groups", Value: `{{ join "|" .Groups }}`},
+		{Name: "X-Forwarded-Employee", Value: `{{ index .Extra "employee_id" }}`},
+	}
+
+	testCases := []struct {
+		name          string
+		client        string
+		authenticated bool
+		request       map[string]string
+		targetURI     string
+		status        int
+		expected      map[string]string
+		absent        []string
+	}{
+		{
+			"ShouldSetEndpointHeaders",
+			"", true, nil, "https://bypass.example.com", fasthttp.StatusOK,
+			map[string]string{"X-Forwarded-User": testUsername, "X-Forwarded-Groups": "abc|123", "X-Forwarded-Employee": "1234"},
+			[]string{"Remote-User", "X-Employee-Name"},
+		},
+		{
+			"ShouldSetRuleHeaders",
+			"", true, nil, "https://identity.example.com", fasthttp.StatusOK,
+			map[string]string{"X-Forwarded-User": "user:" + testUsername, "X-Forwarded-Groups": "abc|123", "X-Forwarded-Employee": "1234", "X-Employee-Name": "John Smith"},
+			nil,
+		},
+		{
+			"ShouldNotSetHeadersForAnonymousUser",
+			"", false, nil, "https://bypass.example.com", fasthttp.StatusOK,
+			nil,
+			[]string{"X-Forwarded-User", "X-Forwarded-Groups", "X-Forwarded-Employee"},
+		},
+		{
+			"ShouldSetEmptyHeadersForAnonymousUserWhenStripped",
+			"strip", false, nil, "https://bypass.example.com", fasthttp.StatusOK,
+			map[string]string{"X-Forwarded-User": "", "X-Forwarded-Groups": "", "X-Forwarded-Employee": ""},
+			nil,
+		},
+		{
+			"ShouldAllowClientHeaders",
+			"allow", true, map[string]string{"X-Forwarded-User": "admin"}, "https://bypass.example.com", fasthttp.StatusOK,
+			map[string]string{"X-Forwarded-User": testUsername},
+			nil,
+		},
+		{
+			"ShouldForbidClientHeaders",
+			"forbid", false, map[string]string{"X-Forwarded-User": "admin"}, "https://bypass.example.com", fasthttp.StatusForbidden,
+			nil,
+			[]string{"X-Forwarded-User"},
+		},
+		{
+			"ShouldForbidClientRuleHeaders",
+			"forbid", true, map[string]string{"X-Employee-Name": "admin"}, "https://identity.example.com", fasthttp.StatusForbidden,
+			nil,
+			[]string{"X-Employee-Name"},
+		},
+		{
+			"ShouldNotForbidRequestsWithoutClientHeaders",
+			"forbid", true, nil, "https://identity.example.com", fasthttp.StatusOK,
+			map[string]string{"X-Employee-Name": "John Smith"},
+			nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			mock := mocks.NewMockAutheliaCtx(t)
+
+			defer mock.Close()
+
+			mock.Ctx.Clock = &mock.Clock
+
+			mock.Clock.Set(time.Now())
+
+			authz, err := s.Builder().WithConfig(&mock.Ctx.Configuration).
+				WithIdentityHeadersConfig(schema.ServerEndpointsAuthzIdentityHeaders{ClientHeaders: tc.client, Headers: headers}).
+				Build()
+			require.NoError(t, err)
+
+			mock.Ctx.Configuration.AccessControl.Rules = []schema.AccessControlRule{
+				{
+					Domains: []string{"bypass.example.com"},
+					Policy:  "bypass",
+				},
+				{
+					Domains: []string{"identity.example.com"},
+					Policy:  "one_factor",
+					IdentityHeaders: []schema.AuthzIdentityHeader{
+						{Name: "x-forwarded-user", Value: "user:{{ .Username }}"},
+						{Name: "X-Employee-Name", Value: "{{ .DisplayName }}"},
+					},
+				},
+			}
+
+			mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&mock.Ctx.Configuration, &mock.Clock)
+
+			s.ConfigureMockSessionProviderWithAutomaticAutheliaURLs(mock)
+
+			s.setRequest(mock.Ctx, fasthttp.MethodGet, s.RequireParseRequestURI(tc.targetURI), true, false)
+
+			for name, value := range tc.request {
+				mock.Ctx.Request.Header.Set(name, value)
+			}
+
+			if tc.authenticated {
+				userSession, err := mock.Ctx.GetSession()
+				require.NoError(t, err)
+
+				userSession.Username = testUsername
+				userSession.DisplayName = "John Smith"
+				userSession.Groups = []string{"abc", "123"}
+				userSession.Emails = []string{"john@example.com"}
+				userSession.Extra = map[string]string{"employee_id": "1234"}
+				userSession.AuthenticationLevel = authentication.OneFactor
+				userSession.RefreshTTL = mock.Clock.Now().Add(5 * time.Minute)
+
+				require.NoError(t, mock.Ctx.SaveSession(userSession))
+			}
+
+			authz.Handler(mock.Ctx)
+
+			assert.Equal(t, tc.status, mock.Ctx.Response.StatusCode())
+
+			for name, value := range tc.expected {
+				actual := mock.Ctx.Response.Header.Peek(name)
+
+				assert.NotNil(t, actual, name)
+				assert.Equal(t, value, string(actual), name)
+			}
+
+			for _, name := range tc.absent {
+				assert.Nil(t, mock.Ctx.Response.Header.Peek(name), name)
+			}
+		})
+	}
+}
+
+func (s *AuthzSuite) TestShouldSetIdentityAssertion() {
+	if s.setRequest == nil {
+		s.T().Skip()
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	s.Require().NoError(err)
+
+	testCases := []struct {
+		name          string
+		enabled       bool
+		client        string
+		authenticated bool
+		request       map[string]string
+		targetURI     string
+		status        int
+		audience      []string
+		lifespan      time.Duration
+	}{
+		{
+			"ShouldSetEndpointAssertion",
+			true, "", true, nil, "https://bypass.example.com", fasthttp.StatusOK,
+			[]string{"https://bypass.example.com"}, time.Minute,
+		},
+		{
+			"ShouldSetRuleAssertion",
+			true, "", true, nil, "https://assertion.example.com", fasthttp.StatusOK,
+			[]string{"app"}, 30 * time.Second,
+		},
+		{
+			"ShouldNotSetAssertionWhenDisabled",
+			false, "", true, nil, "https://bypass.example.com", fasthttp.StatusOK,
+			nil, 0,
+		},
+		{
+			"ShouldNotSetAssertionForAnonymousUser",
+			true, "", false, nil, "https://bypass.example.com", fasthttp.StatusOK,
+			nil, 0,
+		},
+		{
+			"ShouldForbidClientAssertion",
+			true, "forbid", true, map[string]string{"Remote-Assertion": "abc"}, "https://bypass.example.com", fasthttp.StatusForbidden,
+			nil, 0,
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			mock := mocks.NewMockAutheliaCtx(t)
+
+			defer mock.Close()
+
+			mock.Ctx.Clock = &mock.Clock
+
+			mock.Clock.Set(time.Now())
+
+			authz, err := s.Builder().WithConfig(&mock.Ctx.Configuration).
+				WithIdentityHeadersConfig(schema.ServerEndpointsAuthzIdentityHeaders{ClientHeaders: tc.client}).
+				WithIdentityAssertionConfig(schema.ServerEndpointsAuthzIdentityAssertion{Enabled: tc.enabled}).
+				Build()
+			require.NoError(t, err)
+
+			mock.Ctx.Configuration.AccessControl.Rules = []schema.AccessControlRule{
+				{
+					Domains: []string{"bypass.example.com"},
+					Policy:  "bypass",
+				},
+				{
+					Domains: []string{"assertion.example.com"},
+					Policy:  "one_factor",
+					IdentityAssertion: schema.AccessControlRuleIdentityAssertion{
+						Audience: []string{"app"},
+						Lifespan: 30 * time.Second,
+					},
+				},
+			}
+
+			mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&mock.Ctx.Configuration, &mock.Clock)
+			mock.Ctx.Providers.OpenIDConnect = &oidc.OpenIDConnectProvider{
+				KeyManager: oidc.NewKeyManager(&schema.IdentityProvidersOpenIDConnect{
+					IssuerPrivateKeys: []schema.JWK{{KeyID: "abc", Use: oidc.KeyUseSignature, Algorithm: oidc.SigningAlgRSAUsingSHA256, Key: key}},
+					Discovery:         schema.IdentityProvidersOpenIDConnectDiscovery{DefaultKeyIDs: map[string]string{oidc.SigningAlgRSAUsingSHA256: "abc"}},
+				}),
+			}
+
+			s.ConfigureMockSessionProviderWithAutomaticAutheliaURLs(mock)
+
+			targetURI := s.RequireParseRequestURI(tc.targetURI)
+
+			s.setRequest(mock.Ctx, fasthttp.MethodGet, targetURI, true, false)
+
+			for name, value := range tc.request {
+				mock.Ctx.Request.Header.Set(name, value)
+			}
+
+			if tc.authenticated {
+				userSession, err := mock.Ctx.GetSession()
+				require.NoError(t, err)
+
+				userSession.Username = testUsername
+				userSession.DisplayName = "John Smith"
+				userSession.Groups = []string{"abc", "123"}
+				userSession.Emails = []string{"john@example.com"}
+				userSession.AuthenticationLevel = authentication.OneFactor
+				userSession.FirstFactorAuthnTimestamp = mock.Clock.Now().Add(-time.Minute).Unix()
+				userSession.AuthenticationMethodRefs.UsernameAndPassword = true
+				userSession.RefreshTTL = mock.Clock.Now().Add(5 * time.Minute)
+
+				require.NoError(t, mock.Ctx.SaveSession(userSession))
+			}
+
+			authz.Handler(mock.Ctx)
+
+			assert.Equal(t, tc.status, mock.Ctx.Response.StatusCode())
+
+			value := mock.Ctx.Response.Header.Peek("Remote-Assertion")
+
+			if tc.audience == nil {
+				assert.Nil(t, value)
+
+				return
+			}
+
+			require.NotNil(t, value)
+
+			claims := gojwt.MapClaims{}
+
+			token, err := gojwt.ParseWithClaims(string(value), claims, func(token *gojwt.Token) (any, error) {
+				return &key.PublicKey, nil
+			}, gojwt.WithTimeFunc(mock.Clock.Now))
+
+			require.NoError(t, err)
+
+			assert.Equal(t, "abc", token.Header[oidc.JWTHeaderKeyIdentifier])
+			assert.Equal(t, oidc.SigningAlgRSAUsingSHA256, token.Header[oidc.JWTHeaderKeyAlgorithm])
+
+			audience, err := claims.GetAudience()
+			require.NoError(t, err)
+
+			expires, err := claims.GetExpirationTime()
+			require.NoError(t, err)
+
+			issued, err := claims.GetIssuedAt()
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.audience, []string(audience))
+			assert.Equal(t, tc.lifespan, expires.Sub(issued.Time))
+			assert.Equal(t, testUsername, claims[oidc.ClaimSubject])
+			assert.Equal(t, []any{"abc", "123"}, claims[oidc.ClaimGroups])
+			assert.Equal(t, "john@example.com", claims[oidc.ClaimPreferredEmail])
+			assert.Equal(t, "John Smith", claims[oidc.ClaimFullName])
+			assert.Equal(t, []any{oidc.AMRPasswordBasedAuthentication}, claims[oidc.ClaimAuthenticationMethodsReference])
+			assert.Equal(t, float64(mock.Clock.Now().Add(-time.Minute).Unix()), claims[oidc.ClaimAuthenticationTime])
+		})
+	}
+}
+
+func (s *AuthzSuite) TestShouldSendCustomResponses() {
+	if s.setRequest == nil {
+		s.T().Skip()
+	}
+
+	dir := s.T().TempDir()
+
+	template := filepath.Join(dir, "forbidden.json")
+
+	s.Require().NoError(os.WriteFile(template, []byte(`{"status":{{ .StatusCode }},"request_id":{{ toJson .RequestID }},"rule":{{ .Rule.Position }},"policy":{{ toJson .Rule.Policy }},"redirect":{{ toJson .RedirectURL }}}`), 0600))
+
+	testCases := []struct {
+		name      string
+		responses schema.AuthzResponses
+		request   map[string]string
+		targetURI string
+		status    int
+		location  string
+		body      string
+	}{
+		{
+			"ShouldSendDefaultForbidden",
+			schema.AuthzResponses{},
+			nil,
+			"https://deny.example.com", fasthttp.StatusForbidden, "",
+			"403 Forbidden",
+		},
+		{
+			"ShouldSendEndpointForbiddenTemplate",
+			schema.AuthzResponses{Forbidden: schema.AuthzResponse{StatusCode: fasthttp.StatusNotFound, Template: template}},
+			map[string]string{"X-Request-Id": "abc-123"},
+			"https://deny.example.com", fasthttp.StatusNotFound, "",
+			`{"status":404,"request_id":"abc-123","rule":1,"policy":"deny","redirect":""}`,
+		},
+		{
+			"ShouldSendRuleForbiddenRedirect",
+			schema.AuthzResponses{Forbidden: schema.AuthzResponse{Template: template}},
+			map[string]string{"X-Request-Id": "abc-123"},
+			"https://redirect.example.com", fasthttp.StatusFound, "https://example.com/denied",
+			`{"status":302,"request_id":"abc-123","rule":2,"policy":"deny","redirect":"https://example.com/denied"}`,
+		},
+		{
+			"ShouldSendRuleUnauthorizedRedirect",
+			schema.AuthzResponses{},
+			nil,
+			"https://login.example.com", fasthttp.StatusTemporaryRedirect, "https://example.com/login",
+			"",
+		},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			mock := mocks.NewMockAutheliaCtx(t)
+
+			defer mock.Close()
+
+			authz, err := s.Builder().WithConfig(&mock.Ctx.Configuration).
+				WithResponsesConfig(tc.responses).
+				Build()
+			require.NoError(t, err)
+
+			mock.Ctx.Configuration.AccessControl.Rules = []schema.AccessControlRule{
+				{
+					Domains: []string{"deny.example.com"},
+					Policy:  "deny",
+				},
+				{
+					Domains: []string{"redirect.example.com"},
+					Policy:  "deny",
+					Responses: schema.AuthzResponses{
+						Forbidden: schema.AuthzResponse{RedirectURL: &url.URL{Scheme: "https", Host: "example.com", Path: "/denied"}},
+					},
+				},
+				{
+					Domains: []string{"login.example.com"},
+					Policy:  "one_factor",
+					Responses: schema.AuthzResponses{
+						Unauthorized: schema.AuthzResponse{StatusCode: fasthttp.StatusTemporaryRedirect, RedirectURL: &url.URL{Scheme: "https", Host: "example.com", Path: "/login"}},
+					},
+				},
+			}
+
+			mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&mock.Ctx.Configuration, &mock.Clock)
+
+			s.ConfigureMockSessionProviderWithAutomaticAutheliaURLs(mock)
+
+			s.setRequest(mock.Ctx, fasthttp.MethodGet, s.RequireParseRequestURI(tc.targetURI), true, false)
+
+			for name, value := range tc.request {
+				mock.Ctx.Request.Header.Set(name, value)
+			}
+
+			authz.Handler(mock.Ctx)
+
+			assert.Equal(t, tc.status, mock.Ctx.Response.StatusCode())
+
+			if tc.location == "" {
+				assert.Nil(t, mock.Ctx.Response.Header.Peek(fasthttp.HeaderLocation))
+			} else {
+				assert.Equal(t, tc.location, string(mock.Ctx.Response.Header.Peek(fasthttp.HeaderLocation)))
+			}
+
+			if tc.body != "" {
+				assert.Equal(t, tc.body, string(mock.Ctx.Response.Body()))
+			}
+
+			if tc.responses.Forbidden.Template == "" {
+				assert.Nil(t, mock.Ctx.Response.Header.Peek("X-Request-Id"))
+			} else {
+				assert.Equal(t, "abc-123", string(mock.Ctx.Response.Header.Peek("X-Request-Id")))
+				assert.Equal(t, "application/json; charset=utf-8", string(mock.Ctx.Response.Header.ContentType()))
+			}
+		})
+	}
+}
+
 func (s *AuthzSuite) TestShouldApplyPolicyOfOneFactorDomain() {
 	if s.setRequest == nil {
 		s.T().Skip()
 	}
 
-	authz := s.Builder().Build()
+	authz, err := s.Builder().Build()
+	s.Require().NoError(err)
 
 	mock := mocks.NewMockAutheliaCtx(s.T())
 
@@ -366,7 +858,8 @@ func (s *AuthzSuite) TestShouldHandleAnyCaseSchemeParameter() {
 		{"MixedCase", "BaSIc"},
 	}
 
-	authz := s.Builder().Build()
+	authz, err := s.Builder().Build()
+	s.Require().NoError(err)
 
 	for _, tc := range testCases {
 		s.T().Run(tc.name, func(t *testing.T) {
@@ -407,7 +900,8 @@ func (s *AuthzSuite) TestShouldApplyPolicyOfTwoFactorDomain() {
 		s.T().Skip()
 	}
 
-	authz := s.Builder().Build()
+	authz, err := s.Builder().Build()
+	s.Require().NoError(err)
 
 	mock := mocks.NewMockAutheliaCtx(s.T())
 
@@ -451,7 +945,8 @@ func (s *AuthzSuite) TestShouldApplyPolicyOfDenyDomain() {
 		s.T().Skip()
 	}
 
-	authz := s.Builder().Build()
+	authz, err := s.Builder().Build()
+	s.Require().NoError(err)
 
 	mock := mocks.NewMockAutheliaCtx(s.T())
 
@@ -498,7 +993,8 @@ func (s *AuthzSuite) TestShouldApplyPolicyOfOneFactorDomainWithAuthorizationHead
 		NewCookieSessionAuthnStrategy(builder.config.RefreshInterval),
 	)
 
-	authz := builder.Build()
+	authz, err := builder.Build()
+	s.Require().NoError(err)
 
 	mock := mocks.NewMockAutheliaCtx(s.T())
 
@@ -544,7 +1040,8 @@ func (s *AuthzSuite) TestShouldHandleAuthzWithoutHeaderNoCookie() {
 		NewHeaderProxyAuthorizationAuthRequestAuthnStrategy(),
 	)
 
-	authz := builder.Build()
+	authz, err := builder.Build()
+	s.Require().NoError(err)
 
 	mock := mocks.NewMockAutheliaCtx(s.T())
 
@@ -577,7 +1074,8 @@ func (s *AuthzSuite) TestShouldHandleAuthzWithEmptyAuthorizationHeader() {
 		NewHeaderProxyAuthorizationAuthRequestAuthnStrategy(),
 	)
 
-	authz := builder.Build()
+	authz, err := builder.Build()
+	s.Require().NoError(err)
 
 	mock := mocks.NewMockAutheliaCtx(s.T())
 
@@ -612,7 +1110,8 @@ func (s *AuthzSuite) TestShouldHandleAuthzWithAuthorizationHeaderInvalidPassword
 		NewHeaderProxyAuthorizationAuthRequestAuthnStrategy(),
 	)
 
-	authz := builder.Build()
+	authz, err := builder.Build()
+	s.Require().NoError(err)
 
 	mock := mocks.NewMockAutheliaCtx(s.T())
 
@@ -648,7 +1147,8 @@ func (s *AuthzSuite) TestShouldHandleAuthzWithIncorrectAuthHeader() { // TestSho
 		NewHeaderAuthorizationAuthnStrategy(),
 	)
 
-	authz := builder.Build()
+	authz, err := builder.Build()
+	s.Require().NoError(err)
 
 	mock := mocks.NewMockAutheliaCtx(s.T())
 
@@ -680,7 +1180,8 @@ func (s *AuthzSuite) TestShouldDestroySessionWhenInactiveForTooLong() {
 		NewCookieSessionAuthnStrategy(schema.NewRefreshIntervalDuration(testInactivity)),
 	)
 
-	authz := builder.Build()
+	authz, err := builder.Build()
+	s.Require().NoError(err)
 
 	mock := mocks.NewMockAutheliaCtx(s.T())
 
@@ -719,6 +1220,351 @@ func (s *AuthzSuite) TestShouldDestroySessionWhenInactiveForTooLong() {
 	s.Equal(mock.Clock.Now().Unix(), userSession.LastActivity)
 }
 
+func (s *AuthzSuite) TestShouldDestroySessionWhenRevoked() {
+	if s.setRequest == nil {
+		s.T().Skip()
+	}
+
+	authz, err := s.Builder().Build()
+	s.Require().NoError(err)
+
+	mock := mocks.NewMockAutheliaCtx(s.T())
+
+	defer mock.Close()
+
+	mock.Ctx.Clock = &mock.Clock
+
+	mock.Clock.Set(time.Now())
+
+	s.ConfigureMockSessionProviderWithAutomaticAutheliaURLs(mock)
+
+	targetURI := s.RequireParseRequestURI("https://two-factor.example.com")
+
+	s.setRequest(mock.Ctx, fasthttp.MethodGet, targetURI, true, false)
+
+	// The revocation happens before the session is saved which is the same as a session which was saved concurrently
+	// with the revocation or which is otherwise missing from the user session index.
+	_, err = mock.Ctx.Providers.SessionProvider.RevokeAllUserSessions(testUsername, mock.Clock.Now())
+	s.Require().NoError(err)
+
+	userSession, err := mock.Ctx.GetSession()
+	s.Require().NoError(err)
+
+	userSession.Username = testUsername
+	userSession.AuthenticationLevel = authentication.TwoFactor
+	userSession.FirstFactorAuthnTimestamp = mock.Clock.Now().Add(-1 * time.Hour).Unix()
+	userSession.LastActivity = mock.Clock.Now().Unix()
+
+	s.Require().NoError(mock.Ctx.SaveSession(userSession))
+
+	authz.Handler(mock.Ctx)
+
+	s.NotEqual(fasthttp.StatusOK, mock.Ctx.Response.StatusCode())
+
+	userSession, err = mock.Ctx.GetSession()
+	s.Require().NoError(err)
+
+	s.Equal("", userSession.Username)
+	s.Equal(authentication.NotAuthenticated, userSession.AuthenticationLevel)
+}
+
+func (s *AuthzSuite) TestShouldHandleSessionBindingMismatch() {
+	if s.setRequest == nil {
+		s.T().Skip()
+	}
+
+	testCases := []struct {
+		name     string
+		action   string
+		expected string
+	}{
+		{"ShouldOnlyLog", schema.SessionBindingActionLog, testUsername},
+		{"ShouldRequireReauthentication", schema.SessionBindingActionReauthenticate, ""},
+		{"ShouldDestroy", schema.SessionBindingActionDestroy, ""},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			authz, err := s.Builder().WithStrategies(
+				NewCookieSessionAuthnStrategy(schema.NewRefreshIntervalDuration(testInactivity)),
+			).Build()
+			require.NoError(t, err)
+
+			mock := mocks.NewMockAutheliaCtx(t)
+
+			defer mock.Close()
+
+			mock.Ctx.Clock = &mock.Clock
+
+			mock.Clock.Set(time.Now())
+
+			mock.Ctx.Configuration.Session.Binding = schema.SessionBinding{
+				Enabled:    true,
+				IPv4Mask:   24,
+				IPv6Mask:   64,
+				Strictness: schema.SessionBindingStrictnessStrict,
+				Action:     tc.action,
+			}
+
+			s.ConfigureMockSessionProviderWithAutomaticAutheliaURLs(mock)
+
+			targetURI := s.RequireParseRequestURI("https://one-factor.example.com")
+
+			s.setRequest(mock.Ctx, fasthttp.MethodGet, targetURI, true, false)
+
+			userSession, err := mock.Ctx.GetSession()
+			require.NoError(t, err)
+
+			userSession.Username = testUsername
+			userSession.AuthenticationLevel = authentication.OneFactor
+			userSession.LastActivity = mock.Clock.Now().Unix()
+			userSession.RefreshTTL = mock.Clock.Now().Add(5 * time.Minute)
+			userSession.Binding = session.NewUserSessionBinding(&mock.Ctx.Configuration.Session.Binding, net.ParseIP("203.0.113.10"), "curl/8.0")
+
+			require.NoError(t, mock.Ctx.SaveSession(userSession))
+
+			authz.Handler(mock.Ctx)
+
+			userSession, err = mock.Ctx.GetSession()
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, userSession.Username)
+
+			if tc.expected == "" {
+				assert.NotEqual(t, fasthttp.StatusOK, mock.Ctx.Response.StatusCode())
+				assert.Equal(t, authentication.NotAuthenticated, userSession.AuthenticationLevel)
+				assert.Nil(t, userSession.Binding)
+			} else {
+				assert.Equal(t, fasthttp.StatusOK, mock.Ctx.Response.StatusCode())
+				assert.Equal(t, authentication.OneFactor, userSession.AuthenticationLevel)
+			}
+		})
+	}
+}
+
+func (s *AuthzSuite) TestShouldBindSessionEstablishedBeforeBindingEnabled() {
+	if s.setRequest == nil {
+		s.T().Skip()
+	}
+
+	authz, err := s.Builder().WithStrategies(
+		NewCookieSessionAuthnStrategy(schema.NewRefreshIntervalDuration(testInactivity)),
+	).Build()
+	s.Require().NoError(err)
+
+	mock := mocks.NewMockAutheliaCtx(s.T())
+
+	defer mock.Close()
+
+	mock.Ctx.Clock = &mock.Clock
+
+	mock.Clock.Set(time.Now())
+
+	mock.Ctx.Configuration.Session.Binding = schema.SessionBinding{
+		Enabled:    true,
+		IPv4Mask:   24,
+		IPv6Mask:   64,
+		Strictness: schema.SessionBindingStrictnessStrict,
+		Action:     schema.SessionBindingActionDestroy,
+	}
+
+	s.ConfigureMockSessionProviderWithAutomaticAutheliaURLs(mock)
+
+	targetURI := s.RequireParseRequestURI("https://one-factor.example.com")
+
+	s.setRequest(mock.Ctx, fasthttp.MethodGet, targetURI, true, false)
+
+	userSession, err := mock.Ctx.GetSession()
+	s.Require().NoError(err)
+
+	userSession.Username = testUsername
+	userSession.AuthenticationLevel = authentication.OneFactor
+	userSession.LastActivity = mock.Clock.Now().Unix()
+	userSession.RefreshTTL = mock.Clock.Now().Add(5 * time.Minute)
+
+	s.Require().NoError(mock.Ctx.SaveSession(userSession))
+
+	authz.Handler(mock.Ctx)
+
+	s.Equal(fasthttp.StatusOK, mock.Ctx.Response.StatusCode())
+
+	userSession, err = mock.Ctx.GetSession()
+	s.Require().NoError(err)
+
+	s.Equal(testUsername, userSession.Username)
+	s.Require().NotNil(userSession.Binding)
+	s.Equal(session.NewUserSessionBinding(&mock.Ctx.Configuration.Session.Binding, mock.Ctx.RemoteIP(), string(mock.Ctx.UserAgent())), userSession.Binding)
+
+	var entry *logrus.Entry
+
+	for _, e := range mock.Hook.AllEntries() {
+		if e.Data["event"] == "session_binding_established" {
+			entry = e
+		}
+	}
+
+	s.Require().NotNil(entry)
+	s.Equal(logrus.WarnLevel, entry.Level)
+	s.Equal(testUsername, entry.Data["username"])
+	s.Equal(userSession.Binding.IPPrefix, entry.Data["request_ip_prefix"])
+}
+
+func (s *AuthzSuite) TestShouldRequireAuthenticationWhenMaxAuthenticationAgeExceeded() {
+	if s.setRequest == nil {
+		s.T().Skip()
+	}
+
+	testCases := []struct {
+		name                      string
+		firstFactor, secondFactor time.Duration
+		status                    int
+	}{
+		{"ShouldAllowWithinAge", time.Hour * 2, time.Minute * 30, fasthttp.StatusOK},
+		{"ShouldRequireSecondFactor", time.Hour * 2, time.Minute * 10, fasthttp.StatusFound},
+		{"ShouldRequireFirstFactor", time.Minute * 30, time.Minute * 30, fasthttp.StatusFound},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			builder := s.Builder()
+
+			builder = builder.WithStrategies(
+				NewCookieSessionAuthnStrategy(schema.NewRefreshIntervalDuration(time.Minute * 5)),
+			)
+
+			authz, err := builder.Build()
+			require.NoError(t, err)
+
+			mock := mocks.NewMockAutheliaCtx(t)
+
+			defer mock.Close()
+
+			mock.Ctx.Clock = &mock.Clock
+
+			mock.Clock.Set(time.Now())
+
+			mock.Ctx.Configuration.AccessControl.Rules = []schema.AccessControlRule{
+				{
+					Domains: []string{"two-factor.example.com"},
+					Policy:  "two_factor",
+					MaxAuthenticationAge: schema.AccessControlRuleMaxAuthenticationAge{
+						FirstFactor:  tc.firstFactor,
+						SecondFactor: tc.secondFactor,
+					},
+				},
+			}
+
+			mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&mock.Ctx.Configuration, &mock.Clock)
+
+			s.ConfigureMockSessionProviderWithAutomaticAutheliaURLs(mock)
+
+			targetURI := s.RequireParseRequestURI("https://two-factor.example.com")
+
+			s.setRequest(mock.Ctx, fasthttp.MethodGet, targetURI, true, false)
+
+			userSession, err := mock.Ctx.GetSession()
+			require.NoError(t, err)
+
+			userSession.Username = testUsername
+			userSession.AuthenticationLevel = authentication.TwoFactor
+			userSession.KeepMeLoggedIn = true
+			userSession.FirstFactorAuthnTimestamp = mock.Clock.Now().Add(-time.Hour).Unix()
+			userSession.SecondFactorAuthnTimestamp = mock.Clock.Now().Add(-time.Minute * 20).Unix()
+			userSession.RefreshTTL = mock.Clock.Now().Add(time.Minute)
+
+			require.NoError(t, mock.Ctx.SaveSession(userSession))
+
+			authz.Handler(mock.Ctx)
+
+			userSession, err = mock.Ctx.GetSession()
+			require.NoError(t, err)
+
+			assert.Equal(t, testUsername, userSession.Username)
+			assert.Equal(t, authentication.TwoFactor, userSession.AuthenticationLevel)
+
+			switch {
+			case tc.status == fasthttp.StatusOK:
+				assert.Equal(t, fasthttp.StatusOK, mock.Ctx.Response.StatusCode())
+			case s.implementation == AuthzImplAuthRequest, s.implementation == AuthzImplLegacy:
+				assert.Equal(t, fasthttp.StatusUnauthorized, mock.Ctx.Response.StatusCode())
+			default:
+				assert.Equal(t, tc.status, mock.Ctx.Response.StatusCode())
+			}
+		})
+	}
+}
+
+func (s *AuthzSuite) TestShouldMatchExpressionAgainstUserAttributes() {
+	if s.setRequest == nil {
+		s.T().Skip()
+	}
+
+	testCases := []struct {
+		name   string
+		extra  map[string]string
+		status int
+	}{
+		{"ShouldDenyMatchingAttribute", map[string]string{"department": "contractors"}, fasthttp.StatusForbidden},
+		{"ShouldAllowOtherAttribute", map[string]string{"department": "finance"}, fasthttp.StatusOK},
+		{"ShouldAllowMissingAttribute", nil, fasthttp.StatusOK},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			builder := s.Builder()
+
+			builder = builder.WithStrategies(
+				NewCookieSessionAuthnStrategy(schema.NewRefreshIntervalDuration(time.Minute * 5)),
+			)
+
+			authz, err := builder.Build()
+			require.NoError(t, err)
+
+			mock := mocks.NewMockAutheliaCtx(t)
+
+			defer mock.Close()
+
+			mock.Ctx.Clock = &mock.Clock
+
+			mock.Clock.Set(time.Now())
+
+			mock.Ctx.Configuration.AccessControl.Rules = []schema.AccessControlRule{
+				{
+					Domains:    []string{"one-factor.example.com"},
+					Policy:     "deny",
+					Expression: "'department' in subject.attributes && subject.attributes['department'] == 'contractors'",
+				},
+				{
+					Domains: []string{"one-factor.example.com"},
+					Policy:  "one_factor",
+				},
+			}
+
+			mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&mock.Ctx.Configuration, &mock.Clock)
+
+			s.ConfigureMockSessionProviderWithAutomaticAutheliaURLs(mock)
+
+			targetURI := s.RequireParseRequestURI("https://one-factor.example.com")
+
+			s.setRequest(mock.Ctx, fasthttp.MethodGet, targetURI, true, false)
+
+			userSession, err := mock.Ctx.GetSession()
+			require.NoError(t, err)
+
+			userSession.Username = testUsername
+			userSession.AuthenticationLevel = authentication.OneFactor
+			userSession.Extra = tc.extra
+			userSession.RefreshTTL = mock.Clock.Now().Add(time.Minute)
+
+			require.NoError(t, mock.Ctx.SaveSession(userSession))
+
+			authz.Handler(mock.Ctx)
+
+			assert.Equal(t, tc.status, mock.Ctx.Response.StatusCode())
+		})
+	}
+}
+
 func (s *AuthzSuite) TestShouldNotDestroySessionWhenInactiveForTooLongRememberMe() {
 	if s.setRequest == nil {
 		s.T().Skip()
@@ -730,7 +1576,8 @@ func (s *AuthzSuite) TestShouldNotDestroySessionWhenInactiveForTooLongRememberMe
 		NewCookieSessionAuthnStrategy(schema.NewRefreshIntervalDuration(testInactivity)),
 	)
 
-	authz := builder.Build()
+	authz, err := builder.Build()
+	s.Require().NoError(err)
 
 	mock := mocks.NewMockAutheliaCtx(s.T())
 
@@ -780,7 +1627,8 @@ func (s *AuthzSuite) TestShouldNotDestroySessionWhenNotInactiveForTooLong() {
 		NewCookieSessionAuthnStrategy(schema.NewRefreshIntervalDuration(testInactivity)),
 	)
 
-	authz := builder.Build()
+	authz, err := builder.Build()
+	s.Require().NoError(err)
 
 	mock := mocks.NewMockAutheliaCtx(s.T())
 
@@ -831,7 +1679,8 @@ func (s *AuthzSuite) TestShouldUpdateInactivityTimestampEvenWhenHittingForbidden
 		NewCookieSessionAuthnStrategy(schema.NewRefreshIntervalDuration(testInactivity)),
 	)
 
-	authz := builder.Build()
+	authz, err := builder.Build()
+	s.Require().NoError(err)
 
 	mock := mocks.NewMockAutheliaCtx(s.T())
 
@@ -882,7 +1731,8 @@ func (s *AuthzSuite) TestShouldNotRefreshUserDetailsFromBackendWhenRefreshDisabl
 		NewCookieSessionAuthnStrategy(schema.NewRefreshIntervalDurationNever()),
 	)
 
-	authz := builder.Build()
+	authz, err := builder.Build()
+	s.Require().NoError(err)
 
 	user := &authentication.UserDetails{
 		Username: "john",
@@ -975,7 +1825,8 @@ func (s *AuthzSuite) TestShouldDestroySessionWhenUserDoesNotExist() {
 		NewCookieSessionAuthnStrategy(schema.NewRefreshIntervalDuration(5 * time.Minute)),
 	)
 
-	authz := builder.Build()
+	authz, err := builder.Build()
+	s.Require().NoError(err)
 
 	mock := mocks.NewMockAutheliaCtx(s.T())
 
@@ -1063,7 +1914,8 @@ func (s *AuthzSuite) TestShouldUpdateRemovedUserGroupsFromBackendAndDeny() {
 		NewCookieSessionAuthnStrategy(schema.NewRefreshIntervalDuration(5 * time.Minute)),
 	)
 
-	authz := builder.Build()
+	authz, err := builder.Build()
+	s.Require().NoError(err)
 
 	mock := mocks.NewMockAutheliaCtx(s.T())
 
@@ -1149,7 +2001,8 @@ func (s *AuthzSuite) TestShouldUpdateAddedUserGroupsFromBackendAndDeny() {
 		NewCookieSessionAuthnStrategy(schema.NewRefreshIntervalDuration(5 * time.Minute)),
 	)
 
-	authz := builder.Build()
+	authz, err := builder.Build()
+	s.Require().NoError(err)
 
 	mock := mocks.NewMockAutheliaCtx(s.T())
 
@@ -1234,7 +2087,8 @@ func (s *AuthzSuite) TestShouldCheckValidSessionUsernameHeaderAndReturn200() {
 		NewCookieSessionAuthnStrategy(schema.NewRefreshIntervalDuration(testInactivity)),
 	)
 
-	authz := builder.Build()
+	authz, err := builder.Build()
+	s.Require().NoError(err)
 
 	mock := mocks.NewMockAutheliaCtx(s.T())
 
@@ -1287,7 +2141,8 @@ func (s *AuthzSuite) TestShouldCheckInvalidSessionUsernameHeaderAndReturn401AndD
 		NewCookieSessionAuthnStrategy(schema.NewRefreshIntervalDuration(5 * time.Minute)),
 	)
 
-	authz := builder.Build()
+	authz, err := builder.Build()
+	s.Require().NoError(err)
 
 	mock := mocks.NewMockAutheliaCtx(s.T())
 
@@ -1358,7 +2213,8 @@ func (s *AuthzSuite) TestShouldNotRedirectRequestsForBypassACLWhenInactiveForToo
 		NewCookieSessionAuthnStrategy(schema.NewRefreshIntervalDuration(testInactivity)),
 	)
 
-	authz := builder.Build()
+	authz, err := builder.Build()
+	s.Require().NoError(err)
 
 	mock := mocks.NewMockAutheliaCtx(s.T())
 
@@ -1436,7 +2292,8 @@ func (s *AuthzSuite) TestShouldFailToParsePortalURL() {
 		NewCookieSessionAuthnStrategy(schema.NewRefreshIntervalDuration(testInactivity)),
 	)
 
-	authz := builder.Build()
+	authz, err := builder.Build()
+	s.Require().NoError(err)
 
 	mock := mocks.NewMockAutheliaCtx(s.T())
 
@@ -2,11 +2,13 @@ package handlers
 
 import (
 	"net/url"
+	"time"
 
 	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/authorization"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/oidc"
 	"github.com/authelia/authelia/v4/internal/session"
 )
 
@@ -71,6 +73,13 @@ type Authn struct {
 	Level   authentication.Level
 	Object  authorization.Object
 	Type    AuthnType
+
+	// FirstFactorAuthnTime and SecondFactorAuthnTime are the times the respective factors were last authenticated.
+	FirstFactorAuthnTime  time.Time
+	SecondFactorAuthnTime time.Time
+
+	// AuthenticationMethodRefs are the methods used to authenticate.
+	AuthenticationMethodRefs oidc.AuthenticationMethodsReferences
 }
 
 // AuthzConfig represents the configuration elements of the Authz type.
@@ -80,6 +89,19 @@ type AuthzConfig struct {
 	// StatusCodeBadRequest is sent for configuration issues prior to performing authorization checks. It's set by the
 	// builder.
 	StatusCodeBadRequest int
+
+	// IdentityHeaders are the headers sent in authorized responses. It's set by the builder.
+	IdentityHeaders []authorization.IdentityHeader
+
+	// ClientIdentityHeaders determines how copies of the IdentityHeaders supplied by the client are handled.
+	ClientIdentityHeaders string
+
+	// IdentityAssertion is the signed JWT which asserts the identity of the user sent in authorized responses.
+	IdentityAssertion schema.ServerEndpointsAuthzIdentityAssertion
+
+	// Responses are the customized responses sent when access is denied, the options of the matched rule take
+	// precedence.
+	Responses authorization.AccessControlResponses
 }
 
 // AuthzBuilder is a builder pattern for the Authz type.
@@ -87,6 +109,8 @@ type AuthzBuilder struct {
 	config         AuthzConfig
 	implementation AuthzImplementation
 	strategies     []AuthnStrategy
+
+	err error
 }
 
 // AuthnStrategy is a strategy used for Authz authentication.
@@ -122,6 +146,20 @@ const (
 	AuthnStrategyHeaderLegacy                        = "HeaderLegacy"
 )
 
+// ClientIdentityHeaders modes.
+const (
+	// ClientIdentityHeadersAllow sends the identity headers only to authenticated users, leaving any copies of the
+	// headers supplied by the client for anonymous users to the proxy.
+	ClientIdentityHeadersAllow = "allow"
+
+	// ClientIdentityHeadersStrip always sends the identity headers, with empty values for anonymous users, so the proxy
+	// replaces any copies of the headers supplied by the client.
+	ClientIdentityHeadersStrip = "strip"
+
+	// ClientIdentityHeadersForbid forbids requests which contain a copy of any of the identity headers.
+	ClientIdentityHeadersForbid = "forbid"
+)
+
 const (
 	// AuthzImplLegacy is the legacy Authz implementation (VerifyGET).
 	AuthzImplLegacy AuthzImplementation = iota
@@ -1,6 +1,11 @@
 package handlers
 
 import (
+	"bytes"
+	"net/http"
+
+	"github.com/google/uuid"
+
 	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/authorization"
 	"github.com/authelia/authelia/v4/internal/middlewares"
@@ -103,3 +108,56 @@ func generateVerifySessionHasUpToDateProfileTraceLogs(ctx *middlewares.AutheliaC
 		ctx.Logger.Trace("User session display name is current")
 	}
 }
+
+// getAuthzObjectHeaders returns the forwarded request headers as a http.Header for the purpose of ACL matching. Headers
+// which contain credentials are excluded.
+func getAuthzObjectHeaders(ctx *middlewares.AutheliaCtx) (headers http.Header) {
+	headers = http.Header{}
+
+	ctx.Request.Header.VisitAll(func(key, value []byte) {
+		if isAuthzObjectHeaderExcluded(key) {
+			return
+		}
+
+		headers.Add(string(key), string(value))
+	})
+
+	return headers
+}
+
+func isAuthzObjectHeaderExcluded(key []byte) bool {
+	for _, excluded := range authzObjectHeadersExcluded {
+		if bytes.EqualFold(key, excluded) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getAuthzRequestID returns the request ID supplied by the proxy in the X-Request-Id header if it's valid, otherwise
+// it returns a new random request ID.
+func getAuthzRequestID(ctx *middlewares.AutheliaCtx) (id string) {
+	if value := ctx.Request.Header.PeekBytes(headerXRequestID); isValidAuthzRequestID(value) {
+		return string(value)
+	}
+
+	return uuid.NewString()
+}
+
+func isValidAuthzRequestID(value []byte) bool {
+	if len(value) == 0 || len(value) > 128 {
+		return false
+	}
+
+	for _, c := range value {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-', c == '_', c == '.', c == ':':
+			continue
+		default:
+			return false
+		}
+	}
+
+	return true
+}
@@ -21,7 +21,7 @@ func (s *SecondFactorAvailableMethodsFixture) SetupTest() {
 		AccessControl: schema.AccessControl{
 			DefaultPolicy: "deny",
 			Rules:         []schema.AccessControlRule{},
-		}})
+		}}, &s.mock.Clock)
 }
 
 func (s *SecondFactorAvailableMethodsFixture) TearDownTest() {
@@ -49,7 +49,7 @@ func (s *SecondFactorAvailableMethodsFixture) TestShouldHaveAllConfiguredMethods
 			},
 		}}
 
-	s.mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&s.mock.Ctx.Configuration)
+	s.mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&s.mock.Ctx.Configuration, &s.mock.Clock)
 
 	ConfigurationGET(s.mock.Ctx)
 
@@ -79,7 +79,7 @@ func (s *SecondFactorAvailableMethodsFixture) TestShouldRemoveTOTPFromAvailableM
 			},
 		}}
 
-	s.mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&s.mock.Ctx.Configuration)
+	s.mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&s.mock.Ctx.Configuration, &s.mock.Clock)
 
 	ConfigurationGET(s.mock.Ctx)
 
@@ -109,7 +109,7 @@ func (s *SecondFactorAvailableMethodsFixture) TestShouldRemoveWebAuthnFromAvaila
 			},
 		}}
 
-	s.mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&s.mock.Ctx.Configuration)
+	s.mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&s.mock.Ctx.Configuration, &s.mock.Clock)
 
 	ConfigurationGET(s.mock.Ctx)
 
@@ -139,7 +139,7 @@ func (s *SecondFactorAvailableMethodsFixture) TestShouldRemoveDuoFromAvailableMe
 			},
 		}}
 
-	s.mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&s.mock.Ctx.Configuration)
+	s.mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&s.mock.Ctx.Configuration, &s.mock.Clock)
 
 	ConfigurationGET(s.mock.Ctx)
 
@@ -169,7 +169,7 @@ func (s *SecondFactorAvailableMethodsFixture) TestShouldRemoveAllMethodsWhenNoTw
 			},
 		}}
 
-	s.mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&s.mock.Ctx.Configuration)
+	s.mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&s.mock.Ctx.Configuration, &s.mock.Clock)
 
 	ConfigurationGET(s.mock.Ctx)
 
@@ -199,7 +199,7 @@ func (s *SecondFactorAvailableMethodsFixture) TestShouldRemoveAllMethodsWhenAllD
 			},
 		}}
 
-	s.mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&s.mock.Ctx.Configuration)
+	s.mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&s.mock.Ctx.Configuration, &s.mock.Clock)
 
 	ConfigurationGET(s.mock.Ctx)
 
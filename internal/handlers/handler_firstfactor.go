@@ -6,6 +6,7 @@ import (
 
 	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/regulation"
+	"github.com/authelia/authelia/v4/internal/session"
 )
 
 // FirstFactorPOST is the handler performing the first factory.
@@ -32,7 +33,7 @@ func FirstFactorPOST(delayFunc middlewares.TimingAttackDelayFunc) middlewares.Re
 		}
 
 		if bannedUntil, err := ctx.Providers.Regulator.Regulate(ctx, bodyJSON.Username); err != nil {
-			if errors.Is(err, regulation.ErrUserIsBanned) {
+			if errors.Is(err, regulation.ErrUserIsBanned) || errors.Is(err, regulation.ErrRemoteIPIsBanned) {
 				_ = markAuthenticationAttempt(ctx, false, &bannedUntil, bodyJSON.Username, regulation.AuthType1FA, nil)
 
 				respondUnauthorized(ctx, messageAuthenticationFailed)
@@ -136,6 +137,10 @@ func FirstFactorPOST(delayFunc middlewares.TimingAttackDelayFunc) middlewares.Re
 
 		userSession.SetOneFactor(ctx.Clock.Now(), userDetails, keepMeLoggedIn)
 
+		if ctx.Configuration.Session.Binding.Enabled {
+			userSession.Binding = session.NewUserSessionBinding(&ctx.Configuration.Session.Binding, ctx.RemoteIP(), string(ctx.UserAgent()))
+		}
+
 		if ctx.Configuration.AuthenticationBackend.RefreshInterval.Update() {
 			userSession.RefreshTTL = ctx.Clock.Now().Add(ctx.Configuration.AuthenticationBackend.RefreshInterval.Value())
 		}
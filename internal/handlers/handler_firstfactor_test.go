@@ -310,6 +310,52 @@ func (s *FirstFactorSuite) TestShouldSaveUsernameFromAuthenticationBackendInSess
 	assert.Equal(s.T(), []string{"dev", "admins"}, userSession.Groups)
 }
 
+func (s *FirstFactorSuite) TestShouldBindSessionWhenSessionBindingEnabled() {
+	s.mock.Ctx.Configuration.Session.Binding = schema.SessionBinding{
+		Enabled:  true,
+		IPv4Mask: 24,
+		IPv6Mask: 64,
+	}
+
+	s.mock.UserProviderMock.
+		EXPECT().
+		CheckUserPassword(gomock.Eq("test"), gomock.Eq("hello")).
+		Return(true, nil)
+
+	s.mock.UserProviderMock.
+		EXPECT().
+		GetDetails(gomock.Eq("test")).
+		Return(&authentication.UserDetails{
+			Username: "test",
+			Emails:   []string{"test@example.com"},
+			Groups:   []string{"dev", "admins"},
+		}, nil)
+
+	s.mock.StorageMock.
+		EXPECT().
+		AppendAuthenticationLog(s.mock.Ctx, gomock.Any()).
+		Return(nil)
+
+	s.mock.Ctx.Request.Header.Set(fasthttp.HeaderXForwardedFor, "192.168.1.20")
+	s.mock.Ctx.Request.Header.SetUserAgent("firefox")
+	s.mock.Ctx.Request.SetBodyString(`{
+		"username": "test",
+		"password": "hello",
+		"requestMethod": "GET",
+		"keepMeLoggedIn": false
+	}`)
+	FirstFactorPOST(nil)(s.mock.Ctx)
+
+	assert.Equal(s.T(), fasthttp.StatusOK, s.mock.Ctx.Response.StatusCode())
+
+	userSession, err := s.mock.Ctx.GetSession()
+	s.Assert().NoError(err)
+
+	s.Require().NotNil(userSession.Binding)
+	assert.Equal(s.T(), "192.168.1.0/24", userSession.Binding.IPPrefix)
+	assert.Equal(s.T(), "firefox", userSession.Binding.UserAgent)
+}
+
 type FirstFactorRedirectionSuite struct {
 	suite.Suite
 
@@ -326,7 +372,7 @@ func (s *FirstFactorRedirectionSuite) SetupTest() {
 			Policy:  "one_factor",
 		},
 	}
-	s.mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&s.mock.Ctx.Configuration)
+	s.mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&s.mock.Ctx.Configuration, &s.mock.Clock)
 
 	s.mock.UserProviderMock.
 		EXPECT().
@@ -410,7 +456,7 @@ func (s *FirstFactorRedirectionSuite) TestShouldReply200WhenNoTargetURLProvidedA
 		AccessControl: schema.AccessControl{
 			DefaultPolicy: "two_factor",
 		},
-	})
+	}, &s.mock.Clock)
 	s.mock.Ctx.Request.SetBodyString(`{
 		"username": "test",
 		"password": "hello",
@@ -445,7 +491,7 @@ func (s *FirstFactorRedirectionSuite) TestShouldReply200WhenUnsafeTargetURLProvi
 					Policy:  "two_factor",
 				},
 			},
-		}})
+		}}, &s.mock.Clock)
 	s.mock.Ctx.Request.SetBodyString(`{
 		"username": "test",
 		"password": "hello",
@@ -1,10 +1,16 @@
 package handlers
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
+	"path"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/valyala/fasthttp"
 
 	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/session"
 )
 
 type logoutBody struct {
@@ -12,7 +18,19 @@ type logoutBody struct {
 }
 
 type logoutResponseBody struct {
-	SafeTargetURL bool `json:"safeTargetURL"`
+	SafeTargetURL bool   `json:"safeTargetURL"`
+	RedirectURL   string `json:"redirectURL,omitempty"`
+}
+
+// logoutClaims are the claims of the token which carries a global logout from one cookie domain to the next.
+type logoutClaims struct {
+	jwt.RegisteredClaims
+
+	// Domains are the cookie domains which have yet to be logged out in the order they're visited.
+	Domains []string `json:"domains"`
+
+	// RedirectURL is the URL the user is redirected to once all cookie domains have been logged out.
+	RedirectURL string `json:"redirect_url"`
 }
 
 // LogoutPOST is the handler logging out the user attached to the given cookie.
@@ -25,6 +43,12 @@ func LogoutPOST(ctx *middlewares.AutheliaCtx) {
 		ctx.Error(fmt.Errorf("unable to parse body during logout: %w", err), messageOperationFailed)
 	}
 
+	var username string
+
+	if userSession, err := ctx.GetSession(); err == nil {
+		username = userSession.Username
+	}
+
 	err = ctx.DestroySession()
 	if err != nil {
 		ctx.Error(fmt.Errorf("unable to destroy session during logout: %w", err), messageOperationFailed)
@@ -39,8 +63,205 @@ func LogoutPOST(ctx *middlewares.AutheliaCtx) {
 		ctx.Logger.Debugf("Logout target url is %s, safe %t", body.TargetURL, responseBody.SafeTargetURL)
 	}
 
+	if !responseBody.SafeTargetURL {
+		redirectionURL = nil
+	}
+
+	if redirectionURL, err = handleLogoutRedirectURL(ctx, username, redirectionURL); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred starting the global logout for user '%s'", username)
+	} else if redirectionURL != nil {
+		responseBody.RedirectURL = redirectionURL.String()
+	}
+
 	err = ctx.SetJSONBody(responseBody)
 	if err != nil {
 		ctx.Error(fmt.Errorf("unable to set body during logout: %w", err), messageOperationFailed)
 	}
 }
+
+// LogoutGET is the handler which continues a global logout on the cookie domain of the request. It destroys the
+// session of the user the logout was started for and redirects the user agent to the next cookie domain, or to the
+// final redirection URL once all cookie domains have been logged out.
+func LogoutGET(ctx *middlewares.AutheliaCtx) {
+	claims := &logoutClaims{}
+
+	_, err := jwt.ParseWithClaims(string(ctx.QueryArgs().PeekBytes(qryArgToken)), claims,
+		func(token *jwt.Token) (any, error) {
+			return []byte(ctx.Configuration.JWTSecret), nil
+		},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuedAt(),
+		jwt.WithIssuer("Authelia"),
+		jwt.WithAudience(logoutTokenAudience),
+		jwt.WithStrictDecoding(),
+		ctx.GetJWTWithTimeFuncOption(),
+	)
+
+	switch {
+	case err == nil:
+		break
+	case errors.Is(err, jwt.ErrTokenExpired):
+		ctx.Logger.WithError(err).Error("Error occurred continuing the global logout: the token has expired")
+		ctx.ReplyBadRequest()
+
+		return
+	default:
+		ctx.Logger.WithError(err).Error("Error occurred continuing the global logout: the token is invalid")
+		ctx.ReplyBadRequest()
+
+		return
+	}
+
+	var domain string
+
+	if domain, err = ctx.GetCookieDomain(); err != nil || len(claims.Domains) == 0 || claims.Domains[0] != domain {
+		ctx.Logger.Errorf("Error occurred continuing the global logout for user '%s': the token is not valid for the cookie domain '%s'", claims.Subject, domain)
+		ctx.ReplyBadRequest()
+
+		return
+	}
+
+	var userSession session.UserSession
+
+	if userSession, err = ctx.GetSession(); err == nil && userSession.Username != "" {
+		if userSession.Username == claims.Subject {
+			if err = ctx.DestroySession(); err != nil {
+				ctx.Logger.WithError(err).Errorf("Error occurred destroying the session of user '%s' during the global logout of cookie domain '%s'", claims.Subject, domain)
+			}
+		} else {
+			ctx.Logger.Warnf("Skipped destroying the session of user '%s' during the global logout of cookie domain '%s' which was started for user '%s'", userSession.Username, domain, claims.Subject)
+		}
+	}
+
+	claims.Domains = claims.Domains[1:]
+
+	if len(claims.Domains) == 0 {
+		ctx.Logger.Debugf("Completed the global logout for user '%s'", claims.Subject)
+		ctx.Redirect(claims.RedirectURL, fasthttp.StatusFound)
+
+		return
+	}
+
+	var redirectURL *url.URL
+
+	if redirectURL, err = handleLogoutGlobalRedirectURL(ctx, claims); err != nil {
+		ctx.Logger.WithError(err).Errorf("Error occurred continuing the global logout for user '%s'", claims.Subject)
+		ctx.Redirect(claims.RedirectURL, fasthttp.StatusFound)
+
+		return
+	}
+
+	ctx.Redirect(redirectURL.String(), fasthttp.StatusFound)
+}
+
+// handleLogoutRedirectURL returns the URL the user agent should be redirected to after the session of the current
+// cookie domain was destroyed. When global logout is enabled and the user has sessions on other cookie domains this is
+// the logout endpoint of the first of them, otherwise it's the configured logout redirection URL unless a safe target
+// URL was provided.
+func handleLogoutRedirectURL(ctx *middlewares.AutheliaCtx, username string, targetURL *url.URL) (redirectURL *url.URL, err error) {
+	finalURL := targetURL
+
+	if finalURL == nil {
+		finalURL = ctx.Configuration.Session.Logout.RedirectionURL
+	}
+
+	if !ctx.Configuration.Session.Logout.Global || username == "" {
+		if targetURL != nil {
+			return nil, nil
+		}
+
+		return finalURL, nil
+	}
+
+	var domains []string
+
+	if domains, err = handleLogoutGlobalDomains(ctx, username); err != nil || len(domains) == 0 {
+		if targetURL != nil {
+			return nil, err
+		}
+
+		return finalURL, err
+	}
+
+	if finalURL == nil {
+		finalURL = ctx.RootURLSlash()
+	}
+
+	now := ctx.Clock.Now()
+
+	claims := &logoutClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   username,
+			Issuer:    "Authelia",
+			Audience:  jwt.ClaimStrings{logoutTokenAudience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(logoutTokenLifespan)),
+		},
+		Domains:     domains,
+		RedirectURL: finalURL.String(),
+	}
+
+	ctx.Logger.Debugf("Starting the global logout for user '%s' of cookie domains %v", username, domains)
+
+	return handleLogoutGlobalRedirectURL(ctx, claims)
+}
+
+// handleLogoutGlobalDomains returns the configured cookie domains other than the one of the current request which the
+// user has active sessions on, in the order they're configured.
+func handleLogoutGlobalDomains(ctx *middlewares.AutheliaCtx, username string) (domains []string, err error) {
+	var records []session.UserSessionRecord
+
+	if records, err = ctx.Providers.SessionProvider.GetUserSessions(username, ctx.Clock.Now()); err != nil {
+		return nil, fmt.Errorf("unable to retrieve the sessions of the user: %w", err)
+	}
+
+	active := make(map[string]bool, len(records))
+
+	for _, record := range records {
+		active[record.CookieDomain] = true
+	}
+
+	current, _ := ctx.GetCookieDomain()
+
+	for _, cookie := range ctx.Configuration.Session.Cookies {
+		if cookie.Domain == current || cookie.AutheliaURL == nil || !active[cookie.Domain] {
+			continue
+		}
+
+		domains = append(domains, cookie.Domain)
+	}
+
+	return domains, nil
+}
+
+// handleLogoutGlobalRedirectURL signs the claims and returns the URL of the logout endpoint of the first of the
+// remaining cookie domains.
+func handleLogoutGlobalRedirectURL(ctx *middlewares.AutheliaCtx, claims *logoutClaims) (redirectURL *url.URL, err error) {
+	for _, cookie := range ctx.Configuration.Session.Cookies {
+		if cookie.Domain != claims.Domains[0] || cookie.AutheliaURL == nil {
+			continue
+		}
+
+		var token string
+
+		if token, err = jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(ctx.Configuration.JWTSecret)); err != nil {
+			return nil, fmt.Errorf("unable to sign the logout token: %w", err)
+		}
+
+		redirectURL = &url.URL{
+			Scheme: cookie.AutheliaURL.Scheme,
+			Host:   cookie.AutheliaURL.Host,
+			Path:   path.Join(cookie.AutheliaURL.Path, pathLogout),
+		}
+
+		query := redirectURL.Query()
+
+		query.Set(queryArgToken, token)
+
+		redirectURL.RawQuery = query.Encode()
+
+		return redirectURL, nil
+	}
+
+	return nil, fmt.Errorf("unable to find the authelia url of the cookie domain '%s'", claims.Domains[0])
+}
@@ -1,12 +1,17 @@
 package handlers
 
 import (
+	"encoding/json"
+	"net/url"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
+	"github.com/valyala/fasthttp"
 
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/mocks"
 )
 
@@ -18,6 +23,8 @@ type LogoutSuite struct {
 
 func (s *LogoutSuite) SetupTest() {
 	s.mock = mocks.NewMockAutheliaCtx(s.T())
+
+	s.mock.Ctx.Clock = &s.mock.Clock
 	provider, err := s.mock.Ctx.GetSessionProvider()
 	s.Assert().NoError(err)
 
@@ -25,7 +32,7 @@ func (s *LogoutSuite) SetupTest() {
 	s.Assert().NoError(err)
 
 	userSession.Username = testUsername
-	s.Assert().NoError(provider.SaveSession(s.mock.Ctx.RequestCtx, userSession))
+	s.Assert().NoError(provider.SaveIndexedSession(s.mock.Ctx.RequestCtx, userSession, s.mock.Clock.Now(), s.mock.Ctx.RemoteIP()))
 }
 
 func (s *LogoutSuite) TearDownTest() {
@@ -41,6 +48,141 @@ func (s *LogoutSuite) TestShouldDestroySession() {
 	assert.True(s.T(), strings.HasPrefix(string(b), "authelia_session=;"))
 }
 
+func (s *LogoutSuite) response() (body logoutResponseBody) {
+	response := struct {
+		Status string             `json:"status"`
+		Data   logoutResponseBody `json:"data"`
+	}{}
+
+	s.Require().NoError(json.Unmarshal(s.mock.Ctx.Response.Body(), &response))
+	s.Require().Equal("OK", response.Status)
+
+	return response.Data
+}
+
+func (s *LogoutSuite) setupGlobalLogout() (other *fasthttp.RequestCtx) {
+	s.mock.Ctx.Configuration.JWTSecret = testJWTSecret
+	s.mock.Ctx.Configuration.Session.Logout.Global = true
+	s.mock.Ctx.Configuration.Session.Cookies[0].AutheliaURL = &url.URL{Scheme: "https", Host: "auth.example.com"}
+	s.mock.Ctx.Configuration.Session.Cookies[1].AutheliaURL = &url.URL{Scheme: "https", Host: "auth.example2.com"}
+
+	provider, err := s.mock.Ctx.Providers.SessionProvider.Get("example2.com")
+	s.Require().NoError(err)
+
+	other = &fasthttp.RequestCtx{}
+
+	userSession, err := provider.GetSession(other)
+	s.Require().NoError(err)
+
+	userSession.Username = testUsername
+	userSession.AuthenticationLevel = authentication.OneFactor
+	s.Require().NoError(provider.SaveIndexedSession(other, userSession, s.mock.Clock.Now(), s.mock.Ctx.RemoteIP()))
+
+	return other
+}
+
+func (s *LogoutSuite) TestShouldNotReturnRedirectURLByDefault() {
+	s.setupGlobalLogout()
+	s.mock.Ctx.Configuration.Session.Logout.Global = false
+
+	LogoutPOST(s.mock.Ctx)
+
+	s.Equal(logoutResponseBody{}, s.response())
+}
+
+func (s *LogoutSuite) TestShouldReturnConfiguredRedirectionURL() {
+	s.mock.Ctx.Configuration.Session.Logout.RedirectionURL = &url.URL{Scheme: "https", Host: "www.example.com", Path: "/logged-out"}
+
+	LogoutPOST(s.mock.Ctx)
+
+	s.Equal(logoutResponseBody{RedirectURL: "https://www.example.com/logged-out"}, s.response())
+}
+
+func (s *LogoutSuite) TestShouldPreferSafeTargetURLOverConfiguredRedirectionURL() {
+	s.mock.Ctx.Configuration.Session.Logout.RedirectionURL = &url.URL{Scheme: "https", Host: "www.example.com", Path: "/logged-out"}
+	s.mock.Ctx.Request.SetBodyString(`{"targetURL":"https://www.example.com/target"}`)
+
+	LogoutPOST(s.mock.Ctx)
+
+	s.Equal(logoutResponseBody{SafeTargetURL: true}, s.response())
+}
+
+func (s *LogoutSuite) TestShouldLogoutAllCookieDomains() {
+	other := s.setupGlobalLogout()
+
+	s.mock.Ctx.Request.SetBodyString(`{"targetURL":"https://www.example.com/target"}`)
+
+	LogoutPOST(s.mock.Ctx)
+
+	body := s.response()
+
+	s.True(body.SafeTargetURL)
+	s.Require().True(strings.HasPrefix(body.RedirectURL, "https://auth.example2.com/api/logout?token="), body.RedirectURL)
+
+	redirectURL, err := url.ParseRequestURI(body.RedirectURL)
+	s.Require().NoError(err)
+
+	cookie := fasthttp.Cookie{}
+	s.Require().NoError(cookie.ParseBytes(other.Response.Header.PeekCookie("authelia_session")))
+
+	request := &fasthttp.RequestCtx{}
+	request.Request.Header.Set(fasthttp.HeaderXForwardedProto, "https")
+	request.Request.Header.Set(fasthttp.HeaderXForwardedHost, "auth.example2.com")
+	request.Request.Header.SetCookieBytesKV(cookie.Key(), cookie.Value())
+	request.Request.SetRequestURI(redirectURL.RequestURI())
+
+	ctx := middlewares.NewAutheliaCtx(request, s.mock.Ctx.Configuration, s.mock.Ctx.Providers)
+	ctx.Clock = &s.mock.Clock
+
+	LogoutGET(ctx)
+
+	s.Equal(fasthttp.StatusFound, request.Response.StatusCode())
+	s.Equal("https://www.example.com/target", string(request.Response.Header.Peek(fasthttp.HeaderLocation)))
+	s.True(strings.HasPrefix(string(request.Response.Header.PeekCookie("authelia_session")), "authelia_session=;"))
+
+	records, err := s.mock.Ctx.Providers.SessionProvider.GetUserSessions(testUsername, s.mock.Clock.Now())
+	s.Require().NoError(err)
+	s.Len(records, 0)
+}
+
+func (s *LogoutSuite) TestShouldNotLogoutCookieDomainWithInvalidToken() {
+	testCases := []struct {
+		name  string
+		token string
+	}{
+		{"ShouldRejectMissingToken", ""},
+		{"ShouldRejectMalformedToken", "abc"},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			s.mock.Ctx.Response.Reset()
+			s.mock.Ctx.Request.SetRequestURI("/api/logout?token=" + tc.token)
+
+			LogoutGET(s.mock.Ctx)
+
+			s.Equal(fasthttp.StatusBadRequest, s.mock.Ctx.Response.StatusCode())
+			s.Nil(s.mock.Ctx.Response.Header.PeekCookie("authelia_session"))
+		})
+	}
+}
+
+func (s *LogoutSuite) TestShouldNotLogoutCookieDomainNotInToken() {
+	s.setupGlobalLogout()
+
+	LogoutPOST(s.mock.Ctx)
+
+	redirectURL, err := url.ParseRequestURI(s.response().RedirectURL)
+	s.Require().NoError(err)
+
+	s.mock.Ctx.Response.Reset()
+	s.mock.Ctx.Request.SetRequestURI(redirectURL.RequestURI())
+
+	LogoutGET(s.mock.Ctx)
+
+	s.Equal(fasthttp.StatusBadRequest, s.mock.Ctx.Response.StatusCode())
+}
+
 func TestRunLogoutSuite(t *testing.T) {
 	s := new(LogoutSuite)
 	suite.Run(t, s)
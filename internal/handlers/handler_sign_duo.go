@@ -36,6 +36,12 @@ func DuoPOST(duoAPI duo.API) middlewares.RequestHandler {
 			return
 		}
 
+		if regulateSecondFactor(ctx, userSession.Username, regulation.AuthTypeDuo) {
+			respondUnauthorized(ctx, messageMFAValidationFailed)
+
+			return
+		}
+
 		remoteIP := ctx.RemoteIP().String()
 
 		duoDevice, err := ctx.Providers.StorageProvider.LoadPreferredDuoDevice(ctx, userSession.Username)
@@ -78,7 +84,7 @@ func DuoPOST(duoAPI duo.API) middlewares.RequestHandler {
 		}
 
 		if authResponse.Result != allow {
-			_ = markAuthenticationAttempt(ctx, false, nil, userSession.Username, regulation.AuthTypeDuo,
+			markSecondFactorAttemptFailed(ctx, userSession.Username, regulation.AuthTypeDuo,
 				fmt.Errorf("duo auth result: %s, status: %s, message: %s", authResponse.Result, authResponse.Status,
 					authResponse.StatusMessage))
 
@@ -31,6 +31,12 @@ func TimeBasedOneTimePasswordPOST(ctx *middlewares.AutheliaCtx) {
 		return
 	}
 
+	if regulateSecondFactor(ctx, userSession.Username, regulation.AuthTypeTOTP) {
+		respondUnauthorized(ctx, messageMFAValidationFailed)
+
+		return
+	}
+
 	config, err := ctx.Providers.StorageProvider.LoadTOTPConfiguration(ctx, userSession.Username)
 	if err != nil {
 		ctx.Logger.Errorf("Failed to load TOTP configuration: %+v", err)
@@ -50,7 +56,7 @@ func TimeBasedOneTimePasswordPOST(ctx *middlewares.AutheliaCtx) {
 	}
 
 	if !isValid {
-		_ = markAuthenticationAttempt(ctx, false, nil, userSession.Username, regulation.AuthTypeTOTP, nil)
+		markSecondFactorAttemptFailed(ctx, userSession.Username, regulation.AuthTypeTOTP, nil)
 
 		respondUnauthorized(ctx, messageMFAValidationFailed)
 
@@ -5,11 +5,15 @@ import (
 	"errors"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/suite"
+	"github.com/valyala/fasthttp"
 
+	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/mocks"
 	"github.com/authelia/authelia/v4/internal/model"
 	"github.com/authelia/authelia/v4/internal/regulation"
@@ -267,6 +271,138 @@ func (s *HandlerSignTOTPSuite) TestShouldRegenerateSessionForPreventingSessionFi
 		string(s.mock.Ctx.Request.Header.Cookie("authelia_session")))
 }
 
+func (s *HandlerSignTOTPSuite) TestShouldLockSessionWhenBanned() {
+	s.mock.Ctx.Configuration.Regulation.SecondFactor.TOTP = schema.RegulationSecondFactorMethod{MaxRetries: 3, FindTime: time.Minute * 2, BanTime: time.Minute * 5}
+	s.mock.Ctx.Providers.Regulator = regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
+
+	config := model.TOTPConfiguration{ID: 1, Username: "john", Digits: 6, Secret: []byte("secret"), Period: 30, Algorithm: "SHA1"}
+
+	failed := model.AuthenticationAttempt{
+		Username:   "john",
+		Successful: false,
+		Banned:     false,
+		Time:       s.mock.Clock.Now(),
+		Type:       regulation.AuthTypeTOTP,
+		RemoteIP:   model.NewNullIPFromString("0.0.0.0"),
+	}
+
+	gomock.InOrder(
+		s.mock.StorageMock.EXPECT().
+			LoadAuthenticationLogsByUsernameAndType(s.mock.Ctx, "john", regulation.AuthTypeTOTP, gomock.Any(), 3, 0).
+			Return([]model.AuthenticationAttempt{failed, failed}, nil),
+		s.mock.StorageMock.EXPECT().
+			LoadTOTPConfiguration(s.mock.Ctx, gomock.Any()).
+			Return(&config, nil),
+		s.mock.TOTPMock.EXPECT().
+			Validate(gomock.Eq("abc"), gomock.Eq(&config)).
+			Return(false, nil),
+		s.mock.StorageMock.
+			EXPECT().
+			AppendAuthenticationLog(s.mock.Ctx, gomock.Eq(failed)),
+		s.mock.StorageMock.EXPECT().
+			LoadAuthenticationLogsByUsernameAndType(s.mock.Ctx, "john", regulation.AuthTypeTOTP, gomock.Any(), 3, 0).
+			Return([]model.AuthenticationAttempt{failed, failed, failed}, nil),
+		s.mock.StorageMock.EXPECT().
+			LoadRegulationUnbanTimeByUsername(s.mock.Ctx, "john").
+			Return(time.Time{}, nil),
+		s.mock.UserProviderMock.EXPECT().
+			GetDetails(gomock.Eq("john")).
+			Return(&authentication.UserDetails{Username: "john", DisplayName: "John Smith", Emails: []string{"john@example.com"}}, nil),
+		s.mock.NotifierMock.EXPECT().
+			Send(s.mock.Ctx, gomock.Any(), gomock.Eq("Second Factor Authentication Locked"), gomock.Any(), gomock.Any()).
+			Return(nil),
+	)
+
+	bodyBytes, err := json.Marshal(bodySignTOTPRequest{
+		Token: "abc",
+	})
+	s.Require().NoError(err)
+	s.mock.Ctx.Request.SetBody(bodyBytes)
+
+	TimeBasedOneTimePasswordPOST(s.mock.Ctx)
+	s.mock.Assert401KO(s.T(), "Authentication failed, please retry later.")
+
+	userSession, err := s.mock.Ctx.GetSession()
+	s.Require().NoError(err)
+	s.Equal("", userSession.Username)
+}
+
+func (s *HandlerSignTOTPSuite) TestShouldRejectRevokedSession() {
+	s.mock.Ctx.Clock = &s.mock.Clock
+
+	provider, err := s.mock.Ctx.GetSessionProvider()
+	s.Require().NoError(err)
+
+	userSession, err := provider.GetSession(s.mock.Ctx.RequestCtx)
+	s.Require().NoError(err)
+
+	userSession.AuthenticationLevel = authentication.OneFactor
+	userSession.FirstFactorAuthnTimestamp = s.mock.Clock.Now().Add(-time.Minute).Unix()
+
+	s.Require().NoError(provider.SaveSession(s.mock.Ctx.RequestCtx, userSession))
+
+	_, err = s.mock.Ctx.Providers.SessionProvider.RevokeAllUserSessions(testUsername, s.mock.Clock.Now())
+	s.Require().NoError(err)
+
+	bodyBytes, err := json.Marshal(bodySignTOTPRequest{
+		Token: "abc",
+	})
+	s.Require().NoError(err)
+	s.mock.Ctx.Request.SetBody(bodyBytes)
+
+	middlewares.Require1FA(TimeBasedOneTimePasswordPOST)(s.mock.Ctx)
+
+	s.Equal(fasthttp.StatusForbidden, s.mock.Ctx.Response.StatusCode())
+
+	userSession, err = s.mock.Ctx.GetSession()
+	s.Require().NoError(err)
+	s.True(userSession.IsAnonymous())
+}
+
+func (s *HandlerSignTOTPSuite) TestShouldRejectWhenBanned() {
+	s.mock.Ctx.Configuration.Regulation.SecondFactor.TOTP = schema.RegulationSecondFactorMethod{MaxRetries: 3, FindTime: time.Minute * 2, BanTime: time.Minute * 5}
+	s.mock.Ctx.Providers.Regulator = regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
+
+	failed := model.AuthenticationAttempt{
+		Username:   "john",
+		Successful: false,
+		Time:       s.mock.Clock.Now().Add(-time.Minute),
+		Type:       regulation.AuthTypeTOTP,
+	}
+
+	gomock.InOrder(
+		s.mock.StorageMock.EXPECT().
+			LoadAuthenticationLogsByUsernameAndType(s.mock.Ctx, "john", regulation.AuthTypeTOTP, gomock.Any(), 3, 0).
+			Return([]model.AuthenticationAttempt{failed, failed, failed}, nil),
+		s.mock.StorageMock.EXPECT().
+			LoadRegulationUnbanTimeByUsername(s.mock.Ctx, "john").
+			Return(time.Time{}, nil),
+		s.mock.StorageMock.
+			EXPECT().
+			AppendAuthenticationLog(s.mock.Ctx, gomock.Eq(model.AuthenticationAttempt{
+				Username:   "john",
+				Successful: false,
+				Banned:     true,
+				Time:       s.mock.Clock.Now(),
+				Type:       regulation.AuthTypeTOTP,
+				RemoteIP:   model.NewNullIPFromString("0.0.0.0"),
+			})),
+	)
+
+	bodyBytes, err := json.Marshal(bodySignTOTPRequest{
+		Token: "abc",
+	})
+	s.Require().NoError(err)
+	s.mock.Ctx.Request.SetBody(bodyBytes)
+
+	TimeBasedOneTimePasswordPOST(s.mock.Ctx)
+	s.mock.Assert401KO(s.T(), "Authentication failed, please retry later.")
+
+	userSession, err := s.mock.Ctx.GetSession()
+	s.Require().NoError(err)
+	s.Equal("", userSession.Username)
+}
+
 func TestRunHandlerSignTOTPSuite(t *testing.T) {
 	suite.Run(t, new(HandlerSignTOTPSuite))
 }
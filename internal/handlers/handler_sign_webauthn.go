@@ -115,6 +115,12 @@ func WebAuthnAssertionPOST(ctx *middlewares.AutheliaCtx) {
 		return
 	}
 
+	if regulateSecondFactor(ctx, userSession.Username, regulation.AuthTypeWebAuthn) {
+		respondUnauthorized(ctx, messageMFAValidationFailed)
+
+		return
+	}
+
 	if userSession.WebAuthn == nil {
 		ctx.Logger.Errorf("WebAuthn session data is not present in order to handle assertion for user '%s'. This could indicate a user trying to POST to the wrong endpoint, or the session data is not present for the browser they used.", userSession.Username)
 
@@ -154,7 +160,7 @@ func WebAuthnAssertionPOST(ctx *middlewares.AutheliaCtx) {
 	}
 
 	if credential, err = w.ValidateLogin(user, *userSession.WebAuthn, assertionResponse); err != nil {
-		_ = markAuthenticationAttempt(ctx, false, nil, userSession.Username, regulation.AuthTypeWebAuthn, err)
+		markSecondFactorAttemptFailed(ctx, userSession.Username, regulation.AuthTypeWebAuthn, err)
 
 		respondUnauthorized(ctx, messageMFAValidationFailed)
 
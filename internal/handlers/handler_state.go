@@ -1,6 +1,13 @@
 package handlers
 
 import (
+	"net/url"
+	"time"
+
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/authorization"
 	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/session"
 )
@@ -22,7 +29,7 @@ func StateGET(ctx *middlewares.AutheliaCtx) {
 
 	stateResponse := StateResponse{
 		Username:            userSession.Username,
-		AuthenticationLevel: userSession.AuthenticationLevel,
+		AuthenticationLevel: stateAuthenticationLevel(ctx, &userSession),
 	}
 
 	if uri := ctx.GetDefaultRedirectionURL(); uri != nil {
@@ -33,3 +40,50 @@ func StateGET(ctx *middlewares.AutheliaCtx) {
 		ctx.Logger.Errorf("Unable to set state response in body: %s", err)
 	}
 }
+
+// stateAuthenticationLevel returns the authentication level of the user session. When the redirection URL is provided
+// and the rule which matches it has a maximum authentication age which has been exceeded the level is lowered so the
+// portal requires the user to perform the relevant factors again. The rule is matched using the request method of the
+// original request when provided so rules with the methods criteria match the same way as they do in the authz
+// endpoints. The level of the session itself is not modified.
+func stateAuthenticationLevel(ctx *middlewares.AutheliaCtx, userSession *session.UserSession) authentication.Level {
+	rd := ctx.QueryArgs().PeekBytes(qryArgRD)
+
+	if len(rd) == 0 || userSession.AuthenticationLevel == authentication.NotAuthenticated {
+		return userSession.AuthenticationLevel
+	}
+
+	targetURL, err := url.ParseRequestURI(string(rd))
+	if err != nil || !ctx.IsSafeRedirectionTargetURI(targetURL) {
+		return userSession.AuthenticationLevel
+	}
+
+	method := ctx.QueryArgs().PeekBytes(qryArgRM)
+
+	if len(method) == 0 || hasInvalidMethodCharacters(method) {
+		method = []byte(fasthttp.MethodGet)
+	}
+
+	record := ctx.RemoteGeoIP()
+
+	rule, _, _ := ctx.Providers.Authorizer.GetRequiredPolicy(
+		authorization.Subject{
+			Username:    userSession.Username,
+			DisplayName: userSession.DisplayName,
+			Groups:      userSession.Groups,
+			Emails:      userSession.Emails,
+			IP:          ctx.RemoteIP(),
+			Country:     record.Country,
+			ASN:         record.ASN,
+			Attributes:  userSession.Extra,
+		},
+		authorization.NewObject(targetURL, string(method)),
+	)
+
+	if rule == nil || rule.MaxAuthenticationAge.IsZero() {
+		return userSession.AuthenticationLevel
+	}
+
+	return rule.MaxAuthenticationAge.EffectiveLevel(ctx.Clock.Now(), userSession.AuthenticationLevel,
+		time.Unix(userSession.FirstFactorAuthnTimestamp, 0).UTC(), time.Unix(userSession.SecondFactorAuthnTimestamp, 0).UTC())
+}
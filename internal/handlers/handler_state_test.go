@@ -3,6 +3,7 @@ package handlers
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -10,6 +11,8 @@ import (
 	"github.com/valyala/fasthttp"
 
 	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/mocks"
 )
 
@@ -90,6 +93,128 @@ func (s *StateGetSuite) TestShouldReturnAuthenticationLevelFromSession() {
 	assert.Equal(s.T(), expectedBody, actualBody)
 }
 
+func (s *StateGetSuite) TestShouldLowerAuthenticationLevelWhenMaxAuthenticationAgeExceeded() {
+	testCases := []struct {
+		name     string
+		rd       string
+		rm       string
+		expected authentication.Level
+	}{
+		{"ShouldNotLowerWithoutRedirectionURL", "", "", authentication.TwoFactor},
+		{"ShouldLowerForRuleWithExceededAge", "https://finance.example.com/", "", authentication.OneFactor},
+		{"ShouldNotLowerForOtherRules", "https://www.example.com/", "", authentication.TwoFactor},
+		{"ShouldNotLowerForUnsafeRedirectionURL", "https://finance.example.org/", "", authentication.TwoFactor},
+		{"ShouldLowerForMethodRuleWithExceededAge", "https://api.example.com/", fasthttp.MethodPost, authentication.OneFactor},
+		{"ShouldNotLowerForMethodRuleWithOtherMethod", "https://api.example.com/", fasthttp.MethodGet, authentication.TwoFactor},
+		{"ShouldNotLowerForMethodRuleWithoutMethod", "https://api.example.com/", "", authentication.TwoFactor},
+		{"ShouldNotLowerForMethodRuleWithInvalidMethod", "https://api.example.com/", "post", authentication.TwoFactor},
+	}
+
+	for _, tc := range testCases {
+		s.T().Run(tc.name, func(t *testing.T) {
+			mock := mocks.NewMockAutheliaCtx(t)
+
+			defer mock.Close()
+
+			mock.Ctx.Clock = &mock.Clock
+
+			mock.Clock.Set(time.Now())
+
+			mock.Ctx.Configuration.AccessControl.DefaultPolicy = "two_factor"
+			mock.Ctx.Configuration.AccessControl.Rules = []schema.AccessControlRule{
+				{
+					Domains: []string{"finance.example.com", "finance.example.org"},
+					Policy:  "two_factor",
+					MaxAuthenticationAge: schema.AccessControlRuleMaxAuthenticationAge{
+						SecondFactor: time.Minute * 15,
+					},
+				},
+				{
+					Domains: []string{"api.example.com"},
+					Policy:  "two_factor",
+					Methods: []string{fasthttp.MethodPost},
+					MaxAuthenticationAge: schema.AccessControlRuleMaxAuthenticationAge{
+						SecondFactor: time.Minute * 15,
+					},
+				},
+			}
+
+			mock.Ctx.Providers.Authorizer = authorization.NewAuthorizer(&mock.Ctx.Configuration, &mock.Clock)
+
+			userSession, err := mock.Ctx.GetSession()
+			require.NoError(t, err)
+
+			userSession.Username = testUsername
+			userSession.AuthenticationLevel = authentication.TwoFactor
+			userSession.FirstFactorAuthnTimestamp = mock.Clock.Now().Add(-time.Hour).Unix()
+			userSession.SecondFactorAuthnTimestamp = mock.Clock.Now().Add(-time.Minute * 20).Unix()
+
+			require.NoError(t, mock.Ctx.SaveSession(userSession))
+
+			if tc.rd != "" {
+				mock.Ctx.Request.URI().QueryArgs().Set(queryArgRD, tc.rd)
+			}
+
+			if tc.rm != "" {
+				mock.Ctx.Request.URI().QueryArgs().Set(queryArgRM, tc.rm)
+			}
+
+			StateGET(mock.Ctx)
+
+			actual := struct {
+				Data StateResponse `json:"data"`
+			}{}
+
+			require.NoError(t, json.Unmarshal(mock.Ctx.Response.Body(), &actual))
+
+			assert.Equal(t, tc.expected, actual.Data.AuthenticationLevel)
+
+			userSession, err = mock.Ctx.GetSession()
+			require.NoError(t, err)
+
+			assert.Equal(t, authentication.TwoFactor, userSession.AuthenticationLevel)
+		})
+	}
+}
+
+func (s *StateGetSuite) TestShouldReturnAnonymousStateWhenSessionsRevoked() {
+	s.mock.Ctx.Clock = &s.mock.Clock
+
+	userSession, err := s.mock.Ctx.GetSession()
+	s.Require().NoError(err)
+
+	userSession.Username = testUsername
+	userSession.AuthenticationLevel = authentication.TwoFactor
+	userSession.FirstFactorAuthnTimestamp = s.mock.Clock.Now().Add(-time.Minute).Unix()
+	userSession.SecondFactorAuthnTimestamp = s.mock.Clock.Now().Add(-time.Minute).Unix()
+
+	provider, err := s.mock.Ctx.GetSessionProvider()
+	s.Require().NoError(err)
+
+	// The session is saved without being added to the user session index which is the same as a session which was
+	// saved concurrently with the revocation, so it's only rejected by the revocation itself.
+	s.Require().NoError(provider.SaveSession(s.mock.Ctx.RequestCtx, userSession))
+
+	_, err = s.mock.Ctx.Providers.SessionProvider.RevokeAllUserSessions(testUsername, s.mock.Clock.Now())
+	s.Require().NoError(err)
+
+	StateGET(s.mock.Ctx)
+
+	actual := struct {
+		Data StateResponse `json:"data"`
+	}{}
+
+	s.Require().NoError(json.Unmarshal(s.mock.Ctx.Response.Body(), &actual))
+
+	s.Equal(fasthttp.StatusOK, s.mock.Ctx.Response.StatusCode())
+	s.Equal("", actual.Data.Username)
+	s.Equal(authentication.NotAuthenticated, actual.Data.AuthenticationLevel)
+
+	userSession, err = s.mock.Ctx.GetSession()
+	s.Require().NoError(err)
+	s.True(userSession.IsAnonymous())
+}
+
 func TestRunStateGetSuite(t *testing.T) {
 	s := new(StateGetSuite)
 	suite.Run(t, s)
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/session"
+)
+
+// UserSessionsGET returns the active sessions of the user across all cookie domains.
+func UserSessionsGET(ctx *middlewares.AutheliaCtx) {
+	var (
+		userSession session.UserSession
+		records     []session.UserSessionRecord
+		current     string
+		err         error
+	)
+
+	if userSession, current, err = userSessionsCurrent(ctx); err != nil {
+		ctx.Logger.WithError(err).Error("Error occurred retrieving user session")
+
+		ctx.ReplyForbidden()
+
+		return
+	}
+
+	if records, err = ctx.Providers.SessionProvider.GetUserSessions(userSession.Username, ctx.Clock.Now()); err != nil {
+		ctx.Error(err, messageOperationFailed)
+
+		return
+	}
+
+	sessions := make([]UserSessionResponse, len(records))
+
+	for i, record := range records {
+		sessions[i] = UserSessionResponse{
+			ID:           record.ID,
+			CookieDomain: record.CookieDomain,
+			Created:      record.Created,
+			LastActivity: record.LastActivity,
+			RemoteIP:     record.RemoteIP,
+			UserAgent:    record.UserAgent,
+			Current:      record.ID == current,
+		}
+	}
+
+	if err = ctx.SetJSONBody(sessions); err != nil {
+		ctx.Logger.WithError(err).Error("Error occurred trying to set user sessions response in body")
+	}
+}
+
+// UserSessionDELETE revokes an active session of the user other than the current session.
+func UserSessionDELETE(ctx *middlewares.AutheliaCtx) {
+	var (
+		userSession session.UserSession
+		current     string
+		err         error
+	)
+
+	if userSession, current, err = userSessionsCurrent(ctx); err != nil {
+		ctx.Logger.WithError(err).Error("Error occurred retrieving user session")
+
+		ctx.ReplyForbidden()
+
+		return
+	}
+
+	id, _ := ctx.UserValue(UserValueKeySessionID).(string)
+
+	if id == current {
+		ctx.SetStatusCode(fasthttp.StatusBadRequest)
+		ctx.SetJSONError("The current session can't be revoked, use logout instead.")
+
+		return
+	}
+
+	if err = ctx.Providers.SessionProvider.RevokeUserSession(userSession.Username, id, ctx.Clock.Now()); err != nil {
+		if errors.Is(err, session.ErrUserSessionNotFound) {
+			ctx.SetStatusCode(fasthttp.StatusNotFound)
+			ctx.SetJSONError("Could not find the session.")
+		} else {
+			ctx.SetStatusCode(fasthttp.StatusInternalServerError)
+			ctx.SetJSONError(messageOperationFailed)
+			ctx.Logger.WithError(err).Errorf("Failed to revoke session '%s' for user '%s'", id, userSession.Username)
+		}
+
+		return
+	}
+
+	ctx.Logger.WithFields(map[string]any{"username": userSession.Username, "session": id}).Info("Session was revoked by the user")
+
+	ctx.ReplyOK()
+}
+
+// userSessionsCurrent returns the user session and the public identifier of the session of the request.
+func userSessionsCurrent(ctx *middlewares.AutheliaCtx) (userSession session.UserSession, current string, err error) {
+	var provider *session.Session
+
+	if provider, err = ctx.GetSessionProvider(); err != nil {
+		return userSession, "", err
+	}
+
+	if userSession, err = ctx.GetSession(); err != nil {
+		return userSession, "", err
+	}
+
+	if current, err = provider.GetSessionRecordID(ctx.RequestCtx); err != nil {
+		return userSession, "", err
+	}
+
+	return userSession, current, nil
+}
@@ -0,0 +1,172 @@
+package handlers
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/mocks"
+)
+
+type UserSessionsSuite struct {
+	suite.Suite
+
+	mock *mocks.MockAutheliaCtx
+
+	current, other string
+}
+
+func (s *UserSessionsSuite) SetupTest() {
+	s.mock = mocks.NewMockAutheliaCtx(s.T())
+	s.mock.Ctx.Clock = &s.mock.Clock
+
+	provider, err := s.mock.Ctx.GetSessionProvider()
+	s.Require().NoError(err)
+
+	other := &fasthttp.RequestCtx{}
+	other.Request.Header.SetUserAgent("phone")
+
+	for _, ctx := range []*fasthttp.RequestCtx{s.mock.Ctx.RequestCtx, other} {
+		userSession, err := provider.GetSession(ctx)
+		s.Require().NoError(err)
+
+		userSession.Username = testUsername
+		userSession.AuthenticationLevel = authentication.OneFactor
+
+		s.Require().NoError(provider.SaveIndexedSession(ctx, userSession, s.mock.Clock.Now(), s.mock.Ctx.RemoteIP()))
+	}
+
+	s.current, err = provider.GetSessionRecordID(s.mock.Ctx.RequestCtx)
+	s.Require().NoError(err)
+
+	s.other, err = provider.GetSessionRecordID(other)
+	s.Require().NoError(err)
+}
+
+func (s *UserSessionsSuite) TearDownTest() {
+	s.mock.Close()
+}
+
+func (s *UserSessionsSuite) sessions() (sessions []UserSessionResponse) {
+	s.mock.Ctx.Response.Reset()
+
+	UserSessionsGET(s.mock.Ctx)
+
+	s.Require().Equal(fasthttp.StatusOK, s.mock.Ctx.Response.StatusCode())
+
+	body := struct {
+		Status string                `json:"status"`
+		Data   []UserSessionResponse `json:"data"`
+	}{}
+
+	s.Require().NoError(json.Unmarshal(s.mock.Ctx.Response.Body(), &body))
+	s.Require().Equal("OK", body.Status)
+
+	return body.Data
+}
+
+func (s *UserSessionsSuite) TestShouldListSessions() {
+	sessions := s.sessions()
+
+	s.Require().Len(sessions, 2)
+
+	actual := map[string]UserSessionResponse{}
+
+	for _, userSession := range sessions {
+		actual[userSession.ID] = userSession
+	}
+
+	s.Require().Contains(actual, s.current)
+	s.Require().Contains(actual, s.other)
+
+	s.True(actual[s.current].Current)
+	s.False(actual[s.other].Current)
+	s.Equal("phone", actual[s.other].UserAgent)
+	s.Equal("example.com", actual[s.other].CookieDomain)
+	s.NotContains(string(s.mock.Ctx.Response.Body()), "session_id")
+}
+
+func (s *UserSessionsSuite) TestShouldRevokeOtherSession() {
+	s.mock.Ctx.SetUserValue(UserValueKeySessionID, s.other)
+
+	UserSessionDELETE(s.mock.Ctx)
+
+	s.Equal(fasthttp.StatusOK, s.mock.Ctx.Response.StatusCode())
+	s.Equal(`{"status":"OK"}`, string(s.mock.Ctx.Response.Body()))
+
+	sessions := s.sessions()
+
+	s.Require().Len(sessions, 1)
+	s.Equal(s.current, sessions[0].ID)
+}
+
+func (s *UserSessionsSuite) TestShouldNotRevokeCurrentSession() {
+	s.mock.Ctx.SetUserValue(UserValueKeySessionID, s.current)
+
+	UserSessionDELETE(s.mock.Ctx)
+
+	s.Equal(fasthttp.StatusBadRequest, s.mock.Ctx.Response.StatusCode())
+	s.Equal(`{"status":"KO","message":"The current session can't be revoked, use logout instead."}`, string(s.mock.Ctx.Response.Body()))
+	s.Len(s.sessions(), 2)
+}
+
+func (s *UserSessionsSuite) TestShouldNotRevokeUnknownSession() {
+	s.mock.Ctx.SetUserValue(UserValueKeySessionID, "abc")
+
+	UserSessionDELETE(s.mock.Ctx)
+
+	s.Equal(fasthttp.StatusNotFound, s.mock.Ctx.Response.StatusCode())
+	s.Len(s.sessions(), 2)
+}
+
+func TestRunUserSessionsSuite(t *testing.T) {
+	s := new(UserSessionsSuite)
+	suite.Run(t, s)
+}
+
+func TestShouldNotRevokeSessionOfOtherUser(t *testing.T) {
+	mock := mocks.NewMockAutheliaCtx(t)
+	mock.Ctx.Clock = &mock.Clock
+
+	defer mock.Close()
+
+	provider, err := mock.Ctx.GetSessionProvider()
+	require.NoError(t, err)
+
+	other := &fasthttp.RequestCtx{}
+
+	userSession, err := provider.GetSession(other)
+	require.NoError(t, err)
+
+	userSession.Username = "harry"
+	userSession.AuthenticationLevel = authentication.OneFactor
+
+	require.NoError(t, provider.SaveIndexedSession(other, userSession, mock.Clock.Now(), mock.Ctx.RemoteIP()))
+
+	id, err := provider.GetSessionRecordID(other)
+	require.NoError(t, err)
+
+	userSession, err = mock.Ctx.GetSession()
+	require.NoError(t, err)
+
+	userSession.Username = testUsername
+	userSession.AuthenticationLevel = authentication.OneFactor
+
+	require.NoError(t, mock.Ctx.SaveSession(userSession))
+
+	mock.Ctx.SetUserValue(UserValueKeySessionID, id)
+
+	UserSessionDELETE(mock.Ctx)
+
+	assert.Equal(t, fasthttp.StatusNotFound, mock.Ctx.Response.StatusCode())
+
+	records, err := mock.Ctx.Providers.SessionProvider.GetUserSessions("harry", mock.Clock.Now())
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, id, records[0].ID)
+}
@@ -42,11 +42,15 @@ func Handle1FAResponse(ctx *middlewares.AutheliaCtx, targetURI, requestMethod st
 		return
 	}
 
+	record := ctx.RemoteGeoIP()
+
 	_, requiredLevel := ctx.Providers.Authorizer.GetRequiredLevel(
 		authorization.Subject{
 			Username: username,
 			Groups:   groups,
 			IP:       ctx.RemoteIP(),
+			Country:  record.Country,
+			ASN:      record.ASN,
 		},
 		authorization.NewObject(targetURL, requestMethod))
 
@@ -299,6 +303,44 @@ func markAuthenticationAttempt(ctx *middlewares.AutheliaCtx, successful bool, ba
 	return nil
 }
 
+// regulateSecondFactor determines if the user is banned from the second factor method, in which case the attempt is
+// marked as banned and the session is locked.
+func regulateSecondFactor(ctx *middlewares.AutheliaCtx, username, authType string) (banned bool) {
+	bannedUntil, err := ctx.Providers.Regulator.RegulateSecondFactor(ctx, username, authType)
+	if err == nil {
+		return false
+	}
+
+	_ = markAuthenticationAttempt(ctx, false, &bannedUntil, username, authType, nil)
+
+	if err = ctx.DestroySession(); err != nil {
+		ctx.Logger.WithError(err).Errorf("Unable to lock the session of user '%s' who is banned from %s authentication", username, authType)
+	}
+
+	return true
+}
+
+// markSecondFactorAttemptFailed marks a failed second factor authentication attempt. If the attempt results in the user
+// being banned from the second factor method the session is locked, and the user is informed of the event.
+func markSecondFactorAttemptFailed(ctx *middlewares.AutheliaCtx, username, authType string, errAuth error) {
+	if err := markAuthenticationAttempt(ctx, false, nil, username, authType, errAuth); err != nil {
+		return
+	}
+
+	bannedUntil, err := ctx.Providers.Regulator.RegulateSecondFactor(ctx, username, authType)
+	if err == nil {
+		return
+	}
+
+	ctx.Logger.Errorf("User '%s' is banned from %s authentication until %s after too many failed attempts and their session has been locked", username, authType, bannedUntil)
+
+	if err = ctx.DestroySession(); err != nil {
+		ctx.Logger.WithError(err).Errorf("Unable to lock the session of user '%s' who is banned from %s authentication", username, authType)
+	}
+
+	ctxLogEvent(ctx, username, "Second Factor Authentication Locked", map[string]any{"Action": "Second Factor Authentication Locked", "Category": secondFactorCategories[authType], "Banned Until": bannedUntil.Format(time.RFC1123)})
+}
+
 func respondUnauthorized(ctx *middlewares.AutheliaCtx, message string) {
 	ctx.SetStatusCode(fasthttp.StatusUnauthorized)
 	ctx.SetJSONError(message)
@@ -3,6 +3,7 @@ package handlers
 import (
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/ory/fosite"
@@ -120,6 +121,24 @@ type StateResponse struct {
 	DefaultRedirectionURL string               `json:"default_redirection_url"`
 }
 
+// UserSessionResponse represents an active session of the user sent by the user sessions endpoint.
+type UserSessionResponse struct {
+	ID           string    `json:"id"`
+	CookieDomain string    `json:"cookie_domain"`
+	Created      time.Time `json:"created"`
+	LastActivity time.Time `json:"last_activity"`
+	RemoteIP     string    `json:"remote_ip"`
+	UserAgent    string    `json:"user_agent"`
+	Current      bool      `json:"current"`
+}
+
+// AdminUserSessionsRevokeResponse represents the number of sessions and tokens revoked by the admin user sessions
+// endpoint.
+type AdminUserSessionsRevokeResponse struct {
+	Sessions int `json:"sessions"`
+	Tokens   int `json:"tokens"`
+}
+
 // resetPasswordStep1RequestBody model of the reset password (step1) request body.
 type resetPasswordStep1RequestBody struct {
 	Username string `json:"username"`
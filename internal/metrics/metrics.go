@@ -18,4 +18,8 @@ type Recorder interface {
 	RecordRequestOpenIDConnect(endpoint, statusCode string, elapsed time.Duration)
 	RecordAuthz(statusCode string)
 	RecordAuthenticationDuration(success bool, elapsed time.Duration)
+	RecordReload(provider string, success bool)
+	RecordSessionBindingMismatch(action, mismatch string)
+	RecordSessionSecretDecryption(secret int)
+	RecordNotification(result string)
 }
@@ -26,6 +26,10 @@ type Prometheus struct {
 	authzCounter    *prometheus.CounterVec
 	authnCounter    *prometheus.CounterVec
 	authn2FACounter *prometheus.CounterVec
+	reloadCounter   *prometheus.CounterVec
+	bindingCounter  *prometheus.CounterVec
+	secretCounter   *prometheus.CounterVec
+	notifyCounter   *prometheus.CounterVec
 }
 
 // RecordRequest takes the statusCode string, requestMethod string, and the elapsed time.Duration to record the request and request duration metrics.
@@ -59,6 +63,28 @@ func (r *Prometheus) RecordAuthenticationDuration(success bool, elapsed time.Dur
 	r.authnDuration.WithLabelValues(strconv.FormatBool(success)).Observe(elapsed.Seconds())
 }
 
+// RecordReload takes the provider name string and the success boolean to record the provider reload metrics.
+func (r *Prometheus) RecordReload(provider string, success bool) {
+	r.reloadCounter.WithLabelValues(provider, strconv.FormatBool(success)).Inc()
+}
+
+// RecordSessionBindingMismatch takes the action string and the mismatch string to record the session binding mismatch
+// metrics.
+func (r *Prometheus) RecordSessionBindingMismatch(action, mismatch string) {
+	r.bindingCounter.WithLabelValues(action, mismatch).Inc()
+}
+
+// RecordSessionSecretDecryption takes the index of the session secret which decrypted the session data to record the
+// session secret decryption metrics. This is recorded for every decryption rather than once for each session.
+func (r *Prometheus) RecordSessionSecretDecryption(secret int) {
+	r.secretCounter.WithLabelValues(strconv.Itoa(secret)).Inc()
+}
+
+// RecordNotification takes the result of processing a notification by the notification queue and records it.
+func (r *Prometheus) RecordNotification(result string) {
+	r.notifyCounter.WithLabelValues(result).Inc()
+}
+
 func (r *Prometheus) register() {
 	r.authnDuration = promauto.NewHistogramVec(
 		prometheus.HistogramOpts{
@@ -125,4 +151,39 @@ func (r *Prometheus) register() {
 		},
 		[]string{"success", "banned", "type"},
 	)
+
+	r.reloadCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: "authelia",
+			Name:      "reload",
+			Help:      "The number of provider configuration reloads attempted.",
+		},
+		[]string{"provider", "success"},
+	)
+
+	r.bindingCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: "authelia",
+			Name:      "session_binding_mismatch",
+			Help:      "The number of sessions used by a client which does not match the session binding.",
+		},
+		[]string{"action", "mismatch"},
+	)
+
+	r.secretCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: "authelia",
+			Name:      "session_secret_decryptions",
+			Help:      "The number of decryptions of session data by each session secret, counted for every decryption rather than for each session.",
+		},
+		[]string{"secret"},
+	)
+	r.notifyCounter = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Subsystem: "authelia",
+			Name:      "notification",
+			Help:      "The number of notifications processed by the notification queue.",
+		},
+		[]string{"result"},
+	)
 }
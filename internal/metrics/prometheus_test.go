@@ -17,4 +17,8 @@ func TestNewPrometheus(t *testing.T) {
 	p.RecordAuthn(true, false, "WebAuthn")
 	p.RecordAuthn(true, false, "1fa")
 	p.RecordAuthenticationDuration(true, time.Second)
+	p.RecordReload("access-control", false)
+	p.RecordSessionBindingMismatch("reauthenticate", "ip")
+	p.RecordSessionSecretDecryption(1)
+	p.RecordNotification("delivered")
 }
@@ -15,6 +15,7 @@ import (
 
 	"github.com/authelia/authelia/v4/internal/clock"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/geoip"
 	"github.com/authelia/authelia/v4/internal/logging"
 	"github.com/authelia/authelia/v4/internal/model"
 	"github.com/authelia/authelia/v4/internal/random"
@@ -338,7 +339,8 @@ func (ctx *AutheliaCtx) GetCookieDomainSessionProvider(domain string) (provider
 }
 
 // GetSession returns the user session provided the cookie provider could be discovered. It is recommended to get the
-// provider itself if you also need to update or destroy sessions.
+// provider itself if you also need to update or destroy sessions. Sessions which belong to another cookie domain or
+// which have been revoked are destroyed and replaced with an anonymous session.
 func (ctx *AutheliaCtx) GetSession() (userSession session.UserSession, err error) {
 	var provider *session.Session
 
@@ -351,23 +353,40 @@ func (ctx *AutheliaCtx) GetSession() (userSession session.UserSession, err error
 		return provider.NewDefaultUserSession(), nil
 	}
 
-	if userSession.CookieDomain != provider.Config.Domain {
+	switch {
+	case userSession.CookieDomain != provider.Config.Domain:
 		ctx.Logger.Warnf("Destroying session cookie as the cookie domain '%s' does not match the requests detected cookie domain '%s' which may be a sign a user tried to move this cookie from one domain to another", userSession.CookieDomain, provider.Config.Domain)
+	case ctx.isSessionRevoked(provider, userSession):
+		ctx.Logger.WithField("username", userSession.Username).Info("Destroying session cookie as the sessions of the user have been revoked")
+	default:
+		return userSession, nil
+	}
 
-		if err = provider.DestroySession(ctx.RequestCtx); err != nil {
-			ctx.Logger.WithError(err).Error("Error occurred trying to destroy the session cookie")
-		}
+	if err = provider.DestroySession(ctx.RequestCtx); err != nil {
+		ctx.Logger.WithError(err).Error("Error occurred trying to destroy the session cookie")
+	}
 
-		userSession = provider.NewDefaultUserSession()
+	userSession = provider.NewDefaultUserSession()
 
-		if err = provider.SaveSession(ctx.RequestCtx, userSession); err != nil {
-			ctx.Logger.WithError(err).Error("Error occurred trying to save the new session cookie")
-		}
+	if err = provider.SaveSession(ctx.RequestCtx, userSession); err != nil {
+		ctx.Logger.WithError(err).Error("Error occurred trying to save the new session cookie")
 	}
 
 	return userSession, nil
 }
 
+func (ctx *AutheliaCtx) isSessionRevoked(provider *session.Session, userSession session.UserSession) (revoked bool) {
+	var err error
+
+	if revoked, err = provider.IsRevoked(userSession); err != nil {
+		ctx.Logger.WithError(err).WithField("username", userSession.Username).Error("Error occurred while checking if the sessions of the user have been revoked")
+
+		return false
+	}
+
+	return revoked
+}
+
 // SaveSession saves the content of the session.
 func (ctx *AutheliaCtx) SaveSession(userSession session.UserSession) error {
 	provider, err := ctx.GetSessionProvider()
@@ -375,7 +394,7 @@ func (ctx *AutheliaCtx) SaveSession(userSession session.UserSession) error {
 		return fmt.Errorf("unable to save user session: %s", err)
 	}
 
-	return provider.SaveSession(ctx.RequestCtx, userSession)
+	return provider.SaveIndexedSession(ctx.RequestCtx, userSession, ctx.Clock.Now(), ctx.RemoteIP())
 }
 
 // RegenerateSession regenerates a user session.
@@ -484,6 +503,22 @@ func (ctx *AutheliaCtx) RemoteIP() net.IP {
 	return ctx.RequestCtx.RemoteIP()
 }
 
+// RemoteGeoIP returns the geoip.Record of the remote IP. The record is empty if GeoIP is not configured or the lookup
+// fails.
+func (ctx *AutheliaCtx) RemoteGeoIP() (record geoip.Record) {
+	if ctx.Providers.GeoIP == nil {
+		return record
+	}
+
+	var err error
+
+	if record, err = ctx.Providers.GeoIP.Lookup(ctx.RemoteIP()); err != nil {
+		ctx.Logger.WithError(err).Debug("Error occurred looking up the GeoIP record of the remote IP")
+	}
+
+	return record
+}
+
 // GetXForwardedURL returns the parsed X-Forwarded-Proto, X-Forwarded-Host, and X-Forwarded-URI request header as a
 // *url.URL.
 func (ctx *AutheliaCtx) GetXForwardedURL() (requestURI *url.URL, err error) {
@@ -637,6 +672,15 @@ func (ctx *AutheliaCtx) RecordAuthn(success, regulated bool, method string) {
 	ctx.Providers.Metrics.RecordAuthn(success, regulated, method)
 }
 
+// RecordSessionBindingMismatch records session binding mismatch metrics.
+func (ctx *AutheliaCtx) RecordSessionBindingMismatch(action, mismatch string) {
+	if ctx.Providers.Metrics == nil {
+		return
+	}
+
+	ctx.Providers.Metrics.RecordSessionBindingMismatch(action, mismatch)
+}
+
 // GetClock returns the clock. For use with interface fulfillment.
 func (ctx *AutheliaCtx) GetClock() clock.Provider {
 	return ctx.Clock
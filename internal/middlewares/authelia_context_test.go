@@ -1,16 +1,21 @@
 package middlewares_test
 
 import (
+	"fmt"
 	"net"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/valyala/fasthttp"
 
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/clock"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/geoip"
 	"github.com/authelia/authelia/v4/internal/middlewares"
 	"github.com/authelia/authelia/v4/internal/mocks"
 	"github.com/authelia/authelia/v4/internal/model"
@@ -49,6 +54,37 @@ func TestAutheliaCtx_RemoteIP(t *testing.T) {
 	}
 }
 
+func TestAutheliaCtx_RemoteGeoIP(t *testing.T) {
+	testCases := []struct {
+		name     string
+		enabled  bool
+		record   geoip.Record
+		err      error
+		expected geoip.Record
+	}{
+		{"ShouldReturnEmptyRecordWhenDisabled", false, geoip.Record{}, nil, geoip.Record{}},
+		{"ShouldReturnRecord", true, geoip.Record{Country: "AU", ASN: 64512}, nil, geoip.Record{Country: "AU", ASN: 64512}},
+		{"ShouldReturnRecordOnError", true, geoip.Record{Country: "AU"}, fmt.Errorf("failed to lookup the asn"), geoip.Record{Country: "AU"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := mocks.NewMockAutheliaCtx(t)
+			defer mock.Close()
+
+			mock.Ctx.SetRemoteAddr(&net.TCPAddr{Port: 80, IP: net.ParseIP("192.0.2.1")})
+
+			if tc.enabled {
+				mock.Ctx.Providers.GeoIP = mock.GeoIPMock
+
+				mock.GeoIPMock.EXPECT().Lookup(net.ParseIP("192.0.2.1")).Return(tc.record, tc.err)
+			}
+
+			assert.Equal(t, tc.expected, mock.Ctx.RemoteGeoIP())
+		})
+	}
+}
+
 func TestContentTypes(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -316,7 +352,7 @@ func TestShouldCallNextWithAutheliaCtx(t *testing.T) {
 	ctx := &fasthttp.RequestCtx{}
 	configuration := schema.Configuration{}
 	userProvider := mocks.NewMockUserProvider(ctrl)
-	sessionProvider := session.NewProvider(configuration.Session, nil)
+	sessionProvider := session.NewProvider(configuration.Session, nil, nil, nil, clock.New())
 	providers := middlewares.Providers{
 		UserProvider:    userProvider,
 		SessionProvider: sessionProvider,
@@ -566,3 +602,44 @@ func TestAutheliaCtx_GetDefaultRedirectionURL(t *testing.T) {
 
 	assert.Equal(t, &url.URL{Scheme: "https", Host: "www.example2.com"}, mock2.Ctx.GetDefaultRedirectionURL())
 }
+
+func TestAutheliaCtx_GetSessionShouldDestroyRevokedSession(t *testing.T) {
+	mock := mocks.NewMockAutheliaCtx(t)
+
+	defer mock.Close()
+
+	mock.Ctx.Clock = &mock.Clock
+
+	provider, err := mock.Ctx.GetSessionProvider()
+	require.NoError(t, err)
+
+	userSession, err := provider.GetSession(mock.Ctx.RequestCtx)
+	require.NoError(t, err)
+
+	userSession.Username = "john"
+	userSession.AuthenticationLevel = authentication.TwoFactor
+	userSession.FirstFactorAuthnTimestamp = mock.Clock.Now().Add(-time.Minute).Unix()
+
+	require.NoError(t, provider.SaveSession(mock.Ctx.RequestCtx, userSession))
+
+	actual, err := mock.Ctx.GetSession()
+	require.NoError(t, err)
+	assert.Equal(t, "john", actual.Username)
+
+	_, err = mock.Ctx.Providers.SessionProvider.RevokeAllUserSessions("john", mock.Clock.Now())
+	require.NoError(t, err)
+
+	actual, err = mock.Ctx.GetSession()
+	require.NoError(t, err)
+	assert.True(t, actual.IsAnonymous())
+	assert.Equal(t, authentication.NotAuthenticated, actual.AuthenticationLevel)
+
+	// Sessions authenticated after the revocation are not affected.
+	userSession.FirstFactorAuthnTimestamp = mock.Clock.Now().Add(time.Minute).Unix()
+
+	require.NoError(t, provider.SaveSession(mock.Ctx.RequestCtx, userSession))
+
+	actual, err = mock.Ctx.GetSession()
+	require.NoError(t, err)
+	assert.Equal(t, "john", actual.Username)
+}
@@ -0,0 +1,20 @@
+package middlewares
+
+import (
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// RequireAdmin check if user has completed two-factor authentication and is a member of one of the groups permitted to
+// use the Administration endpoints before executing the next handler.
+func RequireAdmin(next RequestHandler) RequestHandler {
+	return func(ctx *AutheliaCtx) {
+		if s, err := ctx.GetSession(); err != nil || s.AuthenticationLevel < authentication.TwoFactor ||
+			!utils.IsStringSliceContainsAny(ctx.Configuration.Server.Endpoints.Admin.Groups, s.Groups) {
+			ctx.ReplyForbidden()
+			return
+		}
+
+		next(ctx)
+	}
+}
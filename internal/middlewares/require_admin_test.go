@@ -0,0 +1,48 @@
+package middlewares_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/middlewares"
+	"github.com/authelia/authelia/v4/internal/mocks"
+	"github.com/authelia/authelia/v4/internal/session"
+)
+
+func TestRequireAdmin(t *testing.T) {
+	testCases := []struct {
+		name     string
+		level    authentication.Level
+		groups   []string
+		expected int
+	}{
+		{"ShouldAllowAdminWithTwoFactor", authentication.TwoFactor, []string{"dev", "admins"}, fasthttp.StatusOK},
+		{"ShouldDenyAdminWithOneFactor", authentication.OneFactor, []string{"admins"}, fasthttp.StatusForbidden},
+		{"ShouldDenyNonAdmin", authentication.TwoFactor, []string{"dev"}, fasthttp.StatusForbidden},
+		{"ShouldDenyAnonymous", authentication.NotAuthenticated, nil, fasthttp.StatusForbidden},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mock := mocks.NewMockAutheliaCtxWithUserSession(t, session.UserSession{
+				CookieDomain:        "example.com",
+				Username:            "john",
+				Groups:              tc.groups,
+				AuthenticationLevel: tc.level,
+			})
+
+			defer mock.Close()
+
+			mock.Ctx.Configuration.Server.Endpoints.Admin.Groups = []string{"admins"}
+
+			middlewares.RequireAdmin(func(ctx *middlewares.AutheliaCtx) {
+				ctx.ReplyOK()
+			})(mock.Ctx)
+
+			assert.Equal(t, tc.expected, mock.Ctx.Response.StatusCode())
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/authelia/authelia/v4/internal/authorization"
 	"github.com/authelia/authelia/v4/internal/clock"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/geoip"
 	"github.com/authelia/authelia/v4/internal/metrics"
 	"github.com/authelia/authelia/v4/internal/notification"
 	"github.com/authelia/authelia/v4/internal/ntp"
@@ -41,6 +42,7 @@ type Providers struct {
 	OpenIDConnect   *oidc.OpenIDConnectProvider
 	Metrics         metrics.Provider
 	NTP             *ntp.Provider
+	GeoIP           geoip.Provider
 	UserProvider    authentication.UserProvider
 	StorageProvider storage.Provider
 	Notifier        notification.Notifier
@@ -48,6 +50,7 @@ type Providers struct {
 	TOTP            totp.Provider
 	PasswordPolicy  PasswordPolicyProvider
 	Random          random.Provider
+	Clock           clock.Provider
 }
 
 // RequestHandler represents an Authelia request handler.
@@ -37,6 +37,7 @@ type MockAutheliaCtx struct {
 	NotifierMock     *MockNotifier
 	TOTPMock         *MockTOTP
 	RandomMock       *MockRandom
+	GeoIPMock        *MockGeoIP
 
 	UserSession *session.UserSession
 
@@ -199,10 +200,10 @@ func NewMockAutheliaCtx(t *testing.T) *MockAutheliaCtx {
 	providers.Notifier = mockAuthelia.NotifierMock
 
 	providers.Authorizer = authorization.NewAuthorizer(
-		&config)
+		&config, &mockAuthelia.Clock)
 
 	providers.SessionProvider = session.NewProvider(
-		config.Session, nil)
+		config.Session, nil, providers.StorageProvider, nil, &mockAuthelia.Clock)
 
 	providers.Regulator = regulation.NewRegulator(config.Regulation, providers.StorageProvider, &mockAuthelia.Clock)
 
@@ -213,6 +214,8 @@ func NewMockAutheliaCtx(t *testing.T) *MockAutheliaCtx {
 
 	providers.Random = random.NewMathematical()
 
+	mockAuthelia.GeoIPMock = NewMockGeoIP(mockAuthelia.Ctrl)
+
 	var err error
 
 	if providers.Templates, err = templates.New(templates.Config{}); err != nil {
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/authelia/authelia/v4/internal/geoip (interfaces: Provider)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	net "net"
+	reflect "reflect"
+
+	geoip "github.com/authelia/authelia/v4/internal/geoip"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockGeoIP is a mock of Provider interface.
+type MockGeoIP struct {
+	ctrl     *gomock.Controller
+	recorder *MockGeoIPMockRecorder
+}
+
+// MockGeoIPMockRecorder is the mock recorder for MockGeoIP.
+type MockGeoIPMockRecorder struct {
+	mock *MockGeoIP
+}
+
+// NewMockGeoIP creates a new mock instance.
+func NewMockGeoIP(ctrl *gomock.Controller) *MockGeoIP {
+	mock := &MockGeoIP{ctrl: ctrl}
+	mock.recorder = &MockGeoIPMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockGeoIP) EXPECT() *MockGeoIPMockRecorder {
+	return m.recorder
+}
+
+// Lookup mocks base method.
+func (m *MockGeoIP) Lookup(arg0 net.IP) (geoip.Record, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lookup", arg0)
+	ret0, _ := ret[0].(geoip.Record)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Lookup indicates an expected call of Lookup.
+func (mr *MockGeoIPMockRecorder) Lookup(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lookup", reflect.TypeOf((*MockGeoIP)(nil).Lookup), arg0)
+}
+
+// StartupCheck mocks base method.
+func (m *MockGeoIP) StartupCheck() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StartupCheck")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StartupCheck indicates an expected call of StartupCheck.
+func (mr *MockGeoIPMockRecorder) StartupCheck() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartupCheck", reflect.TypeOf((*MockGeoIP)(nil).StartupCheck))
+}
@@ -68,6 +68,21 @@ func (mr *MockStorageMockRecorder) BeginTX(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginTX", reflect.TypeOf((*MockStorage)(nil).BeginTX), arg0)
 }
 
+// ClaimNotificationQueueEntry mocks base method.
+func (m *MockStorage) ClaimNotificationQueueEntry(arg0 context.Context, arg1, arg2 int, arg3 time.Time) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimNotificationQueueEntry", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimNotificationQueueEntry indicates an expected call of ClaimNotificationQueueEntry.
+func (mr *MockStorageMockRecorder) ClaimNotificationQueueEntry(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimNotificationQueueEntry", reflect.TypeOf((*MockStorage)(nil).ClaimNotificationQueueEntry), arg0, arg1, arg2, arg3)
+}
+
 // Close mocks base method.
 func (m *MockStorage) Close() error {
 	m.ctrl.T.Helper()
@@ -110,6 +125,21 @@ func (mr *MockStorageMockRecorder) ConsumeIdentityVerification(arg0, arg1, arg2
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConsumeIdentityVerification", reflect.TypeOf((*MockStorage)(nil).ConsumeIdentityVerification), arg0, arg1, arg2)
 }
 
+// CountSessionData mocks base method.
+func (m *MockStorage) CountSessionData(arg0 context.Context, arg1 time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountSessionData", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountSessionData indicates an expected call of CountSessionData.
+func (mr *MockStorageMockRecorder) CountSessionData(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSessionData", reflect.TypeOf((*MockStorage)(nil).CountSessionData), arg0, arg1)
+}
+
 // DeactivateOAuth2Session mocks base method.
 func (m *MockStorage) DeactivateOAuth2Session(arg0 context.Context, arg1 storage.OAuth2SessionType, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -138,6 +168,20 @@ func (mr *MockStorageMockRecorder) DeactivateOAuth2SessionByRequestID(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateOAuth2SessionByRequestID", reflect.TypeOf((*MockStorage)(nil).DeactivateOAuth2SessionByRequestID), arg0, arg1, arg2)
 }
 
+// DeleteNotificationQueueEntry mocks base method.
+func (m *MockStorage) DeleteNotificationQueueEntry(arg0 context.Context, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNotificationQueueEntry", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNotificationQueueEntry indicates an expected call of DeleteNotificationQueueEntry.
+func (mr *MockStorageMockRecorder) DeleteNotificationQueueEntry(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNotificationQueueEntry", reflect.TypeOf((*MockStorage)(nil).DeleteNotificationQueueEntry), arg0, arg1)
+}
+
 // DeletePreferredDuoDevice mocks base method.
 func (m *MockStorage) DeletePreferredDuoDevice(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -152,6 +196,20 @@ func (mr *MockStorageMockRecorder) DeletePreferredDuoDevice(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePreferredDuoDevice", reflect.TypeOf((*MockStorage)(nil).DeletePreferredDuoDevice), arg0, arg1)
 }
 
+// DeleteSessionData mocks base method.
+func (m *MockStorage) DeleteSessionData(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSessionData", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSessionData indicates an expected call of DeleteSessionData.
+func (mr *MockStorageMockRecorder) DeleteSessionData(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSessionData", reflect.TypeOf((*MockStorage)(nil).DeleteSessionData), arg0, arg1)
+}
+
 // DeleteTOTPConfiguration mocks base method.
 func (m *MockStorage) DeleteTOTPConfiguration(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -166,6 +224,20 @@ func (mr *MockStorageMockRecorder) DeleteTOTPConfiguration(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTOTPConfiguration", reflect.TypeOf((*MockStorage)(nil).DeleteTOTPConfiguration), arg0, arg1)
 }
 
+// DeleteUserSessionRecord mocks base method.
+func (m *MockStorage) DeleteUserSessionRecord(arg0 context.Context, arg1 string, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUserSessionRecord", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUserSessionRecord indicates an expected call of DeleteUserSessionRecord.
+func (mr *MockStorageMockRecorder) DeleteUserSessionRecord(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUserSessionRecord", reflect.TypeOf((*MockStorage)(nil).DeleteUserSessionRecord), arg0, arg1, arg2)
+}
+
 // DeleteWebAuthnDevice mocks base method.
 func (m *MockStorage) DeleteWebAuthnDevice(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -224,6 +296,96 @@ func (mr *MockStorageMockRecorder) LoadAuthenticationLogs(arg0, arg1, arg2, arg3
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadAuthenticationLogs", reflect.TypeOf((*MockStorage)(nil).LoadAuthenticationLogs), arg0, arg1, arg2, arg3, arg4)
 }
 
+// LoadAuthenticationLogsByUsernameAndRemoteNetwork mocks base method.
+func (m *MockStorage) LoadAuthenticationLogsByUsernameAndRemoteNetwork(arg0 context.Context, arg1, arg2 string, arg3 time.Time, arg4, arg5 int) ([]model.AuthenticationAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadAuthenticationLogsByUsernameAndRemoteNetwork", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].([]model.AuthenticationAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadAuthenticationLogsByUsernameAndRemoteNetwork indicates an expected call of LoadAuthenticationLogsByUsernameAndRemoteNetwork.
+func (mr *MockStorageMockRecorder) LoadAuthenticationLogsByUsernameAndRemoteNetwork(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadAuthenticationLogsByUsernameAndRemoteNetwork", reflect.TypeOf((*MockStorage)(nil).LoadAuthenticationLogsByUsernameAndRemoteNetwork), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// LoadAuthenticationLogsByUsernameAndType mocks base method.
+func (m *MockStorage) LoadAuthenticationLogsByUsernameAndType(arg0 context.Context, arg1, arg2 string, arg3 time.Time, arg4, arg5 int) ([]model.AuthenticationAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadAuthenticationLogsByUsernameAndType", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].([]model.AuthenticationAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadAuthenticationLogsByUsernameAndType indicates an expected call of LoadAuthenticationLogsByUsernameAndType.
+func (mr *MockStorageMockRecorder) LoadAuthenticationLogsByUsernameAndType(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadAuthenticationLogsByUsernameAndType", reflect.TypeOf((*MockStorage)(nil).LoadAuthenticationLogsByUsernameAndType), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// LoadFailedAuthenticationLogSubjects mocks base method.
+func (m *MockStorage) LoadFailedAuthenticationLogSubjects(arg0 context.Context, arg1 time.Time) ([]model.AuthenticationAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadFailedAuthenticationLogSubjects", arg0, arg1)
+	ret0, _ := ret[0].([]model.AuthenticationAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadFailedAuthenticationLogSubjects indicates an expected call of LoadFailedAuthenticationLogSubjects.
+func (mr *MockStorageMockRecorder) LoadFailedAuthenticationLogSubjects(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadFailedAuthenticationLogSubjects", reflect.TypeOf((*MockStorage)(nil).LoadFailedAuthenticationLogSubjects), arg0, arg1)
+}
+
+// LoadFailedAuthenticationLogsByRemoteNetwork mocks base method.
+func (m *MockStorage) LoadFailedAuthenticationLogsByRemoteNetwork(arg0 context.Context, arg1 string, arg2 time.Time, arg3, arg4 int) ([]model.AuthenticationAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadFailedAuthenticationLogsByRemoteNetwork", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]model.AuthenticationAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadFailedAuthenticationLogsByRemoteNetwork indicates an expected call of LoadFailedAuthenticationLogsByRemoteNetwork.
+func (mr *MockStorageMockRecorder) LoadFailedAuthenticationLogsByRemoteNetwork(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadFailedAuthenticationLogsByRemoteNetwork", reflect.TypeOf((*MockStorage)(nil).LoadFailedAuthenticationLogsByRemoteNetwork), arg0, arg1, arg2, arg3, arg4)
+}
+
+// LoadFailedAuthenticationLogsByUsername mocks base method.
+func (m *MockStorage) LoadFailedAuthenticationLogsByUsername(arg0 context.Context, arg1 string, arg2, arg3 int) ([]model.AuthenticationAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadFailedAuthenticationLogsByUsername", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]model.AuthenticationAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadFailedAuthenticationLogsByUsername indicates an expected call of LoadFailedAuthenticationLogsByUsername.
+func (mr *MockStorageMockRecorder) LoadFailedAuthenticationLogsByUsername(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadFailedAuthenticationLogsByUsername", reflect.TypeOf((*MockStorage)(nil).LoadFailedAuthenticationLogsByUsername), arg0, arg1, arg2, arg3)
+}
+
+// LoadNotificationQueueEntriesPending mocks base method.
+func (m *MockStorage) LoadNotificationQueueEntriesPending(arg0 context.Context, arg1 time.Time, arg2 int) ([]model.NotificationQueueEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadNotificationQueueEntriesPending", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]model.NotificationQueueEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadNotificationQueueEntriesPending indicates an expected call of LoadNotificationQueueEntriesPending.
+func (mr *MockStorageMockRecorder) LoadNotificationQueueEntriesPending(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadNotificationQueueEntriesPending", reflect.TypeOf((*MockStorage)(nil).LoadNotificationQueueEntriesPending), arg0, arg1, arg2)
+}
+
 // LoadOAuth2BlacklistedJTI mocks base method.
 func (m *MockStorage) LoadOAuth2BlacklistedJTI(arg0 context.Context, arg1 string) (*model.OAuth2BlacklistedJTI, error) {
 	m.ctrl.T.Helper()
@@ -299,6 +461,21 @@ func (mr *MockStorageMockRecorder) LoadOAuth2Session(arg0, arg1, arg2 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadOAuth2Session", reflect.TypeOf((*MockStorage)(nil).LoadOAuth2Session), arg0, arg1, arg2)
 }
 
+// LoadOAuth2SessionSignaturesByUsername mocks base method.
+func (m *MockStorage) LoadOAuth2SessionSignaturesByUsername(arg0 context.Context, arg1 storage.OAuth2SessionType, arg2 string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadOAuth2SessionSignaturesByUsername", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadOAuth2SessionSignaturesByUsername indicates an expected call of LoadOAuth2SessionSignaturesByUsername.
+func (mr *MockStorageMockRecorder) LoadOAuth2SessionSignaturesByUsername(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadOAuth2SessionSignaturesByUsername", reflect.TypeOf((*MockStorage)(nil).LoadOAuth2SessionSignaturesByUsername), arg0, arg1, arg2)
+}
+
 // LoadPreferred2FAMethod mocks base method.
 func (m *MockStorage) LoadPreferred2FAMethod(arg0 context.Context, arg1 string) (string, error) {
 	m.ctrl.T.Helper()
@@ -329,6 +506,51 @@ func (mr *MockStorageMockRecorder) LoadPreferredDuoDevice(arg0, arg1 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadPreferredDuoDevice", reflect.TypeOf((*MockStorage)(nil).LoadPreferredDuoDevice), arg0, arg1)
 }
 
+// LoadRegulationUnbanTimeByRemoteNetwork mocks base method.
+func (m *MockStorage) LoadRegulationUnbanTimeByRemoteNetwork(arg0 context.Context, arg1 string) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadRegulationUnbanTimeByRemoteNetwork", arg0, arg1)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadRegulationUnbanTimeByRemoteNetwork indicates an expected call of LoadRegulationUnbanTimeByRemoteNetwork.
+func (mr *MockStorageMockRecorder) LoadRegulationUnbanTimeByRemoteNetwork(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadRegulationUnbanTimeByRemoteNetwork", reflect.TypeOf((*MockStorage)(nil).LoadRegulationUnbanTimeByRemoteNetwork), arg0, arg1)
+}
+
+// LoadRegulationUnbanTimeByUsername mocks base method.
+func (m *MockStorage) LoadRegulationUnbanTimeByUsername(arg0 context.Context, arg1 string) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadRegulationUnbanTimeByUsername", arg0, arg1)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadRegulationUnbanTimeByUsername indicates an expected call of LoadRegulationUnbanTimeByUsername.
+func (mr *MockStorageMockRecorder) LoadRegulationUnbanTimeByUsername(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadRegulationUnbanTimeByUsername", reflect.TypeOf((*MockStorage)(nil).LoadRegulationUnbanTimeByUsername), arg0, arg1)
+}
+
+// LoadSessionData mocks base method.
+func (m *MockStorage) LoadSessionData(arg0 context.Context, arg1 string, arg2 time.Time) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadSessionData", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadSessionData indicates an expected call of LoadSessionData.
+func (mr *MockStorageMockRecorder) LoadSessionData(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadSessionData", reflect.TypeOf((*MockStorage)(nil).LoadSessionData), arg0, arg1, arg2)
+}
+
 // LoadTOTPConfiguration mocks base method.
 func (m *MockStorage) LoadTOTPConfiguration(arg0 context.Context, arg1 string) (*model.TOTPConfiguration, error) {
 	m.ctrl.T.Helper()
@@ -419,6 +641,36 @@ func (mr *MockStorageMockRecorder) LoadUserOpaqueIdentifiers(arg0 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadUserOpaqueIdentifiers", reflect.TypeOf((*MockStorage)(nil).LoadUserOpaqueIdentifiers), arg0)
 }
 
+// LoadUserSessionRecord mocks base method.
+func (m *MockStorage) LoadUserSessionRecord(arg0 context.Context, arg1 string, arg2 string, arg3 time.Time) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadUserSessionRecord", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadUserSessionRecord indicates an expected call of LoadUserSessionRecord.
+func (mr *MockStorageMockRecorder) LoadUserSessionRecord(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadUserSessionRecord", reflect.TypeOf((*MockStorage)(nil).LoadUserSessionRecord), arg0, arg1, arg2, arg3)
+}
+
+// LoadUserSessionRecords mocks base method.
+func (m *MockStorage) LoadUserSessionRecords(arg0 context.Context, arg1 string, arg2 time.Time) ([][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoadUserSessionRecords", arg0, arg1, arg2)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoadUserSessionRecords indicates an expected call of LoadUserSessionRecords.
+func (mr *MockStorageMockRecorder) LoadUserSessionRecords(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadUserSessionRecords", reflect.TypeOf((*MockStorage)(nil).LoadUserSessionRecords), arg0, arg1, arg2)
+}
+
 // LoadWebAuthnDevices mocks base method.
 func (m *MockStorage) LoadWebAuthnDevices(arg0 context.Context, arg1, arg2 int) ([]model.WebAuthnDevice, error) {
 	m.ctrl.T.Helper()
@@ -449,6 +701,34 @@ func (mr *MockStorageMockRecorder) LoadWebAuthnDevicesByUsername(arg0, arg1 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoadWebAuthnDevicesByUsername", reflect.TypeOf((*MockStorage)(nil).LoadWebAuthnDevicesByUsername), arg0, arg1)
 }
 
+// PurgeExpiredSessionData mocks base method.
+func (m *MockStorage) PurgeExpiredSessionData(arg0 context.Context, arg1 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpiredSessionData", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgeExpiredSessionData indicates an expected call of PurgeExpiredSessionData.
+func (mr *MockStorageMockRecorder) PurgeExpiredSessionData(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpiredSessionData", reflect.TypeOf((*MockStorage)(nil).PurgeExpiredSessionData), arg0, arg1)
+}
+
+// RegenerateSessionData mocks base method.
+func (m *MockStorage) RegenerateSessionData(arg0 context.Context, arg1, arg2 string, arg3 sql.NullTime) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegenerateSessionData", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RegenerateSessionData indicates an expected call of RegenerateSessionData.
+func (mr *MockStorageMockRecorder) RegenerateSessionData(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegenerateSessionData", reflect.TypeOf((*MockStorage)(nil).RegenerateSessionData), arg0, arg1, arg2, arg3)
+}
+
 // RevokeOAuth2PARContext mocks base method.
 func (m *MockStorage) RevokeOAuth2PARContext(arg0 context.Context, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -519,6 +799,20 @@ func (mr *MockStorageMockRecorder) SaveIdentityVerification(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveIdentityVerification", reflect.TypeOf((*MockStorage)(nil).SaveIdentityVerification), arg0, arg1)
 }
 
+// SaveNotificationQueueEntry mocks base method.
+func (m *MockStorage) SaveNotificationQueueEntry(arg0 context.Context, arg1 model.NotificationQueueEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveNotificationQueueEntry", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveNotificationQueueEntry indicates an expected call of SaveNotificationQueueEntry.
+func (mr *MockStorageMockRecorder) SaveNotificationQueueEntry(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveNotificationQueueEntry", reflect.TypeOf((*MockStorage)(nil).SaveNotificationQueueEntry), arg0, arg1)
+}
+
 // SaveOAuth2BlacklistedJTI mocks base method.
 func (m *MockStorage) SaveOAuth2BlacklistedJTI(arg0 context.Context, arg1 model.OAuth2BlacklistedJTI) error {
 	m.ctrl.T.Helper()
@@ -660,6 +954,34 @@ func (mr *MockStorageMockRecorder) SavePreferredDuoDevice(arg0, arg1 interface{}
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePreferredDuoDevice", reflect.TypeOf((*MockStorage)(nil).SavePreferredDuoDevice), arg0, arg1)
 }
 
+// SaveRegulationUnban mocks base method.
+func (m *MockStorage) SaveRegulationUnban(arg0 context.Context, arg1 model.RegulationUnban) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveRegulationUnban", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveRegulationUnban indicates an expected call of SaveRegulationUnban.
+func (mr *MockStorageMockRecorder) SaveRegulationUnban(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRegulationUnban", reflect.TypeOf((*MockStorage)(nil).SaveRegulationUnban), arg0, arg1)
+}
+
+// SaveSessionData mocks base method.
+func (m *MockStorage) SaveSessionData(arg0 context.Context, arg1 string, arg2 []byte, arg3 sql.NullTime) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveSessionData", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveSessionData indicates an expected call of SaveSessionData.
+func (mr *MockStorageMockRecorder) SaveSessionData(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveSessionData", reflect.TypeOf((*MockStorage)(nil).SaveSessionData), arg0, arg1, arg2, arg3)
+}
+
 // SaveTOTPConfiguration mocks base method.
 func (m *MockStorage) SaveTOTPConfiguration(arg0 context.Context, arg1 model.TOTPConfiguration) error {
 	m.ctrl.T.Helper()
@@ -688,6 +1010,20 @@ func (mr *MockStorageMockRecorder) SaveUserOpaqueIdentifier(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUserOpaqueIdentifier", reflect.TypeOf((*MockStorage)(nil).SaveUserOpaqueIdentifier), arg0, arg1)
 }
 
+// SaveUserSessionRecord mocks base method.
+func (m *MockStorage) SaveUserSessionRecord(arg0 context.Context, arg1 string, arg2 string, arg3 []byte, arg4 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveUserSessionRecord", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveUserSessionRecord indicates an expected call of SaveUserSessionRecord.
+func (mr *MockStorageMockRecorder) SaveUserSessionRecord(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveUserSessionRecord", reflect.TypeOf((*MockStorage)(nil).SaveUserSessionRecord), arg0, arg1, arg2, arg3, arg4)
+}
+
 // SaveWebAuthnDevice mocks base method.
 func (m *MockStorage) SaveWebAuthnDevice(arg0 context.Context, arg1 model.WebAuthnDevice) error {
 	m.ctrl.T.Helper()
@@ -849,6 +1185,20 @@ func (mr *MockStorageMockRecorder) StartupCheck() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartupCheck", reflect.TypeOf((*MockStorage)(nil).StartupCheck))
 }
 
+// UpdateNotificationQueueEntryError mocks base method.
+func (m *MockStorage) UpdateNotificationQueueEntryError(arg0 context.Context, arg1 int, arg2 bool, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNotificationQueueEntryError", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateNotificationQueueEntryError indicates an expected call of UpdateNotificationQueueEntryError.
+func (mr *MockStorageMockRecorder) UpdateNotificationQueueEntryError(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNotificationQueueEntryError", reflect.TypeOf((*MockStorage)(nil).UpdateNotificationQueueEntryError), arg0, arg1, arg2, arg3)
+}
+
 // UpdateOAuth2PARContext mocks base method.
 func (m *MockStorage) UpdateOAuth2PARContext(arg0 context.Context, arg1 model.OAuth2PARContext) error {
 	m.ctrl.T.Helper()
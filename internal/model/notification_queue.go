@@ -0,0 +1,22 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+// NotificationQueueEntry represents a notification which has been queued for delivery in the database. The Data is
+// the JSON encoded template data which is encrypted at rest.
+type NotificationQueueEntry struct {
+	ID               int            `db:"id"`
+	CreatedAt        time.Time      `db:"created_at"`
+	NextAttemptAt    time.Time      `db:"next_attempt_at"`
+	Attempts         int            `db:"attempts"`
+	DeadLettered     bool           `db:"dead_lettered"`
+	RecipientName    string         `db:"recipient_name"`
+	RecipientAddress string         `db:"recipient_address"`
+	Subject          string         `db:"subject"`
+	Template         string         `db:"template"`
+	Data             []byte         `db:"data"`
+	LastError        sql.NullString `db:"last_error"`
+}
@@ -0,0 +1,15 @@
+package model
+
+import (
+	"database/sql"
+	"time"
+)
+
+// RegulationUnban represents a regulation unban row in the database. Authentication attempts made before the latest
+// unban of a user or remote network are not considered by the regulator.
+type RegulationUnban struct {
+	ID            int            `db:"id"`
+	Time          time.Time      `db:"time"`
+	Username      sql.NullString `db:"username"`
+	RemoteNetwork sql.NullString `db:"remote_network"`
+}
@@ -12,3 +12,25 @@ const (
 var (
 	posixDoubleNewLine = []byte(posixNewLine + posixNewLine)
 )
+
+const (
+	headerContentType      = "Content-Type"
+	headerUserAgent        = "User-Agent"
+	headerWebhookTimestamp = "X-Authelia-Timestamp"
+	headerWebhookSignature = "X-Authelia-Signature"
+	headerDKIMSignature    = "DKIM-Signature"
+
+	mimeApplicationJSON = "application/json"
+
+	webhookUserAgent       = "Authelia"
+	webhookSignaturePrefix = "sha256="
+)
+
+const (
+	queueBatchSize = 20
+
+	queueResultQueued       = "queued"
+	queueResultDelivered    = "delivered"
+	queueResultRetried      = "retried"
+	queueResultDeadLettered = "dead_lettered"
+)
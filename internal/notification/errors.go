@@ -0,0 +1,9 @@
+package notification
+
+import (
+	"errors"
+)
+
+// ErrQueueEntryInvalid is returned when a queued notification can't be delivered regardless of how many times it's
+// attempted, for example when the template it references does not exist.
+var ErrQueueEntryInvalid = errors.New("the queued notification is invalid")
@@ -0,0 +1,96 @@
+package notification
+
+import (
+	"fmt"
+	"net/mail"
+	"os"
+	"strings"
+
+	gomail "github.com/wneessen/go-mail"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/random"
+	"github.com/authelia/authelia/v4/internal/templates"
+)
+
+// newMessageRenderer creates a messageRenderer for the given sender, subject format, and DKIM configuration.
+func newMessageRenderer(sender mail.Address, subject string, disableHTML bool, dkim *schema.NotifierSMTPDKIM) *messageRenderer {
+	var domain string
+
+	if at := strings.LastIndex(sender.Address, "@"); at >= 0 {
+		domain = sender.Address[at+1:]
+	} else {
+		domain = "localhost.localdomain"
+	}
+
+	return &messageRenderer{
+		sender:      sender,
+		subject:     subject,
+		disableHTML: disableHTML,
+		domain:      domain,
+		random:      &random.Cryptographical{},
+		dkim:        newDKIMSigner(dkim),
+	}
+}
+
+// messageRenderer renders the MIME messages sent by the email notifiers.
+type messageRenderer struct {
+	sender      mail.Address
+	subject     string
+	disableHTML bool
+	domain      string
+	random      random.Provider
+	dkim        *dkimSigner
+}
+
+// render the message for a notification and sign it if DKIM is configured.
+func (r *messageRenderer) render(recipient mail.Address, subject string, et *templates.EmailTemplate, data any) (msg *gomail.Msg, err error) {
+	msg = gomail.NewMsg(
+		gomail.WithMIMEVersion(gomail.Mime10),
+		gomail.WithBoundary(r.random.StringCustom(30, random.CharSetAlphaNumeric)),
+	)
+
+	r.setMessageID(msg)
+
+	if err = msg.From(r.sender.String()); err != nil {
+		return nil, fmt.Errorf("failed to set from address: %w", err)
+	}
+
+	if err = msg.AddTo(recipient.String()); err != nil {
+		return nil, fmt.Errorf("failed to set to address: %w", err)
+	}
+
+	msg.Subject(strings.ReplaceAll(r.subject, "{title}", subject))
+
+	switch {
+	case r.disableHTML:
+		if err = msg.SetBodyTextTemplate(et.Text, data); err != nil {
+			return nil, fmt.Errorf("failed to set body: text template errored: %w", err)
+		}
+	default:
+		if err = msg.AddAlternativeTextTemplate(et.Text, data); err != nil {
+			return nil, fmt.Errorf("failed to set body: text template errored: %w", err)
+		}
+
+		if err = msg.AddAlternativeHTMLTemplate(et.HTML, data); err != nil {
+			return nil, fmt.Errorf("failed to set body: html template errored: %w", err)
+		}
+	}
+
+	if r.dkim != nil {
+		if err = r.dkim.sign(msg); err != nil {
+			return nil, fmt.Errorf("failed to sign message: %w", err)
+		}
+	}
+
+	return msg, nil
+}
+
+func (r *messageRenderer) setMessageID(msg *gomail.Msg) {
+	rn := r.random.Intn(100000000)
+	rm := r.random.Intn(10000)
+	rs := r.random.StringCustom(17, random.CharSetAlphaNumeric)
+	pid := os.Getpid() + rm
+
+	msg.SetMessageIDWithValue(fmt.Sprintf("%d.%d%d.%s@%s", pid, rn, rm, rs, r.domain))
+}
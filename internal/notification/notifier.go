@@ -14,3 +14,8 @@ type Notifier interface {
 
 	Send(ctx context.Context, recipient mail.Address, subject string, et *templates.EmailTemplate, data any) (err error)
 }
+
+// MetricsRecorder represents the methods used to record notification metrics.
+type MetricsRecorder interface {
+	RecordNotification(result string)
+}
@@ -0,0 +1,249 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	gomail "github.com/wneessen/go-mail"
+
+	"github.com/authelia/authelia/v4/internal/clock"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/logging"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/storage"
+	"github.com/authelia/authelia/v4/internal/templates"
+)
+
+// NewQueueNotifier creates a QueueNotifier which persists notifications using the storage provider and delivers them
+// with the provided Notifier from a background worker.
+func NewQueueNotifier(config *schema.NotifierQueue, notifier Notifier, store storage.NotificationQueueProvider, provider *templates.Provider, metrics MetricsRecorder, clock clock.Provider) *QueueNotifier {
+	return &QueueNotifier{
+		config:    config,
+		notifier:  notifier,
+		store:     store,
+		templates: provider,
+		metrics:   metrics,
+		clock:     clock,
+		log:       logging.Logger(),
+		signal:    make(chan struct{}, 1),
+	}
+}
+
+// QueueNotifier a notifier which persists notifications to a queue in the storage provider and delivers them in the
+// background with exponential backoff, dead lettering the notifications which can't be delivered.
+type QueueNotifier struct {
+	config    *schema.NotifierQueue
+	notifier  Notifier
+	store     storage.NotificationQueueProvider
+	templates *templates.Provider
+	metrics   MetricsRecorder
+	clock     clock.Provider
+	log       *logrus.Logger
+	signal    chan struct{}
+}
+
+// StartupCheck implements model.StartupCheck to perform startup check operations.
+func (n *QueueNotifier) StartupCheck() (err error) {
+	return n.notifier.StartupCheck()
+}
+
+// Send validates a notification and adds it to the queue. Errors which would prevent the notification from ever being
+// delivered such as an invalid recipient address or a template which fails to execute are returned immediately.
+func (n *QueueNotifier) Send(ctx context.Context, recipient mail.Address, subject string, et *templates.EmailTemplate, data any) (err error) {
+	if _, err = mail.ParseAddress(recipient.Address); err != nil {
+		return fmt.Errorf("notifier: queue: invalid recipient address '%s': %w", recipient.Address, err)
+	}
+
+	name := emailTemplateName(et)
+
+	if _, err = n.templates.GetEmailTemplate(name); err != nil {
+		return fmt.Errorf("notifier: queue: %w", err)
+	}
+
+	if err = et.Text.Execute(io.Discard, data); err != nil {
+		return fmt.Errorf("notifier: queue: failed to execute text template: %w", err)
+	}
+
+	if err = et.HTML.Execute(io.Discard, data); err != nil {
+		return fmt.Errorf("notifier: queue: failed to execute html template: %w", err)
+	}
+
+	entry := model.NotificationQueueEntry{
+		CreatedAt:        n.clock.Now(),
+		RecipientName:    recipient.Name,
+		RecipientAddress: recipient.Address,
+		Subject:          subject,
+		Template:         name,
+	}
+
+	entry.NextAttemptAt = entry.CreatedAt
+
+	if entry.Data, err = json.Marshal(data); err != nil {
+		return fmt.Errorf("notifier: queue: failed to marshal template data: %w", err)
+	}
+
+	if err = n.store.SaveNotificationQueueEntry(ctx, entry); err != nil {
+		return fmt.Errorf("notifier: queue: failed to save notification: %w", err)
+	}
+
+	n.record(queueResultQueued)
+
+	select {
+	case n.signal <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// Run delivers the queued notifications every interval, or as soon as a notification is added to the queue, until the
+// context is done.
+func (n *QueueNotifier) Run(ctx context.Context) (err error) {
+	ticker := time.NewTicker(n.config.Interval)
+
+	defer ticker.Stop()
+
+	for {
+		n.process(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		case <-n.signal:
+		}
+	}
+}
+
+func (n *QueueNotifier) process(ctx context.Context) {
+	for {
+		entries, err := n.store.LoadNotificationQueueEntriesPending(ctx, n.clock.Now(), queueBatchSize)
+		if err != nil {
+			if ctx.Err() == nil {
+				n.log.WithError(err).Error("Failed to load pending notifications from the queue")
+			}
+
+			return
+		}
+
+		for _, entry := range entries {
+			if ctx.Err() != nil {
+				return
+			}
+
+			if err = n.deliver(ctx, entry); err != nil {
+				n.log.WithError(err).WithField("id", entry.ID).Error("Failed to claim notification from the queue")
+
+				return
+			}
+		}
+
+		if len(entries) < queueBatchSize {
+			return
+		}
+	}
+}
+
+func (n *QueueNotifier) deliver(ctx context.Context, entry model.NotificationQueueEntry) (err error) {
+	attempt := entry.Attempts + 1
+
+	var claimed bool
+
+	if claimed, err = n.store.ClaimNotificationQueueEntry(ctx, entry.ID, entry.Attempts, n.clock.Now().Add(n.backoff(attempt))); err != nil {
+		return err
+	}
+
+	if !claimed {
+		return nil
+	}
+
+	log := n.log.WithFields(map[string]any{"id": entry.ID, "recipient": entry.RecipientAddress, "attempt": attempt})
+
+	if err = n.send(ctx, entry); err == nil {
+		if err = n.store.DeleteNotificationQueueEntry(ctx, entry.ID); err != nil {
+			log.WithError(err).Error("Failed to delete delivered notification from the queue")
+		}
+
+		n.record(queueResultDelivered)
+
+		return nil
+	}
+
+	deadLettered := attempt >= n.config.MaxAttempts || isPermanentError(err)
+
+	if uerr := n.store.UpdateNotificationQueueEntryError(ctx, entry.ID, deadLettered, err.Error()); uerr != nil {
+		log.WithError(uerr).Error("Failed to record the notification delivery error in the queue")
+	}
+
+	if deadLettered {
+		log.WithError(err).Error("Failed to deliver notification, it will not be retried and has been dead lettered")
+
+		n.record(queueResultDeadLettered)
+	} else {
+		log.WithError(err).Warn("Failed to deliver notification, it will be retried")
+
+		n.record(queueResultRetried)
+	}
+
+	return nil
+}
+
+func (n *QueueNotifier) send(ctx context.Context, entry model.NotificationQueueEntry) (err error) {
+	var et *templates.EmailTemplate
+
+	if et, err = n.templates.GetEmailTemplate(entry.Template); err != nil {
+		return fmt.Errorf("%w: %w", ErrQueueEntryInvalid, err)
+	}
+
+	data := map[string]any{}
+
+	if err = json.Unmarshal(entry.Data, &data); err != nil {
+		return fmt.Errorf("%w: failed to unmarshal template data: %w", ErrQueueEntryInvalid, err)
+	}
+
+	return n.notifier.Send(ctx, mail.Address{Name: entry.RecipientName, Address: entry.RecipientAddress}, entry.Subject, et, data)
+}
+
+// backoff returns the delay before the attempt after the given attempt, which doubles after each attempt until it
+// reaches the maximum.
+func (n *QueueNotifier) backoff(attempt int) (delay time.Duration) {
+	delay = n.config.Backoff
+
+	for i := 1; i < attempt && delay < n.config.MaxBackoff; i++ {
+		delay *= 2
+	}
+
+	if delay > n.config.MaxBackoff {
+		return n.config.MaxBackoff
+	}
+
+	return delay
+}
+
+func (n *QueueNotifier) record(result string) {
+	if n.metrics != nil {
+		n.metrics.RecordNotification(result)
+	}
+}
+
+// isPermanentError returns true if the error indicates the notification can never be delivered, in which case it's
+// dead lettered without further attempts.
+func isPermanentError(err error) bool {
+	if errors.Is(err, ErrQueueEntryInvalid) {
+		return true
+	}
+
+	var serr *gomail.SendError
+
+	if errors.As(err, &serr) {
+		return serr.Reason == gomail.ErrSMTPRcptTo && !serr.IsTemp()
+	}
+
+	return false
+}
@@ -0,0 +1,338 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"net/mail"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/clock"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/model"
+	"github.com/authelia/authelia/v4/internal/templates"
+)
+
+func TestQueueNotifierShouldQueueAndDeliver(t *testing.T) {
+	notifier, store, delivery, metrics := newTestQueueNotifier(t)
+
+	data := templates.EmailEventValues{
+		Title:       "Second Factor Method Added",
+		DisplayName: "John Smith",
+		RemoteIP:    "127.0.0.1",
+		Details:     map[string]any{"Action": "Second Factor Method Added"},
+	}
+
+	require.NoError(t, notifier.Send(context.Background(), mail.Address{Name: "John Smith", Address: "john@example.com"}, "Second Factor Method Added", notifier.templates.GetEventEmailTemplate(), data))
+
+	require.Len(t, store.entries, 1)
+	assert.Len(t, notifier.signal, 1)
+
+	entry := store.entries[1]
+
+	assert.Equal(t, "john@example.com", entry.RecipientAddress)
+	assert.Equal(t, templates.TemplateNameEmailEvent, entry.Template)
+	assert.NotContains(t, string(entry.Data), "Second Factor Method Added", "data must be encrypted at rest")
+
+	notifier.process(context.Background())
+
+	assert.Len(t, store.entries, 0)
+	require.Len(t, delivery.sent, 1)
+
+	assert.Equal(t, mail.Address{Name: "John Smith", Address: "john@example.com"}, delivery.sent[0].recipient)
+	assert.Equal(t, "Second Factor Method Added", delivery.sent[0].subject)
+	assert.Contains(t, delivery.sent[0].text, "John Smith")
+	assert.Contains(t, delivery.sent[0].text, "127.0.0.1")
+	assert.Contains(t, delivery.sent[0].html, "Second Factor Method Added")
+
+	assert.Equal(t, []string{queueResultQueued, queueResultDelivered}, metrics.results)
+}
+
+func TestQueueNotifierShouldReturnFatalErrors(t *testing.T) {
+	notifier, store, _, _ := newTestQueueNotifier(t)
+
+	et := notifier.templates.GetEventEmailTemplate()
+
+	err := notifier.Send(context.Background(), mail.Address{Address: "john"}, "Title", et, templates.EmailEventValues{})
+
+	assert.EqualError(t, err, "notifier: queue: invalid recipient address 'john': mail: missing '@' or angle-addr")
+
+	err = notifier.Send(context.Background(), mail.Address{}, "Title", et, templates.EmailEventValues{})
+
+	assert.EqualError(t, err, "notifier: queue: invalid recipient address '': mail: no address")
+
+	store.err = errors.New("database is locked")
+
+	err = notifier.Send(context.Background(), mail.Address{Address: "john@example.com"}, "Title", et, templates.EmailEventValues{})
+
+	assert.EqualError(t, err, "notifier: queue: failed to save notification: database is locked")
+
+	assert.Len(t, store.entries, 0)
+}
+
+func TestQueueNotifierShouldRetryAndDeadLetter(t *testing.T) {
+	notifier, store, delivery, metrics := newTestQueueNotifier(t)
+
+	c := notifier.clock.(*clock.Fixed)
+
+	delivery.err = errors.New("connection refused")
+
+	require.NoError(t, notifier.Send(context.Background(), mail.Address{Address: "john@example.com"}, "Title", notifier.templates.GetEventEmailTemplate(), templates.EmailEventValues{}))
+
+	start := c.Now()
+
+	notifier.process(context.Background())
+
+	require.Len(t, store.entries, 1)
+
+	entry := store.entries[1]
+
+	assert.Equal(t, 1, entry.Attempts)
+	assert.False(t, entry.DeadLettered)
+	assert.Equal(t, "connection refused", entry.LastError.String)
+	assert.Equal(t, start.Add(time.Minute), entry.NextAttemptAt)
+
+	notifier.process(context.Background())
+
+	assert.Equal(t, 1, entry.Attempts, "the entry must not be attempted before the backoff has elapsed")
+
+	c.Set(entry.NextAttemptAt)
+
+	notifier.process(context.Background())
+
+	assert.Equal(t, 2, entry.Attempts)
+	assert.False(t, entry.DeadLettered)
+	assert.Equal(t, start.Add(time.Minute*3), entry.NextAttemptAt)
+
+	c.Set(entry.NextAttemptAt)
+
+	notifier.process(context.Background())
+
+	assert.Equal(t, 3, entry.Attempts)
+	assert.True(t, entry.DeadLettered)
+
+	c.Set(c.Now().Add(time.Hour))
+
+	notifier.process(context.Background())
+
+	assert.Equal(t, 3, entry.Attempts)
+	assert.Len(t, delivery.sent, 0)
+
+	assert.Equal(t, []string{queueResultQueued, queueResultRetried, queueResultRetried, queueResultDeadLettered}, metrics.results)
+}
+
+func TestQueueNotifierShouldDeadLetterInvalidEntries(t *testing.T) {
+	notifier, store, delivery, metrics := newTestQueueNotifier(t)
+
+	store.entries[1] = &model.NotificationQueueEntry{ID: 1, RecipientAddress: "john@example.com", Template: "Unknown", NextAttemptAt: notifier.clock.Now()}
+	store.id = 1
+
+	notifier.process(context.Background())
+
+	require.Len(t, store.entries, 1)
+
+	assert.Equal(t, 1, store.entries[1].Attempts)
+	assert.True(t, store.entries[1].DeadLettered)
+	assert.Equal(t, "the queued notification is invalid: email template 'Unknown' does not exist", store.entries[1].LastError.String)
+	assert.Len(t, delivery.sent, 0)
+	assert.Equal(t, []string{queueResultDeadLettered}, metrics.results)
+}
+
+func TestQueueNotifierShouldNotDeliverEntriesClaimedElsewhere(t *testing.T) {
+	notifier, store, delivery, _ := newTestQueueNotifier(t)
+
+	require.NoError(t, notifier.Send(context.Background(), mail.Address{Address: "john@example.com"}, "Title", notifier.templates.GetEventEmailTemplate(), templates.EmailEventValues{}))
+
+	store.stale = true
+
+	notifier.process(context.Background())
+
+	assert.Len(t, delivery.sent, 0)
+	assert.Len(t, store.entries, 1)
+}
+
+func TestQueueNotifierBackoff(t *testing.T) {
+	notifier := &QueueNotifier{config: &schema.NotifierQueue{Backoff: time.Second * 30, MaxBackoff: time.Minute * 5}}
+
+	testCases := []struct {
+		attempt  int
+		expected time.Duration
+	}{
+		{1, time.Second * 30},
+		{2, time.Minute},
+		{3, time.Minute * 2},
+		{4, time.Minute * 4},
+		{5, time.Minute * 5},
+		{100, time.Minute * 5},
+	}
+
+	for _, tc := range testCases {
+		assert.Equal(t, tc.expected, notifier.backoff(tc.attempt), "attempt %d", tc.attempt)
+	}
+}
+
+func TestQueueNotifierRunShouldStopWhenContextIsDone(t *testing.T) {
+	notifier, _, _, _ := newTestQueueNotifier(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan error)
+
+	go func() {
+		done <- notifier.Run(ctx)
+	}()
+
+	cancel()
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for the queue to stop")
+	}
+}
+
+func newTestQueueNotifier(t *testing.T) (notifier *QueueNotifier, store *testQueueStore, delivery *testQueueDelivery, metrics *testQueueMetrics) {
+	provider, err := templates.New(templates.Config{})
+	require.NoError(t, err)
+
+	store = &testQueueStore{entries: map[int]*model.NotificationQueueEntry{}}
+	delivery = &testQueueDelivery{}
+	metrics = &testQueueMetrics{}
+
+	notifier = NewQueueNotifier(&schema.NotifierQueue{Interval: time.Minute, MaxAttempts: 3, Backoff: time.Minute, MaxBackoff: time.Hour}, delivery, store, provider, metrics, clock.NewFixed(time.Unix(1700000000, 0)))
+
+	return notifier, store, delivery, metrics
+}
+
+// testQueueStore is an in-memory storage.NotificationQueueProvider which reverses the bytes of the data to ensure it's
+// treated as opaque by the QueueNotifier.
+type testQueueStore struct {
+	entries map[int]*model.NotificationQueueEntry
+	id      int
+	err     error
+	stale   bool
+}
+
+func (s *testQueueStore) SaveNotificationQueueEntry(_ context.Context, entry model.NotificationQueueEntry) (err error) {
+	if s.err != nil {
+		return s.err
+	}
+
+	s.id++
+
+	entry.ID = s.id
+	entry.Data = reverse(entry.Data)
+
+	s.entries[entry.ID] = &entry
+
+	return nil
+}
+
+func (s *testQueueStore) LoadNotificationQueueEntriesPending(_ context.Context, now time.Time, limit int) (entries []model.NotificationQueueEntry, err error) {
+	for _, entry := range s.entries {
+		if !entry.DeadLettered && !entry.NextAttemptAt.After(now) {
+			e := *entry
+
+			e.Data = reverse(e.Data)
+
+			entries = append(entries, e)
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].NextAttemptAt.Before(entries[j].NextAttemptAt)
+	})
+
+	if len(entries) > limit {
+		entries = entries[:limit]
+	}
+
+	return entries, nil
+}
+
+func (s *testQueueStore) ClaimNotificationQueueEntry(_ context.Context, id, attempts int, nextAttemptAt time.Time) (claimed bool, err error) {
+	entry, ok := s.entries[id]
+	if !ok || s.stale || entry.Attempts != attempts || entry.DeadLettered {
+		return false, nil
+	}
+
+	entry.Attempts++
+	entry.NextAttemptAt = nextAttemptAt
+
+	return true, nil
+}
+
+func (s *testQueueStore) UpdateNotificationQueueEntryError(_ context.Context, id int, deadLettered bool, lastError string) (err error) {
+	if entry, ok := s.entries[id]; ok {
+		entry.DeadLettered = deadLettered
+		entry.LastError.String, entry.LastError.Valid = lastError, true
+	}
+
+	return nil
+}
+
+func (s *testQueueStore) DeleteNotificationQueueEntry(_ context.Context, id int) (err error) {
+	delete(s.entries, id)
+
+	return nil
+}
+
+func reverse(data []byte) []byte {
+	reversed := make([]byte, len(data))
+
+	for i, b := range data {
+		reversed[len(data)-1-i] = b
+	}
+
+	return reversed
+}
+
+type testQueueDelivery struct {
+	sent []testQueueMessage
+	err  error
+}
+
+type testQueueMessage struct {
+	recipient mail.Address
+	subject   string
+	text      string
+	html      string
+}
+
+func (d *testQueueDelivery) StartupCheck() (err error) {
+	return nil
+}
+
+func (d *testQueueDelivery) Send(_ context.Context, recipient mail.Address, subject string, et *templates.EmailTemplate, data any) (err error) {
+	if d.err != nil {
+		return d.err
+	}
+
+	text, html := &strings.Builder{}, &strings.Builder{}
+
+	if err = et.Text.Execute(text, data); err != nil {
+		return err
+	}
+
+	if err = et.HTML.Execute(html, data); err != nil {
+		return err
+	}
+
+	d.sent = append(d.sent, testQueueMessage{recipient: recipient, subject: subject, text: text.String(), html: html.String()})
+
+	return nil
+}
+
+type testQueueMetrics struct {
+	results []string
+}
+
+func (m *testQueueMetrics) RecordNotification(result string) {
+	m.results = append(m.results, result)
+}
@@ -0,0 +1,74 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/mail"
+	"os/exec"
+	"strings"
+
+	gomail "github.com/wneessen/go-mail"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/templates"
+)
+
+// NewSendmailNotifier creates a SendmailNotifier using the notifier configuration.
+func NewSendmailNotifier(config *schema.NotifierSendmail) *SendmailNotifier {
+	return &SendmailNotifier{
+		config:   config,
+		renderer: newMessageRenderer(config.Sender, config.Subject, config.DisableHTMLEmails, config.DKIM),
+	}
+}
+
+// SendmailNotifier a notifier to pipe emails to a sendmail compatible binary such as the one provided by a local MTA.
+type SendmailNotifier struct {
+	config   *schema.NotifierSendmail
+	renderer *messageRenderer
+}
+
+// StartupCheck implements model.StartupCheck to perform startup check operations.
+func (n *SendmailNotifier) StartupCheck() (err error) {
+	if _, err = exec.LookPath(n.config.Path); err != nil {
+		return fmt.Errorf("failed to find executable: %w", err)
+	}
+
+	return nil
+}
+
+// Send a notification via the SendmailNotifier.
+func (n *SendmailNotifier) Send(ctx context.Context, recipient mail.Address, subject string, et *templates.EmailTemplate, data any) (err error) {
+	var msg *gomail.Msg
+
+	if msg, err = n.renderer.render(recipient, subject, et, data); err != nil {
+		return fmt.Errorf("notifier: sendmail: %w", err)
+	}
+
+	stdin := &bytes.Buffer{}
+
+	if _, err = msg.WriteTo(stdin); err != nil {
+		return fmt.Errorf("notifier: sendmail: failed to write message: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, n.config.Timeout)
+
+	defer cancel()
+
+	stderr := &bytes.Buffer{}
+
+	cmd := exec.CommandContext(ctx, n.config.Path, n.config.Arguments...)
+
+	cmd.Stdin = stdin
+	cmd.Stderr = stderr
+
+	if err = cmd.Run(); err != nil {
+		if output := strings.TrimSpace(stderr.String()); output != "" {
+			return fmt.Errorf("notifier: sendmail: failed to send message: %w: %s", err, output)
+		}
+
+		return fmt.Errorf("notifier: sendmail: failed to send message: %w", err)
+	}
+
+	return nil
+}
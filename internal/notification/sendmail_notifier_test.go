@@ -0,0 +1,186 @@
+package notification
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"net/mail"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/templates"
+)
+
+func TestSendmailNotifierShouldPipeMessage(t *testing.T) {
+	dir := t.TempDir()
+
+	path := writeTestSendmailBinary(t, dir, "#!/bin/sh\necho \"$@\" > \""+dir+"/args\"\ncat > \""+dir+"/message\"\n")
+
+	notifier := NewSendmailNotifier(&schema.NotifierSendmail{
+		Path:      path,
+		Arguments: []string{"-oi", "-t"},
+		Timeout:   time.Second * 5,
+		Sender:    mail.Address{Name: "Authelia", Address: "authelia@example.com"},
+		Subject:   "[Authelia] {title}",
+	})
+
+	provider, err := templates.New(templates.Config{})
+	require.NoError(t, err)
+
+	data := templates.EmailEventValues{
+		Title:       "Second Factor Method Added",
+		DisplayName: "John Smith",
+		RemoteIP:    "127.0.0.1",
+		Details:     map[string]any{"Action": "Second Factor Method Added"},
+	}
+
+	require.NoError(t, notifier.Send(context.Background(), mail.Address{Name: "John Smith", Address: "john@example.com"}, "Second Factor Method Added", provider.GetEventEmailTemplate(), data))
+
+	args, err := os.ReadFile(filepath.Join(dir, "args"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "-oi -t\n", string(args))
+
+	message, err := os.ReadFile(filepath.Join(dir, "message"))
+	require.NoError(t, err)
+
+	msg, err := mail.ReadMessage(strings.NewReader(string(message)))
+	require.NoError(t, err)
+
+	assert.Equal(t, `"Authelia" <authelia@example.com>`, msg.Header.Get("From"))
+	assert.Equal(t, `"John Smith" <john@example.com>`, msg.Header.Get("To"))
+	assert.Equal(t, "[Authelia] Second Factor Method Added", msg.Header.Get("Subject"))
+	assert.True(t, strings.HasSuffix(msg.Header.Get("Message-ID"), "@example.com>"))
+	assert.True(t, strings.HasPrefix(msg.Header.Get("Content-Type"), "multipart/alternative;"))
+}
+
+func TestSendmailNotifierShouldSignMessageWithDKIM(t *testing.T) {
+	dir := t.TempDir()
+
+	path := writeTestSendmailBinary(t, dir, "#!/bin/sh\ncat > \""+dir+"/message\"\n")
+
+	public, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	notifier := NewSendmailNotifier(&schema.NotifierSendmail{
+		Path:    path,
+		Timeout: time.Second * 5,
+		Sender:  mail.Address{Address: "authelia@example.com"},
+		Subject: "{title}",
+		DKIM: &schema.NotifierSMTPDKIM{
+			Domain:     "example.com",
+			Selector:   "authelia",
+			PrivateKey: key,
+			Headers:    schema.DefaultSMTPNotifierDKIMConfiguration.Headers,
+		},
+	})
+
+	provider, err := templates.New(templates.Config{})
+	require.NoError(t, err)
+
+	require.NoError(t, notifier.Send(context.Background(), mail.Address{Address: "john@example.com"}, "Title", provider.GetEventEmailTemplate(), templates.EmailEventValues{}))
+
+	message, err := os.ReadFile(filepath.Join(dir, "message"))
+	require.NoError(t, err)
+
+	verifications := verifyTestDKIM(t, message, "v=DKIM1; k=ed25519; p="+base64.StdEncoding.EncodeToString(public))
+
+	require.Len(t, verifications, 1)
+	assert.NoError(t, verifications[0].Err)
+}
+
+func TestSendmailNotifierShouldErrorOnExitStatus(t *testing.T) {
+	dir := t.TempDir()
+
+	testCases := []struct {
+		name     string
+		script   string
+		timeout  time.Duration
+		expected string
+	}{
+		{
+			"ShouldIncludeStderr",
+			"#!/bin/sh\ncat > /dev/null\necho 'sendmail: fatal: no recipient addresses found' >&2\nexit 75\n",
+			time.Second * 5,
+			"notifier: sendmail: failed to send message: exit status 75: sendmail: fatal: no recipient addresses found",
+		},
+		{
+			"ShouldNotIncludeEmptyStderr",
+			"#!/bin/sh\ncat > /dev/null\nexit 1\n",
+			time.Second * 5,
+			"notifier: sendmail: failed to send message: exit status 1",
+		},
+		{
+			"ShouldErrorOnTimeout",
+			"#!/bin/sh\nexec sleep 5\n",
+			time.Millisecond * 100,
+			"notifier: sendmail: failed to send message: signal: killed",
+		},
+	}
+
+	provider, err := templates.New(templates.Config{})
+	require.NoError(t, err)
+
+	for i, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			notifier := NewSendmailNotifier(&schema.NotifierSendmail{
+				Path:    writeTestSendmailBinary(t, filepath.Join(dir, string(rune('a'+i))), tc.script),
+				Timeout: tc.timeout,
+				Sender:  mail.Address{Address: "authelia@example.com"},
+				Subject: "{title}",
+			})
+
+			err := notifier.Send(context.Background(), mail.Address{Address: "john@example.com"}, "Title", provider.GetEventEmailTemplate(), templates.EmailEventValues{})
+
+			assert.EqualError(t, err, tc.expected)
+
+			var exitErr *exec.ExitError
+
+			assert.True(t, errors.As(err, &exitErr))
+		})
+	}
+}
+
+func TestSendmailNotifierStartupCheck(t *testing.T) {
+	dir := t.TempDir()
+
+	path := writeTestSendmailBinary(t, dir, "#!/bin/sh\n")
+
+	notifier := NewSendmailNotifier(&schema.NotifierSendmail{Path: path})
+
+	assert.NoError(t, notifier.StartupCheck())
+
+	notifier = NewSendmailNotifier(&schema.NotifierSendmail{Path: filepath.Join(dir, "missing")})
+
+	assert.EqualError(t, notifier.StartupCheck(), "failed to find executable: exec: \""+filepath.Join(dir, "missing")+"\": stat "+filepath.Join(dir, "missing")+": no such file or directory")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "noexec"), []byte("#!/bin/sh\n"), 0600))
+
+	notifier = NewSendmailNotifier(&schema.NotifierSendmail{Path: filepath.Join(dir, "noexec")})
+
+	assert.EqualError(t, notifier.StartupCheck(), "failed to find executable: exec: \""+filepath.Join(dir, "noexec")+"\": permission denied")
+
+	notifier = NewSendmailNotifier(&schema.NotifierSendmail{Path: dir})
+
+	assert.ErrorContains(t, notifier.StartupCheck(), "failed to find executable: exec: \""+dir+"\": ")
+}
+
+func writeTestSendmailBinary(t *testing.T, dir, script string) string {
+	require.NoError(t, os.MkdirAll(dir, 0700))
+
+	path := filepath.Join(dir, "sendmail")
+
+	require.NoError(t, os.WriteFile(path, []byte(script), 0700)) //nolint:gosec
+
+	return path
+}
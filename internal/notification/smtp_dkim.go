@@ -0,0 +1,69 @@
+package notification
+
+import (
+	"bytes"
+	"crypto"
+	"fmt"
+	"strings"
+
+	"github.com/emersion/go-msgauth/dkim"
+	gomail "github.com/wneessen/go-mail"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// newDKIMSigner creates a dkimSigner using the DKIM configuration, returning nil if the configuration is nil.
+func newDKIMSigner(config *schema.NotifierSMTPDKIM) *dkimSigner {
+	if config == nil {
+		return nil
+	}
+
+	signer, _ := config.PrivateKey.(crypto.Signer)
+
+	return &dkimSigner{
+		options: &dkim.SignOptions{
+			Domain:                 config.Domain,
+			Selector:               config.Selector,
+			Signer:                 signer,
+			HeaderCanonicalization: dkim.CanonicalizationRelaxed,
+			BodyCanonicalization:   dkim.CanonicalizationRelaxed,
+			HeaderKeys:             config.Headers,
+		},
+	}
+}
+
+// dkimSigner signs messages with a DKIM-Signature header.
+type dkimSigner struct {
+	options *dkim.SignOptions
+}
+
+// sign the message by rendering it and adding the resulting DKIM-Signature header. The message must not be modified
+// after it has been signed.
+func (s *dkimSigner) sign(msg *gomail.Msg) (err error) {
+	buf := &bytes.Buffer{}
+
+	if _, err = msg.WriteTo(buf); err != nil {
+		return fmt.Errorf("failed to render message: %w", err)
+	}
+
+	var signer *dkim.Signer
+
+	if signer, err = dkim.NewSigner(s.options); err != nil {
+		return err
+	}
+
+	if _, err = signer.Write(buf.Bytes()); err != nil {
+		_ = signer.Close()
+
+		return err
+	}
+
+	if err = signer.Close(); err != nil {
+		return err
+	}
+
+	// The signature includes the header field name and the final CRLF which are both written by the message itself.
+	msg.SetGenHeaderPreformatted(headerDKIMSignature, strings.TrimSpace(strings.TrimPrefix(signer.Signature(), headerDKIMSignature+":")))
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package notification
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"net/mail"
+	"testing"
+
+	"github.com/emersion/go-msgauth/dkim"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/templates"
+)
+
+func TestSMTPNotifierShouldSignMessageWithDKIM(t *testing.T) {
+	keyRSA, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	publicEd25519, keyEd25519, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKIXPublicKey(&keyRSA.PublicKey)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name      string
+		key       schema.CryptographicPrivateKey
+		record    string
+		algorithm string
+	}{
+		{
+			"ShouldSignWithRSA",
+			keyRSA,
+			"v=DKIM1; k=rsa; p=" + base64.StdEncoding.EncodeToString(der),
+			"a=rsa-sha256",
+		},
+		{
+			"ShouldSignWithEd25519",
+			keyEd25519,
+			"v=DKIM1; k=ed25519; p=" + base64.StdEncoding.EncodeToString(publicEd25519),
+			"a=ed25519-sha256",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			raw := renderTestSMTPMessage(t, &schema.NotifierSMTPDKIM{
+				Domain:     "example.com",
+				Selector:   "authelia",
+				PrivateKey: tc.key,
+				Headers:    schema.DefaultSMTPNotifierDKIMConfiguration.Headers,
+			})
+
+			assert.Contains(t, string(raw), "DKIM-Signature: ")
+			assert.Contains(t, string(raw), tc.algorithm)
+
+			verifications := verifyTestDKIM(t, raw, tc.record)
+
+			require.Len(t, verifications, 1)
+			assert.NoError(t, verifications[0].Err)
+			assert.Equal(t, "example.com", verifications[0].Domain)
+			assert.Equal(t, schema.DefaultSMTPNotifierDKIMConfiguration.Headers, verifications[0].HeaderKeys)
+
+			tampered := bytes.Replace(raw, []byte("John Smith"), []byte("Jane Smith"), 1)
+
+			require.NotEqual(t, raw, tampered)
+
+			verifications = verifyTestDKIM(t, tampered, tc.record)
+
+			require.Len(t, verifications, 1)
+			assert.Error(t, verifications[0].Err)
+		})
+	}
+}
+
+func TestSMTPNotifierShouldNotSignMessageWithoutDKIM(t *testing.T) {
+	raw := renderTestSMTPMessage(t, nil)
+
+	assert.NotContains(t, string(raw), "DKIM-Signature")
+}
+
+func TestSMTPNotifierShouldErrorSigningWithInvalidKey(t *testing.T) {
+	notifier := NewSMTPNotifier(&schema.NotifierSMTP{
+		Address: schema.NewSMTPAddress("", "127.0.0.1", 25),
+		Sender:  mail.Address{Name: "Authelia", Address: "authelia@example.com"},
+		TLS:     &schema.TLS{},
+		DKIM:    &schema.NotifierSMTPDKIM{Domain: "example.com", Selector: "authelia"},
+	}, nil)
+
+	provider, err := templates.New(templates.Config{})
+	require.NoError(t, err)
+
+	_, err = notifier.message(mail.Address{Address: "john@example.com"}, "Title", provider.GetEventEmailTemplate(), templates.EmailEventValues{})
+
+	assert.EqualError(t, err, "notifier: smtp: failed to sign message: dkim: no signer specified")
+}
+
+func renderTestSMTPMessage(t *testing.T, config *schema.NotifierSMTPDKIM) []byte {
+	notifier := NewSMTPNotifier(&schema.NotifierSMTP{
+		Address: schema.NewSMTPAddress("", "127.0.0.1", 25),
+		Sender:  mail.Address{Name: "Authelia", Address: "authelia@example.com"},
+		Subject: "[Authelia] {title}",
+		TLS:     &schema.TLS{},
+		DKIM:    config,
+	}, nil)
+
+	provider, err := templates.New(templates.Config{})
+	require.NoError(t, err)
+
+	data := templates.EmailEventValues{
+		Title:       "Second Factor Method Added",
+		DisplayName: "John Smith",
+		RemoteIP:    "127.0.0.1",
+		Details:     map[string]any{"Action": "Second Factor Method Added"},
+	}
+
+	msg, err := notifier.message(mail.Address{Name: "John Smith", Address: "john@example.com"}, "Second Factor Method Added", provider.GetEventEmailTemplate(), data)
+	require.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+
+	_, err = msg.WriteTo(buf)
+	require.NoError(t, err)
+
+	return buf.Bytes()
+}
+
+func verifyTestDKIM(t *testing.T, raw []byte, record string) []*dkim.Verification {
+	verifications, err := dkim.VerifyWithOptions(bytes.NewReader(raw), &dkim.VerifyOptions{
+		LookupTXT: func(domain string) ([]string, error) {
+			assert.Equal(t, "authelia._domainkey.example.com", domain)
+
+			return []string{record}, nil
+		},
+	})
+
+	require.NoError(t, err)
+
+	return verifications
+}
@@ -6,15 +6,12 @@ import (
 	"crypto/x509"
 	"fmt"
 	"net/mail"
-	"os"
-	"strings"
 
 	"github.com/sirupsen/logrus"
 	gomail "github.com/wneessen/go-mail"
 
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/logging"
-	"github.com/authelia/authelia/v4/internal/random"
 	"github.com/authelia/authelia/v4/internal/templates"
 	"github.com/authelia/authelia/v4/internal/utils"
 )
@@ -52,34 +49,22 @@ func NewSMTPNotifier(config *schema.NotifierSMTP, certPool *x509.CertPool) *SMTP
 		opts = append(opts, gomail.WithTLSPolicy(gomail.TLSMandatory))
 	}
 
-	var domain string
-
-	at := strings.LastIndex(config.Sender.Address, "@")
-
-	if at >= 0 {
-		domain = config.Sender.Address[at+1:]
-	} else {
-		domain = "localhost.localdomain"
-	}
-
 	return &SMTPNotifier{
-		config: config,
-		domain: domain,
-		random: &random.Cryptographical{},
-		tls:    utils.NewTLSConfig(config.TLS, certPool),
-		log:    logging.Logger(),
-		opts:   opts,
+		config:   config,
+		tls:      utils.NewTLSConfig(config.TLS, certPool),
+		log:      logging.Logger(),
+		opts:     opts,
+		renderer: newMessageRenderer(config.Sender, config.Subject, config.DisableHTMLEmails, config.DKIM),
 	}
 }
 
 // SMTPNotifier a notifier to send emails to SMTP servers.
 type SMTPNotifier struct {
-	config *schema.NotifierSMTP
-	domain string
-	random random.Provider
-	tls    *tls.Config
-	log    *logrus.Logger
-	opts   []gomail.Option
+	config   *schema.NotifierSMTP
+	tls      *tls.Config
+	log      *logrus.Logger
+	opts     []gomail.Option
+	renderer *messageRenderer
 }
 
 // StartupCheck implements model.StartupCheck to perform startup check operations.
@@ -105,36 +90,10 @@ func (n *SMTPNotifier) StartupCheck() (err error) {
 
 // Send a notification via the SMTPNotifier.
 func (n *SMTPNotifier) Send(ctx context.Context, recipient mail.Address, subject string, et *templates.EmailTemplate, data any) (err error) {
-	msg := gomail.NewMsg(
-		gomail.WithMIMEVersion(gomail.Mime10),
-		gomail.WithBoundary(n.random.StringCustom(30, random.CharSetAlphaNumeric)),
-	)
-
-	n.setMessageID(msg, n.domain)
-
-	if err = msg.From(n.config.Sender.String()); err != nil {
-		return fmt.Errorf("notifier: smtp: failed to set from address: %w", err)
-	}
+	var msg *gomail.Msg
 
-	if err = msg.AddTo(recipient.String()); err != nil {
-		return fmt.Errorf("notifier: smtp: failed to set to address: %w", err)
-	}
-
-	msg.Subject(strings.ReplaceAll(n.config.Subject, "{title}", subject))
-
-	switch {
-	case n.config.DisableHTMLEmails:
-		if err = msg.SetBodyTextTemplate(et.Text, data); err != nil {
-			return fmt.Errorf("notifier: smtp: failed to set body: text template errored: %w", err)
-		}
-	default:
-		if err = msg.AddAlternativeTextTemplate(et.Text, data); err != nil {
-			return fmt.Errorf("notifier: smtp: failed to set body: text template errored: %w", err)
-		}
-
-		if err = msg.AddAlternativeHTMLTemplate(et.HTML, data); err != nil {
-			return fmt.Errorf("notifier: smtp: failed to set body: html template errored: %w", err)
-		}
+	if msg, err = n.message(recipient, subject, et, data); err != nil {
+		return err
 	}
 
 	var client *gomail.Client
@@ -162,11 +121,11 @@ func (n *SMTPNotifier) Send(ctx context.Context, recipient mail.Address, subject
 	return nil
 }
 
-func (n *SMTPNotifier) setMessageID(msg *gomail.Msg, domain string) {
-	rn := n.random.Intn(100000000)
-	rm := n.random.Intn(10000)
-	rs := n.random.StringCustom(17, random.CharSetAlphaNumeric)
-	pid := os.Getpid() + rm
+// message renders the message for a notification and signs it if DKIM is configured.
+func (n *SMTPNotifier) message(recipient mail.Address, subject string, et *templates.EmailTemplate, data any) (msg *gomail.Msg, err error) {
+	if msg, err = n.renderer.render(recipient, subject, et, data); err != nil {
+		return nil, fmt.Errorf("notifier: smtp: %w", err)
+	}
 
-	msg.SetMessageIDWithValue(fmt.Sprintf("%d.%d%d.%s@%s", pid, rn, rm, rs, domain))
+	return msg, nil
 }
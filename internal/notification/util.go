@@ -0,0 +1,13 @@
+package notification
+
+import (
+	"path"
+	"strings"
+
+	"github.com/authelia/authelia/v4/internal/templates"
+)
+
+// emailTemplateName returns the name of an EmailTemplate without the file extension.
+func emailTemplateName(et *templates.EmailTemplate) string {
+	return strings.TrimSuffix(et.Text.Name(), path.Ext(et.Text.Name()))
+}
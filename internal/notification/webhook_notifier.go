@@ -0,0 +1,186 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+	"strconv"
+	"time"
+
+	"github.com/authelia/authelia/v4/internal/clock"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/templates"
+	"github.com/authelia/authelia/v4/internal/utils"
+)
+
+// NewWebhookNotifier creates a WebhookNotifier using the notifier configuration.
+func NewWebhookNotifier(config *schema.NotifierWebhook, certPool *x509.CertPool, clock clock.Provider) *WebhookNotifier {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if config.TLS != nil {
+		transport.TLSClientConfig = utils.NewTLSConfig(config.TLS, certPool)
+	}
+
+	return &WebhookNotifier{
+		config: config,
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   config.Timeout,
+		},
+		clock: clock,
+	}
+}
+
+// WebhookNotifier a notifier to send notifications to a HTTP endpoint.
+type WebhookNotifier struct {
+	config *schema.NotifierWebhook
+	client *http.Client
+	clock  clock.Provider
+}
+
+// StartupCheck implements model.StartupCheck to perform startup check operations.
+func (n *WebhookNotifier) StartupCheck() (err error) {
+	if n.config.HealthCheckURL == nil {
+		return nil
+	}
+
+	var req *http.Request
+
+	if req, err = http.NewRequestWithContext(context.Background(), http.MethodGet, n.config.HealthCheckURL.String(), nil); err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	n.setHeaders(req)
+
+	if err = n.do(req); err != nil {
+		return fmt.Errorf("failed to perform health check: %w", err)
+	}
+
+	return nil
+}
+
+// Send a notification via the WebhookNotifier.
+func (n *WebhookNotifier) Send(ctx context.Context, recipient mail.Address, subject string, et *templates.EmailTemplate, data any) (err error) {
+	payload := webhookPayload{
+		Time:      n.clock.Now().UTC(),
+		Recipient: webhookRecipient{Name: recipient.Name, Address: recipient.Address},
+		Subject:   subject,
+		Event: webhookEvent{
+			Template: emailTemplateName(et),
+			Values:   data,
+		},
+	}
+
+	buf := &bytes.Buffer{}
+
+	if err = et.Text.Execute(buf, data); err != nil {
+		return fmt.Errorf("notifier: webhook: failed to execute text template: %w", err)
+	}
+
+	payload.Body.Text = buf.String()
+
+	buf.Reset()
+
+	if err = et.HTML.Execute(buf, data); err != nil {
+		return fmt.Errorf("notifier: webhook: failed to execute html template: %w", err)
+	}
+
+	payload.Body.HTML = buf.String()
+
+	var body []byte
+
+	if body, err = json.Marshal(payload); err != nil {
+		return fmt.Errorf("notifier: webhook: failed to marshal payload: %w", err)
+	}
+
+	var req *http.Request
+
+	if req, err = http.NewRequestWithContext(ctx, http.MethodPost, n.config.URL.String(), bytes.NewReader(body)); err != nil {
+		return fmt.Errorf("notifier: webhook: failed to create request: %w", err)
+	}
+
+	n.setHeaders(req)
+
+	req.Header.Set(headerContentType, mimeApplicationJSON)
+
+	if n.config.Secret != "" {
+		timestamp := strconv.FormatInt(payload.Time.Unix(), 10)
+
+		req.Header.Set(headerWebhookTimestamp, timestamp)
+		req.Header.Set(headerWebhookSignature, webhookSignaturePrefix+n.sign(timestamp, body))
+	}
+
+	if err = n.do(req); err != nil {
+		return fmt.Errorf("notifier: webhook: failed to send notification: %w", err)
+	}
+
+	return nil
+}
+
+func (n *WebhookNotifier) setHeaders(req *http.Request) {
+	req.Header.Set(headerUserAgent, webhookUserAgent)
+
+	for _, header := range n.config.Headers {
+		req.Header.Set(header.Name, header.Value)
+	}
+}
+
+func (n *WebhookNotifier) do(req *http.Request) (err error) {
+	var resp *http.Response
+
+	if resp, err = n.client.Do(req); err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// sign returns the hex encoded HMAC-SHA256 of the timestamp and the body separated by a period.
+func (n *WebhookNotifier) sign(timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(n.config.Secret))
+
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+type webhookPayload struct {
+	Time      time.Time        `json:"time"`
+	Recipient webhookRecipient `json:"recipient"`
+	Subject   string           `json:"subject"`
+	Body      webhookBody      `json:"body"`
+	Event     webhookEvent     `json:"event"`
+}
+
+type webhookRecipient struct {
+	Name    string `json:"name,omitempty"`
+	Address string `json:"address"`
+}
+
+type webhookBody struct {
+	Text string `json:"text"`
+	HTML string `json:"html"`
+}
+
+type webhookEvent struct {
+	Template string `json:"template"`
+	Values   any    `json:"values"`
+}
@@ -0,0 +1,160 @@
+package notification
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/clock"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/templates"
+)
+
+func TestWebhookNotifierShouldSend(t *testing.T) {
+	var (
+		header http.Header
+		body   []byte
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/notify", r.URL.Path)
+
+		header = r.Header.Clone()
+		body, _ = io.ReadAll(r.Body)
+
+		w.WriteHeader(http.StatusAccepted)
+	}))
+
+	defer server.Close()
+
+	provider, err := templates.New(templates.Config{})
+	require.NoError(t, err)
+
+	notifier := NewWebhookNotifier(&schema.NotifierWebhook{
+		URL:     mustParseURL(t, server.URL+"/notify"),
+		Timeout: time.Second,
+		Secret:  "abc123",
+		Headers: []schema.NotifierWebhookHeader{{Name: "Authorization", Value: "Bearer xyz"}},
+	}, nil, clock.NewFixed(time.Unix(1700000000, 0)))
+
+	data := templates.EmailEventValues{
+		Title:       "Second Factor Method Added",
+		DisplayName: "John Smith",
+		RemoteIP:    "127.0.0.1",
+		Details:     map[string]any{"Action": "Second Factor Method Added"},
+	}
+
+	require.NoError(t, notifier.Send(context.Background(), mail.Address{Name: "John Smith", Address: "john@example.com"}, "Second Factor Method Added", provider.GetEventEmailTemplate(), data))
+
+	assert.Equal(t, "application/json", header.Get("Content-Type"))
+	assert.Equal(t, "Bearer xyz", header.Get("Authorization"))
+	assert.Equal(t, "1700000000", header.Get("X-Authelia-Timestamp"))
+
+	mac := hmac.New(sha256.New, []byte("abc123"))
+	mac.Write([]byte("1700000000."))
+	mac.Write(body)
+
+	assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), header.Get("X-Authelia-Signature"))
+
+	payload := map[string]any{}
+
+	require.NoError(t, json.Unmarshal(body, &payload))
+
+	assert.Equal(t, "2023-11-14T22:13:20Z", payload["time"])
+	assert.Equal(t, map[string]any{"name": "John Smith", "address": "john@example.com"}, payload["recipient"])
+	assert.Equal(t, "Second Factor Method Added", payload["subject"])
+
+	bodies := payload["body"].(map[string]any)
+
+	assert.Contains(t, bodies["text"], "John Smith")
+	assert.Contains(t, bodies["html"], "John Smith")
+
+	event := payload["event"].(map[string]any)
+
+	assert.Equal(t, templates.TemplateNameEmailEvent, event["template"])
+	assert.Equal(t, "127.0.0.1", event["values"].(map[string]any)["RemoteIP"])
+}
+
+func TestWebhookNotifierShouldNotSignWithoutSecret(t *testing.T) {
+	var header http.Header
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Clone()
+	}))
+
+	defer server.Close()
+
+	provider, err := templates.New(templates.Config{})
+	require.NoError(t, err)
+
+	notifier := NewWebhookNotifier(&schema.NotifierWebhook{URL: mustParseURL(t, server.URL), Timeout: time.Second}, nil, clock.New())
+
+	require.NoError(t, notifier.Send(context.Background(), mail.Address{Address: "john@example.com"}, "Title", provider.GetEventEmailTemplate(), templates.EmailEventValues{}))
+
+	assert.Empty(t, header.Get("X-Authelia-Timestamp"))
+	assert.Empty(t, header.Get("X-Authelia-Signature"))
+}
+
+func TestWebhookNotifierShouldErrorOnUnexpectedStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+
+	defer server.Close()
+
+	provider, err := templates.New(templates.Config{})
+	require.NoError(t, err)
+
+	notifier := NewWebhookNotifier(&schema.NotifierWebhook{URL: mustParseURL(t, server.URL), Timeout: time.Second}, nil, clock.New())
+
+	err = notifier.Send(context.Background(), mail.Address{Address: "john@example.com"}, "Title", provider.GetEventEmailTemplate(), templates.EmailEventValues{})
+
+	assert.EqualError(t, err, "notifier: webhook: failed to send notification: unexpected status code 500")
+}
+
+func TestWebhookNotifierStartupCheck(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "Bearer xyz", r.Header.Get("Authorization"))
+
+		if r.URL.Path != "/health" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+
+	defer server.Close()
+
+	headers := []schema.NotifierWebhookHeader{{Name: "Authorization", Value: "Bearer xyz"}}
+
+	notifier := NewWebhookNotifier(&schema.NotifierWebhook{URL: mustParseURL(t, server.URL), Timeout: time.Second, Headers: headers}, nil, clock.New())
+
+	assert.NoError(t, notifier.StartupCheck())
+
+	notifier = NewWebhookNotifier(&schema.NotifierWebhook{URL: mustParseURL(t, server.URL), HealthCheckURL: mustParseURL(t, server.URL+"/health"), Timeout: time.Second, Headers: headers}, nil, clock.New())
+
+	assert.NoError(t, notifier.StartupCheck())
+
+	notifier = NewWebhookNotifier(&schema.NotifierWebhook{URL: mustParseURL(t, server.URL), HealthCheckURL: mustParseURL(t, server.URL+"/unhealthy"), Timeout: time.Second, Headers: headers}, nil, clock.New())
+
+	assert.EqualError(t, notifier.StartupCheck(), "failed to perform health check: unexpected status code 503")
+}
+
+func mustParseURL(t *testing.T, raw string) *url.URL {
+	u, err := url.Parse(raw)
+	require.NoError(t, err)
+
+	return u
+}
@@ -37,6 +37,28 @@ func NewStore(config *schema.IdentityProvidersOpenIDConnect, provider storage.Pr
 	return store
 }
 
+// RevokeUserTokens revokes every access token and refresh token issued to a user and returns the number of tokens which
+// were revoked.
+func RevokeUserTokens(ctx context.Context, provider storage.Provider, username string) (revoked int, err error) {
+	var signatures []string
+
+	for _, sessionType := range []storage.OAuth2SessionType{storage.OAuth2SessionTypeAccessToken, storage.OAuth2SessionTypeRefreshToken} {
+		if signatures, err = provider.LoadOAuth2SessionSignaturesByUsername(ctx, sessionType, username); err != nil {
+			return revoked, err
+		}
+
+		for _, signature := range signatures {
+			if err = provider.RevokeOAuth2Session(ctx, sessionType, signature); err != nil {
+				return revoked, err
+			}
+
+			revoked++
+		}
+	}
+
+	return revoked, nil
+}
+
 // GenerateOpaqueUserID either retrieves or creates an opaque user id from a sectorID and username.
 func (s *Store) GenerateOpaqueUserID(ctx context.Context, sectorID, username string) (opaqueID *model.UserOpaqueIdentifier, err error) {
 	if opaqueID, err = s.provider.LoadUserOpaqueIdentifierBySignature(ctx, "openid", sectorID, username); err != nil {
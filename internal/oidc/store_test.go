@@ -489,6 +489,47 @@ func (s *StoreSuite) TestRevokeSessions() {
 	s.EqualError(s.store.DeletePARSession(s.ctx, "urn:par2"), "not found")
 }
 
+func (s *StoreSuite) TestRevokeUserTokens() {
+	gomock.InOrder(
+		s.mock.
+			EXPECT().
+			LoadOAuth2SessionSignaturesByUsername(s.ctx, storage.OAuth2SessionTypeAccessToken, "john").
+			Return([]string{"at_example1", "at_example2"}, nil),
+		s.mock.
+			EXPECT().
+			RevokeOAuth2Session(s.ctx, storage.OAuth2SessionTypeAccessToken, "at_example1").
+			Return(nil),
+		s.mock.
+			EXPECT().
+			RevokeOAuth2Session(s.ctx, storage.OAuth2SessionTypeAccessToken, "at_example2").
+			Return(nil),
+		s.mock.
+			EXPECT().
+			LoadOAuth2SessionSignaturesByUsername(s.ctx, storage.OAuth2SessionTypeRefreshToken, "john").
+			Return([]string{"rt_example1"}, nil),
+		s.mock.
+			EXPECT().
+			RevokeOAuth2Session(s.ctx, storage.OAuth2SessionTypeRefreshToken, "rt_example1").
+			Return(nil),
+		s.mock.
+			EXPECT().
+			LoadOAuth2SessionSignaturesByUsername(s.ctx, storage.OAuth2SessionTypeAccessToken, "fred").
+			Return([]string{"at_example3"}, nil),
+		s.mock.
+			EXPECT().
+			RevokeOAuth2Session(s.ctx, storage.OAuth2SessionTypeAccessToken, "at_example3").
+			Return(fmt.Errorf("bad conn")),
+	)
+
+	revoked, err := oidc.RevokeUserTokens(s.ctx, s.mock, "john")
+	s.NoError(err)
+	s.Equal(3, revoked)
+
+	revoked, err = oidc.RevokeUserTokens(s.ctx, s.mock, "fred")
+	s.EqualError(err, "bad conn")
+	s.Equal(0, revoked)
+}
+
 func (s *StoreSuite) TestGetSessions() {
 	challenge := model.MustNullUUID(model.NewRandomNullUUID())
 	session := &oidc.Session{
@@ -5,6 +5,12 @@ import "fmt"
 // ErrUserIsBanned user is banned error message.
 var ErrUserIsBanned = fmt.Errorf("user is banned")
 
+// ErrRemoteIPIsBanned remote ip is banned error message.
+var ErrRemoteIPIsBanned = fmt.Errorf("remote ip is banned")
+
+// ErrNoBannedRemoteNetworks no banned remote networks error message.
+var ErrNoBannedRemoteNetworks = fmt.Errorf("no banned remote networks are within the network range")
+
 const (
 	// AuthType1FA is the string representing an auth log for first-factor authentication.
 	AuthType1FA = "1FA"
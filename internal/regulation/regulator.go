@@ -2,6 +2,8 @@ package regulation
 
 import (
 	"context"
+	"database/sql"
+	"net"
 	"strings"
 	"time"
 
@@ -13,12 +15,40 @@ import (
 
 // NewRegulator create a regulator instance.
 func NewRegulator(config schema.Regulation, store storage.RegulatorProvider, clock clock.Provider) *Regulator {
-	return &Regulator{
-		enabled: config.MaxRetries > 0,
-		store:   store,
-		clock:   clock,
-		config:  config,
+	r := &Regulator{
+		enabled:   config.MaxRetries > 0,
+		enabledIP: config.IP.MaxRetries > 0,
+		network:   config.IP.MaxRetries > 0 || config.Scope == schema.RegulationScopeUserIP,
+		store:     store,
+		clock:     clock,
+		config:    config,
+		trusted:   parseTrustedNetworks(config.IP.TrustedNetworks),
+		user:      regulation{maxRetries: config.MaxRetries, findTime: config.FindTime, banTime: config.BanTime, lookback: config.BanTime, limit: 10},
+		ip:        regulation{maxRetries: config.IP.MaxRetries, findTime: config.IP.FindTime, banTime: config.IP.BanTime, lookback: config.IP.BanTime, limit: config.IP.MaxRetries},
+
+		secondFactor: map[string]*regulation{},
+	}
+
+	for authType, method := range map[string]schema.RegulationSecondFactorMethod{
+		AuthTypeTOTP:     config.SecondFactor.TOTP,
+		AuthTypeWebAuthn: config.SecondFactor.WebAuthn,
+		AuthTypeDuo:      config.SecondFactor.Duo,
+	} {
+		if method.MaxRetries > 0 {
+			r.secondFactor[authType] = &regulation{maxRetries: method.MaxRetries, findTime: method.FindTime, banTime: method.BanTime, lookback: method.BanTime, limit: method.MaxRetries}
+		}
 	}
+
+	if config.Mode == schema.RegulationModeEscalating {
+		r.user.escalate(config.Escalation)
+		r.ip.escalate(config.Escalation)
+
+		for _, method := range r.secondFactor {
+			method.escalate(config.Escalation)
+		}
+	}
+
+	return r
 }
 
 // Mark an authentication attempt.
@@ -26,35 +56,338 @@ func NewRegulator(config schema.Regulation, store storage.RegulatorProvider, clo
 func (r *Regulator) Mark(ctx Context, successful, banned bool, username, requestURI, requestMethod, authType string) error {
 	ctx.RecordAuthn(successful, banned, strings.ToLower(authType))
 
+	ip := ctx.RemoteIP()
+
 	return r.store.AppendAuthenticationLog(ctx, model.AuthenticationAttempt{
 		Time:          r.clock.Now(),
 		Successful:    successful,
 		Banned:        banned,
 		Username:      username,
 		Type:          authType,
-		RemoteIP:      model.NewNullIP(ctx.RemoteIP()),
+		RemoteIP:      model.NewNullIP(ip),
+		RemoteNetwork: r.remoteNetwork(ip),
+		Country:       ctx.RemoteGeoIP().Country,
 		RequestURI:    requestURI,
 		RequestMethod: requestMethod,
 	})
 }
 
-// Regulate the authentication attempts for a given user.
-// This method returns ErrUserIsBanned if the user is banned along with the time until when the user is banned.
-func (r *Regulator) Regulate(ctx context.Context, username string) (time.Time, error) {
+// Regulate the authentication attempts for a given user and the remote network of the request.
+// This method returns ErrRemoteIPIsBanned if the remote network is banned, or ErrUserIsBanned if the user is banned,
+// along with the time until when the ban applies.
+func (r *Regulator) Regulate(ctx Context, username string) (time.Time, error) {
+	network := r.remoteNetwork(ctx.RemoteIP())
+
+	if r.enabledIP && network.Valid && !r.isTrusted(ctx.RemoteIP()) {
+		if bannedUntil, banned := r.regulateRemoteNetwork(ctx, network.String); banned {
+			return bannedUntil, ErrRemoteIPIsBanned
+		}
+	}
+
 	// If there is regulation configuration, no regulation applies.
 	if !r.enabled {
 		return time.Time{}, nil
 	}
 
-	attempts, err := r.store.LoadAuthenticationLogs(ctx, username, r.clock.Now().Add(-r.config.BanTime), 10, 0)
+	if bannedUntil, banned := r.regulateUser(ctx, username, network); banned {
+		return bannedUntil, ErrUserIsBanned
+	}
+
+	return time.Time{}, nil
+}
+
+// RegulateSecondFactor regulates the authentication attempts for a given user with a second factor method. This method
+// returns ErrUserIsBanned if the user is banned from the second factor method, along with the time until when the ban
+// applies.
+func (r *Regulator) RegulateSecondFactor(ctx Context, username, authType string) (time.Time, error) {
+	method, ok := r.secondFactor[authType]
+	if !ok {
+		return time.Time{}, nil
+	}
+
+	attempts, err := r.store.LoadAuthenticationLogsByUsernameAndType(ctx, username, authType, r.clock.Now().Add(-method.lookback), method.limit, 0)
 	if err != nil {
 		return time.Time{}, nil
 	}
 
-	latestFailedAttempts := make([]model.AuthenticationAttempt, 0, r.config.MaxRetries)
+	bannedUntil, banned := method.regulate(attempts, r.clock.Now())
+	if !banned {
+		return time.Time{}, nil
+	}
+
+	var unbanned time.Time
+
+	if unbanned, err = r.store.LoadRegulationUnbanTimeByUsername(ctx, username); err == nil && !unbanned.IsZero() {
+		if bannedUntil, banned = method.regulate(attemptsAfter(attempts, unbanned), r.clock.Now()); !banned {
+			return time.Time{}, nil
+		}
+	}
+
+	return bannedUntil, ErrUserIsBanned
+}
+
+// Bans returns the users and remote networks which are currently banned.
+func (r *Regulator) Bans(ctx context.Context) (bans []Ban, err error) {
+	lookback := r.user.lookback
+
+	if r.ip.lookback > lookback {
+		lookback = r.ip.lookback
+	}
+
+	var subjects []model.AuthenticationAttempt
+
+	if subjects, err = r.store.LoadFailedAuthenticationLogSubjects(ctx, r.clock.Now().Add(-lookback)); err != nil {
+		return nil, err
+	}
+
+	users, networks := map[string]bool{}, map[string]bool{}
+
+	for _, subject := range subjects {
+		if r.enabled {
+			network := sql.NullString{}
+
+			if r.config.Scope == schema.RegulationScopeUserIP {
+				network = subject.RemoteNetwork
+			}
+
+			if key := subject.Username + "@" + network.String; !users[key] {
+				users[key] = true
+
+				if bannedUntil, banned := r.regulateUser(ctx, subject.Username, network); banned {
+					bans = append(bans, Ban{Username: subject.Username, RemoteNetwork: network.String, BannedUntil: bannedUntil})
+				}
+			}
+		}
+
+		if r.enabledIP && subject.RemoteNetwork.Valid && !networks[subject.RemoteNetwork.String] {
+			networks[subject.RemoteNetwork.String] = true
+
+			if _, cidr, err := net.ParseCIDR(subject.RemoteNetwork.String); err != nil || r.isTrusted(cidr.IP) {
+				continue
+			}
+
+			if bannedUntil, banned := r.regulateRemoteNetwork(ctx, subject.RemoteNetwork.String); banned {
+				bans = append(bans, Ban{RemoteNetwork: subject.RemoteNetwork.String, BannedUntil: bannedUntil})
+			}
+		}
+	}
+
+	return bans, nil
+}
+
+// Unban lifts the bans of a user, or of a remote network when the value is an IP or a network range in CIDR notation,
+// and returns the users or remote networks which were unbanned. The authentication attempts made before the unban are
+// no longer considered.
+//
+// An IP or a network range within a single remote network unbans the remote network containing it as determined by the
+// configured masks. A network range larger than a remote network unbans each currently banned remote network within it
+// and returns ErrNoBannedRemoteNetworks if there are none. When the user IP scope is configured unbanning a remote
+// network also lifts the bans of the users on it.
+func (r *Regulator) Unban(ctx context.Context, value string) (unbanned []string, err error) {
+	if ip := net.ParseIP(value); ip != nil {
+		unbanned = []string{r.maskIP(ip).String}
+	} else if _, cidr, errCIDR := net.ParseCIDR(value); errCIDR == nil {
+		if unbanned, err = r.bannedRemoteNetworksWithin(ctx, cidr); err != nil {
+			return nil, err
+		}
+	} else {
+		if err = r.store.SaveRegulationUnban(ctx, model.RegulationUnban{Time: r.clock.Now(), Username: sql.NullString{String: value, Valid: true}}); err != nil {
+			return nil, err
+		}
+
+		return []string{value}, nil
+	}
+
+	now := r.clock.Now()
+
+	for _, network := range unbanned {
+		if err = r.store.SaveRegulationUnban(ctx, model.RegulationUnban{Time: now, RemoteNetwork: sql.NullString{String: network, Valid: true}}); err != nil {
+			return nil, err
+		}
+	}
+
+	return unbanned, nil
+}
+
+// bannedRemoteNetworksWithin returns the remote networks within the network range. When the range is no larger than a
+// remote network this is the remote network containing it, otherwise it's each remote network within it which is
+// currently banned, or which a user is currently banned on when the user IP scope is configured.
+func (r *Regulator) bannedRemoteNetworksWithin(ctx context.Context, cidr *net.IPNet) (networks []string, err error) {
+	ones, bits := cidr.Mask.Size()
+
+	size := r.config.IP.IPv6Mask
+
+	if bits == net.IPv4len*8 {
+		size = r.config.IP.IPv4Mask
+	}
+
+	if ones >= size {
+		return []string{r.maskIP(cidr.IP).String}, nil
+	}
+
+	var bans []Ban
+
+	if bans, err = r.Bans(ctx); err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+
+	for _, ban := range bans {
+		if ban.RemoteNetwork == "" || seen[ban.RemoteNetwork] {
+			continue
+		}
+
+		if ip, _, errCIDR := net.ParseCIDR(ban.RemoteNetwork); errCIDR == nil && cidr.Contains(ip) {
+			seen[ban.RemoteNetwork] = true
+
+			networks = append(networks, ban.RemoteNetwork)
+		}
+	}
+
+	if len(networks) == 0 {
+		return nil, ErrNoBannedRemoteNetworks
+	}
+
+	return networks, nil
+}
+
+func (r *Regulator) regulateRemoteNetwork(ctx context.Context, network string) (bannedUntil time.Time, banned bool) {
+	attempts, err := r.store.LoadFailedAuthenticationLogsByRemoteNetwork(ctx, network, r.clock.Now().Add(-r.ip.lookback), r.ip.limit, 0)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	if bannedUntil, banned = r.ip.regulate(attempts, r.clock.Now()); !banned {
+		return time.Time{}, false
+	}
+
+	var unbanned time.Time
+
+	if unbanned, err = r.store.LoadRegulationUnbanTimeByRemoteNetwork(ctx, network); err != nil || unbanned.IsZero() {
+		return bannedUntil, banned
+	}
+
+	return r.ip.regulate(attemptsAfter(attempts, unbanned), r.clock.Now())
+}
+
+func (r *Regulator) regulateUser(ctx context.Context, username string, network sql.NullString) (bannedUntil time.Time, banned bool) {
+	var (
+		attempts []model.AuthenticationAttempt
+		err      error
+	)
+
+	if r.config.Scope == schema.RegulationScopeUserIP && network.Valid {
+		attempts, err = r.store.LoadAuthenticationLogsByUsernameAndRemoteNetwork(ctx, username, network.String, r.clock.Now().Add(-r.user.lookback), r.user.limit, 0)
+	} else {
+		attempts, err = r.store.LoadAuthenticationLogs(ctx, username, r.clock.Now().Add(-r.user.lookback), r.user.limit, 0)
+	}
+
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	if bannedUntil, banned = r.user.regulate(attempts, r.clock.Now()); !banned {
+		return time.Time{}, false
+	}
+
+	var unbanned, unbannedNetwork time.Time
+
+	if unbanned, err = r.store.LoadRegulationUnbanTimeByUsername(ctx, username); err != nil {
+		return bannedUntil, banned
+	}
+
+	// The bans of the user IP scope only apply to the remote network, so unbanning the remote network also lifts them.
+	if r.config.Scope == schema.RegulationScopeUserIP && network.Valid {
+		if unbannedNetwork, err = r.store.LoadRegulationUnbanTimeByRemoteNetwork(ctx, network.String); err != nil {
+			return bannedUntil, banned
+		}
+
+		if unbannedNetwork.After(unbanned) {
+			unbanned = unbannedNetwork
+		}
+	}
+
+	if unbanned.IsZero() {
+		return bannedUntil, banned
+	}
+
+	return r.user.regulate(attemptsAfter(attempts, unbanned), r.clock.Now())
+}
+
+// remoteNetwork returns the remote network of the IP, which is only determined when either the remote IP regulation or
+// the user IP scope is configured.
+func (r *Regulator) remoteNetwork(ip net.IP) sql.NullString {
+	if !r.network {
+		return sql.NullString{}
+	}
+
+	return r.maskIP(ip)
+}
+
+// maskIP returns the remote network of the IP using the configured masks.
+func (r *Regulator) maskIP(ip net.IP) sql.NullString {
+	if ip == nil {
+		return sql.NullString{}
+	}
+
+	var mask net.IPMask
+
+	if ip4 := ip.To4(); ip4 != nil {
+		ip, mask = ip4, net.CIDRMask(r.config.IP.IPv4Mask, 32)
+	} else {
+		mask = net.CIDRMask(r.config.IP.IPv6Mask, 128)
+	}
+
+	if mask == nil {
+		return sql.NullString{}
+	}
+
+	network := net.IPNet{IP: ip.Mask(mask), Mask: mask}
+
+	return sql.NullString{String: network.String(), Valid: true}
+}
+
+func (r *Regulator) isTrusted(ip net.IP) bool {
+	for _, network := range r.trusted {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// escalate configures the regulation to multiply the ban time for each successive ban within the lookback.
+func (r *regulation) escalate(config schema.RegulationEscalation) {
+	r.escalating, r.multiplier, r.maxBanTime, r.lookback = true, config.Multiplier, config.MaxBanTime, config.Lookback
+
+	if r.maxBanTime < r.banTime {
+		r.maxBanTime = r.banTime
+	}
+
+	// Attempts made while banned are not loaded, so loading the attempts required for every ban up to the one which
+	// reaches the maximum ban time is enough to determine the current ban.
+	levels := 1
+
+	for banTime := r.banTime; banTime < r.maxBanTime && r.multiplier > 1; banTime *= time.Duration(r.multiplier) {
+		levels++
+	}
+
+	r.limit = r.maxRetries * levels
+}
+
+// regulate determines if the attempts, which are ordered from the latest to the oldest, result in a ban, and if so the
+// time until when the ban applies.
+func (r *regulation) regulate(attempts []model.AuthenticationAttempt, now time.Time) (bannedUntil time.Time, banned bool) {
+	if r.escalating {
+		return r.regulateEscalating(attempts, now)
+	}
+
+	latestFailedAttempts := make([]model.AuthenticationAttempt, 0, r.maxRetries)
 
 	for _, attempt := range attempts {
-		if attempt.Successful || len(latestFailedAttempts) >= r.config.MaxRetries {
+		if attempt.Successful || len(latestFailedAttempts) >= r.maxRetries {
 			// We stop appending failed attempts once we find the first successful attempts or we reach
 			// the configured number of retries, meaning the user is already banned.
 			break
@@ -65,19 +398,94 @@ func (r *Regulator) Regulate(ctx context.Context, username string) (time.Time, e
 
 	// If the number of failed attempts within the ban time is less than the max number of retries
 	// then the user is not banned.
-	if len(latestFailedAttempts) < r.config.MaxRetries {
-		return time.Time{}, nil
+	if len(latestFailedAttempts) < r.maxRetries {
+		return time.Time{}, false
 	}
 
 	// Now we compute the time between the latest attempt and the MaxRetry-th one. If it's
 	// within the FindTime then it means that the user has been banned.
 	durationBetweenLatestAttempts := latestFailedAttempts[0].Time.Sub(
-		latestFailedAttempts[r.config.MaxRetries-1].Time)
+		latestFailedAttempts[r.maxRetries-1].Time)
 
-	if durationBetweenLatestAttempts < r.config.FindTime {
-		bannedUntil := latestFailedAttempts[0].Time.Add(r.config.BanTime)
-		return bannedUntil, ErrUserIsBanned
+	if durationBetweenLatestAttempts < r.findTime {
+		return latestFailedAttempts[0].Time.Add(r.banTime), true
 	}
 
-	return time.Time{}, nil
+	return time.Time{}, false
+}
+
+// regulateEscalating replays the failed attempts since the latest successful attempt from the oldest to the latest,
+// multiplying the ban time for each ban which occurred within the lookback.
+func (r *regulation) regulateEscalating(attempts []model.AuthenticationAttempt, now time.Time) (bannedUntil time.Time, banned bool) {
+	n := len(attempts)
+
+	for i, attempt := range attempts {
+		if attempt.Successful {
+			n = i
+
+			break
+		}
+	}
+
+	var (
+		window  []time.Time
+		banTime time.Duration
+	)
+
+	for i := n - 1; i >= 0; i-- {
+		if attempts[i].Time.Before(bannedUntil) {
+			continue
+		}
+
+		window = append(window, attempts[i].Time)
+
+		if len(window) < r.maxRetries {
+			continue
+		}
+
+		if window[len(window)-1].Sub(window[len(window)-r.maxRetries]) < r.findTime {
+			if banTime == 0 {
+				banTime = r.banTime
+			} else if banTime *= time.Duration(r.multiplier); banTime > r.maxBanTime {
+				banTime = r.maxBanTime
+			}
+
+			bannedUntil, window = attempts[i].Time.Add(banTime), nil
+		}
+	}
+
+	if bannedUntil.After(now) {
+		return bannedUntil, true
+	}
+
+	return time.Time{}, false
+}
+
+// attemptsAfter returns the attempts, which are ordered from the latest to the oldest, made after the given time.
+func attemptsAfter(attempts []model.AuthenticationAttempt, after time.Time) []model.AuthenticationAttempt {
+	for i, attempt := range attempts {
+		if !attempt.Time.After(after) {
+			return attempts[:i]
+		}
+	}
+
+	return attempts
+}
+
+func parseTrustedNetworks(networks []string) (trusted []*net.IPNet) {
+	for _, network := range networks {
+		if !strings.Contains(network, "/") {
+			if ip := net.ParseIP(network); ip != nil && ip.To4() != nil {
+				network += "/32"
+			} else {
+				network += "/128"
+			}
+		}
+
+		if _, cidr, err := net.ParseCIDR(network); err == nil {
+			trusted = append(trusted, cidr)
+		}
+	}
+
+	return trusted
 }
@@ -1,6 +1,7 @@
 package regulation_test
 
 import (
+	"database/sql"
 	"fmt"
 	"net"
 	"testing"
@@ -29,6 +30,13 @@ func (s *RegulatorSuite) SetupTest() {
 		MaxRetries: 3,
 		BanTime:    time.Second * 180,
 		FindTime:   time.Second * 30,
+		Scope:      schema.RegulationScopeUser,
+		IP: schema.RegulationIP{
+			BanTime:  time.Second * 180,
+			FindTime: time.Second * 30,
+			IPv4Mask: 24,
+			IPv6Mask: 64,
+		},
 	}
 
 	s.mock.Ctx.Request.Header.Set(fasthttp.HeaderXForwardedFor, "127.0.0.1")
@@ -39,6 +47,8 @@ func (s *RegulatorSuite) TearDownTest() {
 }
 
 func (s *RegulatorSuite) TestShouldMark() {
+	s.mock.Ctx.Configuration.Regulation.IP.MaxRetries = 10
+
 	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
 
 	s.mock.StorageMock.EXPECT().AppendAuthenticationLog(s.mock.Ctx, model.AuthenticationAttempt{
@@ -48,6 +58,7 @@ func (s *RegulatorSuite) TestShouldMark() {
 		Username:      "john",
 		Type:          "1fa",
 		RemoteIP:      model.NewNullIP(net.ParseIP("127.0.0.1")),
+		RemoteNetwork: sql.NullString{String: "127.0.0.0/24", Valid: true},
 		RequestURI:    "https://google.com",
 		RequestMethod: fasthttp.MethodGet,
 	})
@@ -55,6 +66,23 @@ func (s *RegulatorSuite) TestShouldMark() {
 	s.NoError(regulator.Mark(s.mock.Ctx, true, false, "john", "https://google.com", fasthttp.MethodGet, "1fa"))
 }
 
+func (s *RegulatorSuite) TestShouldMarkWithoutRemoteNetwork() {
+	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
+
+	s.mock.StorageMock.EXPECT().AppendAuthenticationLog(s.mock.Ctx, model.AuthenticationAttempt{
+		Time:          s.mock.Clock.Now(),
+		Successful:    false,
+		Banned:        false,
+		Username:      "john",
+		Type:          "1fa",
+		RemoteIP:      model.NewNullIP(net.ParseIP("127.0.0.1")),
+		RequestURI:    "https://google.com",
+		RequestMethod: fasthttp.MethodGet,
+	})
+
+	s.NoError(regulator.Mark(s.mock.Ctx, false, false, "john", "https://google.com", fasthttp.MethodGet, "1fa"))
+}
+
 func (s *RegulatorSuite) TestShouldHandleRegulateError() {
 	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
 
@@ -146,6 +174,10 @@ func (s *RegulatorSuite) TestShouldBanUserIfLatestAttemptsAreWithinFinTime() {
 		LoadAuthenticationLogs(s.mock.Ctx, gomock.Eq("john"), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
 		Return(attemptsInDB, nil)
 
+	s.mock.StorageMock.EXPECT().
+		LoadRegulationUnbanTimeByUsername(s.mock.Ctx, gomock.Eq("john")).
+		Return(time.Time{}, nil)
+
 	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
 
 	_, err := regulator.Regulate(s.mock.Ctx, "john")
@@ -179,6 +211,10 @@ func (s *RegulatorSuite) TestShouldCheckUserIsStillBanned() {
 		LoadAuthenticationLogs(s.mock.Ctx, gomock.Eq("john"), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
 		Return(attemptsInDB, nil)
 
+	s.mock.StorageMock.EXPECT().
+		LoadRegulationUnbanTimeByUsername(s.mock.Ctx, gomock.Eq("john")).
+		Return(time.Time{}, nil)
+
 	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
 
 	_, err := regulator.Regulate(s.mock.Ctx, "john")
@@ -324,7 +360,615 @@ func (s *RegulatorSuite) TestShouldHaveRegulatorDisabled() {
 		BanTime:    time.Second * 180,
 	}
 
+	s.mock.StorageMock.EXPECT().
+		LoadRegulationUnbanTimeByUsername(s.mock.Ctx, gomock.Eq("john")).
+		Return(time.Time{}, nil)
+
 	regulator = regulation.NewRegulator(config, s.mock.StorageMock, &s.mock.Clock)
 	_, err = regulator.Regulate(s.mock.Ctx, "john")
 	assert.Equal(s.T(), regulation.ErrUserIsBanned, err)
 }
+
+func (s *RegulatorSuite) TestShouldBanRemoteNetwork() {
+	attemptsInDB := []model.AuthenticationAttempt{
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-1 * time.Second),
+		},
+		{
+			Username:   "harry",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-4 * time.Second),
+		},
+		{
+			Username:   "bob",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-6 * time.Second),
+		},
+	}
+
+	s.mock.Ctx.Configuration.Regulation.IP.MaxRetries = 3
+
+	s.mock.StorageMock.EXPECT().
+		LoadFailedAuthenticationLogsByRemoteNetwork(s.mock.Ctx, gomock.Eq("127.0.0.0/24"), gomock.Any(), gomock.Eq(3), gomock.Eq(0)).
+		Return(attemptsInDB, nil)
+
+	s.mock.StorageMock.EXPECT().
+		LoadRegulationUnbanTimeByRemoteNetwork(s.mock.Ctx, gomock.Eq("127.0.0.0/24")).
+		Return(time.Time{}, nil)
+
+	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
+
+	until, err := regulator.Regulate(s.mock.Ctx, "james")
+	s.Equal(regulation.ErrRemoteIPIsBanned, err)
+	s.Equal(s.mock.Clock.Now().Add(179*time.Second), until)
+}
+
+func (s *RegulatorSuite) TestShouldNotBanTrustedRemoteNetwork() {
+	s.mock.Ctx.Configuration.Regulation.IP.MaxRetries = 3
+	s.mock.Ctx.Configuration.Regulation.IP.TrustedNetworks = []string{"10.0.0.0/8", "127.0.0.1"}
+
+	s.mock.StorageMock.EXPECT().
+		LoadAuthenticationLogs(s.mock.Ctx, gomock.Eq("john"), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
+		Return(nil, nil)
+
+	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
+
+	_, err := regulator.Regulate(s.mock.Ctx, "john")
+	s.NoError(err)
+}
+
+func (s *RegulatorSuite) TestShouldBanUserOnRemoteNetworkWithScopeUserIP() {
+	attemptsInDB := []model.AuthenticationAttempt{
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-1 * time.Second),
+		},
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-4 * time.Second),
+		},
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-6 * time.Second),
+		},
+	}
+
+	s.mock.Ctx.Configuration.Regulation.Scope = schema.RegulationScopeUserIP
+
+	s.mock.StorageMock.EXPECT().
+		LoadAuthenticationLogsByUsernameAndRemoteNetwork(s.mock.Ctx, gomock.Eq("john"), gomock.Eq("127.0.0.0/24"), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
+		Return(attemptsInDB, nil)
+
+	s.mock.StorageMock.EXPECT().
+		LoadRegulationUnbanTimeByUsername(s.mock.Ctx, gomock.Eq("john")).
+		Return(time.Time{}, nil)
+
+	s.mock.StorageMock.EXPECT().
+		LoadRegulationUnbanTimeByRemoteNetwork(s.mock.Ctx, gomock.Eq("127.0.0.0/24")).
+		Return(time.Time{}, nil)
+
+	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
+
+	_, err := regulator.Regulate(s.mock.Ctx, "john")
+	s.Equal(regulation.ErrUserIsBanned, err)
+}
+
+func (s *RegulatorSuite) TestShouldNotBanUserOnUnbannedRemoteNetworkWithScopeUserIP() {
+	attemptsInDB := []model.AuthenticationAttempt{
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-1 * time.Second),
+		},
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-4 * time.Second),
+		},
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-6 * time.Second),
+		},
+	}
+
+	s.mock.Ctx.Configuration.Regulation.Scope = schema.RegulationScopeUserIP
+
+	s.mock.StorageMock.EXPECT().
+		LoadAuthenticationLogsByUsernameAndRemoteNetwork(s.mock.Ctx, gomock.Eq("john"), gomock.Eq("127.0.0.0/24"), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
+		Return(attemptsInDB, nil)
+
+	s.mock.StorageMock.EXPECT().
+		LoadRegulationUnbanTimeByUsername(s.mock.Ctx, gomock.Eq("john")).
+		Return(s.mock.Clock.Now().Add(-10*time.Second), nil)
+
+	s.mock.StorageMock.EXPECT().
+		LoadRegulationUnbanTimeByRemoteNetwork(s.mock.Ctx, gomock.Eq("127.0.0.0/24")).
+		Return(s.mock.Clock.Now().Add(-5*time.Second), nil)
+
+	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
+
+	_, err := regulator.Regulate(s.mock.Ctx, "john")
+	s.NoError(err)
+}
+
+func (s *RegulatorSuite) TestShouldEscalateBanTime() {
+	attemptsInDB := []model.AuthenticationAttempt{
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-198 * time.Second),
+		},
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-199 * time.Second),
+		},
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-200 * time.Second),
+		},
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-398 * time.Second),
+		},
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-399 * time.Second),
+		},
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-400 * time.Second),
+		},
+	}
+
+	s.mock.Ctx.Configuration.Regulation.Mode = schema.RegulationModeEscalating
+	s.mock.Ctx.Configuration.Regulation.Escalation = schema.RegulationEscalation{
+		Multiplier: 2,
+		MaxBanTime: time.Hour * 24,
+		Lookback:   time.Hour * 24,
+	}
+
+	s.mock.StorageMock.EXPECT().
+		LoadAuthenticationLogs(s.mock.Ctx, gomock.Eq("john"), gomock.Eq(s.mock.Clock.Now().Add(-time.Hour*24)), gomock.Eq(30), gomock.Eq(0)).
+		Return(attemptsInDB, nil)
+
+	s.mock.StorageMock.EXPECT().
+		LoadRegulationUnbanTimeByUsername(s.mock.Ctx, gomock.Eq("john")).
+		Return(time.Time{}, nil)
+
+	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
+
+	until, err := regulator.Regulate(s.mock.Ctx, "john")
+	s.Equal(regulation.ErrUserIsBanned, err)
+	s.Equal(s.mock.Clock.Now().Add(162*time.Second), until)
+}
+
+func (s *RegulatorSuite) TestShouldEscalateBanTimeUpToMaximum() {
+	attemptsInDB := []model.AuthenticationAttempt{
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-1 * time.Second),
+		},
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-2 * time.Second),
+		},
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-3 * time.Second),
+		},
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-600 * time.Second),
+		},
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-601 * time.Second),
+		},
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-602 * time.Second),
+		},
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-900 * time.Second),
+		},
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-901 * time.Second),
+		},
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-902 * time.Second),
+		},
+	}
+
+	s.mock.Ctx.Configuration.Regulation.Mode = schema.RegulationModeEscalating
+	s.mock.Ctx.Configuration.Regulation.Escalation = schema.RegulationEscalation{
+		Multiplier: 3,
+		MaxBanTime: time.Second * 600,
+		Lookback:   time.Hour,
+	}
+
+	s.mock.StorageMock.EXPECT().
+		LoadAuthenticationLogs(s.mock.Ctx, gomock.Eq("john"), gomock.Eq(s.mock.Clock.Now().Add(-time.Hour)), gomock.Eq(9), gomock.Eq(0)).
+		Return(attemptsInDB, nil)
+
+	s.mock.StorageMock.EXPECT().
+		LoadRegulationUnbanTimeByUsername(s.mock.Ctx, gomock.Eq("john")).
+		Return(time.Time{}, nil)
+
+	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
+
+	until, err := regulator.Regulate(s.mock.Ctx, "john")
+	s.Equal(regulation.ErrUserIsBanned, err)
+	s.Equal(s.mock.Clock.Now().Add(599*time.Second), until)
+}
+
+func (s *RegulatorSuite) TestShouldNotEscalateBanTimeBeforeSuccessfulAttempt() {
+	attemptsInDB := []model.AuthenticationAttempt{
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-198 * time.Second),
+		},
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-199 * time.Second),
+		},
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-200 * time.Second),
+		},
+		{
+			Username:   "john",
+			Successful: true,
+			Time:       s.mock.Clock.Now().Add(-210 * time.Second),
+		},
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-398 * time.Second),
+		},
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-399 * time.Second),
+		},
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-400 * time.Second),
+		},
+	}
+
+	s.mock.Ctx.Configuration.Regulation.Mode = schema.RegulationModeEscalating
+	s.mock.Ctx.Configuration.Regulation.Escalation = schema.RegulationEscalation{
+		Multiplier: 2,
+		MaxBanTime: time.Hour * 24,
+		Lookback:   time.Hour * 24,
+	}
+
+	s.mock.StorageMock.EXPECT().
+		LoadAuthenticationLogs(s.mock.Ctx, gomock.Eq("john"), gomock.Any(), gomock.Eq(30), gomock.Eq(0)).
+		Return(attemptsInDB, nil)
+
+	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
+
+	_, err := regulator.Regulate(s.mock.Ctx, "john")
+	s.NoError(err)
+}
+
+func (s *RegulatorSuite) TestShouldNotBanUserUnbannedAfterLatestAttempts() {
+	attemptsInDB := []model.AuthenticationAttempt{
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-1 * time.Second),
+		},
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-4 * time.Second),
+		},
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-6 * time.Second),
+		},
+	}
+
+	s.mock.StorageMock.EXPECT().
+		LoadAuthenticationLogs(s.mock.Ctx, gomock.Eq("john"), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
+		Return(attemptsInDB, nil)
+
+	s.mock.StorageMock.EXPECT().
+		LoadRegulationUnbanTimeByUsername(s.mock.Ctx, gomock.Eq("john")).
+		Return(s.mock.Clock.Now().Add(-5*time.Second), nil)
+
+	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
+
+	_, err := regulator.Regulate(s.mock.Ctx, "john")
+	s.NoError(err)
+}
+
+func (s *RegulatorSuite) TestShouldUnban() {
+	testCases := []struct {
+		name     string
+		have     string
+		expected model.RegulationUnban
+	}{
+		{
+			"ShouldUnbanUsername",
+			"john",
+			model.RegulationUnban{Time: s.mock.Clock.Now(), Username: sql.NullString{String: "john", Valid: true}},
+		},
+		{
+			"ShouldUnbanRemoteNetworkOfIP",
+			"192.168.1.20",
+			model.RegulationUnban{Time: s.mock.Clock.Now(), RemoteNetwork: sql.NullString{String: "192.168.1.0/24", Valid: true}},
+		},
+		{
+			"ShouldUnbanRemoteNetwork",
+			"2001:db8::1/64",
+			model.RegulationUnban{Time: s.mock.Clock.Now(), RemoteNetwork: sql.NullString{String: "2001:db8::/64", Valid: true}},
+		},
+		{
+			"ShouldUnbanRemoteNetworkContainingNetworkRange",
+			"192.168.1.16/28",
+			model.RegulationUnban{Time: s.mock.Clock.Now(), RemoteNetwork: sql.NullString{String: "192.168.1.0/24", Valid: true}},
+		},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
+
+			s.mock.StorageMock.EXPECT().SaveRegulationUnban(s.mock.Ctx, tc.expected).Return(nil)
+
+			expected := tc.expected.Username.String
+			if tc.expected.RemoteNetwork.Valid {
+				expected = tc.expected.RemoteNetwork.String
+			}
+
+			unbanned, err := regulator.Unban(s.mock.Ctx, tc.have)
+			s.NoError(err)
+			s.Equal([]string{expected}, unbanned)
+		})
+	}
+}
+
+func (s *RegulatorSuite) TestShouldUnbanBannedRemoteNetworksWithinNetworkRange() {
+	s.mock.Ctx.Configuration.Regulation.MaxRetries = 0
+	s.mock.Ctx.Configuration.Regulation.IP.MaxRetries = 3
+
+	failed := func(seconds ...int) (attempts []model.AuthenticationAttempt) {
+		for _, second := range seconds {
+			attempts = append(attempts, model.AuthenticationAttempt{Username: "john", Time: s.mock.Clock.Now().Add(time.Duration(-second) * time.Second)})
+		}
+
+		return attempts
+	}
+
+	network := func(value string) sql.NullString {
+		return sql.NullString{String: value, Valid: true}
+	}
+
+	gomock.InOrder(
+		s.mock.StorageMock.EXPECT().
+			LoadFailedAuthenticationLogSubjects(s.mock.Ctx, gomock.Any()).
+			Return([]model.AuthenticationAttempt{
+				{Username: "john", RemoteNetwork: network("10.0.1.0/24")},
+				{Username: "john", RemoteNetwork: network("10.0.2.0/24")},
+				{Username: "john", RemoteNetwork: network("192.168.1.0/24")},
+			}, nil),
+		s.mock.StorageMock.EXPECT().
+			LoadFailedAuthenticationLogsByRemoteNetwork(s.mock.Ctx, gomock.Eq("10.0.1.0/24"), gomock.Any(), gomock.Eq(3), gomock.Eq(0)).
+			Return(failed(1, 2, 3), nil),
+		s.mock.StorageMock.EXPECT().
+			LoadRegulationUnbanTimeByRemoteNetwork(s.mock.Ctx, gomock.Eq("10.0.1.0/24")).
+			Return(time.Time{}, nil),
+		s.mock.StorageMock.EXPECT().
+			LoadFailedAuthenticationLogsByRemoteNetwork(s.mock.Ctx, gomock.Eq("10.0.2.0/24"), gomock.Any(), gomock.Eq(3), gomock.Eq(0)).
+			Return(failed(1), nil),
+		s.mock.StorageMock.EXPECT().
+			LoadFailedAuthenticationLogsByRemoteNetwork(s.mock.Ctx, gomock.Eq("192.168.1.0/24"), gomock.Any(), gomock.Eq(3), gomock.Eq(0)).
+			Return(failed(1, 2, 3), nil),
+		s.mock.StorageMock.EXPECT().
+			LoadRegulationUnbanTimeByRemoteNetwork(s.mock.Ctx, gomock.Eq("192.168.1.0/24")).
+			Return(time.Time{}, nil),
+		s.mock.StorageMock.EXPECT().
+			SaveRegulationUnban(s.mock.Ctx, model.RegulationUnban{Time: s.mock.Clock.Now(), RemoteNetwork: network("10.0.1.0/24")}).
+			Return(nil),
+	)
+
+	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
+
+	unbanned, err := regulator.Unban(s.mock.Ctx, "10.0.0.0/16")
+	s.NoError(err)
+	s.Equal([]string{"10.0.1.0/24"}, unbanned)
+}
+
+func (s *RegulatorSuite) TestShouldUnbanRemoteNetworksOfUsersBannedWithinNetworkRangeWithScopeUserIP() {
+	s.mock.Ctx.Configuration.Regulation.Scope = schema.RegulationScopeUserIP
+
+	failed := func(seconds ...int) (attempts []model.AuthenticationAttempt) {
+		for _, second := range seconds {
+			attempts = append(attempts, model.AuthenticationAttempt{Username: "john", Time: s.mock.Clock.Now().Add(time.Duration(-second) * time.Second)})
+		}
+
+		return attempts
+	}
+
+	network := func(value string) sql.NullString {
+		return sql.NullString{String: value, Valid: true}
+	}
+
+	gomock.InOrder(
+		s.mock.StorageMock.EXPECT().
+			LoadFailedAuthenticationLogSubjects(s.mock.Ctx, gomock.Any()).
+			Return([]model.AuthenticationAttempt{
+				{Username: "john", RemoteNetwork: network("10.0.1.0/24")},
+				{Username: "john", RemoteNetwork: network("192.168.1.0/24")},
+			}, nil),
+		s.mock.StorageMock.EXPECT().
+			LoadAuthenticationLogsByUsernameAndRemoteNetwork(s.mock.Ctx, gomock.Eq("john"), gomock.Eq("10.0.1.0/24"), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
+			Return(failed(1, 2, 3), nil),
+		s.mock.StorageMock.EXPECT().
+			LoadRegulationUnbanTimeByUsername(s.mock.Ctx, gomock.Eq("john")).
+			Return(time.Time{}, nil),
+		s.mock.StorageMock.EXPECT().
+			LoadRegulationUnbanTimeByRemoteNetwork(s.mock.Ctx, gomock.Eq("10.0.1.0/24")).
+			Return(time.Time{}, nil),
+		s.mock.StorageMock.EXPECT().
+			LoadAuthenticationLogsByUsernameAndRemoteNetwork(s.mock.Ctx, gomock.Eq("john"), gomock.Eq("192.168.1.0/24"), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
+			Return(failed(1, 2, 3), nil),
+		s.mock.StorageMock.EXPECT().
+			LoadRegulationUnbanTimeByUsername(s.mock.Ctx, gomock.Eq("john")).
+			Return(time.Time{}, nil),
+		s.mock.StorageMock.EXPECT().
+			LoadRegulationUnbanTimeByRemoteNetwork(s.mock.Ctx, gomock.Eq("192.168.1.0/24")).
+			Return(time.Time{}, nil),
+		s.mock.StorageMock.EXPECT().
+			SaveRegulationUnban(s.mock.Ctx, model.RegulationUnban{Time: s.mock.Clock.Now(), RemoteNetwork: network("10.0.1.0/24")}).
+			Return(nil),
+	)
+
+	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
+
+	unbanned, err := regulator.Unban(s.mock.Ctx, "10.0.0.0/16")
+	s.NoError(err)
+	s.Equal([]string{"10.0.1.0/24"}, unbanned)
+}
+
+func (s *RegulatorSuite) TestShouldNotUnbanNetworkRangeWithoutBannedRemoteNetworks() {
+	s.mock.Ctx.Configuration.Regulation.MaxRetries = 0
+	s.mock.Ctx.Configuration.Regulation.IP.MaxRetries = 3
+
+	s.mock.StorageMock.EXPECT().
+		LoadFailedAuthenticationLogSubjects(s.mock.Ctx, gomock.Any()).
+		Return(nil, nil)
+
+	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
+
+	unbanned, err := regulator.Unban(s.mock.Ctx, "192.168.0.0/16")
+	s.ErrorIs(err, regulation.ErrNoBannedRemoteNetworks)
+	s.Nil(unbanned)
+}
+
+func (s *RegulatorSuite) TestShouldListBans() {
+	s.mock.Ctx.Configuration.Regulation.IP.MaxRetries = 3
+
+	failed := func(username string, seconds ...int) (attempts []model.AuthenticationAttempt) {
+		for _, second := range seconds {
+			attempts = append(attempts, model.AuthenticationAttempt{Username: username, Time: s.mock.Clock.Now().Add(time.Duration(-second) * time.Second)})
+		}
+
+		return attempts
+	}
+
+	gomock.InOrder(
+		s.mock.StorageMock.EXPECT().
+			LoadFailedAuthenticationLogSubjects(s.mock.Ctx, gomock.Eq(s.mock.Clock.Now().Add(-180*time.Second))).
+			Return([]model.AuthenticationAttempt{
+				{Username: "john", RemoteNetwork: sql.NullString{String: "192.168.1.0/24", Valid: true}},
+				{Username: "harry", RemoteNetwork: sql.NullString{String: "192.168.1.0/24", Valid: true}},
+			}, nil),
+		s.mock.StorageMock.EXPECT().
+			LoadAuthenticationLogs(s.mock.Ctx, gomock.Eq("john"), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
+			Return(failed("john", 1, 2, 3), nil),
+		s.mock.StorageMock.EXPECT().
+			LoadRegulationUnbanTimeByUsername(s.mock.Ctx, gomock.Eq("john")).
+			Return(time.Time{}, nil),
+		s.mock.StorageMock.EXPECT().
+			LoadFailedAuthenticationLogsByRemoteNetwork(s.mock.Ctx, gomock.Eq("192.168.1.0/24"), gomock.Any(), gomock.Eq(3), gomock.Eq(0)).
+			Return(append(failed("john", 1, 2, 3), failed("harry", 4)...), nil),
+		s.mock.StorageMock.EXPECT().
+			LoadRegulationUnbanTimeByRemoteNetwork(s.mock.Ctx, gomock.Eq("192.168.1.0/24")).
+			Return(s.mock.Clock.Now().Add(-2500*time.Millisecond), nil),
+		s.mock.StorageMock.EXPECT().
+			LoadAuthenticationLogs(s.mock.Ctx, gomock.Eq("harry"), gomock.Any(), gomock.Eq(10), gomock.Eq(0)).
+			Return(failed("harry", 4), nil),
+	)
+
+	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
+
+	bans, err := regulator.Bans(s.mock.Ctx)
+	s.Require().NoError(err)
+	s.Equal([]regulation.Ban{{Username: "john", BannedUntil: s.mock.Clock.Now().Add(179 * time.Second)}}, bans)
+}
+
+func (s *RegulatorSuite) TestShouldBanUserFromSecondFactorMethod() {
+	s.mock.Ctx.Configuration.Regulation.SecondFactor.TOTP = schema.RegulationSecondFactorMethod{
+		MaxRetries: 3,
+		FindTime:   time.Second * 30,
+		BanTime:    time.Second * 300,
+	}
+
+	attemptsInDB := []model.AuthenticationAttempt{
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-1 * time.Second),
+		},
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-4 * time.Second),
+		},
+		{
+			Username:   "john",
+			Successful: false,
+			Time:       s.mock.Clock.Now().Add(-6 * time.Second),
+		},
+	}
+
+	gomock.InOrder(
+		s.mock.StorageMock.EXPECT().
+			LoadAuthenticationLogsByUsernameAndType(s.mock.Ctx, gomock.Eq("john"), gomock.Eq(regulation.AuthTypeTOTP), gomock.Eq(s.mock.Clock.Now().Add(-300*time.Second)), gomock.Eq(3), gomock.Eq(0)).
+			Return(attemptsInDB, nil),
+		s.mock.StorageMock.EXPECT().
+			LoadRegulationUnbanTimeByUsername(s.mock.Ctx, gomock.Eq("john")).
+			Return(time.Time{}, nil),
+	)
+
+	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
+
+	bannedUntil, err := regulator.RegulateSecondFactor(s.mock.Ctx, "john", regulation.AuthTypeTOTP)
+	s.Equal(regulation.ErrUserIsBanned, err)
+	s.Equal(s.mock.Clock.Now().Add(299*time.Second), bannedUntil)
+}
+
+func (s *RegulatorSuite) TestShouldNotBanUserFromSecondFactorMethodWithoutRegulation() {
+	s.mock.Ctx.Configuration.Regulation.SecondFactor.TOTP = schema.RegulationSecondFactorMethod{
+		MaxRetries: 3,
+		FindTime:   time.Second * 30,
+		BanTime:    time.Second * 300,
+	}
+
+	regulator := regulation.NewRegulator(s.mock.Ctx.Configuration.Regulation, s.mock.StorageMock, &s.mock.Clock)
+
+	_, err := regulator.RegulateSecondFactor(s.mock.Ctx, "john", regulation.AuthTypeDuo)
+	s.NoError(err)
+}
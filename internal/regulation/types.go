@@ -3,9 +3,11 @@ package regulation
 import (
 	"context"
 	"net"
+	"time"
 
 	"github.com/authelia/authelia/v4/internal/clock"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/geoip"
 	"github.com/authelia/authelia/v4/internal/storage"
 )
 
@@ -14,11 +16,48 @@ type Regulator struct {
 	// Is the regulation enabled.
 	enabled bool
 
+	// Is the remote IP regulation enabled.
+	enabledIP bool
+
+	// Is the remote network of the attempts recorded and regulated.
+	network bool
+
 	config schema.Regulation
 
 	store storage.RegulatorProvider
 
 	clock clock.Provider
+
+	trusted []*net.IPNet
+
+	user regulation
+	ip   regulation
+
+	// The regulation of each enabled second factor method keyed by the authentication type.
+	secondFactor map[string]*regulation
+}
+
+// regulation represents the effective regulation of a single kind of subject such as a user or a remote network.
+type regulation struct {
+	maxRetries int
+	findTime   time.Duration
+	banTime    time.Duration
+
+	// lookback is how far back the attempts are loaded, and limit is how many of them are loaded.
+	lookback time.Duration
+	limit    int
+
+	escalating bool
+	multiplier int
+	maxBanTime time.Duration
+}
+
+// Ban represents a user or remote network which is currently banned. The RemoteNetwork of a user ban is only set when
+// the bans are scoped to the user and the remote network.
+type Ban struct {
+	Username      string
+	RemoteNetwork string
+	BannedUntil   time.Time
 }
 
 // Context represents a regulator context.
@@ -27,6 +66,7 @@ type Context interface {
 	MetricsRecorder
 
 	RemoteIP() (ip net.IP)
+	RemoteGeoIP() (record geoip.Record)
 }
 
 // MetricsRecorder represents the methods used to record regulation.
@@ -100,7 +100,7 @@ func handleMethodNotAllowed(ctx *fasthttp.RequestCtx) {
 }
 
 //nolint:gocyclo
-func handleRouter(config *schema.Configuration, providers middlewares.Providers) fasthttp.RequestHandler {
+func handleRouter(config *schema.Configuration, providers middlewares.Providers) (handler fasthttp.RequestHandler, err error) {
 	log := logging.Logger()
 
 	optsTemplatedFile := NewTemplatedFileOptions(config)
@@ -191,7 +191,11 @@ func handleRouter(config *schema.Configuration, providers middlewares.Providers)
 	for name, endpoint := range config.Server.Endpoints.Authz {
 		uri := path.Join(pathAuthz, name)
 
-		authz := handlers.NewAuthzBuilder().WithConfig(config).WithEndpointConfig(endpoint).Build()
+		var authz *handlers.Authz
+
+		if authz, err = handlers.NewAuthzBuilder().WithConfig(config).WithEndpointConfig(endpoint).Build(); err != nil {
+			return nil, fmt.Errorf("error occurred building the authz endpoint '%s': %w", name, err)
+		}
 
 		handler := middlewares.Wrap(metricsVRMW, bridge(authz.Handler))
 
@@ -234,6 +238,7 @@ func handleRouter(config *schema.Configuration, providers middlewares.Providers)
 	delayFunc := middlewares.TimingAttackDelay(10, 250, 85, time.Second, true)
 
 	r.POST("/api/firstfactor", middlewareAPI(handlers.FirstFactorPOST(delayFunc)))
+	r.GET("/api/logout", middlewareAPI(handlers.LogoutGET))
 	r.POST("/api/logout", middlewareAPI(handlers.LogoutPOST))
 
 	// Only register endpoints if forgot password is not disabled.
@@ -250,6 +255,20 @@ func handleRouter(config *schema.Configuration, providers middlewares.Providers)
 	r.POST("/api/user/info", middleware1FA(handlers.UserInfoPOST))
 	r.POST("/api/user/info/2fa_method", middleware1FA(handlers.MethodPreferencePOST))
 
+	// Active sessions of the user.
+	r.GET("/api/user/sessions", middleware1FA(handlers.UserSessionsGET))
+	r.DELETE("/api/user/sessions/{"+handlers.UserValueKeySessionID+"}", middleware1FA(handlers.UserSessionDELETE))
+
+	if config.Server.Endpoints.Admin.Enabled {
+		middlewareAdmin := middlewares.NewBridgeBuilder(*config, providers).
+			WithPreMiddlewares(middlewares.SecurityHeaders, middlewares.SecurityHeadersNoStore, middlewares.SecurityHeadersCSPNone).
+			WithPostMiddlewares(middlewares.RequireAdmin).
+			Build()
+
+		// Administration of the sessions of other users.
+		r.DELETE("/api/admin/users/{"+handlers.UserValueKeyUsername+"}/sessions", middlewareAdmin(handlers.AdminUserSessionsDELETE))
+	}
+
 	if !config.TOTP.Disable {
 		// TOTP related endpoints.
 		r.GET("/api/user/info/totp", middleware1FA(handlers.UserTOTPInfoGET))
@@ -399,14 +418,14 @@ func handleRouter(config *schema.Configuration, providers middlewares.Providers)
 	r.MethodNotAllowed = handleMethodNotAllowed
 	r.NotFound = handleNotFound(bridge(serveIndexHandler))
 
-	handler := middlewares.LogRequest(r.Handler)
+	handler = middlewares.LogRequest(r.Handler)
 	if config.Server.Address.Path() != "/" {
 		handler = middlewares.StripPath(config.Server.Address.Path())(handler)
 	}
 
 	handler = middlewares.Wrap(middlewares.NewMetricsRequest(providers.Metrics), handler)
 
-	return handler
+	return handler, nil
 }
 
 func handleMetrics(path string) fasthttp.RequestHandler {
@@ -21,9 +21,15 @@ func CreateDefaultServer(config *schema.Configuration, providers middlewares.Pro
 		return nil, nil, nil, false, fmt.Errorf("failed to load templated assets: %w", err)
 	}
 
+	var handler fasthttp.RequestHandler
+
+	if handler, err = handleRouter(config, providers); err != nil {
+		return nil, nil, nil, false, err
+	}
+
 	server = &fasthttp.Server{
 		ErrorHandler:          handleError("server"),
-		Handler:               handleRouter(config, providers),
+		Handler:               handler,
 		NoDefaultServerHeader: true,
 		ReadBufferSize:        config.Server.Buffers.Read,
 		WriteBufferSize:       config.Server.Buffers.Write,
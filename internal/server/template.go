@@ -282,6 +282,7 @@ func NewTemplatedFileOptions(config *schema.Configuration) (opts *TemplatedFileO
 		EndpointsTOTP:          !config.TOTP.Disable,
 		EndpointsDuo:           !config.DuoAPI.Disable,
 		EndpointsOpenIDConnect: !(config.IdentityProviders.OIDC == nil),
+		EndpointsAdmin:         config.Server.Endpoints.Admin.Enabled,
 		EndpointsAuthz:         config.Server.Endpoints.Authz,
 	}
 
@@ -314,6 +315,7 @@ type TemplatedFileOptions struct {
 	EndpointsTOTP          bool
 	EndpointsDuo           bool
 	EndpointsOpenIDConnect bool
+	EndpointsAdmin         bool
 
 	EndpointsAuthz map[string]schema.ServerEndpointsAuthz
 }
@@ -372,6 +374,7 @@ func (options *TemplatedFileOptions) OpenAPIData(base, baseURL, domain, nonce st
 		TOTP:           options.EndpointsTOTP,
 		Duo:            options.EndpointsDuo,
 		OpenIDConnect:  options.EndpointsOpenIDConnect,
+		Admin:          options.EndpointsAdmin,
 		EndpointsAuthz: options.EndpointsAuthz,
 	}
 }
@@ -405,6 +408,7 @@ type TemplatedFileOpenAPIData struct {
 	TOTP          bool
 	Duo           bool
 	OpenIDConnect bool
+	Admin         bool
 
 	EndpointsAuthz map[string]schema.ServerEndpointsAuthz
 }
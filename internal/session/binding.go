@@ -0,0 +1,60 @@
+package session
+
+import (
+	"net"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+// NewUserSessionBinding returns a new UserSessionBinding for the given client IP and user agent. The client IP is
+// masked using the configured prefix length so clients which move between addresses in the same network keep their
+// session.
+func NewUserSessionBinding(config *schema.SessionBinding, ip net.IP, userAgent string) *UserSessionBinding {
+	return &UserSessionBinding{
+		IPPrefix:  sessionBindingIPPrefix(config, ip),
+		UserAgent: userAgent,
+	}
+}
+
+// UserSessionBinding is the client fingerprint a session is bound to.
+type UserSessionBinding struct {
+	IPPrefix  string
+	UserAgent string
+}
+
+// Mismatch compares the binding with the binding of the current request using the configured strictness. It returns
+// true if the bindings don't match, as well as the parts of the fingerprint which differ.
+func (b *UserSessionBinding) Mismatch(config *schema.SessionBinding, current *UserSessionBinding) (mismatch bool, ip, userAgent bool) {
+	ip, userAgent = b.IPPrefix != current.IPPrefix, b.UserAgent != current.UserAgent
+
+	switch config.Strictness {
+	case schema.SessionBindingStrictnessLax:
+		mismatch = ip && userAgent
+	case schema.SessionBindingStrictnessIP:
+		mismatch, userAgent = ip, false
+	case schema.SessionBindingStrictnessUserAgent:
+		mismatch, ip = userAgent, false
+	default:
+		mismatch = ip || userAgent
+	}
+
+	return mismatch, ip, userAgent
+}
+
+func sessionBindingIPPrefix(config *schema.SessionBinding, ip net.IP) string {
+	if ip == nil {
+		return ""
+	}
+
+	var mask net.IPMask
+
+	if ip4 := ip.To4(); ip4 != nil {
+		ip, mask = ip4, net.CIDRMask(config.IPv4Mask, 32)
+	} else {
+		mask = net.CIDRMask(config.IPv6Mask, 128)
+	}
+
+	network := net.IPNet{IP: ip.Mask(mask), Mask: mask}
+
+	return network.String()
+}
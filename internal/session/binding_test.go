@@ -0,0 +1,66 @@
+package session
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestNewUserSessionBinding(t *testing.T) {
+	config := &schema.SessionBinding{IPv4Mask: 24, IPv6Mask: 64}
+
+	testCases := []struct {
+		name     string
+		ip       net.IP
+		expected string
+	}{
+		{"ShouldMaskIPv4", net.ParseIP("192.168.1.20"), "192.168.1.0/24"},
+		{"ShouldMaskIPv4MappedIPv6", net.ParseIP("::ffff:192.168.1.20"), "192.168.1.0/24"},
+		{"ShouldMaskIPv6", net.ParseIP("2001:db8:1:2:3:4:5:6"), "2001:db8:1:2::/64"},
+		{"ShouldHandleNil", nil, ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			binding := NewUserSessionBinding(config, tc.ip, "curl/8.0")
+
+			assert.Equal(t, tc.expected, binding.IPPrefix)
+			assert.Equal(t, "curl/8.0", binding.UserAgent)
+		})
+	}
+}
+
+func TestUserSessionBindingMismatch(t *testing.T) {
+	bound := &UserSessionBinding{IPPrefix: "192.168.1.0/24", UserAgent: "firefox"}
+
+	testCases := []struct {
+		name       string
+		strictness string
+		current    *UserSessionBinding
+		expected   bool
+		ip, ua     bool
+	}{
+		{"ShouldMatchStrict", schema.SessionBindingStrictnessStrict, &UserSessionBinding{IPPrefix: "192.168.1.0/24", UserAgent: "firefox"}, false, false, false},
+		{"ShouldNotMatchStrictIP", schema.SessionBindingStrictnessStrict, &UserSessionBinding{IPPrefix: "10.0.0.0/24", UserAgent: "firefox"}, true, true, false},
+		{"ShouldNotMatchStrictUserAgent", schema.SessionBindingStrictnessStrict, &UserSessionBinding{IPPrefix: "192.168.1.0/24", UserAgent: "chrome"}, true, false, true},
+		{"ShouldMatchLaxIP", schema.SessionBindingStrictnessLax, &UserSessionBinding{IPPrefix: "10.0.0.0/24", UserAgent: "firefox"}, false, true, false},
+		{"ShouldNotMatchLaxBoth", schema.SessionBindingStrictnessLax, &UserSessionBinding{IPPrefix: "10.0.0.0/24", UserAgent: "chrome"}, true, true, true},
+		{"ShouldIgnoreUserAgentIP", schema.SessionBindingStrictnessIP, &UserSessionBinding{IPPrefix: "192.168.1.0/24", UserAgent: "chrome"}, false, false, false},
+		{"ShouldNotMatchIP", schema.SessionBindingStrictnessIP, &UserSessionBinding{IPPrefix: "10.0.0.0/24", UserAgent: "chrome"}, true, true, false},
+		{"ShouldIgnoreIPUserAgent", schema.SessionBindingStrictnessUserAgent, &UserSessionBinding{IPPrefix: "10.0.0.0/24", UserAgent: "firefox"}, false, false, false},
+		{"ShouldNotMatchUserAgent", schema.SessionBindingStrictnessUserAgent, &UserSessionBinding{IPPrefix: "10.0.0.0/24", UserAgent: "chrome"}, true, false, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mismatch, ip, ua := bound.Mismatch(&schema.SessionBinding{Strictness: tc.strictness}, tc.current)
+
+			assert.Equal(t, tc.expected, mismatch)
+			assert.Equal(t, tc.ip, ip)
+			assert.Equal(t, tc.ua, ua)
+		})
+	}
+}
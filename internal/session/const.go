@@ -15,3 +15,18 @@ const (
 	userSessionStorerKey = "UserSession"
 	randomSessionChars   = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_!#$%^*"
 )
+
+const (
+	userSessionIndexStorerKey = "UserSessionIndex"
+
+	// userSessionIndexKeyPrefix is the prefix of the key of a user session index in the session store. The ':' character
+	// is not one of the randomSessionChars so the key can never collide with the ID of a session.
+	userSessionIndexKeyPrefix = "user-sessions:"
+
+	// userSessionRevokedKeyPrefix is the prefix of the key which stores the time all sessions of a user were revoked.
+	userSessionRevokedKeyPrefix = "user-sessions-revoked:"
+
+	// userSessionIndexActivityInterval is the minimum interval the last activity of a record in the user session index is
+	// updated at, which avoids writing the index on every request.
+	userSessionIndexActivityInterval = time.Minute
+)
@@ -17,13 +17,23 @@ type Serializer interface {
 
 // EncryptingSerializer a serializer encrypting the data with AES-GCM with 256-bit keys.
 type EncryptingSerializer struct {
-	key [32]byte
+	keys [][32]byte
+
+	metrics MetricsRecorder
 }
 
-// NewEncryptingSerializer return new encrypt instance.
-func NewEncryptingSerializer(secret string) *EncryptingSerializer {
-	key := sha256.Sum256([]byte(secret))
-	return &EncryptingSerializer{key}
+// NewEncryptingSerializer return new encrypt instance. The session data is encrypted with the key derived from the
+// secret, and the keys derived from the secrets are additionally attempted in order when decrypting the session data.
+func NewEncryptingSerializer(secret string, secrets ...string) *EncryptingSerializer {
+	keys := make([][32]byte, 0, len(secrets)+1)
+
+	keys = append(keys, sha256.Sum256([]byte(secret)))
+
+	for _, s := range secrets {
+		keys = append(keys, sha256.Sum256([]byte(s)))
+	}
+
+	return &EncryptingSerializer{keys: keys}
 }
 
 // Encode encode and encrypt session.
@@ -37,14 +47,15 @@ func (e *EncryptingSerializer) Encode(src session.Dict) (data []byte, err error)
 		return nil, fmt.Errorf("unable to marshal session: %v", err)
 	}
 
-	if data, err = utils.Encrypt(dst, &e.key); err != nil {
+	if data, err = utils.Encrypt(dst, &e.keys[0]); err != nil {
 		return nil, fmt.Errorf("unable to encrypt session: %v", err)
 	}
 
 	return data, nil
 }
 
-// Decode decrypt and decode session.
+// Decode decrypt and decode session. Session data which was encrypted with a retired secret is decrypted with the
+// retired secret and is encrypted with the current secret the next time it's encoded.
 func (e *EncryptingSerializer) Decode(dst *session.Dict, src []byte) (err error) {
 	if len(src) == 0 {
 		return nil
@@ -54,13 +65,36 @@ func (e *EncryptingSerializer) Decode(dst *session.Dict, src []byte) (err error)
 		delete(dst.KV, k)
 	}
 
-	var data []byte
+	var (
+		data []byte
+		i    int
+	)
 
-	if data, err = utils.Decrypt(src, &e.key); err != nil {
+	if data, i, err = e.decrypt(src); err != nil {
 		return fmt.Errorf("unable to decrypt session: %s", err)
 	}
 
+	if e.metrics != nil {
+		e.metrics.RecordSessionSecretDecryption(i)
+	}
+
 	_, err = dst.UnmarshalMsg(data)
 
 	return err
 }
+
+func (e *EncryptingSerializer) decrypt(src []byte) (data []byte, i int, err error) {
+	for j := range e.keys {
+		var errKey error
+
+		if data, errKey = utils.Decrypt(src, &e.keys[j]); errKey == nil {
+			return data, j, nil
+		}
+
+		if j == 0 {
+			err = errKey
+		}
+	}
+
+	return nil, -1, err
+}
@@ -39,3 +39,61 @@ func TestShouldNotSupportUnencryptedSessionForBackwardCompatibility(t *testing.T
 	err = serializer.Decode(&decodedPayload, dst)
 	assert.EqualError(t, err, "unable to decrypt session: cipher: message authentication failed")
 }
+
+type testMetricsRecorder struct {
+	secrets []int
+}
+
+func (r *testMetricsRecorder) RecordSessionSecretDecryption(secret int) {
+	r.secrets = append(r.secrets, secret)
+}
+
+func TestShouldDecryptWithRetiredSecretAndEncryptWithCurrentSecret(t *testing.T) {
+	payload := session.Dict{KV: map[string]any{"key": "value"}}
+
+	retired := NewEncryptingSerializer("retired")
+
+	encrypted, err := retired.Encode(payload)
+	require.NoError(t, err)
+
+	metrics := &testMetricsRecorder{}
+
+	serializer := NewEncryptingSerializer("current", "older", "retired")
+	serializer.metrics = metrics
+
+	decoded := session.Dict{}
+	require.NoError(t, serializer.Decode(&decoded, encrypted))
+
+	assert.Equal(t, "value", decoded.KV["key"])
+	assert.Equal(t, []int{2}, metrics.secrets)
+
+	encrypted, err = serializer.Encode(decoded)
+	require.NoError(t, err)
+
+	decoded = session.Dict{}
+	require.NoError(t, NewEncryptingSerializer("current").Decode(&decoded, encrypted))
+	assert.Equal(t, "value", decoded.KV["key"])
+
+	decoded = session.Dict{}
+	assert.EqualError(t, retired.Decode(&decoded, encrypted), "unable to decrypt session: cipher: message authentication failed")
+
+	decoded = session.Dict{}
+	require.NoError(t, serializer.Decode(&decoded, encrypted))
+	assert.Equal(t, []int{2, 0}, metrics.secrets)
+}
+
+func TestShouldNotDecryptWithUnknownSecret(t *testing.T) {
+	payload := session.Dict{KV: map[string]any{"key": "value"}}
+
+	encrypted, err := NewEncryptingSerializer("unknown").Encode(payload)
+	require.NoError(t, err)
+
+	metrics := &testMetricsRecorder{}
+
+	serializer := NewEncryptingSerializer("current", "retired")
+	serializer.metrics = metrics
+
+	decoded := session.Dict{}
+	assert.EqualError(t, serializer.Decode(&decoded, encrypted), "unable to decrypt session: cipher: message authentication failed")
+	assert.Empty(t, metrics.secrets)
+}
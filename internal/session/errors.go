@@ -0,0 +1,8 @@
+package session
+
+import (
+	"errors"
+)
+
+// ErrUserSessionNotFound is returned when a session could not be found in the user session index.
+var ErrUserSessionNotFound = errors.New("the session could not be found")
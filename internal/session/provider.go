@@ -3,29 +3,38 @@ package session
 import (
 	"crypto/x509"
 	"fmt"
+	"time"
 
 	"github.com/fasthttp/session/v2"
 
+	"github.com/authelia/authelia/v4/internal/clock"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/logging"
+	"github.com/authelia/authelia/v4/internal/storage"
 )
 
 // Provider contains a list of domain sessions.
 type Provider struct {
 	sessions map[string]*Session
+	index    *UserSessionIndex
+
+	// retention is the longest duration a session may be valid for across all cookie domains.
+	retention time.Duration
 }
 
 // NewProvider instantiate a session provider given a configuration.
-func NewProvider(config schema.Session, certPool *x509.CertPool) *Provider {
+func NewProvider(config schema.Session, certPool *x509.CertPool, store storage.SessionProvider, metrics MetricsRecorder, clock clock.Provider) *Provider {
 	log := logging.Logger()
 
-	name, p, s, err := NewSessionProvider(config, certPool)
+	name, p, s, err := NewSessionProvider(config, certPool, store, metrics, clock)
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	provider := &Provider{
-		sessions: map[string]*Session{},
+		sessions:  map[string]*Session{},
+		index:     NewUserSessionIndex(p, NewUserSessionIndexStore(config, p, store), s),
+		retention: userSessionIndexRetention(config),
 	}
 
 	var (
@@ -40,6 +49,7 @@ func NewProvider(config schema.Session, certPool *x509.CertPool) *Provider {
 		provider.sessions[dconfig.Domain] = &Session{
 			Config:        dconfig,
 			sessionHolder: holder,
+			index:         provider.index,
 		}
 	}
 
@@ -60,3 +70,19 @@ func (p *Provider) Get(domain string) (*Session, error) {
 
 	return s, nil
 }
+
+// GetUserSessions returns the active sessions of the user across all cookie domains at the provided time.
+func (p *Provider) GetUserSessions(username string, now time.Time) (records []UserSessionRecord, err error) {
+	return p.index.List(username, now)
+}
+
+// RevokeUserSession destroys the session of the user with the provided public ID.
+func (p *Provider) RevokeUserSession(username, id string, now time.Time) (err error) {
+	return p.index.Revoke(username, id, now)
+}
+
+// RevokeAllUserSessions destroys every session of the user across all cookie domains. Sessions of the user which were
+// authenticated before the provided time are also rejected by Session.IsRevoked.
+func (p *Provider) RevokeAllUserSessions(username string, now time.Time) (revoked int, err error) {
+	return p.index.RevokeAll(username, now, p.retention)
+}
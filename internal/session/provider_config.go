@@ -6,15 +6,18 @@ import (
 	"crypto/x509"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/fasthttp/session/v2"
 	"github.com/fasthttp/session/v2/providers/memory"
-	"github.com/fasthttp/session/v2/providers/redis"
+	redisclient "github.com/redis/go-redis/v9"
 	"github.com/sirupsen/logrus"
 	"github.com/valyala/fasthttp"
 
+	"github.com/authelia/authelia/v4/internal/clock"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/logging"
+	"github.com/authelia/authelia/v4/internal/storage"
 	"github.com/authelia/authelia/v4/internal/utils"
 )
 
@@ -93,11 +96,12 @@ func NewProviderConfigAndSession(config schema.SessionCookie, providerName strin
 	return c, p, nil
 }
 
-func NewSessionProvider(config schema.Session, certPool *x509.CertPool) (name string, provider session.Provider, serializer Serializer, err error) {
-	// If redis configuration is provided, then use the redis provider.
+func NewSessionProvider(config schema.Session, certPool *x509.CertPool, store storage.SessionProvider, metrics MetricsRecorder, clock clock.Provider) (name string, provider session.Provider, serializer Serializer, err error) {
+	// If redis configuration is provided, then use the redis provider, otherwise if sql configuration is provided then
+	// use the sql provider.
 	switch {
 	case config.Redis != nil:
-		serializer = NewEncryptingSerializer(config.Secret)
+		serializer = newEncryptingSerializer(config, metrics)
 
 		var tlsConfig *tls.Config
 
@@ -105,66 +109,25 @@ func NewSessionProvider(config schema.Session, certPool *x509.CertPool) (name st
 			tlsConfig = utils.NewTLSConfig(config.Redis.TLS, certPool)
 		}
 
-		if config.Redis.HighAvailability != nil && config.Redis.HighAvailability.SentinelName != "" {
-			addrs := make([]string, 0)
-
-			if config.Redis.Host != "" {
-				addrs = append(addrs, fmt.Sprintf("%s:%d", strings.ToLower(config.Redis.Host), config.Redis.Port))
-			}
-
-			for _, node := range config.Redis.HighAvailability.Nodes {
-				addr := fmt.Sprintf("%s:%d", strings.ToLower(node.Host), node.Port)
-				if !utils.IsStringInSlice(addr, addrs) {
-					addrs = append(addrs, addr)
-				}
-			}
-
+		switch {
+		case config.Redis.Cluster != nil:
+			name = "redis-cluster"
+			provider, err = newRedisClusterSessionProvider(config.Redis, tlsConfig)
+		case config.Redis.HighAvailability != nil && config.Redis.HighAvailability.SentinelName != "":
 			name = "redis-sentinel"
-
-			provider, err = redis.NewFailoverCluster(redis.FailoverConfig{
-				Logger:           logging.LoggerCtxPrintf(logrus.TraceLevel),
-				MasterName:       config.Redis.HighAvailability.SentinelName,
-				SentinelAddrs:    addrs,
-				SentinelUsername: config.Redis.HighAvailability.SentinelUsername,
-				SentinelPassword: config.Redis.HighAvailability.SentinelPassword,
-				RouteByLatency:   config.Redis.HighAvailability.RouteByLatency,
-				RouteRandomly:    config.Redis.HighAvailability.RouteRandomly,
-				Username:         config.Redis.Username,
-				Password:         config.Redis.Password,
-				DB:               config.Redis.DatabaseIndex, // DB is the fasthttp/session property for the Redis DB Index.
-				PoolSize:         config.Redis.MaximumActiveConnections,
-				MinIdleConns:     config.Redis.MinimumIdleConnections,
-				ConnMaxIdleTime:  300,
-				TLSConfig:        tlsConfig,
-				KeyPrefix:        "authelia-session",
-			})
-		} else {
+			provider, err = NewRedisProvider(newRedisClient(config.Redis, tlsConfig), "authelia-session")
+		default:
 			name = "redis"
-			network := "tcp"
-
-			var addr string
-
-			if config.Redis.Port == 0 {
-				network = "unix"
-				addr = config.Redis.Host
-			} else {
-				addr = fmt.Sprintf("%s:%d", config.Redis.Host, config.Redis.Port)
-			}
-
-			provider, err = redis.New(redis.Config{
-				Logger:          logging.LoggerCtxPrintf(logrus.TraceLevel),
-				Network:         network,
-				Addr:            addr,
-				Username:        config.Redis.Username,
-				Password:        config.Redis.Password,
-				DB:              config.Redis.DatabaseIndex, // DB is the fasthttp/session property for the Redis DB Index.
-				PoolSize:        config.Redis.MaximumActiveConnections,
-				MinIdleConns:    config.Redis.MinimumIdleConnections,
-				ConnMaxIdleTime: 300,
-				TLSConfig:       tlsConfig,
-				KeyPrefix:       "authelia-session",
-			})
+			provider, err = NewRedisProvider(newRedisClient(config.Redis, tlsConfig), "authelia-session")
+		}
+	case config.SQL != nil:
+		if store == nil {
+			return "", nil, nil, fmt.Errorf("the sql session provider requires a storage provider")
 		}
+
+		name = "sql"
+		serializer = newEncryptingSerializer(config, metrics)
+		provider = NewSQLProvider(config.SQL, store, clock)
 	default:
 		name = "memory"
 		provider, err = memory.New(memory.Config{})
@@ -172,3 +135,131 @@ func NewSessionProvider(config schema.Session, certPool *x509.CertPool) (name st
 
 	return name, provider, serializer, err
 }
+
+// NewUserSessionIndexStore returns the UserSessionIndexStore which keeps the user session index in the same backend as
+// the sessions of the provider returned by NewSessionProvider, sharing the Redis client of the provider if it has one.
+func NewUserSessionIndexStore(config schema.Session, provider session.Provider, store storage.SessionProvider) UserSessionIndexStore {
+	retention := userSessionIndexRetention(config)
+
+	switch p := provider.(type) {
+	case *RedisClusterProvider:
+		return NewRedisUserSessionIndexStore(p.db, p.keyPrefix, retention)
+	case *RedisProvider:
+		return NewRedisUserSessionIndexStore(p.db, p.keyPrefix, retention)
+	case *SQLProvider:
+		return NewSQLUserSessionIndexStore(store)
+	default:
+		return NewMemoryUserSessionIndexStore()
+	}
+}
+
+// newRedisClient returns the client for the single node or sentinel Redis configuration which is shared by the
+// session provider and the user session index.
+func newRedisClient(config *schema.SessionRedis, tlsConfig *tls.Config) redisclient.UniversalClient {
+	redisclient.SetLogger(logging.LoggerCtxPrintf(logrus.TraceLevel))
+
+	if config.HighAvailability != nil && config.HighAvailability.SentinelName != "" {
+		return redisclient.NewFailoverClusterClient(&redisclient.FailoverOptions{
+			MasterName:       config.HighAvailability.SentinelName,
+			SentinelAddrs:    redisSentinelAddrs(config),
+			SentinelUsername: config.HighAvailability.SentinelUsername,
+			SentinelPassword: config.HighAvailability.SentinelPassword,
+			RouteByLatency:   config.HighAvailability.RouteByLatency,
+			RouteRandomly:    config.HighAvailability.RouteRandomly,
+			Username:         config.Username,
+			Password:         config.Password,
+			DB:               config.DatabaseIndex,
+			PoolSize:         config.MaximumActiveConnections,
+			MinIdleConns:     config.MinimumIdleConnections,
+			ConnMaxIdleTime:  time.Minute * 5,
+			TLSConfig:        tlsConfig,
+		})
+	}
+
+	network, addr := redisNetworkAddr(config)
+
+	return redisclient.NewClient(&redisclient.Options{
+		Network:         network,
+		Addr:            addr,
+		Username:        config.Username,
+		Password:        config.Password,
+		DB:              config.DatabaseIndex,
+		PoolSize:        config.MaximumActiveConnections,
+		MinIdleConns:    config.MinimumIdleConnections,
+		ConnMaxIdleTime: time.Minute * 5,
+		TLSConfig:       tlsConfig,
+	})
+}
+
+// userSessionIndexRetention returns the longest duration a session may be valid for across all cookie domains.
+func userSessionIndexRetention(config schema.Session) (retention time.Duration) {
+	for _, cookie := range config.Cookies {
+		retention = max(retention, cookie.Expiration, cookie.RememberMe)
+	}
+
+	return retention
+}
+
+func redisNetworkAddr(config *schema.SessionRedis) (network, addr string) {
+	if config.Port == 0 {
+		return "unix", config.Host
+	}
+
+	return "tcp", fmt.Sprintf("%s:%d", config.Host, config.Port)
+}
+
+func redisSentinelAddrs(config *schema.SessionRedis) (addrs []string) {
+	addrs = make([]string, 0)
+
+	if config.Host != "" {
+		addrs = append(addrs, fmt.Sprintf("%s:%d", strings.ToLower(config.Host), config.Port))
+	}
+
+	for _, node := range config.HighAvailability.Nodes {
+		addr := fmt.Sprintf("%s:%d", strings.ToLower(node.Host), node.Port)
+		if !utils.IsStringInSlice(addr, addrs) {
+			addrs = append(addrs, addr)
+		}
+	}
+
+	return addrs
+}
+
+func newRedisClusterSessionProvider(config *schema.SessionRedis, tlsConfig *tls.Config) (provider *RedisClusterProvider, err error) {
+	addrs := make([]string, 0)
+
+	if config.Host != "" {
+		addrs = append(addrs, fmt.Sprintf("%s:%d", strings.ToLower(config.Host), config.Port))
+	}
+
+	for _, node := range config.Cluster.Nodes {
+		addr := fmt.Sprintf("%s:%d", strings.ToLower(node.Host), node.Port)
+		if !utils.IsStringInSlice(addr, addrs) {
+			addrs = append(addrs, addr)
+		}
+	}
+
+	return NewRedisClusterProvider(RedisClusterConfig{
+		Logger:          logging.LoggerCtxPrintf(logrus.TraceLevel),
+		Addrs:           addrs,
+		Username:        config.Username,
+		Password:        config.Password,
+		MaxRedirects:    config.Cluster.MaximumRedirects,
+		ReadOnly:        config.Cluster.ReadFromReplicas,
+		RouteByLatency:  config.Cluster.RouteByLatency,
+		RouteRandomly:   config.Cluster.RouteRandomly,
+		PoolSize:        config.MaximumActiveConnections,
+		MinIdleConns:    config.MinimumIdleConnections,
+		ConnMaxIdleTime: time.Minute * 5,
+		TLSConfig:       tlsConfig,
+		KeyPrefix:       "authelia-session",
+	})
+}
+
+func newEncryptingSerializer(config schema.Session, metrics MetricsRecorder) *EncryptingSerializer {
+	serializer := NewEncryptingSerializer(config.Secret, config.Secrets...)
+
+	serializer.metrics = metrics
+
+	return serializer
+}
@@ -0,0 +1,120 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// NewRedisProvider returns a new session provider which persists the session data using the given single node or
+// sentinel Redis client. The client is shared with the user session index so only one connection pool is opened.
+func NewRedisProvider(db redis.UniversalClient, keyPrefix string) (provider *RedisProvider, err error) {
+	if err = db.Ping(context.Background()).Err(); err != nil {
+		_ = db.Close()
+
+		return nil, fmt.Errorf("error connecting to redis: %w", err)
+	}
+
+	return &RedisProvider{
+		db:        db,
+		keyPrefix: keyPrefix,
+	}, nil
+}
+
+// RedisProvider is a session provider which persists the session data in a single node or sentinel Redis.
+type RedisProvider struct {
+	db        redis.UniversalClient
+	keyPrefix string
+}
+
+// Get returns the data for the given session id, or nil if the session does not exist.
+func (p *RedisProvider) Get(id []byte) (data []byte, err error) {
+	if data, err = p.db.Get(context.Background(), p.key(id)).Bytes(); err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// Save saves the data for the given session id with the given expiration.
+func (p *RedisProvider) Save(id, data []byte, expiration time.Duration) (err error) {
+	return p.db.Set(context.Background(), p.key(id), data, expiration).Err()
+}
+
+// Destroy removes the data for the given session id.
+func (p *RedisProvider) Destroy(id []byte) (err error) {
+	return p.db.Del(context.Background(), p.key(id)).Err()
+}
+
+// Regenerate moves the data for the given session id to a new session id with the given expiration.
+func (p *RedisProvider) Regenerate(id, newID []byte, expiration time.Duration) (err error) {
+	ctx := context.Background()
+
+	key, newKey := p.key(id), p.key(newID)
+
+	var exists int64
+
+	if exists, err = p.db.Exists(ctx, key).Result(); err != nil || exists == 0 {
+		return err
+	}
+
+	if err = p.db.Rename(ctx, key, newKey).Err(); err != nil {
+		return err
+	}
+
+	return p.db.Expire(ctx, newKey, expiration).Err()
+}
+
+// Count returns the number of sessions, excluding the user session indexes and revocations which share the key prefix.
+func (p *RedisProvider) Count() int {
+	var count int
+
+	ctx := context.Background()
+
+	iter := p.db.Scan(ctx, 0, p.keyPrefix+":*", 0).Iterator()
+
+	for iter.Next(ctx) {
+		if isRedisSessionKey(p.keyPrefix, iter.Val()) {
+			count++
+		}
+	}
+
+	if iter.Err() != nil {
+		return 0
+	}
+
+	return count
+}
+
+// NeedGC returns false as the expiration of the sessions is handled by Redis.
+func (p *RedisProvider) NeedGC() bool {
+	return false
+}
+
+// GC does nothing as the expiration of the sessions is handled by Redis.
+func (p *RedisProvider) GC() (err error) {
+	return nil
+}
+
+// Close closes the connections to Redis.
+func (p *RedisProvider) Close() (err error) {
+	return p.db.Close()
+}
+
+func (p *RedisProvider) key(id []byte) string {
+	return p.keyPrefix + ":" + string(id)
+}
+
+// isRedisSessionKey returns true if the key is the key of a session rather than the key of a user session index or
+// revocation. The ':' character is not one of the randomSessionChars so only the latter have one after the key prefix.
+func isRedisSessionKey(keyPrefix, key string) bool {
+	return !strings.Contains(strings.TrimPrefix(key, keyPrefix+":"), ":")
+}
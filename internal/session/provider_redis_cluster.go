@@ -0,0 +1,193 @@
+package session
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// NewRedisClusterProvider returns a new session provider which persists the session data in a Redis Cluster. The
+// MOVED and ASK slot redirections are followed by the cluster client up to the configured maximum number of redirects.
+func NewRedisClusterProvider(config RedisClusterConfig) (provider *RedisClusterProvider, err error) {
+	if len(config.Addrs) == 0 {
+		return nil, fmt.Errorf("error creating the redis cluster provider: at least one node address is required")
+	}
+
+	if config.Logger != nil {
+		redis.SetLogger(config.Logger)
+	}
+
+	db := redis.NewClusterClient(&redis.ClusterOptions{
+		Addrs:           config.Addrs,
+		Username:        config.Username,
+		Password:        config.Password,
+		MaxRedirects:    config.MaxRedirects,
+		ReadOnly:        config.ReadOnly,
+		RouteByLatency:  config.RouteByLatency,
+		RouteRandomly:   config.RouteRandomly,
+		PoolSize:        config.PoolSize,
+		MinIdleConns:    config.MinIdleConns,
+		ConnMaxIdleTime: config.ConnMaxIdleTime,
+		TLSConfig:       config.TLSConfig,
+	})
+
+	if err = db.Ping(context.Background()).Err(); err != nil {
+		_ = db.Close()
+
+		return nil, fmt.Errorf("error connecting to the redis cluster: %w", err)
+	}
+
+	return &RedisClusterProvider{
+		db:        db,
+		keyPrefix: config.KeyPrefix,
+	}, nil
+}
+
+// RedisClusterConfig is the configuration used to create the Redis Cluster session provider.
+type RedisClusterConfig struct {
+	Logger RedisLogger
+
+	// Addrs is the list of seed node addresses used to discover the cluster.
+	Addrs []string
+
+	Username string
+	Password string
+
+	// MaxRedirects is the maximum number of MOVED or ASK redirections followed for a command.
+	MaxRedirects int
+
+	// ReadOnly allows read only commands to be sent to the replica nodes.
+	ReadOnly       bool
+	RouteByLatency bool
+	RouteRandomly  bool
+
+	// PoolSize and MinIdleConns apply per cluster node.
+	PoolSize        int
+	MinIdleConns    int
+	ConnMaxIdleTime time.Duration
+
+	TLSConfig *tls.Config
+
+	KeyPrefix string
+}
+
+// RedisLogger is the logger used by the Redis client.
+type RedisLogger interface {
+	Printf(ctx context.Context, format string, v ...any)
+}
+
+// RedisClusterProvider is a session provider which persists the session data in a Redis Cluster. Unlike the single
+// node and sentinel providers the session keys may be located in different hash slots on different nodes, so commands
+// which operate on multiple keys are avoided.
+type RedisClusterProvider struct {
+	db        *redis.ClusterClient
+	keyPrefix string
+}
+
+// Get returns the data for the given session id, or nil if the session does not exist.
+func (p *RedisClusterProvider) Get(id []byte) (data []byte, err error) {
+	if data, err = p.db.Get(context.Background(), p.key(id)).Bytes(); err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// Save saves the data for the given session id with the given expiration.
+func (p *RedisClusterProvider) Save(id, data []byte, expiration time.Duration) (err error) {
+	return p.db.Set(context.Background(), p.key(id), data, expiration).Err()
+}
+
+// Destroy removes the data for the given session id.
+func (p *RedisClusterProvider) Destroy(id []byte) (err error) {
+	return p.db.Del(context.Background(), p.key(id)).Err()
+}
+
+// Regenerate moves the data for the given session id to a new session id with the given expiration. The RENAME
+// command can't be used as the keys are not guaranteed to be in the same hash slot.
+func (p *RedisClusterProvider) Regenerate(id, newID []byte, expiration time.Duration) (err error) {
+	ctx := context.Background()
+
+	var data []byte
+
+	if data, err = p.db.Get(ctx, p.key(id)).Bytes(); err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil
+		}
+
+		return err
+	}
+
+	if err = p.db.Set(ctx, p.key(newID), data, expiration).Err(); err != nil {
+		return err
+	}
+
+	return p.db.Del(ctx, p.key(id)).Err()
+}
+
+// Count returns the number of sessions across all of the master nodes of the cluster, excluding the user session indexes
+// and revocations which share the key prefix.
+func (p *RedisClusterProvider) Count() int {
+	var (
+		count int
+		mu    sync.Mutex
+	)
+
+	match := p.keyPrefix + ":*"
+
+	err := p.db.ForEachMaster(context.Background(), func(ctx context.Context, client *redis.Client) (err error) {
+		var n int
+
+		iter := client.Scan(ctx, 0, match, 0).Iterator()
+
+		for iter.Next(ctx) {
+			if isRedisSessionKey(p.keyPrefix, iter.Val()) {
+				n++
+			}
+		}
+
+		if err = iter.Err(); err != nil {
+			return err
+		}
+
+		mu.Lock()
+		count += n
+		mu.Unlock()
+
+		return nil
+	})
+
+	if err != nil {
+		return 0
+	}
+
+	return count
+}
+
+// NeedGC returns false as the expiration of the sessions is handled by Redis.
+func (p *RedisClusterProvider) NeedGC() bool {
+	return false
+}
+
+// GC does nothing as the expiration of the sessions is handled by Redis.
+func (p *RedisClusterProvider) GC() (err error) {
+	return nil
+}
+
+// Close closes the connections to the cluster.
+func (p *RedisClusterProvider) Close() (err error) {
+	return p.db.Close()
+}
+
+func (p *RedisClusterProvider) key(id []byte) string {
+	return p.keyPrefix + ":" + string(id)
+}
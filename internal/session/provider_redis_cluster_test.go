@@ -0,0 +1,52 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/clock"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestNewRedisClusterProviderShouldErrorWithoutAddrs(t *testing.T) {
+	provider, err := NewRedisClusterProvider(RedisClusterConfig{})
+
+	assert.Nil(t, provider)
+	assert.EqualError(t, err, "error creating the redis cluster provider: at least one node address is required")
+}
+
+func TestNewRedisClusterProviderShouldErrorWhenUnreachable(t *testing.T) {
+	provider, err := NewRedisClusterProvider(RedisClusterConfig{
+		Addrs:        []string{"127.0.0.1:1"},
+		MaxRedirects: 3,
+	})
+
+	assert.Nil(t, provider)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error connecting to the redis cluster: ")
+}
+
+func TestShouldSelectRedisClusterSessionProvider(t *testing.T) {
+	config := newTestSessionConfig()
+	config.Secret = "a_secret"
+	config.Redis = &schema.SessionRedis{
+		Host: "127.0.0.1",
+		Port: 1,
+		Cluster: &schema.SessionRedisCluster{
+			MaximumRedirects: 3,
+			Nodes: []schema.SessionRedisClusterNode{
+				{Host: "127.0.0.1", Port: 1},
+				{Host: "127.0.0.1", Port: 2},
+			},
+		},
+	}
+
+	name, _, serializer, err := NewSessionProvider(config, nil, nil, nil, clock.New())
+
+	assert.Equal(t, "redis-cluster", name)
+	assert.NotNil(t, serializer)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error connecting to the redis cluster: ")
+}
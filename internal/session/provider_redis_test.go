@@ -0,0 +1,96 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/authelia/authelia/v4/internal/clock"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+)
+
+func TestNewRedisProviderShouldErrorWhenUnreachable(t *testing.T) {
+	provider, err := NewRedisProvider(redis.NewClient(&redis.Options{Addr: "127.0.0.1:1"}), "authelia-session")
+
+	assert.Nil(t, provider)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error connecting to redis: ")
+}
+
+func TestShouldSelectRedisSessionProvider(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     *schema.SessionRedis
+		expected string
+	}{
+		{
+			"ShouldSelectRedis",
+			&schema.SessionRedis{Host: "127.0.0.1", Port: 1},
+			"redis",
+		},
+		{
+			"ShouldSelectRedisSentinel",
+			&schema.SessionRedis{
+				Host: "127.0.0.1",
+				Port: 1,
+				HighAvailability: &schema.SessionRedisHighAvailability{
+					SentinelName: "authelia",
+				},
+			},
+			"redis-sentinel",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := newTestSessionConfig()
+			config.Secret = "a_secret"
+			config.Redis = tc.have
+
+			name, _, serializer, err := NewSessionProvider(config, nil, nil, nil, clock.New())
+
+			assert.Equal(t, tc.expected, name)
+			assert.NotNil(t, serializer)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "error connecting to redis: ")
+		})
+	}
+}
+
+func TestShouldShareRedisClientWithUserSessionIndexStore(t *testing.T) {
+	db := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1"})
+
+	defer db.Close()
+
+	config := newTestSessionConfig()
+	config.Redis = &schema.SessionRedis{Host: "127.0.0.1", Port: 1}
+
+	store := NewUserSessionIndexStore(config, &RedisProvider{db: db, keyPrefix: "authelia-session"}, nil)
+
+	require.IsType(t, &RedisUserSessionIndexStore{}, store)
+
+	index := store.(*RedisUserSessionIndexStore)
+
+	assert.Equal(t, db, index.db)
+	assert.Equal(t, "authelia-session", index.keyPrefix)
+}
+
+func TestShouldOnlyCountRedisSessionKeys(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     string
+		expected bool
+	}{
+		{"ShouldCountSession", "authelia-session:" + randomSessionChars, true},
+		{"ShouldNotCountUserSessionIndex", "authelia-session:" + userSessionIndexKey(testUsername), false},
+		{"ShouldNotCountUserSessionRevocation", "authelia-session:" + string(userSessionRevokedKey(testUsername)), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, isRedisSessionKey("authelia-session", tc.have))
+		})
+	}
+}
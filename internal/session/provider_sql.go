@@ -0,0 +1,98 @@
+package session
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/authelia/authelia/v4/internal/clock"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+// NewSQLProvider returns a new session provider which persists the session data using the storage provider.
+func NewSQLProvider(config *schema.SessionSQL, store storage.SessionProvider, clock clock.Provider) *SQLProvider {
+	return &SQLProvider{
+		store:    store,
+		clock:    clock,
+		interval: config.CleanupInterval,
+	}
+}
+
+// SQLProvider is a session provider which persists the session data in the SQL storage backend. The data is expected
+// to be encrypted by the serializer before it reaches this provider.
+type SQLProvider struct {
+	store storage.SessionProvider
+	clock clock.Provider
+
+	interval time.Duration
+
+	mu      sync.Mutex
+	cleaned time.Time
+}
+
+// Get returns the data for the given session id, or nil if the session does not exist or has expired.
+func (p *SQLProvider) Get(id []byte) (data []byte, err error) {
+	return p.store.LoadSessionData(context.Background(), string(id), p.clock.Now())
+}
+
+// Save saves the data for the given session id with the given expiration.
+func (p *SQLProvider) Save(id, data []byte, expiration time.Duration) (err error) {
+	return p.store.SaveSessionData(context.Background(), string(id), data, sqlExpiresAt(p.clock.Now(), expiration))
+}
+
+// Destroy removes the data for the given session id.
+func (p *SQLProvider) Destroy(id []byte) (err error) {
+	return p.store.DeleteSessionData(context.Background(), string(id))
+}
+
+// Regenerate moves the data for the given session id to a new session id with the given expiration.
+func (p *SQLProvider) Regenerate(id, newID []byte, expiration time.Duration) (err error) {
+	return p.store.RegenerateSessionData(context.Background(), string(id), string(newID), sqlExpiresAt(p.clock.Now(), expiration))
+}
+
+// Count returns the number of sessions which have not expired.
+func (p *SQLProvider) Count() int {
+	count, err := p.store.CountSessionData(context.Background(), p.clock.Now())
+	if err != nil {
+		return 0
+	}
+
+	return count
+}
+
+// NeedGC returns true as the expired sessions must be periodically removed from the database.
+func (p *SQLProvider) NeedGC() bool {
+	return true
+}
+
+// GC removes the expired sessions from the database. As every cookie domain shares this provider the removal is only
+// performed once per cleanup interval.
+func (p *SQLProvider) GC() (err error) {
+	p.mu.Lock()
+
+	defer p.mu.Unlock()
+
+	now := p.clock.Now()
+
+	if !p.cleaned.IsZero() && now.Sub(p.cleaned) < p.interval {
+		return nil
+	}
+
+	if err = p.store.PurgeExpiredSessionData(context.Background(), now); err != nil {
+		return err
+	}
+
+	p.cleaned = now
+
+	return nil
+}
+
+func sqlExpiresAt(now time.Time, expiration time.Duration) sql.NullTime {
+	if expiration <= 0 {
+		return sql.NullTime{}
+	}
+
+	return sql.NullTime{Time: now.Add(expiration), Valid: true}
+}
@@ -0,0 +1,185 @@
+package session
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/clock"
+	"github.com/authelia/authelia/v4/internal/configuration/schema"
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+func newTestSQLiteStorage(t *testing.T) *storage.SQLiteProvider {
+	store := storage.NewSQLiteProvider(&schema.Configuration{
+		Storage: schema.Storage{
+			EncryptionKey: "a_not_so_secure_encryption_key",
+			Local: &schema.StorageLocal{
+				Path: filepath.Join(t.TempDir(), "db.sqlite3"),
+			},
+		},
+	})
+
+	t.Cleanup(func() {
+		_ = store.Close()
+	})
+
+	require.NoError(t, store.StartupCheck())
+
+	return store
+}
+
+func TestSQLProvider(t *testing.T) {
+	store := newTestSQLiteStorage(t)
+
+	now := time.Unix(1700000000, 0)
+
+	provider := NewSQLProvider(&schema.SessionSQL{CleanupInterval: time.Hour}, store, clock.NewFixed(now))
+
+	assert.True(t, provider.NeedGC())
+
+	data, err := provider.Get([]byte("abc"))
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+
+	require.NoError(t, provider.Save([]byte("abc"), []byte("data"), time.Minute))
+	require.NoError(t, provider.Save([]byte("persistent"), []byte("forever"), 0))
+	require.NoError(t, provider.Save([]byte("expired"), []byte("old"), -time.Minute))
+
+	data, err = provider.Get([]byte("abc"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("data"), data)
+
+	require.NoError(t, provider.Save([]byte("abc"), []byte("updated"), time.Minute))
+
+	data, err = provider.Get([]byte("abc"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("updated"), data)
+
+	require.NoError(t, store.SaveSessionData(context.Background(), "expired", []byte("old"), sqlExpiresAt(now.Add(-time.Minute), time.Second)))
+
+	data, err = provider.Get([]byte("expired"))
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+
+	assert.Equal(t, 2, provider.Count())
+
+	require.NoError(t, provider.Regenerate([]byte("abc"), []byte("xyz"), time.Minute))
+
+	data, err = provider.Get([]byte("abc"))
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+
+	data, err = provider.Get([]byte("xyz"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("updated"), data)
+
+	require.NoError(t, provider.GC())
+
+	assert.Equal(t, 2, provider.Count())
+
+	require.NoError(t, provider.Destroy([]byte("xyz")))
+
+	data, err = provider.Get([]byte("xyz"))
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+
+	data, err = provider.Get([]byte("persistent"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("forever"), data)
+
+	assert.Equal(t, 1, provider.Count())
+}
+
+func TestSQLProviderShouldThrottleGC(t *testing.T) {
+	store := newTestSQLiteStorage(t)
+
+	now := time.Unix(1700000000, 0)
+
+	fixed := clock.NewFixed(now)
+
+	provider := NewSQLProvider(&schema.SessionSQL{CleanupInterval: time.Hour}, store, fixed)
+
+	require.NoError(t, provider.GC())
+
+	require.NoError(t, store.SaveSessionData(context.Background(), "expired", []byte("old"), sqlExpiresAt(now, time.Minute)))
+
+	fixed.Set(now.Add(time.Minute * 30))
+
+	require.NoError(t, provider.GC())
+
+	count, err := store.CountSessionData(context.Background(), time.Unix(0, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	fixed.Set(now.Add(time.Hour))
+
+	require.NoError(t, provider.GC())
+
+	count, err = store.CountSessionData(context.Background(), time.Unix(0, 0))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestShouldUseSQLSessionProvider(t *testing.T) {
+	store := newTestSQLiteStorage(t)
+
+	config := newTestSessionConfig()
+	config.Secret = "a_secret"
+	config.SQL = &schema.SessionSQL{CleanupInterval: time.Minute}
+
+	provider := NewProvider(config, nil, store, nil, clock.New())
+
+	domain, err := provider.Get(testDomain)
+	require.NoError(t, err)
+
+	ctx := &fasthttp.RequestCtx{}
+
+	userSession, err := domain.GetSession(ctx)
+	require.NoError(t, err)
+
+	userSession.Username = testUsername
+	userSession.AuthenticationLevel = authentication.OneFactor
+
+	now := time.Now()
+
+	require.NoError(t, domain.SaveIndexedSession(ctx, userSession, now, nil))
+
+	records, err := provider.GetUserSessions(testUsername, now)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+
+	count, err := store.CountSessionData(context.Background(), now)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	// The user session index records are stored individually and encrypted by the serializer.
+	indexed, err := store.LoadUserSessionRecords(context.Background(), testUsername, now)
+	assert.NoError(t, err)
+	require.Len(t, indexed, 1)
+	assert.NotContains(t, string(indexed[0]), records[0].SessionID)
+
+	// The session data must be encrypted by the serializer before it's stored.
+	raw, err := store.LoadSessionData(context.Background(), records[0].SessionID, time.Now())
+	assert.NoError(t, err)
+	assert.NotEmpty(t, raw)
+	assert.NotContains(t, string(raw), testUsername)
+}
+
+func TestShouldNotCreateSQLSessionProviderWithoutStorage(t *testing.T) {
+	config := newTestSessionConfig()
+	config.SQL = &schema.SessionSQL{}
+
+	name, provider, serializer, err := NewSessionProvider(config, nil, nil, nil, clock.New())
+
+	assert.EqualError(t, err, "the sql session provider requires a storage provider")
+	assert.Equal(t, "", name)
+	assert.Nil(t, provider)
+	assert.Nil(t, serializer)
+}
@@ -9,12 +9,12 @@ import (
 
 	"github.com/authelia/authelia/v4/internal/authentication"
 	"github.com/authelia/authelia/v4/internal/authorization"
+	"github.com/authelia/authelia/v4/internal/clock"
 	"github.com/authelia/authelia/v4/internal/configuration/schema"
 	"github.com/authelia/authelia/v4/internal/oidc"
 )
 
-func newTestSession() (*Session, error) {
-	config := schema.Session{}
+func newTestSessionConfig() (config schema.Session) {
 	config.Cookies = []schema.SessionCookie{
 		{
 			SessionCookieCommon: schema.SessionCookieCommon{
@@ -25,7 +25,11 @@ func newTestSession() (*Session, error) {
 		},
 	}
 
-	provider := NewProvider(config, nil)
+	return config
+}
+
+func newTestSession() (*Session, error) {
+	provider := NewProvider(newTestSessionConfig(), nil, nil, nil, clock.New())
 
 	return provider.Get(testDomain)
 }
@@ -2,6 +2,8 @@ package session
 
 import (
 	"encoding/json"
+	"fmt"
+	"net"
 	"time"
 
 	"github.com/fasthttp/session/v2"
@@ -15,6 +17,7 @@ type Session struct {
 	Config schema.SessionCookie
 
 	sessionHolder *session.Session
+	index         *UserSessionIndex
 }
 
 // NewDefaultUserSession returns a new default UserSession for this session provider.
@@ -55,26 +58,90 @@ func (p *Session) GetSession(ctx *fasthttp.RequestCtx) (userSession UserSession,
 
 // SaveSession save the user session.
 func (p *Session) SaveSession(ctx *fasthttp.RequestCtx, userSession UserSession) (err error) {
+	_, _, err = p.save(ctx, userSession)
+
+	return err
+}
+
+// SaveIndexedSession saves the user session and updates the record of the session in the user session index with the
+// provided time and remote IP of the request. The index is not updated for anonymous sessions.
+func (p *Session) SaveIndexedSession(ctx *fasthttp.RequestCtx, userSession UserSession, now time.Time, remoteIP net.IP) (err error) {
+	var (
+		sessionID  []byte
+		expiration time.Duration
+	)
+
+	if sessionID, expiration, err = p.save(ctx, userSession); err != nil {
+		return err
+	}
+
+	if userSession.IsAnonymous() || p.index == nil {
+		return nil
+	}
+
+	if expiration <= 0 {
+		expiration = p.Config.Expiration
+	}
+
+	if err = p.index.Touch(userSession.Username, newUserSessionRecord(sessionID, p.Config.Domain, now, expiration, remoteIP, string(ctx.UserAgent()))); err != nil {
+		return fmt.Errorf("failed to update the user session index: %w", err)
+	}
+
+	return nil
+}
+
+func (p *Session) save(ctx *fasthttp.RequestCtx, userSession UserSession) (sessionID []byte, expiration time.Duration, err error) {
 	var (
 		store           *session.Store
 		userSessionJSON []byte
 	)
 
 	if store, err = p.sessionHolder.Get(ctx); err != nil {
-		return err
+		return nil, 0, err
 	}
 
 	if userSessionJSON, err = json.Marshal(userSession); err != nil {
-		return err
+		return nil, 0, err
 	}
 
 	store.Set(userSessionStorerKey, userSessionJSON)
 
+	// The store is reset when it's saved so the session ID and expiration must be copied beforehand.
+	sessionID, expiration = append([]byte(nil), store.GetSessionID()...), store.GetExpiration()
+
 	if err = p.sessionHolder.Save(ctx, store); err != nil {
-		return err
+		return nil, 0, err
 	}
 
-	return nil
+	return sessionID, expiration, nil
+}
+
+// GetSessionRecordID returns the public identifier of the session of the request as used in the user session index.
+func (p *Session) GetSessionRecordID(ctx *fasthttp.RequestCtx) (id string, err error) {
+	var store *session.Store
+
+	if store, err = p.sessionHolder.Get(ctx); err != nil {
+		return "", err
+	}
+
+	return NewUserSessionRecordID(store.GetSessionID()), nil
+}
+
+// IsRevoked returns true if all sessions of the user were revoked after the user session was authenticated. The
+// authentication timestamp of the user session only has a granularity of one second so a user session authenticated
+// within the same second as the revocation is considered revoked.
+func (p *Session) IsRevoked(userSession UserSession) (revoked bool, err error) {
+	if userSession.IsAnonymous() || p.index == nil {
+		return false, nil
+	}
+
+	var at time.Time
+
+	if at, err = p.index.Revoked(userSession.Username); err != nil || at.IsZero() {
+		return false, err
+	}
+
+	return !time.Unix(userSession.FirstFactorAuthnTimestamp, 0).After(at), nil
 }
 
 // RegenerateSession regenerate a session ID.
@@ -25,6 +25,7 @@ type UserSession struct {
 	// TODO(c.michaud): move groups out of the session.
 	Groups []string
 	Emails []string
+	Extra  map[string]string
 
 	KeepMeLoggedIn      bool
 	AuthenticationLevel authentication.Level
@@ -43,6 +44,30 @@ type UserSession struct {
 	PasswordResetUsername *string
 
 	RefreshTTL time.Time
+
+	// Binding is the client fingerprint recorded at login when session binding is enabled.
+	Binding *UserSessionBinding
+}
+
+// UserSessionRecord is a record of an active session of a user stored in the user session index.
+type UserSessionRecord struct {
+	// ID is the public identifier of the session which is derived from the session ID.
+	ID string `json:"id"`
+
+	// SessionID is the session ID stored in the session cookie and must never be exposed.
+	SessionID string `json:"session_id"`
+
+	CookieDomain string    `json:"cookie_domain"`
+	Created      time.Time `json:"created"`
+	LastActivity time.Time `json:"last_activity"`
+	Expires      time.Time `json:"expires"`
+	RemoteIP     string    `json:"remote_ip"`
+	UserAgent    string    `json:"user_agent"`
+}
+
+// MetricsRecorder represents the methods used to record session metrics.
+type MetricsRecorder interface {
+	RecordSessionSecretDecryption(secret int)
 }
 
 // Identity identity of the user who is being verified.
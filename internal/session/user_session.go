@@ -34,6 +34,7 @@ func (s *UserSession) SetOneFactor(now time.Time, details *authentication.UserDe
 	s.DisplayName = details.DisplayName
 	s.Groups = details.Groups
 	s.Emails = details.Emails
+	s.Extra = details.Extra
 
 	s.AuthenticationMethodRefs.UsernameAndPassword = true
 }
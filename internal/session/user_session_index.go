@@ -0,0 +1,299 @@
+package session
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/fasthttp/session/v2"
+)
+
+// NewUserSessionIndex returns a new UserSessionIndex which stores the records in the provided UserSessionIndexStore
+// and looks up the sessions in the provided session.Provider. The records are encoded with the provided Serializer if
+// it's not nil.
+func NewUserSessionIndex(provider session.Provider, store UserSessionIndexStore, serializer Serializer) *UserSessionIndex {
+	return &UserSessionIndex{
+		provider:   provider,
+		store:      store,
+		serializer: serializer,
+	}
+}
+
+// UserSessionIndex is an index of the sessions of each user stored alongside the sessions in the session store. This
+// allows listing and revoking the sessions of a user as the session store itself only stores sessions by ID. Each
+// record is stored individually so concurrent updates of different sessions of a user don't overwrite each other.
+type UserSessionIndex struct {
+	provider   session.Provider
+	store      UserSessionIndexStore
+	serializer Serializer
+}
+
+// Touch adds the session to the index of the user or updates the last activity, remote IP, user agent, and expiration
+// of the session if it's already in the index.
+func (i *UserSessionIndex) Touch(username string, record UserSessionRecord) (err error) {
+	var current *UserSessionRecord
+
+	if current, err = i.load(username, record.ID, record.LastActivity); err != nil {
+		return err
+	}
+
+	if current != nil {
+		if current.CookieDomain == record.CookieDomain && current.RemoteIP == record.RemoteIP && current.UserAgent == record.UserAgent &&
+			record.LastActivity.Sub(current.LastActivity) < userSessionIndexActivityInterval {
+			return nil
+		}
+
+		record.Created = current.Created
+	}
+
+	return i.save(username, record)
+}
+
+// List returns the sessions of the user in the index. Sessions which have expired or which no longer exist in the
+// session store are removed from the index.
+func (i *UserSessionIndex) List(username string, now time.Time) (records []UserSessionRecord, err error) {
+	if records, err = i.loadAll(username, now); err != nil {
+		return nil, err
+	}
+
+	active := make([]UserSessionRecord, 0, len(records))
+
+	var data []byte
+
+	for _, record := range records {
+		if data, err = i.provider.Get([]byte(record.SessionID)); err != nil {
+			return nil, fmt.Errorf("failed to lookup session: %w", err)
+		}
+
+		if len(data) == 0 {
+			if err = i.store.DeleteRecord(username, record.ID); err != nil {
+				return nil, fmt.Errorf("failed to delete the user session index record: %w", err)
+			}
+
+			continue
+		}
+
+		active = append(active, record)
+	}
+
+	sort.Slice(active, func(a, b int) bool {
+		return active[a].LastActivity.After(active[b].LastActivity)
+	})
+
+	return active, nil
+}
+
+// Revoke destroys the session with the provided public ID in the session store and removes it from the index.
+func (i *UserSessionIndex) Revoke(username, id string, now time.Time) (err error) {
+	var record *UserSessionRecord
+
+	if record, err = i.load(username, id, now); err != nil {
+		return err
+	}
+
+	if record == nil {
+		return ErrUserSessionNotFound
+	}
+
+	if err = i.provider.Destroy([]byte(record.SessionID)); err != nil {
+		return fmt.Errorf("failed to destroy session: %w", err)
+	}
+
+	if err = i.store.DeleteRecord(username, id); err != nil {
+		return fmt.Errorf("failed to delete the user session index record: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeAll destroys every session of the user in the session store and removes them from the index. The time of the
+// revocation is retained for the provided duration so sessions which are missing from the index or which are saved
+// concurrently can be rejected. The time is retained with a granularity of one second to match the authentication
+// timestamps of the user sessions.
+func (i *UserSessionIndex) RevokeAll(username string, now time.Time, retention time.Duration) (revoked int, err error) {
+	if err = i.provider.Save(userSessionRevokedKey(username), []byte(strconv.FormatInt(now.Unix(), 10)), retention); err != nil {
+		return 0, fmt.Errorf("failed to save the user session revocation: %w", err)
+	}
+
+	var records []UserSessionRecord
+
+	if records, err = i.loadAll(username, now); err != nil {
+		return 0, err
+	}
+
+	for _, record := range records {
+		if err = i.provider.Destroy([]byte(record.SessionID)); err != nil {
+			return revoked, fmt.Errorf("failed to destroy session: %w", err)
+		}
+
+		if err = i.store.DeleteRecord(username, record.ID); err != nil {
+			return revoked, fmt.Errorf("failed to delete the user session index record: %w", err)
+		}
+
+		revoked++
+	}
+
+	return revoked, nil
+}
+
+// Revoked returns the time all sessions of the user were last revoked, or the zero value if they never were.
+func (i *UserSessionIndex) Revoked(username string) (revoked time.Time, err error) {
+	var data []byte
+
+	if data, err = i.provider.Get(userSessionRevokedKey(username)); err != nil {
+		return revoked, fmt.Errorf("failed to load the user session revocation: %w", err)
+	}
+
+	if len(data) == 0 {
+		return revoked, nil
+	}
+
+	var unix int64
+
+	if unix, err = strconv.ParseInt(string(data), 10, 64); err != nil {
+		return revoked, fmt.Errorf("failed to decode the user session revocation: %w", err)
+	}
+
+	return time.Unix(unix, 0), nil
+}
+
+func (i *UserSessionIndex) load(username, id string, now time.Time) (record *UserSessionRecord, err error) {
+	var data []byte
+
+	if data, err = i.store.LoadRecord(username, id, now); err != nil {
+		return nil, fmt.Errorf("failed to load the user session index record: %w", err)
+	}
+
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	if record, err = i.decode(data); err != nil {
+		return nil, err
+	}
+
+	if record == nil || record.Expires.Before(now) {
+		return nil, nil
+	}
+
+	return record, nil
+}
+
+// loadAll returns the records of the user which have not expired. Records which have expired are removed from the
+// store as they're not removed by every UserSessionIndexStore implementation.
+func (i *UserSessionIndex) loadAll(username string, now time.Time) (records []UserSessionRecord, err error) {
+	var values [][]byte
+
+	if values, err = i.store.LoadRecords(username, now); err != nil {
+		return nil, fmt.Errorf("failed to load the user session index: %w", err)
+	}
+
+	records = make([]UserSessionRecord, 0, len(values))
+
+	var record *UserSessionRecord
+
+	for _, data := range values {
+		if record, err = i.decode(data); err != nil {
+			return nil, err
+		}
+
+		if record == nil {
+			continue
+		}
+
+		if record.Expires.Before(now) {
+			if err = i.store.DeleteRecord(username, record.ID); err != nil {
+				return nil, fmt.Errorf("failed to delete the user session index record: %w", err)
+			}
+
+			continue
+		}
+
+		records = append(records, *record)
+	}
+
+	return records, nil
+}
+
+func (i *UserSessionIndex) save(username string, record UserSessionRecord) (err error) {
+	var data []byte
+
+	if data, err = json.Marshal(record); err != nil {
+		return fmt.Errorf("failed to encode the user session index record: %w", err)
+	}
+
+	if i.serializer != nil {
+		if data, err = i.serializer.Encode(session.Dict{KV: map[string]any{userSessionIndexStorerKey: data}}); err != nil {
+			return fmt.Errorf("failed to encode the user session index record: %w", err)
+		}
+	}
+
+	if err = i.store.SaveRecord(username, record.ID, data, record.Expires); err != nil {
+		return fmt.Errorf("failed to save the user session index record: %w", err)
+	}
+
+	return nil
+}
+
+func (i *UserSessionIndex) decode(data []byte) (record *UserSessionRecord, err error) {
+	if i.serializer != nil {
+		dict := session.Dict{KV: map[string]any{}}
+
+		if err = i.serializer.Decode(&dict, data); err != nil {
+			return nil, fmt.Errorf("failed to decode the user session index record: %w", err)
+		}
+
+		var ok bool
+
+		if data, ok = dict.KV[userSessionIndexStorerKey].([]byte); !ok {
+			return nil, nil
+		}
+	}
+
+	record = &UserSessionRecord{}
+
+	if err = json.Unmarshal(data, record); err != nil {
+		return nil, fmt.Errorf("failed to decode the user session index record: %w", err)
+	}
+
+	return record, nil
+}
+
+// NewUserSessionRecordID returns the public identifier of a session. The session ID itself is a secret so it's hashed
+// to produce an identifier which can be safely displayed and used to revoke the session.
+func NewUserSessionRecordID(sessionID []byte) string {
+	sum := sha256.Sum256(sessionID)
+
+	return hex.EncodeToString(sum[:16])
+}
+
+func newUserSessionRecord(sessionID []byte, domain string, now time.Time, expiration time.Duration, remoteIP net.IP, userAgent string) UserSessionRecord {
+	record := UserSessionRecord{
+		ID:           NewUserSessionRecordID(sessionID),
+		SessionID:    string(sessionID),
+		CookieDomain: domain,
+		Created:      now,
+		LastActivity: now,
+		Expires:      now.Add(expiration),
+		UserAgent:    userAgent,
+	}
+
+	if remoteIP != nil {
+		record.RemoteIP = remoteIP.String()
+	}
+
+	return record
+}
+
+func userSessionIndexKey(username string) string {
+	return userSessionIndexKeyPrefix + username
+}
+
+func userSessionRevokedKey(username string) []byte {
+	return []byte(userSessionRevokedKeyPrefix + username)
+}
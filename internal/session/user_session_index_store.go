@@ -0,0 +1,211 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/authelia/authelia/v4/internal/storage"
+)
+
+// UserSessionIndexStore persists the records of the UserSessionIndex. Each record is saved, loaded, and deleted
+// individually so updates of the records of different sessions of a user from multiple instances don't overwrite
+// each other.
+type UserSessionIndexStore interface {
+	SaveRecord(username, id string, data []byte, expires time.Time) (err error)
+	LoadRecord(username, id string, now time.Time) (data []byte, err error)
+	LoadRecords(username string, now time.Time) (data [][]byte, err error)
+	DeleteRecord(username, id string) (err error)
+}
+
+// NewMemoryUserSessionIndexStore returns a new UserSessionIndexStore which keeps the records in memory. This is only
+// suitable for the memory session provider as the sessions themselves are not shared between instances.
+func NewMemoryUserSessionIndexStore() *MemoryUserSessionIndexStore {
+	return &MemoryUserSessionIndexStore{
+		users: map[string]map[string]memoryUserSessionRecord{},
+	}
+}
+
+// MemoryUserSessionIndexStore is a UserSessionIndexStore which keeps the records in memory.
+type MemoryUserSessionIndexStore struct {
+	mu    sync.Mutex
+	users map[string]map[string]memoryUserSessionRecord
+}
+
+type memoryUserSessionRecord struct {
+	data    []byte
+	expires time.Time
+}
+
+// SaveRecord saves the record with the given id for the user.
+func (s *MemoryUserSessionIndexStore) SaveRecord(username, id string, data []byte, expires time.Time) (err error) {
+	s.mu.Lock()
+
+	defer s.mu.Unlock()
+
+	records, ok := s.users[username]
+	if !ok {
+		records = map[string]memoryUserSessionRecord{}
+
+		s.users[username] = records
+	}
+
+	records[id] = memoryUserSessionRecord{data: data, expires: expires}
+
+	return nil
+}
+
+// LoadRecord returns the record with the given id for the user, or nil if it does not exist or has expired.
+func (s *MemoryUserSessionIndexStore) LoadRecord(username, id string, now time.Time) (data []byte, err error) {
+	s.mu.Lock()
+
+	defer s.mu.Unlock()
+
+	if record, ok := s.users[username][id]; ok && record.expires.After(now) {
+		return record.data, nil
+	}
+
+	return nil, nil
+}
+
+// LoadRecords returns the records for the user which have not expired, removing the records which have.
+func (s *MemoryUserSessionIndexStore) LoadRecords(username string, now time.Time) (data [][]byte, err error) {
+	s.mu.Lock()
+
+	defer s.mu.Unlock()
+
+	for id, record := range s.users[username] {
+		if !record.expires.After(now) {
+			delete(s.users[username], id)
+
+			continue
+		}
+
+		data = append(data, record.data)
+	}
+
+	if len(s.users[username]) == 0 {
+		delete(s.users, username)
+	}
+
+	return data, nil
+}
+
+// DeleteRecord removes the record with the given id for the user.
+func (s *MemoryUserSessionIndexStore) DeleteRecord(username, id string) (err error) {
+	s.mu.Lock()
+
+	defer s.mu.Unlock()
+
+	delete(s.users[username], id)
+
+	if len(s.users[username]) == 0 {
+		delete(s.users, username)
+	}
+
+	return nil
+}
+
+// NewRedisUserSessionIndexStore returns a new UserSessionIndexStore which keeps the records of each user as the fields
+// of a Redis hash. Redis can't expire individual fields so the hash expires after the retention, which must be at least
+// the longest duration a session may be valid for, and the expired records are removed by the UserSessionIndex.
+func NewRedisUserSessionIndexStore(db redis.UniversalClient, keyPrefix string, retention time.Duration) *RedisUserSessionIndexStore {
+	return &RedisUserSessionIndexStore{
+		db:        db,
+		keyPrefix: keyPrefix,
+		retention: retention,
+	}
+}
+
+// RedisUserSessionIndexStore is a UserSessionIndexStore which keeps the records of each user in a Redis hash.
+type RedisUserSessionIndexStore struct {
+	db        redis.UniversalClient
+	keyPrefix string
+	retention time.Duration
+}
+
+// SaveRecord saves the record with the given id for the user and extends the expiration of the hash.
+func (s *RedisUserSessionIndexStore) SaveRecord(username, id string, data []byte, _ time.Time) (err error) {
+	key := s.key(username)
+
+	_, err = s.db.TxPipelined(context.Background(), func(pipe redis.Pipeliner) error {
+		pipe.HSet(context.Background(), key, id, data)
+		pipe.Expire(context.Background(), key, s.retention)
+
+		return nil
+	})
+
+	return err
+}
+
+// LoadRecord returns the record with the given id for the user, or nil if it does not exist.
+func (s *RedisUserSessionIndexStore) LoadRecord(username, id string, _ time.Time) (data []byte, err error) {
+	if data, err = s.db.HGet(context.Background(), s.key(username), id).Bytes(); err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// LoadRecords returns the records for the user.
+func (s *RedisUserSessionIndexStore) LoadRecords(username string, _ time.Time) (data [][]byte, err error) {
+	var values map[string]string
+
+	if values, err = s.db.HGetAll(context.Background(), s.key(username)).Result(); err != nil {
+		return nil, err
+	}
+
+	for _, value := range values {
+		data = append(data, []byte(value))
+	}
+
+	return data, nil
+}
+
+// DeleteRecord removes the record with the given id for the user.
+func (s *RedisUserSessionIndexStore) DeleteRecord(username, id string) (err error) {
+	return s.db.HDel(context.Background(), s.key(username), id).Err()
+}
+
+func (s *RedisUserSessionIndexStore) key(username string) string {
+	return s.keyPrefix + ":" + userSessionIndexKey(username)
+}
+
+// NewSQLUserSessionIndexStore returns a new UserSessionIndexStore which keeps the records in the SQL storage backend.
+func NewSQLUserSessionIndexStore(store storage.SessionProvider) *SQLUserSessionIndexStore {
+	return &SQLUserSessionIndexStore{
+		store: store,
+	}
+}
+
+// SQLUserSessionIndexStore is a UserSessionIndexStore which keeps the records in the SQL storage backend.
+type SQLUserSessionIndexStore struct {
+	store storage.SessionProvider
+}
+
+// SaveRecord saves the record with the given id for the user.
+func (s *SQLUserSessionIndexStore) SaveRecord(username, id string, data []byte, expires time.Time) (err error) {
+	return s.store.SaveUserSessionRecord(context.Background(), username, id, data, expires)
+}
+
+// LoadRecord returns the record with the given id for the user, or nil if it does not exist or has expired.
+func (s *SQLUserSessionIndexStore) LoadRecord(username, id string, now time.Time) (data []byte, err error) {
+	return s.store.LoadUserSessionRecord(context.Background(), username, id, now)
+}
+
+// LoadRecords returns the records for the user which have not expired.
+func (s *SQLUserSessionIndexStore) LoadRecords(username string, now time.Time) (data [][]byte, err error) {
+	return s.store.LoadUserSessionRecords(context.Background(), username, now)
+}
+
+// DeleteRecord removes the record with the given id for the user.
+func (s *SQLUserSessionIndexStore) DeleteRecord(username, id string) (err error) {
+	return s.store.DeleteUserSessionRecord(context.Background(), username, id)
+}
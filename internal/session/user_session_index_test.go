@@ -0,0 +1,270 @@
+package session
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/fasthttp/session/v2/providers/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/valyala/fasthttp"
+
+	"github.com/authelia/authelia/v4/internal/authentication"
+	"github.com/authelia/authelia/v4/internal/clock"
+)
+
+func TestShouldIndexUserSessions(t *testing.T) {
+	provider := NewProvider(newTestSessionConfig(), nil, nil, nil, clock.New())
+
+	domain, err := provider.Get(testDomain)
+	require.NoError(t, err)
+
+	newCtx := func(userAgent string) *fasthttp.RequestCtx {
+		ctx := &fasthttp.RequestCtx{}
+
+		ctx.Request.Header.SetUserAgent(userAgent)
+
+		return ctx
+	}
+
+	laptop, phone, anonymous := newCtx("laptop"), newCtx("phone"), newCtx("anonymous")
+
+	now := time.Now()
+
+	for ctx, ip := range map[*fasthttp.RequestCtx]string{laptop: "192.168.0.1", phone: "10.0.0.1"} {
+		userSession, err := domain.GetSession(ctx)
+		require.NoError(t, err)
+
+		userSession.Username = testUsername
+		userSession.AuthenticationLevel = authentication.OneFactor
+
+		require.NoError(t, domain.SaveIndexedSession(ctx, userSession, now, net.ParseIP(ip)))
+	}
+
+	userSession, err := domain.GetSession(anonymous)
+	require.NoError(t, err)
+	require.NoError(t, domain.SaveIndexedSession(anonymous, userSession, now, net.ParseIP("192.168.0.2")))
+
+	records, err := provider.GetUserSessions(testUsername, now)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	laptopID, err := domain.GetSessionRecordID(laptop)
+	require.NoError(t, err)
+
+	phoneID, err := domain.GetSessionRecordID(phone)
+	require.NoError(t, err)
+
+	actual := map[string]UserSessionRecord{}
+
+	for _, record := range records {
+		actual[record.ID] = record
+	}
+
+	require.Contains(t, actual, laptopID)
+	require.Contains(t, actual, phoneID)
+
+	assert.Equal(t, testDomain, actual[laptopID].CookieDomain)
+	assert.Equal(t, "192.168.0.1", actual[laptopID].RemoteIP)
+	assert.Equal(t, "laptop", actual[laptopID].UserAgent)
+	assert.Equal(t, "10.0.0.1", actual[phoneID].RemoteIP)
+	assert.Equal(t, "phone", actual[phoneID].UserAgent)
+	assert.Equal(t, now.Add(testExpiration).Unix(), actual[phoneID].Expires.Unix())
+
+	assert.ErrorIs(t, provider.RevokeUserSession(testUsername, "abc", now), ErrUserSessionNotFound)
+	assert.ErrorIs(t, provider.RevokeUserSession("harry", laptopID, now), ErrUserSessionNotFound)
+
+	require.NoError(t, provider.RevokeUserSession(testUsername, laptopID, now))
+
+	userSession, err = domain.GetSession(laptop)
+	require.NoError(t, err)
+	assert.True(t, userSession.IsAnonymous())
+
+	userSession, err = domain.GetSession(phone)
+	require.NoError(t, err)
+	assert.Equal(t, testUsername, userSession.Username)
+
+	records, err = provider.GetUserSessions(testUsername, now)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, phoneID, records[0].ID)
+
+	require.NoError(t, domain.DestroySession(phone))
+
+	records, err = provider.GetUserSessions(testUsername, now)
+	require.NoError(t, err)
+	assert.Len(t, records, 0)
+}
+
+func TestUserSessionIndexShouldEncryptAndPrune(t *testing.T) {
+	provider, err := memory.New(memory.Config{})
+	require.NoError(t, err)
+
+	store := NewMemoryUserSessionIndexStore()
+
+	index := NewUserSessionIndex(provider, store, NewEncryptingSerializer("a_secret"))
+
+	now := time.Now()
+
+	require.NoError(t, provider.Save([]byte("active"), []byte("data"), time.Hour))
+	require.NoError(t, provider.Save([]byte("expired"), []byte("data"), time.Hour))
+
+	require.NoError(t, index.Touch(testUsername, UserSessionRecord{ID: "1", SessionID: "active", Created: now, LastActivity: now, Expires: now.Add(time.Hour)}))
+	require.NoError(t, index.Touch(testUsername, UserSessionRecord{ID: "2", SessionID: "expired", Created: now, LastActivity: now, Expires: now.Add(time.Minute)}))
+	require.NoError(t, index.Touch(testUsername, UserSessionRecord{ID: "3", SessionID: "destroyed", Created: now, LastActivity: now, Expires: now.Add(time.Hour)}))
+
+	data, err := store.LoadRecord(testUsername, "1", now)
+	require.NoError(t, err)
+	require.NotEmpty(t, data)
+	assert.NotContains(t, string(data), "active")
+
+	records, err := index.List(testUsername, now.Add(time.Minute*2))
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "1", records[0].ID)
+
+	// Activity within the interval is not written to the index.
+	require.NoError(t, index.Touch(testUsername, UserSessionRecord{ID: "1", SessionID: "active", Created: now.Add(time.Second), LastActivity: now.Add(time.Second), Expires: now.Add(time.Hour)}))
+
+	records, err = index.List(testUsername, now)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, now.Unix(), records[0].LastActivity.Unix())
+
+	require.NoError(t, index.Touch(testUsername, UserSessionRecord{ID: "1", SessionID: "active", Created: now.Add(time.Hour), LastActivity: now.Add(time.Minute * 2), Expires: now.Add(time.Hour * 2)}))
+
+	records, err = index.List(testUsername, now)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, now.Unix(), records[0].Created.Unix())
+	assert.Equal(t, now.Add(time.Minute*2).Unix(), records[0].LastActivity.Unix())
+}
+
+func TestShouldRevokeAllUserSessions(t *testing.T) {
+	provider := NewProvider(newTestSessionConfig(), nil, nil, nil, clock.New())
+
+	domain, err := provider.Get(testDomain)
+	require.NoError(t, err)
+
+	laptop, phone := &fasthttp.RequestCtx{}, &fasthttp.RequestCtx{}
+
+	now := time.Now()
+
+	for _, ctx := range []*fasthttp.RequestCtx{laptop, phone} {
+		userSession, err := domain.GetSession(ctx)
+		require.NoError(t, err)
+
+		userSession.Username = testUsername
+		userSession.AuthenticationLevel = authentication.OneFactor
+		userSession.FirstFactorAuthnTimestamp = time.Now().Add(-time.Minute).Unix()
+
+		require.NoError(t, domain.SaveIndexedSession(ctx, userSession, now, nil))
+
+		revoked, err := domain.IsRevoked(userSession)
+		require.NoError(t, err)
+		assert.False(t, revoked)
+	}
+
+	revoked, err := provider.RevokeAllUserSessions(testUsername, now)
+	require.NoError(t, err)
+	assert.Equal(t, 2, revoked)
+
+	for _, ctx := range []*fasthttp.RequestCtx{laptop, phone} {
+		userSession, err := domain.GetSession(ctx)
+		require.NoError(t, err)
+		assert.True(t, userSession.IsAnonymous())
+	}
+
+	records, err := provider.GetUserSessions(testUsername, now)
+	require.NoError(t, err)
+	assert.Len(t, records, 0)
+
+	userSession := NewDefaultUserSession()
+	userSession.Username = testUsername
+	userSession.AuthenticationLevel = authentication.OneFactor
+	userSession.FirstFactorAuthnTimestamp = time.Now().Add(-time.Minute).Unix()
+
+	isRevoked, err := domain.IsRevoked(userSession)
+	require.NoError(t, err)
+	assert.True(t, isRevoked)
+
+	userSession.FirstFactorAuthnTimestamp = time.Now().Add(time.Minute).Unix()
+
+	isRevoked, err = domain.IsRevoked(userSession)
+	require.NoError(t, err)
+	assert.False(t, isRevoked)
+
+	userSession.Username = "harry"
+	userSession.FirstFactorAuthnTimestamp = time.Now().Add(-time.Minute).Unix()
+
+	isRevoked, err = domain.IsRevoked(userSession)
+	require.NoError(t, err)
+	assert.False(t, isRevoked)
+}
+
+func TestShouldRevokeUserSessionsAuthenticatedWithinTheSameSecond(t *testing.T) {
+	provider := NewProvider(newTestSessionConfig(), nil, nil, nil, clock.New())
+
+	domain, err := provider.Get(testDomain)
+	require.NoError(t, err)
+
+	now := time.Unix(1700000000, 999000000)
+
+	_, err = provider.RevokeAllUserSessions(testUsername, now)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		have     time.Time
+		expected bool
+	}{
+		{"ShouldRevokeBefore", now.Add(-time.Second), true},
+		{"ShouldRevokeSameSecondBefore", now.Add(-time.Millisecond * 500), true},
+		{"ShouldRevokeSameSecondAfter", time.Unix(now.Unix(), 999999999), true},
+		{"ShouldNotRevokeNextSecond", time.Unix(now.Unix()+1, 0), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			userSession := NewDefaultUserSession()
+			userSession.Username = testUsername
+			userSession.AuthenticationLevel = authentication.OneFactor
+			userSession.FirstFactorAuthnTimestamp = tc.have.Unix()
+
+			revoked, err := domain.IsRevoked(userSession)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, revoked)
+		})
+	}
+}
+
+func TestUserSessionIndexShouldNotOverwriteConcurrentRecords(t *testing.T) {
+	provider, err := memory.New(memory.Config{})
+	require.NoError(t, err)
+
+	store := NewMemoryUserSessionIndexStore()
+
+	// Each index represents a separate instance sharing the same session and index stores.
+	a, b := NewUserSessionIndex(provider, store, nil), NewUserSessionIndex(provider, store, nil)
+
+	now := time.Now()
+
+	require.NoError(t, provider.Save([]byte("laptop"), []byte("data"), time.Hour))
+	require.NoError(t, provider.Save([]byte("phone"), []byte("data"), time.Hour))
+
+	require.NoError(t, a.Touch(testUsername, newUserSessionRecord([]byte("laptop"), testDomain, now, time.Hour, net.ParseIP("192.168.0.1"), "laptop")))
+	require.NoError(t, b.Touch(testUsername, newUserSessionRecord([]byte("phone"), testDomain, now, time.Hour, net.ParseIP("10.0.0.1"), "phone")))
+
+	records, err := a.List(testUsername, now)
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	require.NoError(t, b.Revoke(testUsername, NewUserSessionRecordID([]byte("laptop")), now))
+
+	records, err = a.List(testUsername, now)
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "phone", records[0].UserAgent)
+	assert.Equal(t, "10.0.0.1", records[0].RemoteIP)
+}
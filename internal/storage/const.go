@@ -8,9 +8,13 @@ const (
 	tableAuthenticationLogs   = "authentication_logs"
 	tableDuoDevices           = "duo_devices"
 	tableIdentityVerification = "identity_verification"
+	tableNotificationQueue    = "notification_queue"
+	tableRegulationUnbans     = "regulation_unbans"
+	tableSessions             = "sessions"
 	tableTOTPConfigurations   = "totp_configurations"
 	tableUserOpaqueIdentifier = "user_opaque_identifier"
 	tableUserPreferences      = "user_preferences"
+	tableUserSessions         = "user_sessions"
 	tableWebAuthnDevices      = "webauthn_devices"
 
 	tableOAuth2BlacklistedJTI          = "oauth2_blacklisted_jti"
@@ -0,0 +1 @@
+ALTER TABLE authentication_logs ADD COLUMN country VARCHAR(2) NOT NULL DEFAULT '';
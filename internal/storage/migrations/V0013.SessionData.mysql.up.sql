@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS sessions (
+	id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+	session_id VARCHAR(255) NOT NULL,
+	expires_at TIMESTAMP NULL DEFAULT NULL,
+	data BLOB NOT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_520_ci;
+
+CREATE UNIQUE INDEX sessions_session_id_key ON sessions (session_id);
+CREATE INDEX sessions_expires_at_idx ON sessions (expires_at);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS sessions (
+	id SERIAL CONSTRAINT sessions_pkey PRIMARY KEY,
+	session_id VARCHAR(255) NOT NULL,
+	expires_at TIMESTAMP WITH TIME ZONE NULL DEFAULT NULL,
+	data BYTEA NOT NULL
+);
+
+CREATE UNIQUE INDEX sessions_session_id_key ON sessions (session_id);
+CREATE INDEX sessions_expires_at_idx ON sessions (expires_at);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS authentication_logs_remote_network_idx;
+
+ALTER TABLE authentication_logs DROP COLUMN remote_network;
@@ -0,0 +1,3 @@
+ALTER TABLE authentication_logs ADD COLUMN remote_network VARCHAR(43) NULL DEFAULT NULL;
+
+CREATE INDEX authentication_logs_remote_network_idx ON authentication_logs (time, remote_network, auth_type);
@@ -0,0 +1,3 @@
+DROP INDEX authentication_logs_remote_network_idx ON authentication_logs;
+
+ALTER TABLE authentication_logs DROP COLUMN remote_network;
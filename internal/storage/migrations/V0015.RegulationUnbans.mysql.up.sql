@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS regulation_unbans (
+	id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+	time TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	username VARCHAR(100) NULL DEFAULT NULL,
+	remote_network VARCHAR(43) NULL DEFAULT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_520_ci;
+
+CREATE INDEX regulation_unbans_username_idx ON regulation_unbans (username, time);
+CREATE INDEX regulation_unbans_remote_network_idx ON regulation_unbans (remote_network, time);
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS notification_queue (
+	id SERIAL CONSTRAINT notification_queue_pkey PRIMARY KEY,
+	created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	attempts INTEGER NOT NULL DEFAULT 0,
+	dead_lettered BOOLEAN NOT NULL DEFAULT FALSE,
+	recipient_name VARCHAR(255) NOT NULL,
+	recipient_address VARCHAR(255) NOT NULL,
+	subject VARCHAR(255) NOT NULL,
+	template VARCHAR(100) NOT NULL,
+	data BYTEA NOT NULL,
+	last_error TEXT NULL DEFAULT NULL
+);
+
+CREATE INDEX notification_queue_next_attempt_at_idx ON notification_queue (dead_lettered, next_attempt_at);
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS user_sessions (
+	id INTEGER NOT NULL PRIMARY KEY AUTO_INCREMENT,
+	username VARCHAR(100) NOT NULL,
+	record_id VARCHAR(64) NOT NULL,
+	expires_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+	data BLOB NOT NULL
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_520_ci;
+
+CREATE UNIQUE INDEX user_sessions_username_record_id_key ON user_sessions (username, record_id);
+CREATE INDEX user_sessions_expires_at_idx ON user_sessions (expires_at);
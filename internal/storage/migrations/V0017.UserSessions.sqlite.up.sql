@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS user_sessions (
+	id INTEGER NOT NULL PRIMARY KEY AUTOINCREMENT,
+	username VARCHAR(100) NOT NULL,
+	record_id VARCHAR(64) NOT NULL,
+	expires_at TIMESTAMP NOT NULL,
+	data BLOB NOT NULL
+);
+
+CREATE UNIQUE INDEX user_sessions_username_record_id_key ON user_sessions (username, record_id);
+CREATE INDEX user_sessions_expires_at_idx ON user_sessions (expires_at);
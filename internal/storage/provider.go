@@ -16,6 +16,8 @@ type Provider interface {
 	model.StartupCheck
 
 	RegulatorProvider
+	SessionProvider
+	NotificationQueueProvider
 
 	storage.Transactional
 
@@ -64,6 +66,7 @@ type Provider interface {
 	DeactivateOAuth2Session(ctx context.Context, sessionType OAuth2SessionType, signature string) (err error)
 	DeactivateOAuth2SessionByRequestID(ctx context.Context, sessionType OAuth2SessionType, requestID string) (err error)
 	LoadOAuth2Session(ctx context.Context, sessionType OAuth2SessionType, signature string) (session *model.OAuth2Session, err error)
+	LoadOAuth2SessionSignaturesByUsername(ctx context.Context, sessionType OAuth2SessionType, username string) (signatures []string, err error)
 
 	SaveOAuth2PARContext(ctx context.Context, par model.OAuth2PARContext) (err error)
 	LoadOAuth2PARContext(ctx context.Context, signature string) (par *model.OAuth2PARContext, err error)
@@ -92,4 +95,37 @@ type Provider interface {
 type RegulatorProvider interface {
 	AppendAuthenticationLog(ctx context.Context, attempt model.AuthenticationAttempt) (err error)
 	LoadAuthenticationLogs(ctx context.Context, username string, fromDate time.Time, limit, page int) (attempts []model.AuthenticationAttempt, err error)
+	LoadAuthenticationLogsByUsernameAndRemoteNetwork(ctx context.Context, username, network string, fromDate time.Time, limit, page int) (attempts []model.AuthenticationAttempt, err error)
+	LoadAuthenticationLogsByUsernameAndType(ctx context.Context, username, authType string, fromDate time.Time, limit, page int) (attempts []model.AuthenticationAttempt, err error)
+	LoadFailedAuthenticationLogsByRemoteNetwork(ctx context.Context, network string, fromDate time.Time, limit, page int) (attempts []model.AuthenticationAttempt, err error)
+	LoadFailedAuthenticationLogSubjects(ctx context.Context, fromDate time.Time) (subjects []model.AuthenticationAttempt, err error)
+	LoadFailedAuthenticationLogsByUsername(ctx context.Context, username string, limit, page int) (attempts []model.AuthenticationAttempt, err error)
+
+	SaveRegulationUnban(ctx context.Context, unban model.RegulationUnban) (err error)
+	LoadRegulationUnbanTimeByUsername(ctx context.Context, username string) (unbanned time.Time, err error)
+	LoadRegulationUnbanTimeByRemoteNetwork(ctx context.Context, network string) (unbanned time.Time, err error)
+}
+
+// SessionProvider is an interface providing storage capabilities for persisting session data.
+type SessionProvider interface {
+	SaveSessionData(ctx context.Context, id string, data []byte, expiresAt sql.NullTime) (err error)
+	LoadSessionData(ctx context.Context, id string, now time.Time) (data []byte, err error)
+	RegenerateSessionData(ctx context.Context, id, newID string, expiresAt sql.NullTime) (err error)
+	DeleteSessionData(ctx context.Context, id string) (err error)
+	CountSessionData(ctx context.Context, now time.Time) (count int, err error)
+	PurgeExpiredSessionData(ctx context.Context, now time.Time) (err error)
+
+	SaveUserSessionRecord(ctx context.Context, username, id string, data []byte, expiresAt time.Time) (err error)
+	LoadUserSessionRecord(ctx context.Context, username, id string, now time.Time) (data []byte, err error)
+	LoadUserSessionRecords(ctx context.Context, username string, now time.Time) (data [][]byte, err error)
+	DeleteUserSessionRecord(ctx context.Context, username, id string) (err error)
+}
+
+// NotificationQueueProvider is an interface providing storage capabilities for persisting queued notifications.
+type NotificationQueueProvider interface {
+	SaveNotificationQueueEntry(ctx context.Context, entry model.NotificationQueueEntry) (err error)
+	LoadNotificationQueueEntriesPending(ctx context.Context, now time.Time, limit int) (entries []model.NotificationQueueEntry, err error)
+	ClaimNotificationQueueEntry(ctx context.Context, id, attempts int, nextAttemptAt time.Time) (claimed bool, err error)
+	UpdateNotificationQueueEntryError(ctx context.Context, id int, deadLettered bool, lastError string) (err error)
+	DeleteNotificationQueueEntry(ctx context.Context, id int) (err error)
 }
@@ -34,6 +34,16 @@ func NewSQLProvider(config *schema.Configuration, name, driverName, dataSourceNa
 		sqlInsertAuthenticationAttempt:            fmt.Sprintf(queryFmtInsertAuthenticationLogEntry, tableAuthenticationLogs),
 		sqlSelectAuthenticationAttemptsByUsername: fmt.Sprintf(queryFmtSelect1FAAuthenticationLogEntryByUsername, tableAuthenticationLogs),
 
+		sqlSelectAuthenticationAttemptsByUsernameAndRemoteNetwork: fmt.Sprintf(queryFmtSelect1FAAuthenticationLogEntryByUsernameAndRemoteNetwork, tableAuthenticationLogs),
+		sqlSelectAuthenticationAttemptsByUsernameAndType:          fmt.Sprintf(queryFmtSelectAuthenticationLogEntryByUsernameAndType, tableAuthenticationLogs),
+		sqlSelectFailedAuthenticationAttemptsByRemoteNetwork:      fmt.Sprintf(queryFmtSelect1FAFailedAuthenticationLogEntryByRemoteNetwork, tableAuthenticationLogs),
+		sqlSelectFailedAuthenticationAttemptSubjects:              fmt.Sprintf(queryFmtSelect1FAFailedAuthenticationLogEntrySubjects, tableAuthenticationLogs),
+		sqlSelectFailedAuthenticationAttemptsByUsername:           fmt.Sprintf(queryFmtSelectFailedAuthenticationLogEntryByUsername, tableAuthenticationLogs),
+
+		sqlInsertRegulationUnban:                      fmt.Sprintf(queryFmtInsertRegulationUnban, tableRegulationUnbans),
+		sqlSelectLatestRegulationUnbanByUsername:      fmt.Sprintf(queryFmtSelectLatestRegulationUnbanByUsername, tableRegulationUnbans),
+		sqlSelectLatestRegulationUnbanByRemoteNetwork: fmt.Sprintf(queryFmtSelectLatestRegulationUnbanByRemoteNetwork, tableRegulationUnbans),
+
 		sqlInsertIdentityVerification:  fmt.Sprintf(queryFmtInsertIdentityVerification, tableIdentityVerification),
 		sqlConsumeIdentityVerification: fmt.Sprintf(queryFmtConsumeIdentityVerification, tableIdentityVerification),
 		sqlSelectIdentityVerification:  fmt.Sprintf(queryFmtSelectIdentityVerification, tableIdentityVerification),
@@ -87,40 +97,45 @@ func NewSQLProvider(config *schema.Configuration, name, driverName, dataSourceNa
 		sqlUpdateOAuth2ConsentSessionGranted:       fmt.Sprintf(queryFmtUpdateOAuth2ConsentSessionGranted, tableOAuth2ConsentSession),
 		sqlSelectOAuth2ConsentSessionByChallengeID: fmt.Sprintf(queryFmtSelectOAuth2ConsentSessionByChallengeID, tableOAuth2ConsentSession),
 
-		sqlInsertOAuth2AccessTokenSession:                fmt.Sprintf(queryFmtInsertOAuth2Session, tableOAuth2AccessTokenSession),
-		sqlSelectOAuth2AccessTokenSession:                fmt.Sprintf(queryFmtSelectOAuth2Session, tableOAuth2AccessTokenSession),
-		sqlRevokeOAuth2AccessTokenSession:                fmt.Sprintf(queryFmtRevokeOAuth2Session, tableOAuth2AccessTokenSession),
-		sqlRevokeOAuth2AccessTokenSessionByRequestID:     fmt.Sprintf(queryFmtRevokeOAuth2SessionByRequestID, tableOAuth2AccessTokenSession),
-		sqlDeactivateOAuth2AccessTokenSession:            fmt.Sprintf(queryFmtDeactivateOAuth2Session, tableOAuth2AccessTokenSession),
-		sqlDeactivateOAuth2AccessTokenSessionByRequestID: fmt.Sprintf(queryFmtDeactivateOAuth2SessionByRequestID, tableOAuth2AccessTokenSession),
-
-		sqlInsertOAuth2AuthorizeCodeSession:                fmt.Sprintf(queryFmtInsertOAuth2Session, tableOAuth2AuthorizeCodeSession),
-		sqlSelectOAuth2AuthorizeCodeSession:                fmt.Sprintf(queryFmtSelectOAuth2Session, tableOAuth2AuthorizeCodeSession),
-		sqlRevokeOAuth2AuthorizeCodeSession:                fmt.Sprintf(queryFmtRevokeOAuth2Session, tableOAuth2AuthorizeCodeSession),
-		sqlRevokeOAuth2AuthorizeCodeSessionByRequestID:     fmt.Sprintf(queryFmtRevokeOAuth2SessionByRequestID, tableOAuth2AuthorizeCodeSession),
-		sqlDeactivateOAuth2AuthorizeCodeSession:            fmt.Sprintf(queryFmtDeactivateOAuth2Session, tableOAuth2AuthorizeCodeSession),
-		sqlDeactivateOAuth2AuthorizeCodeSessionByRequestID: fmt.Sprintf(queryFmtDeactivateOAuth2SessionByRequestID, tableOAuth2AuthorizeCodeSession),
-
-		sqlInsertOAuth2OpenIDConnectSession:                fmt.Sprintf(queryFmtInsertOAuth2Session, tableOAuth2OpenIDConnectSession),
-		sqlSelectOAuth2OpenIDConnectSession:                fmt.Sprintf(queryFmtSelectOAuth2Session, tableOAuth2OpenIDConnectSession),
-		sqlRevokeOAuth2OpenIDConnectSession:                fmt.Sprintf(queryFmtRevokeOAuth2Session, tableOAuth2OpenIDConnectSession),
-		sqlRevokeOAuth2OpenIDConnectSessionByRequestID:     fmt.Sprintf(queryFmtRevokeOAuth2SessionByRequestID, tableOAuth2OpenIDConnectSession),
-		sqlDeactivateOAuth2OpenIDConnectSession:            fmt.Sprintf(queryFmtDeactivateOAuth2Session, tableOAuth2OpenIDConnectSession),
-		sqlDeactivateOAuth2OpenIDConnectSessionByRequestID: fmt.Sprintf(queryFmtDeactivateOAuth2SessionByRequestID, tableOAuth2OpenIDConnectSession),
-
-		sqlInsertOAuth2PKCERequestSession:                fmt.Sprintf(queryFmtInsertOAuth2Session, tableOAuth2PKCERequestSession),
-		sqlSelectOAuth2PKCERequestSession:                fmt.Sprintf(queryFmtSelectOAuth2Session, tableOAuth2PKCERequestSession),
-		sqlRevokeOAuth2PKCERequestSession:                fmt.Sprintf(queryFmtRevokeOAuth2Session, tableOAuth2PKCERequestSession),
-		sqlRevokeOAuth2PKCERequestSessionByRequestID:     fmt.Sprintf(queryFmtRevokeOAuth2SessionByRequestID, tableOAuth2PKCERequestSession),
-		sqlDeactivateOAuth2PKCERequestSession:            fmt.Sprintf(queryFmtDeactivateOAuth2Session, tableOAuth2PKCERequestSession),
-		sqlDeactivateOAuth2PKCERequestSessionByRequestID: fmt.Sprintf(queryFmtDeactivateOAuth2SessionByRequestID, tableOAuth2PKCERequestSession),
-
-		sqlInsertOAuth2RefreshTokenSession:                fmt.Sprintf(queryFmtInsertOAuth2Session, tableOAuth2RefreshTokenSession),
-		sqlSelectOAuth2RefreshTokenSession:                fmt.Sprintf(queryFmtSelectOAuth2Session, tableOAuth2RefreshTokenSession),
-		sqlRevokeOAuth2RefreshTokenSession:                fmt.Sprintf(queryFmtRevokeOAuth2Session, tableOAuth2RefreshTokenSession),
-		sqlRevokeOAuth2RefreshTokenSessionByRequestID:     fmt.Sprintf(queryFmtRevokeOAuth2SessionByRequestID, tableOAuth2RefreshTokenSession),
-		sqlDeactivateOAuth2RefreshTokenSession:            fmt.Sprintf(queryFmtDeactivateOAuth2Session, tableOAuth2RefreshTokenSession),
-		sqlDeactivateOAuth2RefreshTokenSessionByRequestID: fmt.Sprintf(queryFmtDeactivateOAuth2SessionByRequestID, tableOAuth2RefreshTokenSession),
+		sqlInsertOAuth2AccessTokenSession:                     fmt.Sprintf(queryFmtInsertOAuth2Session, tableOAuth2AccessTokenSession),
+		sqlSelectOAuth2AccessTokenSession:                     fmt.Sprintf(queryFmtSelectOAuth2Session, tableOAuth2AccessTokenSession),
+		sqlSelectOAuth2AccessTokenSessionSignaturesByUsername: fmt.Sprintf(queryFmtSelectOAuth2SessionSignaturesByUsername, tableOAuth2AccessTokenSession, tableUserOpaqueIdentifier),
+		sqlRevokeOAuth2AccessTokenSession:                     fmt.Sprintf(queryFmtRevokeOAuth2Session, tableOAuth2AccessTokenSession),
+		sqlRevokeOAuth2AccessTokenSessionByRequestID:          fmt.Sprintf(queryFmtRevokeOAuth2SessionByRequestID, tableOAuth2AccessTokenSession),
+		sqlDeactivateOAuth2AccessTokenSession:                 fmt.Sprintf(queryFmtDeactivateOAuth2Session, tableOAuth2AccessTokenSession),
+		sqlDeactivateOAuth2AccessTokenSessionByRequestID:      fmt.Sprintf(queryFmtDeactivateOAuth2SessionByRequestID, tableOAuth2AccessTokenSession),
+
+		sqlInsertOAuth2AuthorizeCodeSession:                     fmt.Sprintf(queryFmtInsertOAuth2Session, tableOAuth2AuthorizeCodeSession),
+		sqlSelectOAuth2AuthorizeCodeSession:                     fmt.Sprintf(queryFmtSelectOAuth2Session, tableOAuth2AuthorizeCodeSession),
+		sqlSelectOAuth2AuthorizeCodeSessionSignaturesByUsername: fmt.Sprintf(queryFmtSelectOAuth2SessionSignaturesByUsername, tableOAuth2AuthorizeCodeSession, tableUserOpaqueIdentifier),
+		sqlRevokeOAuth2AuthorizeCodeSession:                     fmt.Sprintf(queryFmtRevokeOAuth2Session, tableOAuth2AuthorizeCodeSession),
+		sqlRevokeOAuth2AuthorizeCodeSessionByRequestID:          fmt.Sprintf(queryFmtRevokeOAuth2SessionByRequestID, tableOAuth2AuthorizeCodeSession),
+		sqlDeactivateOAuth2AuthorizeCodeSession:                 fmt.Sprintf(queryFmtDeactivateOAuth2Session, tableOAuth2AuthorizeCodeSession),
+		sqlDeactivateOAuth2AuthorizeCodeSessionByRequestID:      fmt.Sprintf(queryFmtDeactivateOAuth2SessionByRequestID, tableOAuth2AuthorizeCodeSession),
+
+		sqlInsertOAuth2OpenIDConnectSession:                     fmt.Sprintf(queryFmtInsertOAuth2Session, tableOAuth2OpenIDConnectSession),
+		sqlSelectOAuth2OpenIDConnectSession:                     fmt.Sprintf(queryFmtSelectOAuth2Session, tableOAuth2OpenIDConnectSession),
+		sqlSelectOAuth2OpenIDConnectSessionSignaturesByUsername: fmt.Sprintf(queryFmtSelectOAuth2SessionSignaturesByUsername, tableOAuth2OpenIDConnectSession, tableUserOpaqueIdentifier),
+		sqlRevokeOAuth2OpenIDConnectSession:                     fmt.Sprintf(queryFmtRevokeOAuth2Session, tableOAuth2OpenIDConnectSession),
+		sqlRevokeOAuth2OpenIDConnectSessionByRequestID:          fmt.Sprintf(queryFmtRevokeOAuth2SessionByRequestID, tableOAuth2OpenIDConnectSession),
+		sqlDeactivateOAuth2OpenIDConnectSession:                 fmt.Sprintf(queryFmtDeactivateOAuth2Session, tableOAuth2OpenIDConnectSession),
+		sqlDeactivateOAuth2OpenIDConnectSessionByRequestID:      fmt.Sprintf(queryFmtDeactivateOAuth2SessionByRequestID, tableOAuth2OpenIDConnectSession),
+
+		sqlInsertOAuth2PKCERequestSession:                     fmt.Sprintf(queryFmtInsertOAuth2Session, tableOAuth2PKCERequestSession),
+		sqlSelectOAuth2PKCERequestSession:                     fmt.Sprintf(queryFmtSelectOAuth2Session, tableOAuth2PKCERequestSession),
+		sqlSelectOAuth2PKCERequestSessionSignaturesByUsername: fmt.Sprintf(queryFmtSelectOAuth2SessionSignaturesByUsername, tableOAuth2PKCERequestSession, tableUserOpaqueIdentifier),
+		sqlRevokeOAuth2PKCERequestSession:                     fmt.Sprintf(queryFmtRevokeOAuth2Session, tableOAuth2PKCERequestSession),
+		sqlRevokeOAuth2PKCERequestSessionByRequestID:          fmt.Sprintf(queryFmtRevokeOAuth2SessionByRequestID, tableOAuth2PKCERequestSession),
+		sqlDeactivateOAuth2PKCERequestSession:                 fmt.Sprintf(queryFmtDeactivateOAuth2Session, tableOAuth2PKCERequestSession),
+		sqlDeactivateOAuth2PKCERequestSessionByRequestID:      fmt.Sprintf(queryFmtDeactivateOAuth2SessionByRequestID, tableOAuth2PKCERequestSession),
+
+		sqlInsertOAuth2RefreshTokenSession:                     fmt.Sprintf(queryFmtInsertOAuth2Session, tableOAuth2RefreshTokenSession),
+		sqlSelectOAuth2RefreshTokenSession:                     fmt.Sprintf(queryFmtSelectOAuth2Session, tableOAuth2RefreshTokenSession),
+		sqlSelectOAuth2RefreshTokenSessionSignaturesByUsername: fmt.Sprintf(queryFmtSelectOAuth2SessionSignaturesByUsername, tableOAuth2RefreshTokenSession, tableUserOpaqueIdentifier),
+		sqlRevokeOAuth2RefreshTokenSession:                     fmt.Sprintf(queryFmtRevokeOAuth2Session, tableOAuth2RefreshTokenSession),
+		sqlRevokeOAuth2RefreshTokenSessionByRequestID:          fmt.Sprintf(queryFmtRevokeOAuth2SessionByRequestID, tableOAuth2RefreshTokenSession),
+		sqlDeactivateOAuth2RefreshTokenSession:                 fmt.Sprintf(queryFmtDeactivateOAuth2Session, tableOAuth2RefreshTokenSession),
+		sqlDeactivateOAuth2RefreshTokenSessionByRequestID:      fmt.Sprintf(queryFmtDeactivateOAuth2SessionByRequestID, tableOAuth2RefreshTokenSession),
 
 		sqlInsertMigration:       fmt.Sprintf(queryFmtInsertMigration, tableMigrations),
 		sqlSelectMigrations:      fmt.Sprintf(queryFmtSelectMigrations, tableMigrations),
@@ -129,6 +144,25 @@ func NewSQLProvider(config *schema.Configuration, name, driverName, dataSourceNa
 		sqlUpsertEncryptionValue: fmt.Sprintf(queryFmtUpsertEncryptionValue, tableEncryption),
 		sqlSelectEncryptionValue: fmt.Sprintf(queryFmtSelectEncryptionValue, tableEncryption),
 
+		sqlUpsertSessionData:        fmt.Sprintf(queryFmtUpsertSessionData, tableSessions),
+		sqlSelectSessionData:        fmt.Sprintf(queryFmtSelectSessionData, tableSessions),
+		sqlUpdateSessionDataID:      fmt.Sprintf(queryFmtUpdateSessionDataID, tableSessions),
+		sqlDeleteSessionData:        fmt.Sprintf(queryFmtDeleteSessionData, tableSessions),
+		sqlCountSessionData:         fmt.Sprintf(queryFmtCountSessionData, tableSessions),
+		sqlDeleteExpiredSessionData: fmt.Sprintf(queryFmtDeleteExpiredSessionData, tableSessions),
+
+		sqlSelectUserSessionRecord:         fmt.Sprintf(queryFmtSelectUserSessionRecord, tableUserSessions),
+		sqlSelectUserSessionRecords:        fmt.Sprintf(queryFmtSelectUserSessionRecords, tableUserSessions),
+		sqlUpsertUserSessionRecord:         fmt.Sprintf(queryFmtUpsertUserSessionRecord, tableUserSessions),
+		sqlDeleteUserSessionRecord:         fmt.Sprintf(queryFmtDeleteUserSessionRecord, tableUserSessions),
+		sqlDeleteExpiredUserSessionRecords: fmt.Sprintf(queryFmtDeleteExpiredUserSessionRecords, tableUserSessions),
+
+		sqlInsertNotificationQueueEntry:          fmt.Sprintf(queryFmtInsertNotificationQueueEntry, tableNotificationQueue),
+		sqlSelectPendingNotificationQueueEntries: fmt.Sprintf(queryFmtSelectPendingNotificationQueueEntries, tableNotificationQueue),
+		sqlUpdateNotificationQueueEntryClaim:     fmt.Sprintf(queryFmtUpdateNotificationQueueEntryClaim, tableNotificationQueue),
+		sqlUpdateNotificationQueueEntryError:     fmt.Sprintf(queryFmtUpdateNotificationQueueEntryError, tableNotificationQueue),
+		sqlDeleteNotificationQueueEntry:          fmt.Sprintf(queryFmtDeleteNotificationQueueEntry, tableNotificationQueue),
+
 		sqlFmtRenameTable: queryFmtRenameTable,
 	}
 
@@ -148,8 +182,18 @@ type SQLProvider struct {
 	log *logrus.Logger
 
 	// Table: authentication_logs.
-	sqlInsertAuthenticationAttempt            string
-	sqlSelectAuthenticationAttemptsByUsername string
+	sqlInsertAuthenticationAttempt                            string
+	sqlSelectAuthenticationAttemptsByUsername                 string
+	sqlSelectAuthenticationAttemptsByUsernameAndRemoteNetwork string
+	sqlSelectAuthenticationAttemptsByUsernameAndType          string
+	sqlSelectFailedAuthenticationAttemptsByRemoteNetwork      string
+	sqlSelectFailedAuthenticationAttemptSubjects              string
+	sqlSelectFailedAuthenticationAttemptsByUsername           string
+
+	// Table: regulation_unbans.
+	sqlInsertRegulationUnban                      string
+	sqlSelectLatestRegulationUnbanByUsername      string
+	sqlSelectLatestRegulationUnbanByRemoteNetwork string
 
 	// Table: identity_verification.
 	sqlInsertIdentityVerification  string
@@ -202,6 +246,28 @@ type SQLProvider struct {
 	sqlUpsertEncryptionValue string
 	sqlSelectEncryptionValue string
 
+	// Table: sessions.
+	sqlUpsertSessionData        string
+	sqlSelectSessionData        string
+	sqlUpdateSessionDataID      string
+	sqlDeleteSessionData        string
+	sqlCountSessionData         string
+	sqlDeleteExpiredSessionData string
+
+	// Table: user_sessions.
+	sqlSelectUserSessionRecord         string
+	sqlSelectUserSessionRecords        string
+	sqlUpsertUserSessionRecord         string
+	sqlDeleteUserSessionRecord         string
+	sqlDeleteExpiredUserSessionRecords string
+
+	// Table: notification_queue.
+	sqlInsertNotificationQueueEntry          string
+	sqlSelectPendingNotificationQueueEntries string
+	sqlUpdateNotificationQueueEntryClaim     string
+	sqlUpdateNotificationQueueEntryError     string
+	sqlDeleteNotificationQueueEntry          string
+
 	// Table: oauth2_consent_preconfiguration.
 	sqlInsertOAuth2ConsentPreConfiguration  string
 	sqlSelectOAuth2ConsentPreConfigurations string
@@ -214,28 +280,31 @@ type SQLProvider struct {
 	sqlSelectOAuth2ConsentSessionByChallengeID string
 
 	// Table: oauth2_authorization_code_session.
-	sqlInsertOAuth2AuthorizeCodeSession                string
-	sqlSelectOAuth2AuthorizeCodeSession                string
-	sqlRevokeOAuth2AuthorizeCodeSession                string
-	sqlRevokeOAuth2AuthorizeCodeSessionByRequestID     string
-	sqlDeactivateOAuth2AuthorizeCodeSession            string
-	sqlDeactivateOAuth2AuthorizeCodeSessionByRequestID string
+	sqlInsertOAuth2AuthorizeCodeSession                     string
+	sqlSelectOAuth2AuthorizeCodeSession                     string
+	sqlSelectOAuth2AuthorizeCodeSessionSignaturesByUsername string
+	sqlRevokeOAuth2AuthorizeCodeSession                     string
+	sqlRevokeOAuth2AuthorizeCodeSessionByRequestID          string
+	sqlDeactivateOAuth2AuthorizeCodeSession                 string
+	sqlDeactivateOAuth2AuthorizeCodeSessionByRequestID      string
 
 	// Table: oauth2_access_token_session.
-	sqlInsertOAuth2AccessTokenSession                string
-	sqlSelectOAuth2AccessTokenSession                string
-	sqlRevokeOAuth2AccessTokenSession                string
-	sqlRevokeOAuth2AccessTokenSessionByRequestID     string
-	sqlDeactivateOAuth2AccessTokenSession            string
-	sqlDeactivateOAuth2AccessTokenSessionByRequestID string
+	sqlInsertOAuth2AccessTokenSession                     string
+	sqlSelectOAuth2AccessTokenSession                     string
+	sqlSelectOAuth2AccessTokenSessionSignaturesByUsername string
+	sqlRevokeOAuth2AccessTokenSession                     string
+	sqlRevokeOAuth2AccessTokenSessionByRequestID          string
+	sqlDeactivateOAuth2AccessTokenSession                 string
+	sqlDeactivateOAuth2AccessTokenSessionByRequestID      string
 
 	// Table: oauth2_openid_connect_session.
-	sqlInsertOAuth2OpenIDConnectSession                string
-	sqlSelectOAuth2OpenIDConnectSession                string
-	sqlRevokeOAuth2OpenIDConnectSession                string
-	sqlRevokeOAuth2OpenIDConnectSessionByRequestID     string
-	sqlDeactivateOAuth2OpenIDConnectSession            string
-	sqlDeactivateOAuth2OpenIDConnectSessionByRequestID string
+	sqlInsertOAuth2OpenIDConnectSession                     string
+	sqlSelectOAuth2OpenIDConnectSession                     string
+	sqlSelectOAuth2OpenIDConnectSessionSignaturesByUsername string
+	sqlRevokeOAuth2OpenIDConnectSession                     string
+	sqlRevokeOAuth2OpenIDConnectSessionByRequestID          string
+	sqlDeactivateOAuth2OpenIDConnectSession                 string
+	sqlDeactivateOAuth2OpenIDConnectSessionByRequestID      string
 
 	// Table: oauth2_par_context.
 	sqlInsertOAuth2PARContext string
@@ -244,20 +313,22 @@ type SQLProvider struct {
 	sqlRevokeOAuth2PARContext string
 
 	// Table: oauth2_pkce_request_session.
-	sqlInsertOAuth2PKCERequestSession                string
-	sqlSelectOAuth2PKCERequestSession                string
-	sqlRevokeOAuth2PKCERequestSession                string
-	sqlRevokeOAuth2PKCERequestSessionByRequestID     string
-	sqlDeactivateOAuth2PKCERequestSession            string
-	sqlDeactivateOAuth2PKCERequestSessionByRequestID string
+	sqlInsertOAuth2PKCERequestSession                     string
+	sqlSelectOAuth2PKCERequestSession                     string
+	sqlSelectOAuth2PKCERequestSessionSignaturesByUsername string
+	sqlRevokeOAuth2PKCERequestSession                     string
+	sqlRevokeOAuth2PKCERequestSessionByRequestID          string
+	sqlDeactivateOAuth2PKCERequestSession                 string
+	sqlDeactivateOAuth2PKCERequestSessionByRequestID      string
 
 	// Table: oauth2_refresh_token_session.
-	sqlInsertOAuth2RefreshTokenSession                string
-	sqlSelectOAuth2RefreshTokenSession                string
-	sqlRevokeOAuth2RefreshTokenSession                string
-	sqlRevokeOAuth2RefreshTokenSessionByRequestID     string
-	sqlDeactivateOAuth2RefreshTokenSession            string
-	sqlDeactivateOAuth2RefreshTokenSessionByRequestID string
+	sqlInsertOAuth2RefreshTokenSession                     string
+	sqlSelectOAuth2RefreshTokenSession                     string
+	sqlSelectOAuth2RefreshTokenSessionSignaturesByUsername string
+	sqlRevokeOAuth2RefreshTokenSession                     string
+	sqlRevokeOAuth2RefreshTokenSessionByRequestID          string
+	sqlDeactivateOAuth2RefreshTokenSession                 string
+	sqlDeactivateOAuth2RefreshTokenSessionByRequestID      string
 
 	sqlUpsertOAuth2BlacklistedJTI string
 	sqlSelectOAuth2BlacklistedJTI string
@@ -674,6 +745,33 @@ func (p *SQLProvider) LoadOAuth2Session(ctx context.Context, sessionType OAuth2S
 	return session, nil
 }
 
+// LoadOAuth2SessionSignaturesByUsername loads the signatures of all OAuth2Session's which have not been revoked for
+// a username from the database.
+func (p *SQLProvider) LoadOAuth2SessionSignaturesByUsername(ctx context.Context, sessionType OAuth2SessionType, username string) (signatures []string, err error) {
+	var query string
+
+	switch sessionType {
+	case OAuth2SessionTypeAccessToken:
+		query = p.sqlSelectOAuth2AccessTokenSessionSignaturesByUsername
+	case OAuth2SessionTypeAuthorizeCode:
+		query = p.sqlSelectOAuth2AuthorizeCodeSessionSignaturesByUsername
+	case OAuth2SessionTypeOpenIDConnect:
+		query = p.sqlSelectOAuth2OpenIDConnectSessionSignaturesByUsername
+	case OAuth2SessionTypePKCEChallenge:
+		query = p.sqlSelectOAuth2PKCERequestSessionSignaturesByUsername
+	case OAuth2SessionTypeRefreshToken:
+		query = p.sqlSelectOAuth2RefreshTokenSessionSignaturesByUsername
+	default:
+		return nil, fmt.Errorf("error selecting oauth2 session signatures for user '%s': unknown oauth2 session type '%s'", username, sessionType.String())
+	}
+
+	if err = p.db.SelectContext(ctx, &signatures, query, username); err != nil {
+		return nil, fmt.Errorf("error selecting oauth2 %s session signatures for user '%s': %w", sessionType.String(), username, err)
+	}
+
+	return signatures, nil
+}
+
 // SaveOAuth2PARContext save a OAuth2PARContext to the database.
 func (p *SQLProvider) SaveOAuth2PARContext(ctx context.Context, par model.OAuth2PARContext) (err error) {
 	if par.Session, err = p.encrypt(par.Session); err != nil {
@@ -1034,7 +1132,7 @@ func (p *SQLProvider) LoadPreferredDuoDevice(ctx context.Context, username strin
 func (p *SQLProvider) AppendAuthenticationLog(ctx context.Context, attempt model.AuthenticationAttempt) (err error) {
 	if _, err = p.db.ExecContext(ctx, p.sqlInsertAuthenticationAttempt,
 		attempt.Time, attempt.Successful, attempt.Banned, attempt.Username,
-		attempt.Type, attempt.RemoteIP, attempt.RequestURI, attempt.RequestMethod); err != nil {
+		attempt.Type, attempt.RemoteIP, attempt.RemoteNetwork, attempt.Country, attempt.RequestURI, attempt.RequestMethod); err != nil {
 		return fmt.Errorf("error inserting authentication attempt for user '%s': %w", attempt.Username, err)
 	}
 
@@ -1055,3 +1153,301 @@ func (p *SQLProvider) LoadAuthenticationLogs(ctx context.Context, username strin
 
 	return attempts, nil
 }
+
+// LoadAuthenticationLogsByUsernameAndRemoteNetwork retrieve the latest authentications of a user from a remote network
+// from the authentication log.
+func (p *SQLProvider) LoadAuthenticationLogsByUsernameAndRemoteNetwork(ctx context.Context, username, network string, fromDate time.Time, limit, page int) (attempts []model.AuthenticationAttempt, err error) {
+	attempts = make([]model.AuthenticationAttempt, 0, limit)
+
+	if err = p.db.SelectContext(ctx, &attempts, p.sqlSelectAuthenticationAttemptsByUsernameAndRemoteNetwork, fromDate, username, network, limit, limit*page); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoAuthenticationLogs
+		}
+
+		return nil, fmt.Errorf("error selecting authentication logs for user '%s' from remote network '%s': %w", username, network, err)
+	}
+
+	return attempts, nil
+}
+
+// LoadAuthenticationLogsByUsernameAndType retrieve the latest authentications of a user of a given type from the
+// authentication log.
+func (p *SQLProvider) LoadAuthenticationLogsByUsernameAndType(ctx context.Context, username, authType string, fromDate time.Time, limit, page int) (attempts []model.AuthenticationAttempt, err error) {
+	attempts = make([]model.AuthenticationAttempt, 0, limit)
+
+	if err = p.db.SelectContext(ctx, &attempts, p.sqlSelectAuthenticationAttemptsByUsernameAndType, fromDate, username, authType, limit, limit*page); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoAuthenticationLogs
+		}
+
+		return nil, fmt.Errorf("error selecting %s authentication logs for user '%s': %w", authType, username, err)
+	}
+
+	return attempts, nil
+}
+
+// LoadFailedAuthenticationLogsByRemoteNetwork retrieve the latest failed authentications of any user from a remote
+// network from the authentication log.
+func (p *SQLProvider) LoadFailedAuthenticationLogsByRemoteNetwork(ctx context.Context, network string, fromDate time.Time, limit, page int) (attempts []model.AuthenticationAttempt, err error) {
+	attempts = make([]model.AuthenticationAttempt, 0, limit)
+
+	if err = p.db.SelectContext(ctx, &attempts, p.sqlSelectFailedAuthenticationAttemptsByRemoteNetwork, fromDate, network, limit, limit*page); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoAuthenticationLogs
+		}
+
+		return nil, fmt.Errorf("error selecting failed authentication logs from remote network '%s': %w", network, err)
+	}
+
+	return attempts, nil
+}
+
+// LoadFailedAuthenticationLogSubjects retrieve the distinct usernames and remote networks of the failed authentications
+// from the authentication log.
+func (p *SQLProvider) LoadFailedAuthenticationLogSubjects(ctx context.Context, fromDate time.Time) (subjects []model.AuthenticationAttempt, err error) {
+	if err = p.db.SelectContext(ctx, &subjects, p.sqlSelectFailedAuthenticationAttemptSubjects, fromDate); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("error selecting failed authentication log subjects: %w", err)
+	}
+
+	return subjects, nil
+}
+
+// LoadFailedAuthenticationLogsByUsername retrieve the latest failed authentications of any type of a user including
+// the attempts made while banned from the authentication log.
+func (p *SQLProvider) LoadFailedAuthenticationLogsByUsername(ctx context.Context, username string, limit, page int) (attempts []model.AuthenticationAttempt, err error) {
+	attempts = make([]model.AuthenticationAttempt, 0, limit)
+
+	if err = p.db.SelectContext(ctx, &attempts, p.sqlSelectFailedAuthenticationAttemptsByUsername, username, limit, limit*page); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNoAuthenticationLogs
+		}
+
+		return nil, fmt.Errorf("error selecting failed authentication logs for user '%s': %w", username, err)
+	}
+
+	return attempts, nil
+}
+
+// SaveRegulationUnban saves a regulation unban of a user or remote network.
+func (p *SQLProvider) SaveRegulationUnban(ctx context.Context, unban model.RegulationUnban) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlInsertRegulationUnban, unban.Time, unban.Username, unban.RemoteNetwork); err != nil {
+		return fmt.Errorf("error inserting regulation unban: %w", err)
+	}
+
+	return nil
+}
+
+// LoadRegulationUnbanTimeByUsername loads the time of the latest regulation unban of a user, returning the zero time
+// if the user was never unbanned.
+func (p *SQLProvider) LoadRegulationUnbanTimeByUsername(ctx context.Context, username string) (unbanned time.Time, err error) {
+	if err = p.db.GetContext(ctx, &unbanned, p.sqlSelectLatestRegulationUnbanByUsername, username); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return time.Time{}, nil
+		}
+
+		return time.Time{}, fmt.Errorf("error selecting regulation unban for user '%s': %w", username, err)
+	}
+
+	return unbanned, nil
+}
+
+// LoadRegulationUnbanTimeByRemoteNetwork loads the time of the latest regulation unban of a remote network, returning
+// the zero time if the remote network was never unbanned.
+func (p *SQLProvider) LoadRegulationUnbanTimeByRemoteNetwork(ctx context.Context, network string) (unbanned time.Time, err error) {
+	if err = p.db.GetContext(ctx, &unbanned, p.sqlSelectLatestRegulationUnbanByRemoteNetwork, network); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return time.Time{}, nil
+		}
+
+		return time.Time{}, fmt.Errorf("error selecting regulation unban for remote network '%s': %w", network, err)
+	}
+
+	return unbanned, nil
+}
+
+// SaveSessionData saves the encoded session data for the given session id to the database.
+func (p *SQLProvider) SaveSessionData(ctx context.Context, id string, data []byte, expiresAt sql.NullTime) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlUpsertSessionData, id, expiresAt, data); err != nil {
+		return fmt.Errorf("error upserting session data: %w", err)
+	}
+
+	return nil
+}
+
+// LoadSessionData loads the encoded session data for the given session id from the database, returning nil if the
+// session does not exist or has expired.
+func (p *SQLProvider) LoadSessionData(ctx context.Context, id string, now time.Time) (data []byte, err error) {
+	if err = p.db.GetContext(ctx, &data, p.sqlSelectSessionData, id, now); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("error selecting session data: %w", err)
+	}
+
+	return data, nil
+}
+
+// RegenerateSessionData moves the session data of the given session id to a new session id in the database.
+func (p *SQLProvider) RegenerateSessionData(ctx context.Context, id, newID string, expiresAt sql.NullTime) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlUpdateSessionDataID, newID, expiresAt, id); err != nil {
+		return fmt.Errorf("error updating session data id: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteSessionData deletes the session data for the given session id from the database.
+func (p *SQLProvider) DeleteSessionData(ctx context.Context, id string) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlDeleteSessionData, id); err != nil {
+		return fmt.Errorf("error deleting session data: %w", err)
+	}
+
+	return nil
+}
+
+// CountSessionData returns the number of sessions in the database which have not expired.
+func (p *SQLProvider) CountSessionData(ctx context.Context, now time.Time) (count int, err error) {
+	if err = p.db.GetContext(ctx, &count, p.sqlCountSessionData, now); err != nil {
+		return 0, fmt.Errorf("error counting session data: %w", err)
+	}
+
+	return count, nil
+}
+
+// PurgeExpiredSessionData deletes all expired session data from the database.
+func (p *SQLProvider) PurgeExpiredSessionData(ctx context.Context, now time.Time) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlDeleteExpiredSessionData, now); err != nil {
+		return fmt.Errorf("error deleting expired session data: %w", err)
+	}
+
+	if _, err = p.db.ExecContext(ctx, p.sqlDeleteExpiredUserSessionRecords, now); err != nil {
+		return fmt.Errorf("error deleting expired user session records: %w", err)
+	}
+
+	return nil
+}
+
+// SaveUserSessionRecord saves the encoded record of a session in the index of the sessions of a user to the database.
+func (p *SQLProvider) SaveUserSessionRecord(ctx context.Context, username, id string, data []byte, expiresAt time.Time) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlUpsertUserSessionRecord, username, id, expiresAt, data); err != nil {
+		return fmt.Errorf("error upserting user session record with id '%s' for user '%s': %w", id, username, err)
+	}
+
+	return nil
+}
+
+// LoadUserSessionRecord loads the encoded record of a session in the index of the sessions of a user from the
+// database, returning nil if the record does not exist or has expired.
+func (p *SQLProvider) LoadUserSessionRecord(ctx context.Context, username, id string, now time.Time) (data []byte, err error) {
+	if err = p.db.GetContext(ctx, &data, p.sqlSelectUserSessionRecord, username, id, now); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("error selecting user session record with id '%s' for user '%s': %w", id, username, err)
+	}
+
+	return data, nil
+}
+
+// LoadUserSessionRecords loads the encoded records of the sessions of a user which have not expired from the database.
+func (p *SQLProvider) LoadUserSessionRecords(ctx context.Context, username string, now time.Time) (data [][]byte, err error) {
+	if err = p.db.SelectContext(ctx, &data, p.sqlSelectUserSessionRecords, username, now); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("error selecting user session records for user '%s': %w", username, err)
+	}
+
+	return data, nil
+}
+
+// DeleteUserSessionRecord deletes the record of a session in the index of the sessions of a user from the database.
+func (p *SQLProvider) DeleteUserSessionRecord(ctx context.Context, username, id string) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlDeleteUserSessionRecord, username, id); err != nil {
+		return fmt.Errorf("error deleting user session record with id '%s' for user '%s': %w", id, username, err)
+	}
+
+	return nil
+}
+
+// SaveNotificationQueueEntry saves a notification to the queue after encrypting the template data.
+func (p *SQLProvider) SaveNotificationQueueEntry(ctx context.Context, entry model.NotificationQueueEntry) (err error) {
+	if entry.Data, err = p.encrypt(entry.Data); err != nil {
+		return fmt.Errorf("error encrypting notification queue entry data for recipient '%s': %w", entry.RecipientAddress, err)
+	}
+
+	if _, err = p.db.ExecContext(ctx, p.sqlInsertNotificationQueueEntry,
+		entry.CreatedAt, entry.NextAttemptAt, entry.RecipientName, entry.RecipientAddress, entry.Subject, entry.Template, entry.Data); err != nil {
+		return fmt.Errorf("error inserting notification queue entry for recipient '%s': %w", entry.RecipientAddress, err)
+	}
+
+	return nil
+}
+
+// LoadNotificationQueueEntriesPending loads the notifications from the queue which are not dead lettered and are due
+// to be attempted at the given time, decrypting the template data.
+func (p *SQLProvider) LoadNotificationQueueEntriesPending(ctx context.Context, now time.Time, limit int) (entries []model.NotificationQueueEntry, err error) {
+	entries = make([]model.NotificationQueueEntry, 0, limit)
+
+	if err = p.db.SelectContext(ctx, &entries, p.sqlSelectPendingNotificationQueueEntries, now, limit); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("error selecting pending notification queue entries: %w", err)
+	}
+
+	for i, entry := range entries {
+		if entries[i].Data, err = p.decrypt(entry.Data); err != nil {
+			return nil, fmt.Errorf("error decrypting notification queue entry data with id '%d': %w", entry.ID, err)
+		}
+	}
+
+	return entries, nil
+}
+
+// ClaimNotificationQueueEntry increments the attempts of a notification in the queue and sets the time of the next
+// attempt, provided the attempts still match the given value. The claimed value is false if another worker claimed
+// the notification first.
+func (p *SQLProvider) ClaimNotificationQueueEntry(ctx context.Context, id, attempts int, nextAttemptAt time.Time) (claimed bool, err error) {
+	var (
+		result   sql.Result
+		affected int64
+	)
+
+	if result, err = p.db.ExecContext(ctx, p.sqlUpdateNotificationQueueEntryClaim, nextAttemptAt, id, attempts); err != nil {
+		return false, fmt.Errorf("error updating notification queue entry with id '%d': %w", id, err)
+	}
+
+	if affected, err = result.RowsAffected(); err != nil {
+		return false, fmt.Errorf("error updating notification queue entry with id '%d': %w", id, err)
+	}
+
+	return affected == 1, nil
+}
+
+// UpdateNotificationQueueEntryError records the last error of a notification in the queue and optionally marks it as
+// dead lettered so no further attempts are made.
+func (p *SQLProvider) UpdateNotificationQueueEntryError(ctx context.Context, id int, deadLettered bool, lastError string) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlUpdateNotificationQueueEntryError, deadLettered, lastError, id); err != nil {
+		return fmt.Errorf("error updating notification queue entry with id '%d': %w", id, err)
+	}
+
+	return nil
+}
+
+// DeleteNotificationQueueEntry deletes a notification from the queue.
+func (p *SQLProvider) DeleteNotificationQueueEntry(ctx context.Context, id int) (err error) {
+	if _, err = p.db.ExecContext(ctx, p.sqlDeleteNotificationQueueEntry, id); err != nil {
+		return fmt.Errorf("error deleting notification queue entry with id '%d': %w", id, err)
+	}
+
+	return nil
+}
@@ -36,6 +36,8 @@ func NewPostgreSQLProvider(config *schema.Configuration, caCertPool *x509.CertPo
 	provider.sqlUpsertPreferred2FAMethod = fmt.Sprintf(queryFmtUpsertPreferred2FAMethodPostgreSQL, tableUserPreferences)
 	provider.sqlUpsertEncryptionValue = fmt.Sprintf(queryFmtUpsertEncryptionValuePostgreSQL, tableEncryption)
 	provider.sqlUpsertOAuth2BlacklistedJTI = fmt.Sprintf(queryFmtUpsertOAuth2BlacklistedJTIPostgreSQL, tableOAuth2BlacklistedJTI)
+	provider.sqlUpsertSessionData = fmt.Sprintf(queryFmtUpsertSessionDataPostgreSQL, tableSessions)
+	provider.sqlUpsertUserSessionRecord = fmt.Sprintf(queryFmtUpsertUserSessionRecordPostgreSQL, tableUserSessions)
 	provider.sqlInsertOAuth2ConsentPreConfiguration = fmt.Sprintf(queryFmtInsertOAuth2ConsentPreConfigurationPostgreSQL, tableOAuth2ConsentPreConfiguration)
 
 	// PostgreSQL requires rebinding of any query that contains a '?' placeholder to use the '$#' notation placeholders.
@@ -71,6 +73,15 @@ func NewPostgreSQLProvider(config *schema.Configuration, caCertPool *x509.CertPo
 
 	provider.sqlInsertAuthenticationAttempt = provider.db.Rebind(provider.sqlInsertAuthenticationAttempt)
 	provider.sqlSelectAuthenticationAttemptsByUsername = provider.db.Rebind(provider.sqlSelectAuthenticationAttemptsByUsername)
+	provider.sqlSelectAuthenticationAttemptsByUsernameAndRemoteNetwork = provider.db.Rebind(provider.sqlSelectAuthenticationAttemptsByUsernameAndRemoteNetwork)
+	provider.sqlSelectAuthenticationAttemptsByUsernameAndType = provider.db.Rebind(provider.sqlSelectAuthenticationAttemptsByUsernameAndType)
+	provider.sqlSelectFailedAuthenticationAttemptsByRemoteNetwork = provider.db.Rebind(provider.sqlSelectFailedAuthenticationAttemptsByRemoteNetwork)
+	provider.sqlSelectFailedAuthenticationAttemptSubjects = provider.db.Rebind(provider.sqlSelectFailedAuthenticationAttemptSubjects)
+	provider.sqlSelectFailedAuthenticationAttemptsByUsername = provider.db.Rebind(provider.sqlSelectFailedAuthenticationAttemptsByUsername)
+
+	provider.sqlInsertRegulationUnban = provider.db.Rebind(provider.sqlInsertRegulationUnban)
+	provider.sqlSelectLatestRegulationUnbanByUsername = provider.db.Rebind(provider.sqlSelectLatestRegulationUnbanByUsername)
+	provider.sqlSelectLatestRegulationUnbanByRemoteNetwork = provider.db.Rebind(provider.sqlSelectLatestRegulationUnbanByRemoteNetwork)
 
 	provider.sqlInsertMigration = provider.db.Rebind(provider.sqlInsertMigration)
 	provider.sqlSelectMigrations = provider.db.Rebind(provider.sqlSelectMigrations)
@@ -78,6 +89,22 @@ func NewPostgreSQLProvider(config *schema.Configuration, caCertPool *x509.CertPo
 
 	provider.sqlSelectEncryptionValue = provider.db.Rebind(provider.sqlSelectEncryptionValue)
 
+	provider.sqlSelectSessionData = provider.db.Rebind(provider.sqlSelectSessionData)
+	provider.sqlUpdateSessionDataID = provider.db.Rebind(provider.sqlUpdateSessionDataID)
+	provider.sqlDeleteSessionData = provider.db.Rebind(provider.sqlDeleteSessionData)
+	provider.sqlCountSessionData = provider.db.Rebind(provider.sqlCountSessionData)
+	provider.sqlDeleteExpiredSessionData = provider.db.Rebind(provider.sqlDeleteExpiredSessionData)
+	provider.sqlSelectUserSessionRecord = provider.db.Rebind(provider.sqlSelectUserSessionRecord)
+	provider.sqlSelectUserSessionRecords = provider.db.Rebind(provider.sqlSelectUserSessionRecords)
+	provider.sqlDeleteUserSessionRecord = provider.db.Rebind(provider.sqlDeleteUserSessionRecord)
+	provider.sqlDeleteExpiredUserSessionRecords = provider.db.Rebind(provider.sqlDeleteExpiredUserSessionRecords)
+
+	provider.sqlInsertNotificationQueueEntry = provider.db.Rebind(provider.sqlInsertNotificationQueueEntry)
+	provider.sqlSelectPendingNotificationQueueEntries = provider.db.Rebind(provider.sqlSelectPendingNotificationQueueEntries)
+	provider.sqlUpdateNotificationQueueEntryClaim = provider.db.Rebind(provider.sqlUpdateNotificationQueueEntryClaim)
+	provider.sqlUpdateNotificationQueueEntryError = provider.db.Rebind(provider.sqlUpdateNotificationQueueEntryError)
+	provider.sqlDeleteNotificationQueueEntry = provider.db.Rebind(provider.sqlDeleteNotificationQueueEntry)
+
 	provider.sqlSelectOAuth2ConsentPreConfigurations = provider.db.Rebind(provider.sqlSelectOAuth2ConsentPreConfigurations)
 
 	provider.sqlInsertOAuth2ConsentSession = provider.db.Rebind(provider.sqlInsertOAuth2ConsentSession)
@@ -92,6 +119,7 @@ func NewPostgreSQLProvider(config *schema.Configuration, caCertPool *x509.CertPo
 	provider.sqlDeactivateOAuth2AccessTokenSession = provider.db.Rebind(provider.sqlDeactivateOAuth2AccessTokenSession)
 	provider.sqlDeactivateOAuth2AccessTokenSessionByRequestID = provider.db.Rebind(provider.sqlDeactivateOAuth2AccessTokenSessionByRequestID)
 	provider.sqlSelectOAuth2AccessTokenSession = provider.db.Rebind(provider.sqlSelectOAuth2AccessTokenSession)
+	provider.sqlSelectOAuth2AccessTokenSessionSignaturesByUsername = provider.db.Rebind(provider.sqlSelectOAuth2AccessTokenSessionSignaturesByUsername)
 
 	provider.sqlInsertOAuth2AuthorizeCodeSession = provider.db.Rebind(provider.sqlInsertOAuth2AuthorizeCodeSession)
 	provider.sqlRevokeOAuth2AuthorizeCodeSession = provider.db.Rebind(provider.sqlRevokeOAuth2AuthorizeCodeSession)
@@ -99,6 +127,7 @@ func NewPostgreSQLProvider(config *schema.Configuration, caCertPool *x509.CertPo
 	provider.sqlDeactivateOAuth2AuthorizeCodeSession = provider.db.Rebind(provider.sqlDeactivateOAuth2AuthorizeCodeSession)
 	provider.sqlDeactivateOAuth2AuthorizeCodeSessionByRequestID = provider.db.Rebind(provider.sqlDeactivateOAuth2AuthorizeCodeSessionByRequestID)
 	provider.sqlSelectOAuth2AuthorizeCodeSession = provider.db.Rebind(provider.sqlSelectOAuth2AuthorizeCodeSession)
+	provider.sqlSelectOAuth2AuthorizeCodeSessionSignaturesByUsername = provider.db.Rebind(provider.sqlSelectOAuth2AuthorizeCodeSessionSignaturesByUsername)
 
 	provider.sqlInsertOAuth2OpenIDConnectSession = provider.db.Rebind(provider.sqlInsertOAuth2OpenIDConnectSession)
 	provider.sqlRevokeOAuth2OpenIDConnectSession = provider.db.Rebind(provider.sqlRevokeOAuth2OpenIDConnectSession)
@@ -106,6 +135,7 @@ func NewPostgreSQLProvider(config *schema.Configuration, caCertPool *x509.CertPo
 	provider.sqlDeactivateOAuth2OpenIDConnectSession = provider.db.Rebind(provider.sqlDeactivateOAuth2OpenIDConnectSession)
 	provider.sqlDeactivateOAuth2OpenIDConnectSessionByRequestID = provider.db.Rebind(provider.sqlDeactivateOAuth2OpenIDConnectSessionByRequestID)
 	provider.sqlSelectOAuth2OpenIDConnectSession = provider.db.Rebind(provider.sqlSelectOAuth2OpenIDConnectSession)
+	provider.sqlSelectOAuth2OpenIDConnectSessionSignaturesByUsername = provider.db.Rebind(provider.sqlSelectOAuth2OpenIDConnectSessionSignaturesByUsername)
 
 	provider.sqlInsertOAuth2PARContext = provider.db.Rebind(provider.sqlInsertOAuth2PARContext)
 	provider.sqlUpdateOAuth2PARContext = provider.db.Rebind(provider.sqlUpdateOAuth2PARContext)
@@ -118,6 +148,7 @@ func NewPostgreSQLProvider(config *schema.Configuration, caCertPool *x509.CertPo
 	provider.sqlDeactivateOAuth2PKCERequestSession = provider.db.Rebind(provider.sqlDeactivateOAuth2PKCERequestSession)
 	provider.sqlDeactivateOAuth2PKCERequestSessionByRequestID = provider.db.Rebind(provider.sqlDeactivateOAuth2PKCERequestSessionByRequestID)
 	provider.sqlSelectOAuth2PKCERequestSession = provider.db.Rebind(provider.sqlSelectOAuth2PKCERequestSession)
+	provider.sqlSelectOAuth2PKCERequestSessionSignaturesByUsername = provider.db.Rebind(provider.sqlSelectOAuth2PKCERequestSessionSignaturesByUsername)
 
 	provider.sqlInsertOAuth2RefreshTokenSession = provider.db.Rebind(provider.sqlInsertOAuth2RefreshTokenSession)
 	provider.sqlRevokeOAuth2RefreshTokenSession = provider.db.Rebind(provider.sqlRevokeOAuth2RefreshTokenSession)
@@ -125,6 +156,7 @@ func NewPostgreSQLProvider(config *schema.Configuration, caCertPool *x509.CertPo
 	provider.sqlDeactivateOAuth2RefreshTokenSession = provider.db.Rebind(provider.sqlDeactivateOAuth2RefreshTokenSession)
 	provider.sqlDeactivateOAuth2RefreshTokenSessionByRequestID = provider.db.Rebind(provider.sqlDeactivateOAuth2RefreshTokenSessionByRequestID)
 	provider.sqlSelectOAuth2RefreshTokenSession = provider.db.Rebind(provider.sqlSelectOAuth2RefreshTokenSession)
+	provider.sqlSelectOAuth2RefreshTokenSessionSignaturesByUsername = provider.db.Rebind(provider.sqlSelectOAuth2RefreshTokenSessionSignaturesByUsername)
 
 	provider.sqlSelectOAuth2BlacklistedJTI = provider.db.Rebind(provider.sqlSelectOAuth2BlacklistedJTI)
 
@@ -35,6 +35,7 @@ func (p *SQLProvider) SchemaEncryptionChangeKey(ctx context.Context, key string)
 	encChangeFuncs := []EncryptionChangeKeyFunc{
 		schemaEncryptionChangeKeyTOTP,
 		schemaEncryptionChangeKeyWebAuthn,
+		schemaEncryptionChangeKeyNotificationQueue,
 	}
 
 	for i := 0; true; i++ {
@@ -91,6 +92,7 @@ func (p *SQLProvider) SchemaEncryptionCheckKey(ctx context.Context, verbose bool
 		encCheckFuncs := []EncryptionCheckKeyFunc{
 			schemaEncryptionCheckKeyTOTP,
 			schemaEncryptionCheckKeyWebAuthn,
+			schemaEncryptionCheckKeyNotificationQueue,
 		}
 
 		for i := 0; true; i++ {
@@ -193,6 +195,46 @@ func schemaEncryptionChangeKeyWebAuthn(ctx context.Context, provider *SQLProvide
 	return nil
 }
 
+func schemaEncryptionChangeKeyNotificationQueue(ctx context.Context, provider *SQLProvider, tx *sqlx.Tx, key [32]byte) (err error) {
+	var count int
+
+	if err = tx.GetContext(ctx, &count, fmt.Sprintf(queryFmtSelectRowCount, tableNotificationQueue)); err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return nil
+	}
+
+	entries := make([]encNotificationQueueEntry, 0, count)
+
+	if err = tx.SelectContext(ctx, &entries, fmt.Sprintf(queryFmtSelectNotificationQueueEntriesEncryptedData, tableNotificationQueue)); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+
+		return fmt.Errorf("error selecting notification queue entries: %w", err)
+	}
+
+	query := provider.db.Rebind(fmt.Sprintf(queryFmtUpdateNotificationQueueEntryData, tableNotificationQueue))
+
+	for _, e := range entries {
+		if e.Data, err = provider.decrypt(e.Data); err != nil {
+			return fmt.Errorf("error decrypting notification queue entry data with id '%d': %w", e.ID, err)
+		}
+
+		if e.Data, err = utils.Encrypt(e.Data, &key); err != nil {
+			return fmt.Errorf("error encrypting notification queue entry data with id '%d': %w", e.ID, err)
+		}
+
+		if _, err = tx.ExecContext(ctx, query, e.Data, e.ID); err != nil {
+			return fmt.Errorf("error updating notification queue entry data with id '%d': %w", e.ID, err)
+		}
+	}
+
+	return nil
+}
+
 func schemaEncryptionChangeKeyOpenIDConnect(typeOAuth2Session OAuth2SessionType) EncryptionChangeKeyFunc {
 	return func(ctx context.Context, provider *SQLProvider, tx *sqlx.Tx, key [32]byte) (err error) {
 		var count int
@@ -293,6 +335,37 @@ func schemaEncryptionCheckKeyWebAuthn(ctx context.Context, provider *SQLProvider
 	return tableWebAuthnDevices, result
 }
 
+func schemaEncryptionCheckKeyNotificationQueue(ctx context.Context, provider *SQLProvider) (table string, result EncryptionValidationTableResult) {
+	var (
+		rows *sqlx.Rows
+		err  error
+	)
+
+	if rows, err = provider.db.QueryxContext(ctx, fmt.Sprintf(queryFmtSelectNotificationQueueEntriesEncryptedData, tableNotificationQueue)); err != nil {
+		return tableNotificationQueue, EncryptionValidationTableResult{Error: fmt.Errorf("error selecting notification queue entries: %w", err)}
+	}
+
+	var entry encNotificationQueueEntry
+
+	for rows.Next() {
+		result.Total++
+
+		if err = rows.StructScan(&entry); err != nil {
+			_ = rows.Close()
+
+			return tableNotificationQueue, EncryptionValidationTableResult{Error: fmt.Errorf("error scanning notification queue entry to struct: %w", err)}
+		}
+
+		if _, err = provider.decrypt(entry.Data); err != nil {
+			result.Invalid++
+		}
+	}
+
+	_ = rows.Close()
+
+	return tableNotificationQueue, result
+}
+
 func schemaEncryptionCheckKeyOpenIDConnect(typeOAuth2Session OAuth2SessionType) EncryptionCheckKeyFunc {
 	return func(ctx context.Context, provider *SQLProvider) (table string, result EncryptionValidationTableResult) {
 		var (
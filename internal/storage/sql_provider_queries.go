@@ -201,8 +201,8 @@ const (
 
 const (
 	queryFmtInsertAuthenticationLogEntry = `
-		INSERT INTO %s (time, successful, banned, username, auth_type, remote_ip, request_uri, request_method)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?);`
+		INSERT INTO %s (time, successful, banned, username, auth_type, remote_ip, remote_network, country, request_uri, request_method)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?);`
 
 	queryFmtSelect1FAAuthenticationLogEntryByUsername = `
 		SELECT time, successful, username
@@ -211,6 +211,63 @@ const (
 		ORDER BY time DESC
 		LIMIT ?
 		OFFSET ?;`
+
+	queryFmtSelect1FAAuthenticationLogEntryByUsernameAndRemoteNetwork = `
+		SELECT time, successful, username
+		FROM %s
+		WHERE time > ? AND username = ? AND remote_network = ? AND auth_type = '1FA' AND banned = FALSE
+		ORDER BY time DESC
+		LIMIT ?
+		OFFSET ?;`
+
+	queryFmtSelectAuthenticationLogEntryByUsernameAndType = `
+		SELECT time, successful, username
+		FROM %s
+		WHERE time > ? AND username = ? AND auth_type = ? AND banned = FALSE
+		ORDER BY time DESC
+		LIMIT ?
+		OFFSET ?;`
+
+	queryFmtSelect1FAFailedAuthenticationLogEntrySubjects = `
+		SELECT DISTINCT username, remote_network
+		FROM %s
+		WHERE time > ? AND auth_type = '1FA' AND successful = FALSE AND banned = FALSE;`
+
+	queryFmtSelectFailedAuthenticationLogEntryByUsername = `
+		SELECT id, time, successful, banned, username, auth_type, remote_ip, remote_network, country, request_uri, request_method
+		FROM %s
+		WHERE username = ? AND successful = FALSE
+		ORDER BY time DESC
+		LIMIT ?
+		OFFSET ?;`
+
+	queryFmtSelect1FAFailedAuthenticationLogEntryByRemoteNetwork = `
+		SELECT time, successful, username
+		FROM %s
+		WHERE time > ? AND remote_network = ? AND auth_type = '1FA' AND successful = FALSE AND banned = FALSE
+		ORDER BY time DESC
+		LIMIT ?
+		OFFSET ?;`
+)
+
+const (
+	queryFmtInsertRegulationUnban = `
+		INSERT INTO %s (time, username, remote_network)
+		VALUES (?, ?, ?);`
+
+	queryFmtSelectLatestRegulationUnbanByUsername = `
+		SELECT time
+		FROM %s
+		WHERE username = ?
+		ORDER BY time DESC
+		LIMIT 1;`
+
+	queryFmtSelectLatestRegulationUnbanByRemoteNetwork = `
+		SELECT time
+		FROM %s
+		WHERE remote_network = ?
+		ORDER BY time DESC
+		LIMIT 1;`
 )
 
 const (
@@ -284,6 +341,12 @@ const (
 		FROM %s
 		WHERE signature = ? AND revoked = FALSE;`
 
+	queryFmtSelectOAuth2SessionSignaturesByUsername = `
+		SELECT s.signature
+		FROM %s s
+		JOIN %s u ON s.subject = u.identifier
+		WHERE u.username = ? AND s.revoked = FALSE;`
+
 	queryFmtSelectOAuth2SessionEncryptedData = `
 		SELECT id, session_data
 		FROM %s;`
@@ -369,3 +432,102 @@ const (
 		SELECT id, service, sector_id, username, identifier
 		FROM %s;`
 )
+
+const (
+	queryFmtSelectSessionData = `
+		SELECT data
+		FROM %s
+		WHERE session_id = ? AND (expires_at IS NULL OR expires_at > ?);`
+
+	queryFmtUpsertSessionData = `
+		REPLACE INTO %s (session_id, expires_at, data)
+		VALUES(?, ?, ?);`
+
+	queryFmtUpsertSessionDataPostgreSQL = `
+		INSERT INTO %s (session_id, expires_at, data)
+		VALUES ($1, $2, $3)
+			ON CONFLICT (session_id)
+			DO UPDATE SET expires_at = $2, data = $3;`
+
+	queryFmtUpdateSessionDataID = `
+		UPDATE %s
+		SET session_id = ?, expires_at = ?
+		WHERE session_id = ?;`
+
+	queryFmtDeleteSessionData = `
+		DELETE FROM %s
+		WHERE session_id = ?;`
+
+	queryFmtCountSessionData = `
+		SELECT COUNT(id)
+		FROM %s
+		WHERE expires_at IS NULL OR expires_at > ?;`
+
+	queryFmtDeleteExpiredSessionData = `
+		DELETE FROM %s
+		WHERE expires_at IS NOT NULL AND expires_at <= ?;`
+
+	queryFmtSelectUserSessionRecord = `
+		SELECT data
+		FROM %s
+		WHERE username = ? AND record_id = ? AND expires_at > ?;`
+
+	queryFmtSelectUserSessionRecords = `
+		SELECT data
+		FROM %s
+		WHERE username = ? AND expires_at > ?;`
+
+	queryFmtUpsertUserSessionRecord = `
+		REPLACE INTO %s (username, record_id, expires_at, data)
+		VALUES(?, ?, ?, ?);`
+
+	queryFmtUpsertUserSessionRecordPostgreSQL = `
+		INSERT INTO %s (username, record_id, expires_at, data)
+		VALUES ($1, $2, $3, $4)
+			ON CONFLICT (username, record_id)
+			DO UPDATE SET expires_at = $3, data = $4;`
+
+	queryFmtDeleteUserSessionRecord = `
+		DELETE FROM %s
+		WHERE username = ? AND record_id = ?;`
+
+	queryFmtDeleteExpiredUserSessionRecords = `
+		DELETE FROM %s
+		WHERE expires_at <= ?;`
+)
+
+const (
+	queryFmtInsertNotificationQueueEntry = `
+		INSERT INTO %s (created_at, next_attempt_at, recipient_name, recipient_address, subject, template, data)
+		VALUES (?, ?, ?, ?, ?, ?, ?);`
+
+	queryFmtSelectPendingNotificationQueueEntries = `
+		SELECT id, created_at, next_attempt_at, attempts, dead_lettered, recipient_name, recipient_address, subject, template, data, last_error
+		FROM %s
+		WHERE dead_lettered = FALSE AND next_attempt_at <= ?
+		ORDER BY next_attempt_at ASC
+		LIMIT ?;`
+
+	queryFmtUpdateNotificationQueueEntryClaim = `
+		UPDATE %s
+		SET attempts = attempts + 1, next_attempt_at = ?
+		WHERE id = ? AND attempts = ? AND dead_lettered = FALSE;`
+
+	queryFmtUpdateNotificationQueueEntryError = `
+		UPDATE %s
+		SET dead_lettered = ?, last_error = ?
+		WHERE id = ?;`
+
+	queryFmtDeleteNotificationQueueEntry = `
+		DELETE FROM %s
+		WHERE id = ?;`
+
+	queryFmtSelectNotificationQueueEntriesEncryptedData = `
+		SELECT id, data
+		FROM %s;`
+
+	queryFmtUpdateNotificationQueueEntryData = `
+		UPDATE %s
+		SET data = ?
+		WHERE id = ?;`
+)
@@ -42,6 +42,11 @@ type encTOTPConfiguration struct {
 	Secret []byte `db:"secret" json:"-"`
 }
 
+type encNotificationQueueEntry struct {
+	ID   int    `db:"id"`
+	Data []byte `db:"data"`
+}
+
 // EncryptionValidationResult contains information about the success of a schema encryption validation.
 type EncryptionValidationResult struct {
 	InvalidCheckValue bool
@@ -0,0 +1,51 @@
+package suites
+
+import (
+	"time"
+)
+
+var redisClusterSuiteName = "RedisCluster"
+
+func init() {
+	dockerEnvironment := NewDockerEnvironment([]string{
+		"internal/suites/docker-compose.yml",
+		"internal/suites/RedisCluster/docker-compose.yml",
+		"internal/suites/example/compose/authelia/docker-compose.backend.{}.yml",
+		"internal/suites/example/compose/authelia/docker-compose.frontend.{}.yml",
+		"internal/suites/example/compose/nginx/backend/docker-compose.yml",
+		"internal/suites/example/compose/nginx/portal/docker-compose.yml",
+		"internal/suites/example/compose/smtp/docker-compose.yml",
+		"internal/suites/example/compose/redis-cluster/docker-compose.yml",
+		"internal/suites/example/compose/ldap/docker-compose.yml",
+	})
+
+	setup := func(suitePath string) (err error) {
+		if err = dockerEnvironment.Up(); err != nil {
+			return err
+		}
+
+		if err = waitUntilAutheliaIsReady(dockerEnvironment, redisClusterSuiteName); err != nil {
+			return err
+		}
+
+		return updateDevEnvFileForDomain(BaseDomain, true)
+	}
+
+	displayAutheliaLogs := func() error {
+		return dockerEnvironment.PrintLogs("authelia-backend", "authelia-frontend")
+	}
+
+	teardown := func(suitePath string) error {
+		err := dockerEnvironment.Down()
+		return err
+	}
+
+	GlobalRegistry.Register(redisClusterSuiteName, Suite{
+		SetUp:           setup,
+		SetUpTimeout:    5 * time.Minute,
+		OnSetupTimeout:  displayAutheliaLogs,
+		OnError:         displayAutheliaLogs,
+		TearDown:        teardown,
+		TearDownTimeout: 2 * time.Minute,
+	})
+}
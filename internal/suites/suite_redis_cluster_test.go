@@ -0,0 +1,33 @@
+package suites
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RedisClusterSuite struct {
+	*RodSuite
+}
+
+func NewRedisClusterSuite() *RedisClusterSuite {
+	return &RedisClusterSuite{
+		RodSuite: NewRodSuite(redisClusterSuiteName),
+	}
+}
+
+func (s *RedisClusterSuite) Test1FAScenario() {
+	suite.Run(s.T(), New1FAScenario())
+}
+
+func (s *RedisClusterSuite) Test2FAScenario() {
+	suite.Run(s.T(), New2FAScenario())
+}
+
+func TestRedisClusterSuite(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping suite test in short mode")
+	}
+
+	suite.Run(t, NewRedisClusterSuite())
+}
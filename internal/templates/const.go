@@ -3,6 +3,14 @@ package templates
 const (
 	extText = ".txt"
 	extHTML = ".html"
+	extHTM  = ".htm"
+	extJSON = ".json"
+)
+
+const (
+	contentTypeTextPlain       = "text/plain; charset=utf-8"
+	contentTypeTextHTML        = "text/html; charset=utf-8"
+	contentTypeApplicationJSON = "application/json; charset=utf-8"
 )
 
 // Template File Names.
@@ -7,6 +7,7 @@ import (
 	"encoding/base32"
 	"encoding/base64"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"hash"
 	"net/url"
@@ -86,6 +87,7 @@ func FuncMap() map[string]any {
 		"uuidv4":      FuncUUIDv4,
 		"urlquery":    url.QueryEscape,
 		"urlunquery":  url.QueryUnescape,
+		"toJson":      FuncToJSON,
 	}
 }
 
@@ -448,6 +450,16 @@ func FuncUUIDv4() string {
 	return uuid.New().String()
 }
 
+// FuncToJSON is a helper function that provides similar functionality to the helm toJson func.
+func FuncToJSON(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+
+	return string(data)
+}
+
 // FuncFileContent returns the file content.
 func FuncFileContent(path string) (data string, err error) {
 	var raw []byte
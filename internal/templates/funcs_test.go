@@ -723,6 +723,25 @@ func TestFuncUUIDv4(t *testing.T) {
 	assert.Len(t, FuncUUIDv4(), 36)
 }
 
+func TestFuncToJSON(t *testing.T) {
+	testCases := []struct {
+		name     string
+		have     any
+		expected string
+	}{
+		{"ShouldEncodeString", "a \"quoted\" <value>", `"a \"quoted\" \u003cvalue\u003e"`},
+		{"ShouldEncodeSlice", []string{"a", "b"}, `["a","b"]`},
+		{"ShouldEncodeNil", nil, `null`},
+		{"ShouldNotEncodeChannel", make(chan int), ``},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, FuncToJSON(tc.have))
+		})
+	}
+}
+
 func TestFuncFileContent(t *testing.T) {
 	testCases := []struct {
 		name           string
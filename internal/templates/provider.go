@@ -5,6 +5,7 @@ import (
 	th "html/template"
 	"io/fs"
 	"path"
+	"sync"
 	tt "text/template"
 )
 
@@ -25,6 +26,9 @@ func New(config Config) (provider *Provider, err error) {
 type Provider struct {
 	config    Config
 	templates Templates
+
+	mu    sync.Mutex
+	authz map[string]*AuthzResponseTemplate
 }
 
 // LoadTemplatedAssets takes an embed.FS and loads each templated asset document into a Template.
@@ -94,11 +98,48 @@ func (p *Provider) GetIdentityVerificationEmailTemplate() (t *EmailTemplate) {
 	return p.templates.notification.identityVerification
 }
 
+// GetEmailTemplate returns the EmailTemplate with the given name which is one of the TemplateNameEmail constants.
+func (p *Provider) GetEmailTemplate(name string) (t *EmailTemplate, err error) {
+	switch name {
+	case TemplateNameEmailEvent:
+		return p.templates.notification.event, nil
+	case TemplateNameEmailIdentityVerification:
+		return p.templates.notification.identityVerification, nil
+	default:
+		return nil, fmt.Errorf("email template '%s' does not exist", name)
+	}
+}
+
 // GetOpenIDConnectAuthorizeResponseFormPostTemplate returns a Template used to generate the OpenID Connect 1.0 Form Post Authorize Response.
 func (p *Provider) GetOpenIDConnectAuthorizeResponseFormPostTemplate() (t *th.Template) {
 	return p.templates.oidc.formpost
 }
 
+// GetAuthzResponseTemplate returns the AuthzResponseTemplate used to render the body of a customized Authz response.
+// The template is loaded from the path on first use and cached for subsequent uses. Templates with the .html or .htm
+// extension are parsed as HTML templates, and all other templates are parsed as text templates.
+func (p *Provider) GetAuthzResponseTemplate(path string) (t *AuthzResponseTemplate, err error) {
+	p.mu.Lock()
+
+	defer p.mu.Unlock()
+
+	if t = p.authz[path]; t != nil {
+		return t, nil
+	}
+
+	if t, err = loadAuthzResponseTemplate(path); err != nil {
+		return nil, err
+	}
+
+	if p.authz == nil {
+		p.authz = map[string]*AuthzResponseTemplate{}
+	}
+
+	p.authz[path] = t
+
+	return t, nil
+}
+
 func (p *Provider) load() (err error) {
 	var errs []error
 
@@ -77,3 +77,30 @@ type EmailIdentityVerificationValues struct {
 	LinkURL     string
 	LinkText    string
 }
+
+// AuthzResponseTemplate is a template used to render the body of a customized Authz response alongside the
+// content type of the rendered body.
+type AuthzResponseTemplate struct {
+	Template    Template
+	ContentType string
+}
+
+// AuthzResponseValues are the values used for the Authz response templates.
+type AuthzResponseValues struct {
+	StatusCode  int
+	StatusText  string
+	RequestID   string
+	TargetURL   string
+	Method      string
+	Username    string
+	RemoteIP    string
+	RedirectURL string
+	Rule        AuthzResponseRuleValues
+}
+
+// AuthzResponseRuleValues are the values of the matched access control rule used for the Authz response templates.
+// The Position is 0 when the default policy is applied.
+type AuthzResponseRuleValues struct {
+	Position int
+	Policy   string
+}
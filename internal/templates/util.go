@@ -19,7 +19,7 @@ const (
 // IMPORTANT: This is a copy of github.com/authelia/authelia/internal/configuration's secretSuffixes except all uppercase.
 // Make sure you update these at the same time.
 var envSecretSuffixes = []string{
-	"KEY", "SECRET", "PASSWORD", "TOKEN", "CERTIFICATE_CHAIN",
+	"KEY", "SECRET", "SECRETS", "PASSWORD", "TOKEN", "CERTIFICATE_CHAIN",
 }
 
 func isSecretEnvKey(key string) (isSecretEnvKey bool) {
@@ -171,3 +171,34 @@ func strslice(v any) []string {
 		}
 	}
 }
+
+func loadAuthzResponseTemplate(tPath string) (t *AuthzResponseTemplate, err error) {
+	var data []byte
+
+	if data, err = os.ReadFile(tPath); err != nil {
+		return nil, fmt.Errorf("failed to read authz response template at path '%s': %w", tPath, err)
+	}
+
+	name := filepath.Base(tPath)
+
+	switch strings.ToLower(filepath.Ext(tPath)) {
+	case extHTML, extHTM:
+		t = &AuthzResponseTemplate{ContentType: contentTypeTextHTML}
+
+		t.Template, err = th.New(name).Funcs(FuncMap()).Parse(string(data))
+	case extJSON:
+		t = &AuthzResponseTemplate{ContentType: contentTypeApplicationJSON}
+
+		t.Template, err = tt.New(name).Funcs(FuncMap()).Parse(string(data))
+	default:
+		t = &AuthzResponseTemplate{ContentType: contentTypeTextPlain}
+
+		t.Template, err = tt.New(name).Funcs(FuncMap()).Parse(string(data))
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse authz response template at path '%s': %w", tPath, err)
+	}
+
+	return t, nil
+}
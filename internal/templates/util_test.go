@@ -1,9 +1,11 @@
 package templates
 
 import (
+	"bytes"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"text/template"
 
@@ -19,7 +21,7 @@ func TestIsSecretEnvKey(t *testing.T) {
 	}{
 		{"ShouldReturnFalseForKeysWithoutPrefix", []string{"A_KEY", "A_SECRET", "A_PASSWORD", "NOT_AUTHELIA_A_PASSWORD"}, false},
 		{"ShouldReturnFalseForKeysWithoutSuffix", []string{"AUTHELIA_EXAMPLE", "X_AUTHELIA_EXAMPLE", "X_AUTHELIA_PASSWORD_NOT"}, false},
-		{"ShouldReturnTrueForSecretKeys", []string{"AUTHELIA_JWT_SECRET", "AUTHELIA_IDENTITY_PROVIDERS_OIDC_HMAC_SECRET", "AUTHELIA_IDENTITY_PROVIDERS_OIDC_ISSUER_CERTIFICATE_CHAIN", "X_AUTHELIA_JWT_SECRET", "X_AUTHELIA_IDENTITY_PROVIDERS_OIDC_HMAC_SECRET", "X_AUTHELIA_IDENTITY_PROVIDERS_OIDC_ISSUER_CERTIFICATE_CHAIN"}, true},
+		{"ShouldReturnTrueForSecretKeys", []string{"AUTHELIA_JWT_SECRET", "AUTHELIA_SESSION_SECRETS", "AUTHELIA_IDENTITY_PROVIDERS_OIDC_HMAC_SECRET", "AUTHELIA_IDENTITY_PROVIDERS_OIDC_ISSUER_CERTIFICATE_CHAIN", "X_AUTHELIA_JWT_SECRET", "X_AUTHELIA_IDENTITY_PROVIDERS_OIDC_HMAC_SECRET", "X_AUTHELIA_IDENTITY_PROVIDERS_OIDC_ISSUER_CERTIFICATE_CHAIN"}, true},
 		{"ShouldReturnTrueForSecretKeysEvenWithMixedCase", []string{"aUTHELIA_JWT_SECRET", "aUTHELIA_IDENTITY_PROVIDERS_OIDC_HMAC_SECRET", "aUTHELIA_IDENTITY_PROVIDERS_OIDC_ISSUER_CERTIFICATE_CHAIN", "X_aUTHELIA_JWT_SECREt", "X_aUTHELIA_IDENTITY_PROVIDERS_OIDC_HMAC_SECRET", "x_AUTHELIA_IDENTITY_PROVIDERS_OIDC_ISSUER_CERTIFICATE_CHAIN"}, true},
 	}
 
@@ -102,3 +104,52 @@ func TestParseMiscTemplates(t *testing.T) {
 		})
 	}
 }
+
+func TestLoadAuthzResponseTemplate(t *testing.T) {
+	dir := t.TempDir()
+
+	values := AuthzResponseValues{StatusCode: 403, StatusText: "Forbidden", RequestID: "abc", TargetURL: "https://example.com/?a=<b>"}
+
+	testCases := []struct {
+		name        string
+		file        string
+		content     string
+		contentType string
+		expected    string
+		err         string
+	}{
+		{"ShouldLoadHTML", "forbidden.html", `<p>{{ .TargetURL }}</p>`, "text/html; charset=utf-8", `<p>https://example.com/?a=&lt;b&gt;</p>`, ""},
+		{"ShouldLoadJSON", "forbidden.json", `{"request_id":{{ toJson .RequestID }},"status":{{ .StatusCode }}}`, "application/json; charset=utf-8", `{"request_id":"abc","status":403}`, ""},
+		{"ShouldLoadText", "forbidden.txt", `{{ .StatusCode }} {{ .StatusText }}`, "text/plain; charset=utf-8", `403 Forbidden`, ""},
+		{"ShouldErrorParse", "bad.html", `{{ .StatusCode `, "", "", "failed to parse authz response template at path '%s': template: bad.html:1: unclosed action"},
+		{"ShouldErrorRead", "missing.html", "", "", "", "failed to read authz response template at path '%s': open %s: no such file or directory"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(dir, tc.file)
+
+			if tc.content != "" {
+				require.NoError(t, os.WriteFile(path, []byte(tc.content), 0600))
+			}
+
+			actual, err := loadAuthzResponseTemplate(path)
+
+			if tc.err != "" {
+				assert.EqualError(t, err, strings.ReplaceAll(tc.err, "%s", path))
+				assert.Nil(t, actual)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			buf := &bytes.Buffer{}
+
+			require.NoError(t, actual.Template.Execute(buf, values))
+
+			assert.Equal(t, tc.contentType, actual.ContentType)
+			assert.Equal(t, tc.expected, buf.String())
+		})
+	}
+}
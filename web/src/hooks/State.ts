@@ -1,6 +1,10 @@
+import { useCallback } from "react";
+
 import { useRemoteCall } from "@hooks/RemoteCall";
 import { getState } from "@services/State";
 
-export function useAutheliaState() {
-    return useRemoteCall(getState, []);
+export function useAutheliaState(targetURL?: string, requestMethod?: string) {
+    const fn = useCallback(() => getState(targetURL, requestMethod), [targetURL, requestMethod]);
+
+    return useRemoteCall(fn, [targetURL, requestMethod]);
 }
@@ -12,6 +12,18 @@ export interface AutheliaState {
     authentication_level: AuthenticationLevel;
 }
 
-export async function getState(): Promise<AutheliaState> {
+export async function getState(targetURL?: string, requestMethod?: string): Promise<AutheliaState> {
+    if (targetURL) {
+        // The target URL and request method are used to apply the maximum authentication age of the matching access
+        // control rule.
+        const params = new URLSearchParams({ rd: targetURL });
+
+        if (requestMethod) {
+            params.set("rm", requestMethod);
+        }
+
+        return Get<AutheliaState>(`${StatePath}?${params.toString()}`);
+    }
+
     return Get<AutheliaState>(StatePath);
 }
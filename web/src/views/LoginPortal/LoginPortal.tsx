@@ -10,7 +10,7 @@ import {
     SecondFactorTOTPSubRoute,
     SecondFactorWebAuthnSubRoute,
 } from "@constants/Routes";
-import { RedirectionURL } from "@constants/SearchParams";
+import { RedirectionURL, RequestMethod } from "@constants/SearchParams";
 import { useConfiguration } from "@hooks/Configuration";
 import { useNotifications } from "@hooks/NotificationsContext";
 import { useQueryParam } from "@hooks/QueryParam";
@@ -40,12 +40,13 @@ const LoginPortal = function (props: Props) {
     const navigate = useNavigate();
     const location = useLocation();
     const redirectionURL = useQueryParam(RedirectionURL);
+    const requestMethod = useQueryParam(RequestMethod);
     const { createErrorNotification } = useNotifications();
     const [firstFactorDisabled, setFirstFactorDisabled] = useState(true);
     const [broadcastRedirect, setBroadcastRedirect] = useState(false);
     const redirector = useRedirector();
 
-    const [state, fetchState, , fetchStateError] = useAutheliaState();
+    const [state, fetchState, , fetchStateError] = useAutheliaState(redirectionURL, requestMethod);
     const [userInfo, fetchUserInfo, , fetchUserInfoError] = useUserInfoPOST();
     const [configuration, fetchConfiguration, , fetchConfigurationError] = useConfiguration();
     const [searchParams] = useSearchParams();